@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"go_4_vocab_keep/internal/blobstore"
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/ratelimit"
+	"go_4_vocab_keep/internal/repository"
+	"go_4_vocab_keep/internal/service"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// command はサーバーを起動せずに実行する運用向けのサブコマンドです。
+// `./server <name> [flags]` の形式で実行します。
+type command struct {
+	summary string
+	run     func(ctx context.Context, db *gorm.DB, args []string) error
+}
+
+var commands = map[string]command{
+	"generate-readings": {
+		summary: "読みを入力していない全単語について、読み (ふりがな) を自動生成し直します",
+		run:     runGenerateReadings,
+	},
+	"import-anki": {
+		summary: "Ankiのパッケージ (.apkg/.colpkg) から単語を取り込みます",
+		run:     runImportAnki,
+	},
+	"normalize-terms": {
+		summary: "全単語の正規化済みの値 (重複判定用) を現在の設定で再計算します",
+		run:     runNormalizeTerms,
+	},
+	"purge-login-attempts": {
+		summary: "数え直しの対象になった古いログイン失敗の記録を削除します",
+		run:     runPurgeLoginAttempts,
+	},
+	"purge-rate-limits": {
+		summary: "しばらく使われていないリクエスト数の制限の状態 (rate_limit.backend が postgres の場合) を削除します",
+		run:     runPurgeRateLimits,
+	},
+	"purge-trash": {
+		summary: "保持期間を過ぎたゴミ箱の単語を完全に削除します",
+		run:     runPurgeTrash,
+	},
+}
+
+// runCommand はサブコマンドを実行し、終了コードを返します
+func runCommand(ctx context.Context, db *gorm.DB, logger *slog.Logger, name string, args []string) int {
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", name)
+		printCommands()
+		return 2
+	}
+
+	ctx = middleware.WithLogger(ctx, logger.With("command", name))
+	if err := cmd.run(ctx, db, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		logger.Error("Command failed", "command", name, "error", err)
+		return 1
+	}
+	return 0
+}
+
+func printCommands() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", name, commands[name].summary)
+	}
+}
+
+func runImportAnki(ctx context.Context, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("import-anki", flag.ContinueOnError)
+	tenant := fs.String("tenant", "", "取り込み先のテナントID")
+	email := fs.String("email", "", "取り込み先のテナントのメールアドレス (-tenant の代わりに指定)")
+	file := fs.String("file", "", "Ankiのパッケージ (.apkg/.colpkg) のパス")
+	termField := fs.String("term-field", "", "単語として使うフィールド名 (省略時は1番目のフィールド)")
+	definitionField := fs.String("definition-field", "", "意味として使うフィールド名 (省略時は2番目のフィールド)")
+	withScheduling := fs.Bool("with-scheduling", false, "Ankiの復習間隔から学習レベルと次回復習日を引き継ぐ")
+	onDuplicate := fs.String("on-duplicate", model.DuplicateStrategySkip, "既存の単語と重複した場合の扱い (skip, overwrite, suffix)")
+	dryRun := fs.Bool("dry-run", false, "DBに書き込まずに結果だけを表示する")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || (*tenant == "") == (*email == "") {
+		fs.Usage()
+		return errors.New("-file と、-tenant または -email のどちらか一方を指定してください")
+	}
+
+	tenantID, err := resolveTenantID(ctx, db, *tenant, *email)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	importService := service.NewImportService(db, repository.NewGormWordRepository(), repository.NewGormProgressRepository(), &config.Cfg)
+	result, err := importService.ImportAnki(ctx, tenantID, f, info.Size(), &model.ImportAnkiRequest{
+		TermField:       *termField,
+		DefinitionField: *definitionField,
+		WithScheduling:  *withScheduling,
+		OnDuplicate:     *onDuplicate,
+		DryRun:          *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+func runPurgeTrash(ctx context.Context, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	retentionDays := fs.Int("retention-days", config.Cfg.Trash.RetentionDays, "ゴミ箱に残す日数 (省略時は設定ファイルの trash.retention_days)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *retentionDays <= 0 {
+		return errors.New("-retention-days には1以上を指定してください")
+	}
+
+	cfg := config.Cfg
+	cfg.Trash.RetentionDays = *retentionDays
+	blobs, err := newBlobStore(ctx)
+	if err != nil {
+		return err
+	}
+	trashService := service.NewTrashService(db, repository.NewGormWordRepository(), repository.NewGormProgressRepository(), blobs, &cfg)
+	purged, err := trashService.PurgeExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("purged %d words\n", purged)
+	return nil
+}
+
+func runPurgeLoginAttempts(ctx context.Context, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("purge-login-attempts", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	guard := service.NewLoginGuard(db, repository.NewGormLoginAttemptRepository(), &config.Cfg)
+	purged, err := guard.PurgeStale(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("purged %d login attempts\n", purged)
+	return nil
+}
+
+func runPurgeRateLimits(ctx context.Context, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("purge-rate-limits", flag.ContinueOnError)
+	idle := fs.Duration("idle", 24*time.Hour, "この時間使われていない状態を削除する (rate_limit.policies の最も長い period より長くすること)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *idle <= 0 {
+		return errors.New("-idle には正の時間を指定してください")
+	}
+
+	purged, err := ratelimit.NewPostgresStore(db).DeleteIdle(ctx, time.Now().Add(-*idle))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("purged %d rate limit buckets\n", purged)
+	return nil
+}
+
+func runNormalizeTerms(ctx context.Context, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("normalize-terms", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	wordService := service.NewWordService(db, repository.NewGormWordRepository(), repository.NewGormProgressRepository(), repository.NewGormWordRevisionRepository(), repository.NewGormSenseRepository(), nil, &config.Cfg)
+	result, err := wordService.RenormalizeTerms(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("scanned %d words, updated %d, conflicts %d\n", result.Scanned, result.Updated, result.Conflicts)
+	if result.Conflicts > 0 {
+		return errors.New("一部の単語が他の単語と重複するため更新できませんでした (ログを確認してください)")
+	}
+	return nil
+}
+
+func runGenerateReadings(ctx context.Context, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("generate-readings", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	wordService := service.NewWordService(db, repository.NewGormWordRepository(), repository.NewGormProgressRepository(), repository.NewGormWordRevisionRepository(), repository.NewGormSenseRepository(), nil, &config.Cfg)
+	result, err := wordService.GenerateReadings(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("scanned %d words, updated %d, unresolved %d\n", result.Scanned, result.Updated, result.Unresolved)
+	return nil
+}
+
+// newBlobStore は設定に従って添付ファイルのストレージを返します。
+// ローカルの署名付きURLの署名鍵が未設定の場合は、JWTの秘密鍵を代わりに使います。
+func newBlobStore(ctx context.Context) (blobstore.BlobStore, error) {
+	return blobstore.NewFromConfig(ctx, &config.Cfg.Storage, config.Cfg.JWT.SecretKey)
+}
+
+// resolveTenantID はテナントIDかメールアドレスから取り込み先のテナントを特定します
+func resolveTenantID(ctx context.Context, db *gorm.DB, tenant, email string) (uuid.UUID, error) {
+	if tenant != "" {
+		id, err := uuid.Parse(tenant)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("invalid tenant id: %w", err)
+		}
+		if _, err := repository.NewGormTenantRepository().FindByID(ctx, db, id); err != nil {
+			return uuid.Nil, fmt.Errorf("tenant not found: %w", err)
+		}
+		return id, nil
+	}
+
+	t, err := repository.NewGormTenantRepository().FindByEmail(ctx, db, email)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("tenant not found: %w", err)
+	}
+	return t.TenantID, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/service"
+)
+
+// runTrashPurgeJob は interval ごとに保持期間を過ぎたゴミ箱の単語を完全削除します。ctx がキャンセルされるまで戻りません。
+// 複数のインスタンスで同時に実行されても、同じ行を削除し合うだけで結果は変わらない。
+func runTrashPurgeJob(ctx context.Context, trashService service.TrashService, interval time.Duration, logger *slog.Logger) {
+	logger = logger.With("job", "trash_purge")
+	ctx = middleware.WithLogger(ctx, logger)
+	logger.Info("Trash purge job started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := trashService.PurgeExpired(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logger.Error("Trash purge job failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Trash purge job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,9 +3,13 @@ package main
 import (
 	"context"
 	"errors"
+	"go_4_vocab_keep/internal/blobstore"
 	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/dictionary"
 	"go_4_vocab_keep/internal/handlers"
 	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/oidc"
+	"go_4_vocab_keep/internal/ratelimit"
 	"go_4_vocab_keep/internal/repository"
 	"go_4_vocab_keep/internal/service"
 	"log"
@@ -65,29 +69,94 @@ func main() {
 	sqlDB, _ := db.DB()
 	defer sqlDB.Close()
 
+	// サブコマンドが指定された場合はサーバーを起動せずに実行して終了する
+	if len(os.Args) > 1 {
+		code := runCommand(context.Background(), db, logger, os.Args[1], os.Args[2:])
+		sqlDB.Close()
+		os.Exit(code)
+	}
+
 	// Dependency Injection
 	tenantRepo := repository.NewGormTenantRepository()
 	identityRepo := repository.NewGormIdentityRepository()
 	wordRepo := repository.NewGormWordRepository()
 	progressRepo := repository.NewGormProgressRepository()
 	tokenRepo := repository.NewGormTokenRepository()
+	sessionRepo := repository.NewGormSessionRepository()
+	mfaRepo := repository.NewGormMFARepository()
+	loginAttemptRepo := repository.NewGormLoginAttemptRepository()
+	revisionRepo := repository.NewGormWordRevisionRepository()
+	senseRepo := repository.NewGormSenseRepository()
+
+	attachmentRepo := repository.NewGormAttachmentRepository()
 
 	mailer := service.NewMailer(&config.Cfg)
+	blobs, err := newBlobStore(context.Background())
+	if err != nil {
+		slog.Error("Error initializing blob storage", "error", err)
+		os.Exit(1)
+	}
+
+	dictionaryProvider, err := dictionary.NewFromConfig(&config.Cfg.Dictionary)
+	if err != nil {
+		slog.Error("Error initializing dictionary", "error", err)
+		os.Exit(1)
+	}
 
-	wordService := service.NewWordService(db, wordRepo, progressRepo)
+	rateLimitStore, err := ratelimit.NewFromConfig(&config.Cfg.RateLimit, db)
+	if err != nil {
+		slog.Error("Error initializing rate limit store", "error", err)
+		os.Exit(1)
+	}
+	rateLimiter, err := middleware.NewRateLimiter(rateLimitStore, &config.Cfg.RateLimit, logger)
+	if err != nil {
+		slog.Error("Error initializing rate limiter", "error", err)
+		os.Exit(1)
+	}
+
+	trustedProxies, err := middleware.ParseTrustedProxies(config.Cfg.Server.TrustedProxies)
+	if err != nil {
+		slog.Error("Error parsing trusted proxies", "error", err)
+		os.Exit(1)
+	}
+
+	googleProvider, err := oidc.NewGoogleProviderFromConfig(&config.Cfg.GoogleOAuth, nil)
+	if err != nil {
+		slog.Error("Error initializing Google login", "error", err)
+		os.Exit(1)
+	}
+	oidcProviders, err := oidc.NewProvidersFromConfig(&config.Cfg.OIDC, nil)
+	if err != nil {
+		slog.Error("Error initializing OIDC providers", "error", err)
+		os.Exit(1)
+	}
+
+	dictionaryService := service.NewDictionaryService(dictionaryProvider, &config.Cfg)
+	wordService := service.NewWordService(db, wordRepo, progressRepo, revisionRepo, senseRepo, dictionaryService, &config.Cfg)
+	importService := service.NewImportService(db, wordRepo, progressRepo, &config.Cfg)
+	exportService := service.NewExportService(db, wordRepo)
+	trashService := service.NewTrashService(db, wordRepo, progressRepo, blobs, &config.Cfg)
+	attachmentService := service.NewAttachmentService(db, wordRepo, attachmentRepo, blobs, &config.Cfg)
 	reviewService := service.NewReviewService(db, progressRepo, &config.Cfg)
-	authService := service.NewAuthService(db, tenantRepo, identityRepo, tokenRepo, mailer, &config.Cfg)
+	sessionCache := service.NewSessionCache(db, sessionRepo, &config.Cfg)
+	loginGuard := service.NewLoginGuard(db, loginAttemptRepo, &config.Cfg)
+	authService := service.NewAuthService(db, tenantRepo, identityRepo, tokenRepo, sessionRepo, mfaRepo, loginGuard, sessionCache, googleProvider, oidcProviders, mailer, &config.Cfg)
 
 	wordHandler := handlers.NewWordHandler(wordService)
+	importHandler := handlers.NewImportHandler(importService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
 	reviewHandler := handlers.NewReviewHandler(reviewService)
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, &config.Cfg)
+	dictionaryHandler := handlers.NewDictionaryHandler(dictionaryService)
 
 	r := chi.NewRouter()
 
 	// Middleware
 	// 基本的なアクセスログ (メソッド、パス、ステータス等)
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.ClientInfoMiddleware(trustedProxies))
 	r.Use(middleware.LoggingMiddleware(logger))
 
 	// CORS 設定と適用 (設定ファイルから読み込んだ値を使用)
@@ -109,30 +178,77 @@ func main() {
 	// API Routes
 	r.Route("/api/v1", func(r chi.Router) {
 		// 認証不要
-		r.Post("/register", authHandler.Register)
-		r.Post("/login", authHandler.Login)
+		r.With(rateLimiter.Limit("register")).Post("/register", authHandler.Register)
+		r.With(rateLimiter.Limit("login")).Post("/login", authHandler.Login)
+		r.With(rateLimiter.Limit("login")).Post("/login/mfa", authHandler.LoginWithMFA)
 		r.Get("/verify-email", authHandler.VerifyAccount)
-		r.Post("/forgot-password", authHandler.RequestPasswordReset)
-		r.Post("/reset-password", authHandler.ResetPassword)
-		r.Post("/auth/google/callback", authHandler.HandleGoogleLogin)
+		r.With(rateLimiter.Limit("verify_email_resend_ip"), rateLimiter.Limit("verify_email_resend_email")).Post("/verify-email/resend", authHandler.ResendVerificationEmail)
+		r.With(rateLimiter.Limit("forgot_password_ip"), rateLimiter.Limit("forgot_password_email")).Post("/forgot-password", authHandler.RequestPasswordReset)
+		r.With(rateLimiter.Limit("reset_password")).Post("/reset-password", authHandler.ResetPassword)
+		r.With(rateLimiter.Limit("login")).Post("/auth/google/callback", authHandler.HandleGoogleLogin)
+		r.With(rateLimiter.Limit("refresh")).Post("/auth/refresh", authHandler.RefreshToken)
+		r.With(rateLimiter.Limit("email_confirm")).Post("/auth/email/confirm", authHandler.ConfirmEmailChange)
+		r.Get("/auth/oidc/providers", authHandler.ListOIDCProviders)
+		r.With(rateLimiter.Limit("login")).Post("/auth/oidc/{provider}/authorize", authHandler.StartOIDCLogin)
+		r.With(rateLimiter.Limit("login")).Post("/auth/oidc/{provider}/callback", authHandler.HandleOIDCCallback)
+
+		// ローカルストレージの添付ファイルの配信 (URLの署名で認可するため認証不要)
+		if local, ok := blobs.(*blobstore.LocalStore); ok {
+			r.Get("/blobs/*", handlers.NewBlobHandler(local).ServeBlob)
+		}
 
 		// 要認証
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.JWTAuthMiddleware(&config.Cfg))
+			r.Use(middleware.JWTAuthMiddleware(&config.Cfg, sessionCache))
+			r.Use(rateLimiter.Limit("api"))
 
 			// 認証
 			r.Route("/auth", func(r chi.Router) {
 				r.Get("/me", authHandler.GetMe)
+				r.Post("/logout", authHandler.Logout)
+				r.Get("/sessions", authHandler.ListSessions)
+				r.Delete("/sessions/{session_id}", authHandler.RevokeSession)
+				r.Post("/mfa/totp/enroll", authHandler.EnrollTOTP)
+				r.Post("/mfa/totp/confirm", authHandler.ConfirmTOTP)
+				r.Post("/mfa/totp/disable", authHandler.DisableTOTP)
+				r.Post("/password", authHandler.ChangePassword)
+				r.Post("/email", authHandler.RequestEmailChange)
+				r.Get("/identities", authHandler.ListIdentities)
+				r.Post("/identities/google", authHandler.LinkGoogleIdentity)
+				r.Post("/identities/local", authHandler.SetLocalPassword)
+				r.Post("/identities/oidc/{provider}/authorize", authHandler.StartOIDCLink)
+				r.Post("/identities/oidc/{provider}", authHandler.LinkOIDCIdentity)
+				r.Delete("/identities/{identity_id}", authHandler.UnlinkIdentity)
 			})
 
 			// 単語
+			r.Post("/words:bulk", wordHandler.BulkWords)
 			r.Route("/words", func(r chi.Router) {
 				r.Post("/", wordHandler.PostWord)
 				r.Get("/", wordHandler.GetWords)
+				r.Post("/import", importHandler.ImportWords)
+				r.Post("/import/anki", importHandler.ImportAnki)
+				r.Get("/export", exportHandler.ExportWords)
+				r.Get("/duplicates", wordHandler.GetDuplicates)
+				r.Post("/extract", wordHandler.ExtractWords)
 				r.Get("/{word_id}", wordHandler.GetWord)
 				r.Put("/{word_id}", wordHandler.PutWord)
 				r.Patch("/{word_id}", wordHandler.PatchWord)
 				r.Delete("/{word_id}", wordHandler.DeleteWord)
+				r.Get("/{word_id}/revisions", wordHandler.ListRevisions)
+				r.Post("/{word_id}/revisions/{rev}/revert", wordHandler.RevertRevision)
+				r.Get("/{word_id}/attachments", attachmentHandler.ListAttachments)
+				r.Post("/{word_id}/attachments", attachmentHandler.UploadAttachment)
+				r.Get("/{word_id}/attachments/{attachment_id}/url", attachmentHandler.GetAttachmentURL)
+				r.Delete("/{word_id}/attachments/{attachment_id}", attachmentHandler.DeleteAttachment)
+			})
+
+			// ゴミ箱
+			r.Route("/trash/words", func(r chi.Router) {
+				r.Get("/", trashHandler.ListWords)
+				r.Delete("/", trashHandler.EmptyTrash)
+				r.Post("/{word_id}/restore", trashHandler.RestoreWord)
+				r.Delete("/{word_id}", trashHandler.PurgeWord)
 			})
 
 			// 復習
@@ -141,6 +257,9 @@ func main() {
 				r.Get("/summary", reviewHandler.GetReviewSummary)
 				r.Put("/{word_id}/result", reviewHandler.UpsertLearningProgressBasedOnReview)
 			})
+
+			// 辞書
+			r.Get("/dictionary/lookup", dictionaryHandler.Lookup)
 		})
 	})
 
@@ -172,6 +291,13 @@ func main() {
 		IdleTimeout:  120 * time.Second,
 	}
 
+	// バックグラウンドジョブ
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if config.Cfg.Trash.PurgeInterval > 0 {
+		go runTrashPurgeJob(jobCtx, trashService, config.Cfg.Trash.PurgeInterval, logger)
+	}
+
 	go func() {
 		slog.Info("Server listening", slog.String("port", config.Cfg.Server.Port))
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -184,6 +310,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	slog.Info("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_words_tags;
+
+ALTER TABLE public.words DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,3 @@
+ALTER TABLE public.words ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_words_tags ON public.words USING GIN (tags);
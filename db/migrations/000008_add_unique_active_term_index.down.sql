@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_words_tenant_term_active;
+
+-- 変更した単語名は戻さない (戻すと再び重複する)。元の単語名を確認できるよう word_duplicate_renames も残す
@@ -0,0 +1,49 @@
+-- 同じテナント内で有効な (論理削除されていない) 単語の重複を防ぐ。
+-- 既に重複している単語がある場合はインデックスを作成できないため、最も古いもの以外の単語名に " (2)", " (3)" … を付けて区別する
+-- (取り込みの重複時の名前の付け方と同じ)。単語は削除せず、変更した単語は word_duplicate_renames に記録する。
+CREATE TABLE IF NOT EXISTS public.word_duplicate_renames (
+    word_id UUID NOT NULL,
+    tenant_id UUID NOT NULL,
+    old_term VARCHAR(255) NOT NULL,
+    new_term VARCHAR(255) NOT NULL,
+    migration VARCHAR(100) NOT NULL, -- 変更したマイグレーション
+    renamed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (word_id, migration)
+);
+
+DO $$
+DECLARE
+    dup RECORD;
+    n INTEGER;
+    candidate VARCHAR(255);
+BEGIN
+    FOR dup IN
+        SELECT word_id, tenant_id, term
+        FROM (
+            SELECT word_id, tenant_id, term,
+                   ROW_NUMBER() OVER (PARTITION BY tenant_id, term ORDER BY created_at, word_id) AS rn
+            FROM public.words
+            WHERE deleted_at IS NULL
+        ) AS ranked
+        WHERE rn > 1
+        ORDER BY tenant_id, term, rn
+    LOOP
+        n := 2;
+        LOOP
+            candidate := left(dup.term, 255 - length(' (' || n || ')')) || ' (' || n || ')';
+            EXIT WHEN NOT EXISTS (
+                SELECT 1 FROM public.words
+                WHERE tenant_id = dup.tenant_id AND term = candidate AND deleted_at IS NULL
+            );
+            n := n + 1;
+        END LOOP;
+
+        UPDATE public.words SET term = candidate, updated_at = CURRENT_TIMESTAMP WHERE word_id = dup.word_id;
+        INSERT INTO public.word_duplicate_renames (word_id, tenant_id, old_term, new_term, migration)
+        VALUES (dup.word_id, dup.tenant_id, dup.term, candidate, '000008_add_unique_active_term_index');
+    END LOOP;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_words_tenant_term_active
+    ON public.words (tenant_id, term)
+    WHERE deleted_at IS NULL;
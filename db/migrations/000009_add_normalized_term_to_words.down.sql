@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS uq_words_tenant_normalized_term_active;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_words_tenant_term_active
+    ON public.words (tenant_id, term)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE public.words DROP COLUMN IF EXISTS normalized_term;
@@ -0,0 +1,55 @@
+-- 表記揺れ (全角/半角、大文字/小文字、前後の空白) を無視して重複を判定するため、正規化した単語を保存する。
+-- アプリケーションは Go の textutil.NormalizeTerm で値を設定する。ここでの初期値はその近似であり、
+-- 正確な値 (カナの統一を含む) はデプロイ後に `normalize-terms` コマンドで再計算する。
+ALTER TABLE public.words ADD COLUMN IF NOT EXISTS normalized_term TEXT;
+
+UPDATE public.words
+SET normalized_term = lower(btrim(regexp_replace(normalize(term, NFKC), '\s+', ' ', 'g')));
+
+ALTER TABLE public.words ALTER COLUMN normalized_term SET NOT NULL;
+
+-- 正規化すると重複する有効な単語は、最も古いもの以外の単語名に " (2)", " (3)" … を付けて区別する。
+-- 単語は削除せず、変更した単語は word_duplicate_renames (000008 で作成) に記録する。
+DO $$
+DECLARE
+    dup RECORD;
+    n INTEGER;
+    candidate VARCHAR(255);
+    candidate_normalized TEXT;
+BEGIN
+    FOR dup IN
+        SELECT word_id, tenant_id, term
+        FROM (
+            SELECT word_id, tenant_id, term,
+                   ROW_NUMBER() OVER (PARTITION BY tenant_id, normalized_term ORDER BY created_at, word_id) AS rn
+            FROM public.words
+            WHERE deleted_at IS NULL
+        ) AS ranked
+        WHERE rn > 1
+        ORDER BY tenant_id, term, rn
+    LOOP
+        n := 2;
+        LOOP
+            candidate := left(dup.term, 255 - length(' (' || n || ')')) || ' (' || n || ')';
+            candidate_normalized := lower(btrim(regexp_replace(normalize(candidate, NFKC), '\s+', ' ', 'g')));
+            EXIT WHEN NOT EXISTS (
+                SELECT 1 FROM public.words
+                WHERE tenant_id = dup.tenant_id AND normalized_term = candidate_normalized AND deleted_at IS NULL
+            );
+            n := n + 1;
+        END LOOP;
+
+        UPDATE public.words
+        SET term = candidate, normalized_term = candidate_normalized, updated_at = CURRENT_TIMESTAMP
+        WHERE word_id = dup.word_id;
+        INSERT INTO public.word_duplicate_renames (word_id, tenant_id, old_term, new_term, migration)
+        VALUES (dup.word_id, dup.tenant_id, dup.term, candidate, '000009_add_normalized_term_to_words');
+    END LOOP;
+END $$;
+
+-- 一意性は正規化した単語で判定する (正規化が同じなら元の単語も同じなので、既存のインデックスは不要になる)
+DROP INDEX IF EXISTS uq_words_tenant_term_active;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_words_tenant_normalized_term_active
+    ON public.words (tenant_id, normalized_term)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,19 @@
+-- 単語の変更履歴。wordRepo.Update を通した変更ごとに1行追加する
+CREATE TABLE IF NOT EXISTS word_revisions (
+    revision_id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    word_id UUID NOT NULL,
+    revision_no INTEGER NOT NULL, -- 単語ごとの通し番号 (1始まり)
+    old_values JSONB NOT NULL, -- 変更された項目の変更前の値
+    new_values JSONB NOT NULL, -- 変更された項目の変更後の値
+    request_id TEXT NOT NULL DEFAULT '', -- 変更したリクエストのID (ログとの突き合わせ用)
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    -- 単語を完全削除したら履歴も削除する (ゴミ箱にある間は残る)
+    FOREIGN KEY (word_id) REFERENCES words(word_id) ON DELETE CASCADE,
+
+    UNIQUE (word_id, revision_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_revisions_tenant_word ON word_revisions (tenant_id, word_id);
@@ -0,0 +1,5 @@
+ALTER TABLE public.words
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS examples,
+    DROP COLUMN IF EXISTS part_of_speech,
+    DROP COLUMN IF EXISTS reading;
@@ -0,0 +1,6 @@
+-- 単語の詳細情報: 読み (ふりがな)、品詞、例文 (訳付き)、メモ
+ALTER TABLE public.words
+    ADD COLUMN IF NOT EXISTS reading TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS part_of_speech TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS examples JSONB NOT NULL DEFAULT '[]', -- [{"sentence": "...", "translation": "..."}]
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '';
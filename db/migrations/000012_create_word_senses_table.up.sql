@@ -0,0 +1,18 @@
+-- 単語の語義。1つの単語に複数の意味 (例: bank の「土手」と「銀行」) を順序付きで登録する
+CREATE TABLE IF NOT EXISTS word_senses (
+    sense_id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    word_id UUID NOT NULL,
+    position INTEGER NOT NULL, -- 表示順 (0始まり)
+    definition TEXT NOT NULL,
+    example TEXT NOT NULL DEFAULT '',
+    tag TEXT NOT NULL DEFAULT '', -- 語義の分野などの任意のラベル (例: "金融")
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    -- 単語を完全削除したら語義も削除する (ゴミ箱にある間は残る)
+    FOREIGN KEY (word_id) REFERENCES words(word_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_senses_word_position ON word_senses (word_id, position);
@@ -0,0 +1,19 @@
+-- 単語の添付ファイル (発音の音声・画像)。ファイル本体はブロブストレージに保存し、ここにはメタデータだけを持つ
+CREATE TABLE IF NOT EXISTS word_attachments (
+    attachment_id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    word_id UUID NOT NULL,
+    kind TEXT NOT NULL, -- 'audio' または 'image'
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    filename TEXT NOT NULL DEFAULT '', -- アップロード時の元のファイル名 (表示用)
+    storage_key TEXT NOT NULL, -- ブロブストレージ上のキー (words/{word_id}/{attachment_id})
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    -- 単語を完全削除したら添付ファイルのメタデータも削除する (ファイル本体はアプリケーションが削除する)
+    FOREIGN KEY (word_id) REFERENCES words(word_id) ON DELETE CASCADE,
+    CONSTRAINT chk_word_attachments_kind CHECK (kind IN ('audio', 'image'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_attachments_word_id ON word_attachments (word_id, created_at);
@@ -0,0 +1,8 @@
+-- 穴埋めの進捗は従来の一意制約に収まらないため削除する
+DELETE FROM learning_progress WHERE direction <> 'recognition';
+
+ALTER TABLE learning_progress DROP CONSTRAINT IF EXISTS uq_learning_progress_tenant_word_direction;
+ALTER TABLE learning_progress ADD CONSTRAINT learning_progress_tenant_id_word_id_key UNIQUE (tenant_id, word_id);
+
+ALTER TABLE learning_progress DROP CONSTRAINT IF EXISTS chk_learning_progress_direction;
+ALTER TABLE learning_progress DROP COLUMN IF EXISTS direction;
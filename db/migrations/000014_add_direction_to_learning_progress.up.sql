@@ -0,0 +1,12 @@
+-- 学習進捗を出題形式ごとに持てるようにする。
+-- recognition: 単語を見て意味を答える (従来の形式)、cloze: 例文の空欄に単語を答える (穴埋め)
+ALTER TABLE learning_progress
+    ADD COLUMN IF NOT EXISTS direction TEXT NOT NULL DEFAULT 'recognition';
+
+ALTER TABLE learning_progress
+    ADD CONSTRAINT chk_learning_progress_direction CHECK (direction IN ('recognition', 'cloze'));
+
+-- 1つの単語に出題形式ごとの進捗を持てるよう、一意制約に direction を加える
+ALTER TABLE learning_progress DROP CONSTRAINT IF EXISTS learning_progress_tenant_id_word_id_key;
+ALTER TABLE learning_progress
+    ADD CONSTRAINT uq_learning_progress_tenant_word_direction UNIQUE (tenant_id, word_id, direction);
@@ -0,0 +1,7 @@
+-- 読みを利用者が入力したか (true) 、単語から自動生成したか (false) 。
+-- 自動生成の読みは単語の変更に合わせて作り直すが、利用者が入力した読みは上書きしない。
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS reading_manual BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 自動生成の導入前に登録された読みは、すべて利用者が入力したもの
+UPDATE words SET reading_manual = TRUE WHERE reading <> '';
@@ -0,0 +1,17 @@
+-- リフレッシュトークン。トークンそのものは保存せず、SHA-256 のハッシュ (16進数) だけを保存する
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    token_id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    family_id UUID NOT NULL, -- ログインごとに発行し、ローテーションで引き継ぐ
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ, -- ローテーションで新しいトークンに交換した日時
+    revoked_at TIMESTAMPTZ, -- 失効させた日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    CONSTRAINT uq_refresh_tokens_token_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_tenant_id ON refresh_tokens (tenant_id);
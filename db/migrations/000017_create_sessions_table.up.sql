@@ -0,0 +1,25 @@
+-- ログインごとのセッション。アクセストークンの jti とリフレッシュトークンの family_id にセッションIDを使う
+CREATE TABLE IF NOT EXISTS sessions (
+    session_id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMPTZ, -- ログアウト・失効させた日時
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_tenant_id ON sessions (tenant_id, last_seen_at DESC);
+
+-- 発行済みのリフレッシュトークンのファミリーをセッションとして引き継ぐ (端末の情報は不明のまま)
+INSERT INTO sessions (session_id, tenant_id, created_at, last_seen_at, revoked_at)
+SELECT family_id,
+       tenant_id,
+       MIN(created_at),
+       MAX(created_at),
+       CASE WHEN BOOL_AND(revoked_at IS NOT NULL) THEN MAX(revoked_at) END
+FROM refresh_tokens
+GROUP BY family_id, tenant_id
+ON CONFLICT (session_id) DO NOTHING;
@@ -0,0 +1,23 @@
+-- TOTP (認証アプリ) による2段階認証の設定。enabled_at が NULL の間は登録手続き中
+CREATE TABLE IF NOT EXISTS tenant_totp (
+    tenant_id UUID PRIMARY KEY,
+    secret TEXT NOT NULL, -- 暗号化した秘密鍵
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- 最後に受け付けたコードの時間ステップ (同じコードの再利用を防ぐ)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE
+);
+
+-- 2段階認証のリカバリーコード。コードそのものは保存せず、SHA-256 のハッシュ (16進数) だけを保存する
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_tenant_id ON mfa_recovery_codes (tenant_id, code_hash);
@@ -0,0 +1,9 @@
+-- ログインに失敗した回数とロックの状態。複数のサーバーで制限を共有するためDBに保存する
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key TEXT PRIMARY KEY, -- 'account:{メールアドレス}', 'ip:{IPアドレス}' など
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_last_failed_at ON login_attempts (last_failed_at);
@@ -0,0 +1,8 @@
+-- リクエスト数の制限 (トークンバケット) の状態。rate_limit.backend が postgres の場合に使い、複数のサーバーで制限を共有する
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY, -- '{ポリシー名}:{ip|tenant|email}:{値}'
+    tokens DOUBLE PRECISION NOT NULL, -- 残りのトークン数
+    updated_at TIMESTAMPTZ NOT NULL -- tokens を計算した日時
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
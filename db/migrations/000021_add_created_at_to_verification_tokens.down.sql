@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_verification_tokens_tenant_id;
+ALTER TABLE user_verification_tokens DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,8 @@
+-- 確認メールを送り直せる間隔を判定するため、トークンの発行日時を記録する。
+-- 既存のトークンは有効期限 (発行から24時間) から逆算する。
+ALTER TABLE user_verification_tokens
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE user_verification_tokens SET created_at = expires_at - INTERVAL '24 hours';
+
+CREATE INDEX IF NOT EXISTS idx_user_verification_tokens_tenant_id ON user_verification_tokens (tenant_id);
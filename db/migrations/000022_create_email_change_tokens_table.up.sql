@@ -0,0 +1,12 @@
+-- メールアドレスの変更の確認用トークン。新しいメールアドレスに送ったリンクを開くまで変更しない
+CREATE TABLE IF NOT EXISTS email_change_tokens (
+    token TEXT PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    new_email TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_change_tokens_tenant_id ON email_change_tokens (tenant_id);
@@ -0,0 +1,3 @@
+ALTER TABLE user_verification_tokens
+    DROP COLUMN IF EXISTS pending_password_hash,
+    DROP COLUMN IF EXISTS pending_name;
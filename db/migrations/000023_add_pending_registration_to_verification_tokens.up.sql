@@ -0,0 +1,5 @@
+-- 有効化されていないアカウントに登録し直した場合の名前とパスワードを、確認メールのトークンごとに保持する。
+-- 登録し直しただけでは既存の認証情報を書き換えず、そのトークンのリンクで有効化した時に限り反映する。
+ALTER TABLE user_verification_tokens
+    ADD COLUMN IF NOT EXISTS pending_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS pending_password_hash TEXT;
@@ -0,0 +1,85 @@
+// Package blobstore は単語の添付ファイル (音声・画像) などのバイナリを保存するストレージを抽象化します。
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+)
+
+// ストレージの種類 (storage.type)
+const (
+	TypeLocal = "local"
+	TypeS3    = "s3"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Object は読み出したファイルです。Body は呼び出し側で Close してください。
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// BlobStore はキーを指定してファイルを保存・取得・削除するストレージです。
+// キーは "/" 区切りの相対パス (例: "words/{word_id}/{attachment_id}") で、".." などは使えません。
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix は prefix で始まるキーのファイルをすべて削除します (prefix は "/" で終わること)
+	DeletePrefix(ctx context.Context, prefix string) error
+	// SignedURL は認証なしで ttl の間だけダウンロードできるURLを返します
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// NewFromConfig は設定に応じた BlobStore を返します。
+// ローカルの署名鍵が未設定の場合は fallbackSigningKey (JWTの秘密鍵など) を使います。
+func NewFromConfig(ctx context.Context, cfg *config.StorageConfig, fallbackSigningKey string) (BlobStore, error) {
+	switch cfg.Type {
+	case TypeLocal, "":
+		key := cfg.Local.SigningKey
+		if key == "" {
+			key = fallbackSigningKey
+		}
+		return NewLocalStore(cfg.Local.Dir, cfg.Local.BaseURL, []byte(key))
+	case TypeS3:
+		return NewS3Store(ctx, S3Options{
+			Bucket:          cfg.S3.Bucket,
+			Region:          cfg.S3.Region,
+			Endpoint:        cfg.S3.Endpoint,
+			UsePathStyle:    cfg.S3.UsePathStyle,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+		})
+	default:
+		return nil, fmt.Errorf("blobstore: unknown storage type %q", cfg.Type)
+	}
+}
+
+// validateKey はキーがストレージの外を指さない正規化された相対パスかを確認します
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key || key == "." ||
+		strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+// validatePrefix は DeletePrefix に渡す prefix を確認します (誤ってすべてを削除しないよう空は許可しない)
+func validatePrefix(prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return fmt.Errorf("%w: prefix must end with '/': %q", ErrInvalidKey, prefix)
+	}
+	return validateKey(strings.TrimSuffix(prefix, "/"))
+}
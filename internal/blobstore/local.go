@@ -0,0 +1,184 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrURLExpired       = errors.New("signed url expired")
+)
+
+// contentTypeSuffix はファイルの Content-Type を保存する隣接ファイルの拡張子
+const contentTypeSuffix = ".content-type"
+
+// LocalStore はローカルのファイルシステムにファイルを保存する BlobStore です。
+// 署名付きURLはアプリケーション自身が配信する (baseURL 以下のハンドラで Verify してから Open する) 前提です。
+type LocalStore struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewLocalStore は dir 以下にファイルを保存する LocalStore を返します。
+// baseURL は署名付きURLの先頭部分 (例: "http://localhost:8080/api/v1/blobs") です。
+func NewLocalStore(dir, baseURL string, signingKey []byte) (*LocalStore, error) {
+	if dir == "" {
+		return nil, errors.New("blobstore.NewLocalStore: dir is required")
+	}
+	if len(signingKey) == 0 {
+		return nil, errors.New("blobstore.NewLocalStore: signing key is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("blobstore.NewLocalStore: %w", err)
+	}
+	return &LocalStore{
+		dir:        dir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: signingKey,
+		now:        time.Now,
+	}, nil
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	dst := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+
+	// 書き込み途中のファイルが読まれないよう、一時ファイルに書いてから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("blobstore.LocalStore.Put: size mismatch (expected %d, wrote %d)", size, n)
+	}
+	if err := os.WriteFile(dst+contentTypeSuffix, []byte(contentType), 0o640); err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (*Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("blobstore.LocalStore.Open: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("blobstore.LocalStore.Open: %w", err)
+	}
+	contentType, err := os.ReadFile(s.path(key) + contentTypeSuffix)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		f.Close()
+		return nil, fmt.Errorf("blobstore.LocalStore.Open: %w", err)
+	}
+	if len(contentType) == 0 {
+		contentType = []byte("application/octet-stream")
+	}
+	return &Object{Body: f, ContentType: string(contentType), Size: info.Size()}, nil
+}
+
+// Delete はファイルを削除します。存在しない場合も成功とします。
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	for _, p := range []string{s.path(key), s.path(key) + contentTypeSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("blobstore.LocalStore.Delete: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := validatePrefix(prefix); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(s.path(strings.TrimSuffix(prefix, "/"))); err != nil {
+		return fmt.Errorf("blobstore.LocalStore.DeletePrefix: %w", err)
+	}
+	return nil
+}
+
+// SignedURL は baseURL/{key}?expires=...&signature=... 形式のURLを返します
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.sign(key, expires))
+	return s.baseURL + "/" + strings.Join(segments, "/") + "?" + q.Encode(), nil
+}
+
+// Verify は SignedURL で発行したURLのキー・有効期限・署名を検証します
+func (s *LocalStore) Verify(key, expires, signature string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if s.now().Unix() > exp {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,119 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/blobstore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := blobstore.NewLocalStore(t.TempDir(), "http://localhost/api/v1/blobs", []byte("secret"))
+	require.NoError(t, err)
+
+	key := "words/w1/a1"
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5, "audio/mpeg"))
+
+	obj, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	body, err := io.ReadAll(obj.Body)
+	require.NoError(t, obj.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, "audio/mpeg", obj.ContentType)
+	assert.EqualValues(t, 5, obj.Size)
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Open(ctx, key)
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	// 冪等性: 存在しないキーの削除も成功
+	assert.NoError(t, store.Delete(ctx, key))
+}
+
+func TestLocalStore_DeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	store, err := blobstore.NewLocalStore(t.TempDir(), "http://localhost/api/v1/blobs", []byte("secret"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "words/w1/a1", strings.NewReader("a"), 1, "image/png"))
+	require.NoError(t, store.Put(ctx, "words/w1/a2", strings.NewReader("b"), 1, "image/png"))
+	require.NoError(t, store.Put(ctx, "words/w2/a3", strings.NewReader("c"), 1, "image/png"))
+
+	require.NoError(t, store.DeletePrefix(ctx, "words/w1/"))
+
+	for _, key := range []string{"words/w1/a1", "words/w1/a2"} {
+		_, err := store.Open(ctx, key)
+		assert.ErrorIs(t, err, blobstore.ErrNotFound, key)
+	}
+	obj, err := store.Open(ctx, "words/w2/a3")
+	require.NoError(t, err)
+	obj.Body.Close()
+
+	assert.ErrorIs(t, store.DeletePrefix(ctx, ""), blobstore.ErrInvalidKey)
+	assert.ErrorIs(t, store.DeletePrefix(ctx, "words"), blobstore.ErrInvalidKey)
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store, err := blobstore.NewLocalStore(t.TempDir(), "http://localhost/api/v1/blobs", []byte("secret"))
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../x", "words/../../x", "words//a", `words\a`} {
+		t.Run(key, func(t *testing.T) {
+			err := store.Put(ctx, key, strings.NewReader("x"), 1, "image/png")
+			assert.ErrorIs(t, err, blobstore.ErrInvalidKey)
+		})
+	}
+}
+
+func TestLocalStore_SignedURL(t *testing.T) {
+	ctx := context.Background()
+	store, err := blobstore.NewLocalStore(t.TempDir(), "http://localhost/api/v1/blobs/", []byte("secret"))
+	require.NoError(t, err)
+
+	signed, err := store.SignedURL(ctx, "words/w1/a1", time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/blobs/words/w1/a1", u.Path)
+
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	tests := []struct {
+		name      string
+		key       string
+		expires   string
+		signature string
+		wantErr   error
+	}{
+		{name: "正常系: 発行したURL", key: "words/w1/a1", expires: expires, signature: signature},
+		{name: "異常系: 別のキー", key: "words/w1/a2", expires: expires, signature: signature, wantErr: blobstore.ErrInvalidSignature},
+		{name: "異常系: 有効期限の改ざん", key: "words/w1/a1", expires: "9999999999", signature: signature, wantErr: blobstore.ErrInvalidSignature},
+		{name: "異常系: 署名なし", key: "words/w1/a1", expires: expires, signature: "", wantErr: blobstore.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.Verify(tt.key, tt.expires, tt.signature)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	expired, err := store.SignedURL(ctx, "words/w1/a1", -time.Minute)
+	require.NoError(t, err)
+	u, err = url.Parse(expired)
+	require.NoError(t, err)
+	assert.ErrorIs(t, store.Verify("words/w1/a1", u.Query().Get("expires"), u.Query().Get("signature")), blobstore.ErrURLExpired)
+}
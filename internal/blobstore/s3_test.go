@@ -0,0 +1,189 @@
+package blobstore_test
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/blobstore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 はパス形式 (/{bucket}/{key}) のリクエストに応答する、MinIOの代わりのインメモリのS3互換サーバーです。
+// 署名は検証せず、テストに必要な PutObject / GetObject / DeleteObject / ListObjectsV2 だけを実装しています。
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	body        []byte
+	contentType string
+}
+
+func newFakeS3(bucket string) *fakeS3 {
+	return &fakeS3{bucket: bucket, objects: make(map[string]fakeObject)}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket != f.bucket {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		f.list(w, r.URL.Query().Get("prefix"))
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = fakeObject{body: body, contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet:
+		obj, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Write(obj.body)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, prefix string) {
+	type content struct {
+		Key  string `xml:"Key"`
+		Size int    `xml:"Size"`
+	}
+	result := struct {
+		XMLName     xml.Name  `xml:"ListBucketResult"`
+		Name        string    `xml:"Name"`
+		Prefix      string    `xml:"Prefix"`
+		KeyCount    int       `xml:"KeyCount"`
+		IsTruncated bool      `xml:"IsTruncated"`
+		Contents    []content `xml:"Contents"`
+	}{Name: f.bucket, Prefix: prefix}
+	for key, obj := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			result.Contents = append(result.Contents, content{Key: key, Size: len(obj.body)})
+		}
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+	result.KeyCount = len(result.Contents)
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
+
+func newTestS3Store(t *testing.T) (*blobstore.S3Store, *fakeS3, *httptest.Server) {
+	t.Helper()
+	fake := newFakeS3("vocab-test")
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := blobstore.NewS3Store(context.Background(), blobstore.S3Options{
+		Bucket:          "vocab-test",
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		UsePathStyle:    true,
+		AccessKeyID:     "minioadmin",
+		SecretAccessKey: "minioadmin",
+	})
+	require.NoError(t, err)
+	return store, fake, server
+}
+
+func TestS3Store_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store, fake, _ := newTestS3Store(t)
+
+	key := "words/w1/a1"
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5, "audio/mpeg"))
+	assert.Equal(t, "hello", string(fake.objects[key].body))
+	assert.Equal(t, "audio/mpeg", fake.objects[key].contentType)
+
+	obj, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	body, err := io.ReadAll(obj.Body)
+	require.NoError(t, obj.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, "audio/mpeg", obj.ContentType)
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Open(ctx, key)
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+}
+
+func TestS3Store_PutNonSeekableReader(t *testing.T) {
+	ctx := context.Background()
+	store, fake, _ := newTestS3Store(t)
+
+	// io.Reader だけを実装した入力 (シークできない)
+	r := io.MultiReader(strings.NewReader("hel"), strings.NewReader("lo"))
+	require.NoError(t, store.Put(ctx, "words/w1/a1", r, -1, "image/png"))
+	assert.Equal(t, "hello", string(fake.objects["words/w1/a1"].body))
+}
+
+func TestS3Store_DeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	store, fake, _ := newTestS3Store(t)
+
+	for _, key := range []string{"words/w1/a1", "words/w1/a2", "words/w2/a3"} {
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("x"), 1, "image/png"))
+	}
+
+	require.NoError(t, store.DeletePrefix(ctx, "words/w1/"))
+
+	var keys []string
+	for key := range fake.objects {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"words/w2/a3"}, keys)
+}
+
+func TestS3Store_SignedURL(t *testing.T) {
+	ctx := context.Background()
+	store, _, server := newTestS3Store(t)
+
+	require.NoError(t, store.Put(ctx, "words/w1/a1", strings.NewReader("hello"), 5, "audio/mpeg"))
+
+	signed, err := store.SignedURL(ctx, "words/w1/a1", 15*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed, server.URL+"/vocab-test/words/w1/a1?"), signed)
+	assert.Contains(t, signed, "X-Amz-Signature=")
+	assert.Contains(t, signed, "X-Amz-Expires=900")
+
+	resp, err := http.Get(signed)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello", string(body))
+}
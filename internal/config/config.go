@@ -24,6 +24,8 @@ type DatabaseConfig struct {
 
 type ServerConfig struct {
 	Port string `mapstructure:"port"`
+	// X-Forwarded-For を信頼するリバースプロキシ (CIDR または IP アドレス)。空なら接続元の IP アドレスをそのまま使う
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type AppConfig struct {
@@ -32,8 +34,20 @@ type AppConfig struct {
 	FrontendURL string `mapstructure:"frontend_url"`
 }
 
+type LoginProtectionConfig struct {
+	AccountMaxFailures int           `mapstructure:"account_max_failures"` // アカウントごとに、ロックせずに許す連続した失敗の回数
+	IPMaxFailures      int           `mapstructure:"ip_max_failures"`      // IPアドレスごとに、ロックせずに許す連続した失敗の回数
+	BaseLockout        time.Duration `mapstructure:"base_lockout"`         // 最初のロックの時間 (以降は失敗するたびに倍になる)
+	MaxLockout         time.Duration `mapstructure:"max_lockout"`          // ロックの時間の上限
+	ResetAfter         time.Duration `mapstructure:"reset_after"`          // 最後の失敗からこの時間が経つと回数を数え直す
+}
+
 type AuthConfig struct {
-	Enabled bool `mapstructure:"enabled"`
+	Enabled         bool                  `mapstructure:"enabled"`
+	SessionCacheTTL time.Duration         `mapstructure:"session_cache_ttl"` // セッションの失効の確認結果をキャッシュする時間
+	LoginProtection LoginProtectionConfig `mapstructure:"login_protection"`
+	// 確認メールを送り直せる間隔 (再送・有効化前の登録し直しに適用する)
+	VerificationResendCooldown time.Duration `mapstructure:"verification_resend_cooldown"`
 }
 
 type CORSConfig struct {
@@ -46,9 +60,26 @@ type CORSConfig struct {
 	Debug            bool     `mapstructure:"debug"`
 }
 
+type RefreshCookieConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`   // true の場合、リフレッシュトークンをレスポンスボディではなく HttpOnly Cookie で送る
+	Name     string `mapstructure:"name"`      // Cookie の名前
+	Path     string `mapstructure:"path"`      // Cookie を送る対象のパス
+	Domain   string `mapstructure:"domain"`    // 空ならAPIのホストのみ
+	Secure   bool   `mapstructure:"secure"`    // true の場合、HTTPSでのみ送る
+	SameSite string `mapstructure:"same_site"` // "strict", "lax" or "none"
+}
+
 type JWTConfig struct {
-	SecretKey      string        `mapstructure:"secret_key"`
-	AccessTokenTTL time.Duration `mapstructure:"access_token_ttl"`
+	SecretKey       string              `mapstructure:"secret_key"`
+	AccessTokenTTL  time.Duration       `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL time.Duration       `mapstructure:"refresh_token_ttl"`
+	RefreshCookie   RefreshCookieConfig `mapstructure:"refresh_cookie"`
+}
+
+type MFAConfig struct {
+	Issuer        string        `mapstructure:"issuer"`         // 認証アプリに表示する発行者名 (空ならアプリ名)
+	ChallengeTTL  time.Duration `mapstructure:"challenge_ttl"`  // パスワード認証後、認証アプリのコードを入力するまでの有効期限
+	EncryptionKey string        `mapstructure:"encryption_key"` // TOTPの秘密鍵をDBに保存する際の暗号鍵 (空ならJWTの秘密鍵から導出する)
 }
 
 type SMTPConfig struct {
@@ -77,6 +108,92 @@ type GoogleOAuthConfig struct {
 	RedirectURL  string `mapstructure:"redirect_url"`
 }
 
+type TrashConfig struct {
+	RetentionDays int           `mapstructure:"retention_days"` // 削除した単語をゴミ箱に残す日数
+	PurgeInterval time.Duration `mapstructure:"purge_interval"` // 保持期間を過ぎた単語を完全削除するジョブの実行間隔 (0なら実行しない)
+}
+
+type LocalStorageConfig struct {
+	Dir        string `mapstructure:"dir"`         // ファイルを保存するディレクトリ
+	BaseURL    string `mapstructure:"base_url"`    // 署名付きURLの先頭部分 (ファイルを配信するAPIのURL)
+	SigningKey string `mapstructure:"signing_key"` // 署名付きURLの署名鍵 (空ならJWTの秘密鍵を使う)
+}
+
+type S3StorageConfig struct {
+	Bucket          string `mapstructure:"bucket"`
+	Region          string `mapstructure:"region"`
+	Endpoint        string `mapstructure:"endpoint"`       // MinIOなどS3互換ストレージのURL (空ならAWSのS3)
+	UsePathStyle    bool   `mapstructure:"use_path_style"` // MinIOなどでは true にする
+	AccessKeyID     string `mapstructure:"access_key_id"`  // 空ならIAMロールの認証情報を使う
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+}
+
+type StorageConfig struct {
+	Type         string             `mapstructure:"type"` // "local" or "s3"
+	Local        LocalStorageConfig `mapstructure:"local"`
+	S3           S3StorageConfig    `mapstructure:"s3"`
+	SignedURLTTL time.Duration      `mapstructure:"signed_url_ttl"` // 署名付きURLの有効期間
+}
+
+type AttachmentConfig struct {
+	MaxAudioSize int64 `mapstructure:"max_audio_size"` // 音声ファイルの最大サイズ (バイト)
+	MaxImageSize int64 `mapstructure:"max_image_size"` // 画像ファイルの最大サイズ (バイト)
+	MaxPerWord   int   `mapstructure:"max_per_word"`   // 1つの単語に添付できるファイルの数
+}
+
+type ImportConfig struct {
+	MaxAnkiCollectionSize int64 `mapstructure:"max_anki_collection_size"` // Anki のパッケージ内のコレクションの展開後の最大サイズ (バイト)
+}
+
+type TermNormalizationConfig struct {
+	UnifyKana bool `mapstructure:"unify_kana"` // true の場合、カタカナとひらがなの違いを無視して重複を判定する
+}
+
+type OfflineDictionaryConfig struct {
+	File   string `mapstructure:"file"`   // 辞書ファイルのパス (gzip圧縮されたものも可)
+	Format string `mapstructure:"format"` // "jmdict", "edict" or "tsv" (空なら拡張子から判定)
+}
+
+type HTTPDictionaryConfig struct {
+	URL     string        `mapstructure:"url"`     // 検索APIのURL (term クエリパラメータを付けて呼び出す)
+	APIKey  string        `mapstructure:"api_key"` // 空でなければ Authorization: Bearer ヘッダーで送る
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type DictionaryConfig struct {
+	Type       string                  `mapstructure:"type"`        // "" (使わない), "offline" or "http"
+	MaxResults int                     `mapstructure:"max_results"` // 検索結果として返す見出しの最大数
+	Offline    OfflineDictionaryConfig `mapstructure:"offline"`
+	HTTP       HTTPDictionaryConfig    `mapstructure:"http"`
+}
+
+type RateLimitPolicyConfig struct {
+	Key    string        `mapstructure:"key"`    // 制限の単位: "ip", "tenant" (認証済みのテナント) or "email" (リクエストボディの email)
+	Limit  int           `mapstructure:"limit"`  // period あたりに受け付けるリクエスト数
+	Period time.Duration `mapstructure:"period"` // limit を数える期間
+	Burst  int           `mapstructure:"burst"`  // 連続して受け付ける最大数 (0なら limit)
+}
+
+type RateLimitConfig struct {
+	Enabled  bool                             `mapstructure:"enabled"`
+	Backend  string                           `mapstructure:"backend"` // "memory" (サーバーごと) or "postgres" (全サーバーで共有)
+	Policies map[string]RateLimitPolicyConfig `mapstructure:"policies"`
+}
+
+// OIDCProviderConfig は OpenID Connect のプロバイダの設定です
+type OIDCProviderConfig struct {
+	Name         string   `mapstructure:"name"`          // ログイン画面に表示する名前
+	Issuer       string   `mapstructure:"issuer"`        // ディスカバリに使う発行者のURL
+	ClientID     string   `mapstructure:"client_id"`     // ID トークンの aud として検証する
+	ClientSecret string   `mapstructure:"client_secret"` // 機密情報
+	RedirectURL  string   `mapstructure:"redirect_url"`  // 認可後に戻るフロントエンドのURL
+	Scopes       []string `mapstructure:"scopes"`        // 省略時は openid, email, profile
+}
+
+type OIDCConfig struct {
+	Providers map[string]OIDCProviderConfig `mapstructure:"providers"` // キーは Identity.AuthProvider として保存する
+}
+
 type Config struct {
 	Database    DatabaseConfig    `mapstructure:"database"`
 	Server      ServerConfig      `mapstructure:"server"`
@@ -85,10 +202,20 @@ type Config struct {
 	Log         LogConfig         `mapstructure:"log"`
 	CORS        CORSConfig        `mapstructure:"cors"`
 	JWT         JWTConfig         `mapstructure:"jwt"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	MFA         MFAConfig         `mapstructure:"mfa"`
 	SMTP        SMTPConfig        `mapstructure:"smtp"`
 	SES         SESConfig         `mapstructure:"ses"`
 	Mailer      MailerConfig      `mapstructure:"mailer"`
 	GoogleOAuth GoogleOAuthConfig `mapstructure:"google_oauth"`
+	OIDC        OIDCConfig        `mapstructure:"oidc"`
+	Trash       TrashConfig       `mapstructure:"trash"`
+	Import      ImportConfig      `mapstructure:"import"`
+
+	TermNormalization TermNormalizationConfig `mapstructure:"term_normalization"`
+	Storage           StorageConfig           `mapstructure:"storage"`
+	Attachment        AttachmentConfig        `mapstructure:"attachment"`
+	Dictionary        DictionaryConfig        `mapstructure:"dictionary"`
 }
 
 // Cfg はアプリケーション全体の設定を保持するグローバル変数
@@ -0,0 +1,91 @@
+// Package dictionary は単語の意味を調べる辞書 (オフラインの辞書ファイル・外部の検索API) を抽象化します。
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go_4_vocab_keep/internal/config"
+)
+
+// 辞書の種類 (dictionary.type)
+const (
+	TypeOffline = "offline"
+	TypeHTTP    = "http"
+)
+
+// Entry は辞書の見出し1件です
+type Entry struct {
+	Term         string   `json:"term"`
+	Reading      string   `json:"reading"`        // 読み (見出しがかなの場合や、辞書に読みがない場合は空)
+	Definitions  []string `json:"definitions"`    // 語義ごとの意味
+	PartOfSpeech string   `json:"part_of_speech"` // 品詞 (model.PartOfSpeechXxx と同じ値。不明なら空)
+}
+
+// DictionaryProvider は単語の意味を調べる辞書です。
+// 見つからない場合はエラーではなく空のスライスを返します。見出しは辞書の優先度 (よく使われる語が先) の順に並びます。
+type DictionaryProvider interface {
+	Lookup(ctx context.Context, term string) ([]Entry, error)
+}
+
+// NewFromConfig は設定に応じた DictionaryProvider を返します。辞書を使わない設定 (type が空) の場合は nil を返します。
+func NewFromConfig(cfg *config.DictionaryConfig) (DictionaryProvider, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case TypeOffline:
+		return LoadOfflineProvider(cfg.Offline.File, cfg.Offline.Format)
+	case TypeHTTP:
+		return NewHTTPProvider(HTTPOptions{
+			URL:     cfg.HTTP.URL,
+			APIKey:  cfg.HTTP.APIKey,
+			Timeout: cfg.HTTP.Timeout,
+		})
+	default:
+		return nil, fmt.Errorf("dictionary: unknown dictionary type %q", cfg.Type)
+	}
+}
+
+// 品詞の値 (model.PartOfSpeechXxx と同じ。dictionary は model に依存しないため文字列で持つ)
+const (
+	posNoun         = "noun"
+	posVerb         = "verb"
+	posAdjective    = "adjective"
+	posAdverb       = "adverb"
+	posPronoun      = "pronoun"
+	posConjunction  = "conjunction"
+	posInterjection = "interjection"
+	posParticle     = "particle"
+	posAuxiliary    = "auxiliary"
+	posPhrase       = "phrase"
+)
+
+// partOfSpeechFromTag は JMdict/EDICT の品詞タグ (n, v5k, adj-i など) を品詞の値に変換します。
+// 品詞以外のタグ (uk, vt など) の場合は false を返します。
+func partOfSpeechFromTag(tag string) (string, bool) {
+	switch {
+	case tag == "n" || strings.HasPrefix(tag, "n-") || tag == "vs":
+		return posNoun, true // vs は「する」を付けて動詞になる名詞
+	case tag == "adj-i" || tag == "adj-ix" || tag == "adj-na" || tag == "adj-ku" || tag == "adj-shiku" || tag == "adj-t" || tag == "adj-f":
+		return posAdjective, true
+	case tag == "adv" || tag == "adv-to":
+		return posAdverb, true
+	case tag == "v1" || tag == "v1-s" || strings.HasPrefix(tag, "v5") || strings.HasPrefix(tag, "v2") || strings.HasPrefix(tag, "v4") ||
+		tag == "vk" || tag == "vz" || tag == "vs-i" || tag == "vs-s" || tag == "vs-c" || tag == "vr" || tag == "vn":
+		return posVerb, true
+	case tag == "pn":
+		return posPronoun, true
+	case tag == "conj":
+		return posConjunction, true
+	case tag == "int":
+		return posInterjection, true
+	case tag == "prt":
+		return posParticle, true
+	case tag == "aux" || tag == "aux-v" || tag == "aux-adj":
+		return posAuxiliary, true
+	case tag == "exp":
+		return posPhrase, true
+	}
+	return "", false
+}
@@ -0,0 +1,146 @@
+package dictionary
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// maxEDICTLineSize は EDICT の1行の最大サイズ (語義の多い見出しは数KBになる)
+const maxEDICTLineSize = 1 << 20
+
+// parseEDICT は EDICT / EDICT2 形式の辞書を読み込みます。UTF-8 でなければ EUC-JP (配布元の文字コード) として扱います。
+//
+//	漢字;漢字2 [かな;かな2] /(n,vs) (1) gloss; gloss/(2) gloss/EntL1234X/
+func parseEDICT(r io.Reader) ([]parsedEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(data) {
+		if data, err = japanese.EUCJP.NewDecoder().Bytes(data); err != nil {
+			return nil, fmt.Errorf("decode EUC-JP: %w", err)
+		}
+	}
+
+	var entries []parsedEntry
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), maxEDICTLineSize)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "？？？") {
+			continue // 空行・コメント・ファイル先頭の著作権表示の行
+		}
+		if pe, ok := parseEDICTLine(line); ok {
+			entries = append(entries, pe)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func parseEDICTLine(line string) (parsedEntry, bool) {
+	slash := strings.Index(line, " /")
+	if slash < 0 {
+		return parsedEntry{}, false
+	}
+	head, body := line[:slash], strings.Trim(line[slash+2:], "/")
+
+	var kanji, kana []string
+	if open := strings.Index(head, " ["); open >= 0 && strings.HasSuffix(head, "]") {
+		kanji = splitEDICTHeadwords(head[:open])
+		kana = splitEDICTHeadwords(head[open+2 : len(head)-1])
+	} else {
+		kana = splitEDICTHeadwords(head)
+	}
+	if len(kanji) == 0 && len(kana) == 0 {
+		return parsedEntry{}, false
+	}
+
+	pe := parsedEntry{headwords: append(kanji, kana...)}
+	if len(kanji) > 0 {
+		pe.entry.Term = kanji[0]
+		if len(kana) > 0 {
+			pe.entry.Reading = kana[0]
+		}
+	} else {
+		pe.entry.Term = kana[0]
+	}
+
+	for _, field := range strings.Split(body, "/") {
+		if field == "" || strings.HasPrefix(field, "EntL") {
+			continue
+		}
+		tags, text := splitLeadingTags(field)
+		newSense := false
+		for _, tag := range tags {
+			if isSenseNumber(tag) {
+				newSense = true
+				continue
+			}
+			for _, t := range strings.Split(tag, ",") {
+				if pos, ok := partOfSpeechFromTag(t); ok && pe.entry.PartOfSpeech == "" {
+					pe.entry.PartOfSpeech = pos
+				}
+			}
+		}
+		if text == "" {
+			continue
+		}
+		// 番号のない訳語は同じ語義の続き
+		if n := len(pe.entry.Definitions); newSense || n == 0 {
+			pe.entry.Definitions = append(pe.entry.Definitions, text)
+		} else {
+			pe.entry.Definitions[n-1] += "; " + text
+		}
+	}
+	return pe, true
+}
+
+// splitEDICTHeadwords は ";" で区切られた表記を分割し、(P) などの付記を取り除きます
+func splitEDICTHeadwords(s string) []string {
+	var words []string
+	for _, w := range strings.Split(s, ";") {
+		if i := strings.Index(w, "("); i >= 0 {
+			w = w[:i]
+		}
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// splitLeadingTags は先頭の括弧書き ((n,vs) (1) (uk) など) をタグとして取り出し、残りの訳語を返します
+func splitLeadingTags(s string) ([]string, string) {
+	var tags []string
+	s = strings.TrimSpace(s)
+	for strings.HasPrefix(s, "(") {
+		end := strings.Index(s, ")")
+		if end < 0 {
+			break
+		}
+		tags = append(tags, s[1:end])
+		s = strings.TrimSpace(s[end+1:])
+	}
+	return tags, s
+}
+
+func isSenseNumber(tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for _, r := range tag {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
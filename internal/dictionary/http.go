@@ -0,0 +1,102 @@
+package dictionary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultHTTPTimeout は HTTPOptions.Timeout を指定しなかった場合のタイムアウト
+const defaultHTTPTimeout = 5 * time.Second
+
+// maxHTTPResponseSize は検索APIのレスポンスとして読み込む最大サイズ
+const maxHTTPResponseSize = 1 << 20
+
+// HTTPOptions は HTTPProvider の設定です
+type HTTPOptions struct {
+	URL     string        // 検索APIのURL (term クエリパラメータを付けて GET で呼び出す)
+	APIKey  string        // 空でなければ Authorization: Bearer ヘッダーで送る
+	Timeout time.Duration // Client を指定しない場合のタイムアウト (0なら defaultHTTPTimeout)
+	Client  *http.Client  // 省略時は Timeout を設定した http.Client
+}
+
+// HTTPProvider は外部の検索APIで単語を調べる DictionaryProvider です。
+// APIは GET {url}?term=... に対して {"entries": [Entry...]} を返すものとします (このAPIの /api/v1/dictionary/lookup と同じ形式)。
+// 404 は見つからなかったものとして扱います。
+type HTTPProvider struct {
+	url    *url.URL
+	apiKey string
+	client *http.Client
+}
+
+// httpLookupResponse は検索APIのレスポンスです
+type httpLookupResponse struct {
+	Entries []Entry `json:"entries"`
+}
+
+// NewHTTPProvider は HTTPProvider を返します
+func NewHTTPProvider(opts HTTPOptions) (*HTTPProvider, error) {
+	if opts.URL == "" {
+		return nil, errors.New("dictionary: http dictionary url is not configured")
+	}
+	u, err := url.Parse(opts.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("dictionary: invalid http dictionary url %q", opts.URL)
+	}
+	client := opts.Client
+	if client == nil {
+		timeout := opts.Timeout
+		if timeout <= 0 {
+			timeout = defaultHTTPTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	return &HTTPProvider{url: u, apiKey: opts.APIKey, client: client}, nil
+}
+
+func (p *HTTPProvider) Lookup(ctx context.Context, term string) ([]Entry, error) {
+	u := *p.url
+	q := u.Query()
+	q.Set("term", term)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary.HTTPProvider.Lookup: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary.HTTPProvider.Lookup: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return []Entry{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dictionary.HTTPProvider.Lookup: unexpected status %d", resp.StatusCode)
+	}
+
+	var body httpLookupResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxHTTPResponseSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("dictionary.HTTPProvider.Lookup: decode response: %w", err)
+	}
+	entries := make([]Entry, 0, len(body.Entries))
+	for _, e := range body.Entries {
+		if e.Term == "" || len(e.Definitions) == 0 {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
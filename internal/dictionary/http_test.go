@@ -0,0 +1,68 @@
+package dictionary_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_4_vocab_keep/internal/dictionary"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider_Lookup(t *testing.T) {
+	var gotQuery, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		gotAuth = r.Header.Get("Authorization")
+		switch r.URL.Query().Get("term") {
+		case "りんご":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"term": "りんご",
+				"entries": []map[string]any{
+					{"term": "林檎", "reading": "りんご", "definitions": []string{"apple"}, "part_of_speech": "noun"},
+					{"term": "空の見出し", "definitions": []string{}}, // 意味のない見出しは除く
+				},
+			})
+		case "missing":
+			http.NotFound(w, r)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := dictionary.NewHTTPProvider(dictionary.HTTPOptions{URL: srv.URL + "/lookup?lang=ja", APIKey: "secret", Client: srv.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("正常系: 見出しを返す", func(t *testing.T) {
+		got, err := p.Lookup(ctx, "りんご")
+		require.NoError(t, err)
+		assert.Equal(t, []dictionary.Entry{{Term: "林檎", Reading: "りんご", Definitions: []string{"apple"}, PartOfSpeech: "noun"}}, got)
+		assert.Equal(t, "lang=ja&term=%E3%82%8A%E3%82%93%E3%81%94", gotQuery)
+		assert.Equal(t, "Bearer secret", gotAuth)
+	})
+
+	t.Run("正常系: 404は見つからなかったものとして扱う", func(t *testing.T) {
+		got, err := p.Lookup(ctx, "missing")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("異常系: 検索APIのエラー", func(t *testing.T) {
+		_, err := p.Lookup(ctx, "error")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewHTTPProvider_InvalidURL(t *testing.T) {
+	for _, u := range []string{"", "ftp://example.com", "/lookup"} {
+		_, err := dictionary.NewHTTPProvider(dictionary.HTTPOptions{URL: u})
+		assert.Error(t, err, u)
+	}
+}
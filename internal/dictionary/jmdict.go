@@ -0,0 +1,88 @@
+package dictionary
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// jmdictEntry は JMdict の <entry> 要素です (使う項目だけを読み込む)
+type jmdictEntry struct {
+	Kanji    []string `xml:"k_ele>keb"`
+	Readings []string `xml:"r_ele>reb"`
+	Senses   []struct {
+		POS     []string `xml:"pos"`
+		Glosses []struct {
+			Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"gloss"`
+	} `xml:"sense"`
+}
+
+// parseJMdict は JMdict の XML を読み込みます。訳語は英語 (xml:lang が未指定か eng) のものだけを使います。
+// 品詞は DTD で定義された実体参照 (&n; など) で書かれているため、展開せずにタグ名として扱います。
+func parseJMdict(r io.Reader) ([]parsedEntry, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false // DTD の実体参照をそのまま文字列として読み込む
+
+	var entries []parsedEntry
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "entry" {
+			continue
+		}
+		var je jmdictEntry
+		if err := d.DecodeElement(&je, &start); err != nil {
+			return nil, err
+		}
+		if pe, ok := jmdictToEntry(&je); ok {
+			entries = append(entries, pe)
+		}
+	}
+	return entries, nil
+}
+
+func jmdictToEntry(je *jmdictEntry) (parsedEntry, bool) {
+	if len(je.Kanji) == 0 && len(je.Readings) == 0 {
+		return parsedEntry{}, false
+	}
+	pe := parsedEntry{headwords: append(append([]string{}, je.Kanji...), je.Readings...)}
+	if len(je.Kanji) > 0 {
+		pe.entry.Term = je.Kanji[0]
+		if len(je.Readings) > 0 {
+			pe.entry.Reading = je.Readings[0]
+		}
+	} else {
+		pe.entry.Term = je.Readings[0]
+	}
+
+	for _, sense := range je.Senses {
+		for _, p := range sense.POS {
+			tag := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(p), "&"), ";")
+			if pos, ok := partOfSpeechFromTag(tag); ok && pe.entry.PartOfSpeech == "" {
+				pe.entry.PartOfSpeech = pos
+			}
+		}
+		var glosses []string
+		for _, g := range sense.Glosses {
+			if g.Lang != "" && g.Lang != "eng" {
+				continue
+			}
+			if text := strings.TrimSpace(g.Text); text != "" {
+				glosses = append(glosses, text)
+			}
+		}
+		if len(glosses) > 0 {
+			pe.entry.Definitions = append(pe.entry.Definitions, strings.Join(glosses, "; "))
+		}
+	}
+	return pe, true
+}
@@ -0,0 +1,156 @@
+package dictionary
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go_4_vocab_keep/internal/textutil"
+)
+
+// 辞書ファイルの形式 (dictionary.offline.format)
+const (
+	FormatJMdict = "jmdict" // JMdict の XML (JMdict_e など)
+	FormatEDICT  = "edict"  // EDICT / EDICT2 (UTF-8 または EUC-JP)
+	FormatTSV    = "tsv"    // 単語<TAB>読み<TAB>意味[<TAB>品詞] (同じ単語・読みの行は語義としてまとめる)
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported dictionary format")
+
+// parsedEntry は辞書ファイルから読み込んだ見出しと、検索に使う表記 (漢字・かなのすべての表記) です
+type parsedEntry struct {
+	entry     Entry
+	headwords []string
+}
+
+// OfflineProvider は辞書ファイルをメモリに読み込んで検索する DictionaryProvider です
+type OfflineProvider struct {
+	entries []Entry
+	index   map[string][]int // 正規化した表記 → entries の添字
+}
+
+// LoadOfflineProvider は辞書ファイルを読み込みます。format が空の場合は拡張子から判定します。
+func LoadOfflineProvider(path, format string) (*OfflineProvider, error) {
+	if path == "" {
+		return nil, errors.New("dictionary: offline dictionary file is not configured")
+	}
+	if format == "" {
+		format = detectFormat(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary: %w", err)
+	}
+	defer f.Close()
+
+	p, err := NewOfflineProvider(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary: load %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// detectFormat はファイル名から辞書の形式を推定します (.gz は除いて判定する)
+func detectFormat(path string) string {
+	name := strings.ToLower(filepath.Base(strings.TrimSuffix(path, ".gz")))
+	switch {
+	case strings.HasSuffix(name, ".tsv"):
+		return FormatTSV
+	case strings.HasSuffix(name, ".xml"), strings.HasPrefix(name, "jmdict"):
+		return FormatJMdict
+	default:
+		return FormatEDICT
+	}
+}
+
+// NewOfflineProvider は r から format 形式の辞書を読み込みます。gzip で圧縮されている場合は展開します。
+func NewOfflineProvider(r io.Reader, format string) (*OfflineProvider, error) {
+	r, err := maybeGunzip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed []parsedEntry
+	switch format {
+	case FormatJMdict:
+		parsed, err = parseJMdict(r)
+	case FormatEDICT:
+		parsed, err = parseEDICT(r)
+	case FormatTSV:
+		parsed, err = parseTSV(r)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := &OfflineProvider{
+		entries: make([]Entry, 0, len(parsed)),
+		index:   make(map[string][]int, len(parsed)),
+	}
+	for _, pe := range parsed {
+		if len(pe.entry.Definitions) == 0 {
+			continue
+		}
+		i := len(p.entries)
+		p.entries = append(p.entries, pe.entry)
+		for _, hw := range pe.headwords {
+			key := lookupKey(hw)
+			if key == "" {
+				continue
+			}
+			if ids := p.index[key]; len(ids) > 0 && ids[len(ids)-1] == i {
+				continue // 正規化すると同じになる表記 (カタカナとひらがなの読みなど)
+			}
+			p.index[key] = append(p.index[key], i)
+		}
+	}
+	return p, nil
+}
+
+// Len は読み込んだ見出しの数を返します
+func (p *OfflineProvider) Len() int {
+	return len(p.entries)
+}
+
+// Lookup は表記または読みが term と一致する見出しを返します (全角/半角・大文字/小文字・カタカナ/ひらがなの違いは無視する)。
+// 英単語で見つからない場合は原形 (LemmatizeEnglish) でも探します。
+func (p *OfflineProvider) Lookup(ctx context.Context, term string) ([]Entry, error) {
+	ids := p.index[lookupKey(term)]
+	if len(ids) == 0 && !textutil.ContainsJapanese(term) && !strings.ContainsAny(strings.TrimSpace(term), " \t") {
+		ids = p.index[lookupKey(textutil.LemmatizeEnglish(strings.TrimSpace(term)))]
+	}
+
+	entries := make([]Entry, 0, len(ids))
+	for _, i := range ids {
+		e := p.entries[i]
+		e.Definitions = slices.Clone(e.Definitions)
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func lookupKey(s string) string {
+	return textutil.NormalizeTerm(s, textutil.NormalizeOptions{UnifyKana: true})
+}
+
+// maybeGunzip は r が gzip 形式であれば展開する Reader を返します
+func maybeGunzip(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
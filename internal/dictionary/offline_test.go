@@ -0,0 +1,154 @@
+package dictionary_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strings"
+	"testing"
+
+	"go_4_vocab_keep/internal/dictionary"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+const testJMdict = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE JMdict [
+<!ELEMENT JMdict (entry*)>
+<!ENTITY n "noun (common) (futsuumeishi)">
+<!ENTITY v1 "Ichidan verb">
+<!ENTITY vt "transitive verb">
+]>
+<JMdict>
+<entry>
+<ent_seq>1358280</ent_seq>
+<k_ele><keb>食べる</keb></k_ele>
+<k_ele><keb>喰べる</keb></k_ele>
+<r_ele><reb>たべる</reb></r_ele>
+<sense>
+<pos>&v1;</pos>
+<pos>&vt;</pos>
+<gloss>to eat</gloss>
+<gloss xml:lang="ger">essen</gloss>
+</sense>
+<sense>
+<gloss>to live on (e.g. a salary)</gloss>
+<gloss>to live off</gloss>
+</sense>
+</entry>
+<entry>
+<ent_seq>1589350</ent_seq>
+<r_ele><reb>りんご</reb></r_ele>
+<r_ele><reb>リンゴ</reb></r_ele>
+<sense>
+<pos>&n;</pos>
+<gloss>apple</gloss>
+</sense>
+</entry>
+</JMdict>
+`
+
+const testEDICT = "　？？？？ /,EDICT, EDICT_SUB(P), EDRDG, 2024/\n" +
+	"銀行 [ぎんこう] /(n) (1) bank/(2) blood bank/(P)/EntL1257470X/\n" +
+	"走る;奔る [はしる] /(v5r,vi) to run/to travel (movement of vehicles)/EntL1404975X/\n" +
+	"ありがとう /(int) thank you/thanks/\n"
+
+func TestOfflineProvider_JMdict(t *testing.T) {
+	ctx := context.Background()
+	p, err := dictionary.NewOfflineProvider(strings.NewReader(testJMdict), dictionary.FormatJMdict)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Len())
+
+	want := []dictionary.Entry{{
+		Term:         "食べる",
+		Reading:      "たべる",
+		Definitions:  []string{"to eat", "to live on (e.g. a salary); to live off"},
+		PartOfSpeech: "verb",
+	}}
+	for _, term := range []string{"食べる", "喰べる", "たべる", "タベル"} {
+		got, err := p.Lookup(ctx, term)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, term)
+	}
+
+	got, err := p.Lookup(ctx, "リンゴ")
+	require.NoError(t, err)
+	assert.Equal(t, []dictionary.Entry{{Term: "りんご", Definitions: []string{"apple"}, PartOfSpeech: "noun"}}, got)
+}
+
+func TestOfflineProvider_EDICT(t *testing.T) {
+	ctx := context.Background()
+	eucjp, err := japanese.EUCJP.NewEncoder().String(testEDICT)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "正常系: UTF-8", input: testEDICT},
+		{name: "正常系: EUC-JP", input: eucjp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := dictionary.NewOfflineProvider(strings.NewReader(tt.input), dictionary.FormatEDICT)
+			require.NoError(t, err)
+			assert.Equal(t, 3, p.Len())
+
+			got, err := p.Lookup(ctx, "ぎんこう")
+			require.NoError(t, err)
+			assert.Equal(t, []dictionary.Entry{{Term: "銀行", Reading: "ぎんこう", Definitions: []string{"bank", "blood bank"}, PartOfSpeech: "noun"}}, got)
+
+			got, err = p.Lookup(ctx, "奔る")
+			require.NoError(t, err)
+			assert.Equal(t, []dictionary.Entry{{Term: "走る", Reading: "はしる", Definitions: []string{"to run; to travel (movement of vehicles)"}, PartOfSpeech: "verb"}}, got)
+
+			got, err = p.Lookup(ctx, "ありがとう")
+			require.NoError(t, err)
+			assert.Equal(t, []dictionary.Entry{{Term: "ありがとう", Definitions: []string{"thank you; thanks"}, PartOfSpeech: "interjection"}}, got)
+		})
+	}
+}
+
+func TestOfflineProvider_TSV(t *testing.T) {
+	ctx := context.Background()
+	input := "term\treading\tdefinition\tpart_of_speech\n" +
+		"# コメント行\n" +
+		"run\t\t走る\tverb\n" +
+		"run\t\t経営する\n" +
+		"Bank\t\t銀行\tn\n"
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err := zw.Write([]byte(input))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	p, err := dictionary.NewOfflineProvider(&gz, dictionary.FormatTSV)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Len())
+
+	tests := []struct {
+		name string
+		term string
+		want []dictionary.Entry
+	}{
+		{name: "正常系: 同じ単語の行は語義としてまとめる", term: "run", want: []dictionary.Entry{{Term: "run", Definitions: []string{"走る", "経営する"}, PartOfSpeech: "verb"}}},
+		{name: "正常系: 大文字小文字・全角半角を区別しない", term: "ｂａｎｋ", want: []dictionary.Entry{{Term: "Bank", Definitions: []string{"銀行"}, PartOfSpeech: "noun"}}},
+		{name: "正常系: 見つからない場合は原形で探す", term: "running", want: []dictionary.Entry{{Term: "run", Definitions: []string{"走る", "経営する"}, PartOfSpeech: "verb"}}},
+		{name: "正常系: 見つからない", term: "apple", want: []dictionary.Entry{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Lookup(ctx, tt.term)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewOfflineProvider_UnsupportedFormat(t *testing.T) {
+	_, err := dictionary.NewOfflineProvider(strings.NewReader(""), "csv")
+	assert.ErrorIs(t, err, dictionary.ErrUnsupportedFormat)
+}
@@ -0,0 +1,73 @@
+package dictionary
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseTSV は 単語<TAB>読み<TAB>意味[<TAB>品詞] 形式の辞書を読み込みます。
+// 1行目が "term" で始まる場合はヘッダーとして読み飛ばし、"#" で始まる行はコメントとして扱います。
+// 単語と読みが同じ行は1つの見出しの語義としてまとめます。品詞は model.PartOfSpeechXxx の値か、JMdict の品詞タグで指定します。
+func parseTSV(r io.Reader) ([]parsedEntry, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = '\t'
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var entries []parsedEntry
+	byKey := make(map[string]int)
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && len(rec) > 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "term") {
+			continue
+		}
+		if len(rec) < 3 {
+			return nil, fmt.Errorf("line %d: expected term, reading and definition columns", line)
+		}
+		term, reading, definition := strings.TrimSpace(rec[0]), strings.TrimSpace(rec[1]), strings.TrimSpace(rec[2])
+		if term == "" || definition == "" {
+			continue
+		}
+
+		key := term + "\t" + reading
+		i, ok := byKey[key]
+		if !ok {
+			i = len(entries)
+			byKey[key] = i
+			pe := parsedEntry{entry: Entry{Term: term, Reading: reading}, headwords: []string{term}}
+			if reading != "" {
+				pe.headwords = append(pe.headwords, reading)
+			}
+			entries = append(entries, pe)
+		}
+		e := &entries[i].entry
+		e.Definitions = append(e.Definitions, definition)
+		if len(rec) >= 4 && e.PartOfSpeech == "" {
+			e.PartOfSpeech = tsvPartOfSpeech(strings.TrimSpace(rec[3]))
+		}
+	}
+	return entries, nil
+}
+
+// tsvPartOfSpeech は TSV の品詞の列を品詞の値に変換します (不明な値は空)
+func tsvPartOfSpeech(s string) string {
+	switch s {
+	case posNoun, posVerb, posAdjective, posAdverb, posPronoun, posConjunction, posInterjection, posParticle, posAuxiliary, posPhrase,
+		"preposition", "other":
+		return s
+	}
+	if pos, ok := partOfSpeechFromTag(s); ok {
+		return pos
+	}
+	return ""
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go_4_vocab_keep/internal/blobstore"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxAttachmentUploadSize はリクエスト全体のサイズの上限 (20MB)。
+// 種類ごとのファイルサイズの上限は設定 (attachment.max_audio_size など) に従ってサービスで確認する
+const maxAttachmentUploadSize = 20 << 20
+
+// attachmentUploadTimeout は添付ファイルのアップロードに許容する時間。
+// 低速な回線からでも上限 (20MB) までのファイルを受け取りきれるよう、サーバー全体のタイムアウトより長くする
+const attachmentUploadTimeout = 2 * time.Minute
+
+// attachmentFormMemory は multipart のうちメモリに保持する上限。超えた分は一時ファイルに書き出される
+const attachmentFormMemory = 1 << 20
+
+type AttachmentHandler struct {
+	service service.AttachmentService
+}
+
+// NewAttachmentHandler は AttachmentHandler の新しいインスタンスを生成します
+func NewAttachmentHandler(s service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: s,
+	}
+}
+
+// UploadAttachment は multipart/form-data でアップロードされた音声・画像を単語に添付するハンドラ
+// フォーム項目: file (必須)
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()))
+
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(attachmentUploadTimeout)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		logger.Warn("Failed to extend read deadline", "error", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		logger.Warn("Failed to extend write deadline", "error", err)
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentUploadSize)
+	if err := r.ParseMultipartForm(attachmentFormMemory); err != nil {
+		logger.Warn("Failed to parse multipart form", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "ファイルを読み込めませんでした。20MB以下のファイルをmultipart/form-data形式で送信してください。", "file", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		logger.Warn("Attachment file missing", "error", err)
+		appErr := model.NewAppError("VALIDATION_ERROR", "ファイルは必須項目です。", "file", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.Upload(r.Context(), userID, wordID, &service.UploadAttachmentInput{
+		Filename:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Size:        header.Size,
+		Body:        file,
+	})
+	if err != nil {
+		logger.Error("Error uploading attachment in service", "error", err, "filename", header.Filename)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Attachment uploaded successfully", "attachment_id", attachment.AttachmentID.String())
+	webutil.RespondWithJSON(w, http.StatusCreated, attachment, logger)
+}
+
+// ListAttachments は単語の添付ファイルの一覧を作成順に返すハンドラ
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()))
+
+	attachments, err := h.service.List(r.Context(), userID, wordID)
+	if err != nil {
+		logger.Error("Error listing attachments in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Attachments listed successfully", "count", len(attachments))
+	webutil.RespondWithJSON(w, http.StatusOK, attachments, logger)
+}
+
+// GetAttachmentURL は添付ファイルをダウンロードするための署名付きURLを発行するハンドラ
+func (h *AttachmentHandler) GetAttachmentURL(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	attachmentID, ok := parseAttachmentIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()), slog.String("attachment_id", attachmentID.String()))
+
+	res, err := h.service.GetURL(r.Context(), userID, wordID, attachmentID)
+	if err != nil {
+		logger.Error("Error getting attachment URL in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Attachment URL issued successfully")
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+// DeleteAttachment は単語の添付ファイルを削除するハンドラ
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	attachmentID, ok := parseAttachmentIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()), slog.String("attachment_id", attachmentID.String()))
+
+	if err := h.service.Delete(r.Context(), userID, wordID, attachmentID); err != nil {
+		logger.Error("Error deleting attachment in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Attachment deleted successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAttachmentIDParam はURLの {attachment_id} を解析します。不正な場合はエラーレスポンスを書き込み false を返します
+func parseAttachmentIDParam(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (uuid.UUID, bool) {
+	idStr := chi.URLParam(r, "attachment_id")
+	attachmentID, err := uuid.Parse(idStr)
+	if err != nil {
+		logger.Warn("Invalid attachment ID format", "attachment_id_str", idStr, "error", err)
+		appErr := model.NewAppError("INVALID_URL_PARAM", "attachment_idの形式が正しくありません。", "attachment_id", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return uuid.Nil, false
+	}
+	return attachmentID, true
+}
+
+// BlobHandler はローカルストレージに保存したファイルを、署名付きURLで配信します。
+// 署名がURLに含まれるため認証は不要です (S3の場合はS3が直接配信するため使いません)。
+type BlobHandler struct {
+	store *blobstore.LocalStore
+}
+
+// NewBlobHandler は BlobHandler の新しいインスタンスを生成します
+func NewBlobHandler(store *blobstore.LocalStore) *BlobHandler {
+	return &BlobHandler{
+		store: store,
+	}
+}
+
+// ServeBlob は GET /blobs/{key}?expires=...&signature=... のファイルを返すハンドラ
+func (h *BlobHandler) ServeBlob(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	key, err := url.PathUnescape(chi.URLParam(r, "*"))
+	if err != nil {
+		key = ""
+	}
+	q := r.URL.Query()
+	if err := h.store.Verify(key, q.Get("expires"), q.Get("signature")); err != nil {
+		logger.Warn("Invalid signed blob URL", "key", key, "error", err)
+		msg := "URLが正しくありません。"
+		if errors.Is(err, blobstore.ErrURLExpired) {
+			msg = "URLの有効期限が切れています。もう一度URLを取得してください。"
+		}
+		webutil.HandleError(w, logger, model.NewAppError("INVALID_SIGNATURE", msg, "", model.ErrForbidden))
+		return
+	}
+
+	obj, err := h.store.Open(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			webutil.HandleError(w, logger, model.NewAppError("NOT_FOUND", "ファイルが見つかりません。", "", model.ErrNotFound))
+			return
+		}
+		logger.Error("Failed to open blob", "key", key, "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	defer obj.Body.Close()
+
+	w.Header().Set("Content-Type", obj.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, obj.Body); err != nil {
+		logger.Warn("Failed to write blob", "key", key, "error", err)
+	}
+}
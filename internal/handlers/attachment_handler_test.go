@@ -0,0 +1,225 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"go_4_vocab_keep/internal/handlers"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+
+	svc_mocks "go_4_vocab_keep/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newMultipartRequest は fieldName の項目にファイルを1つ含む multipart/form-data のリクエストを作成します
+func newMultipartRequest(t *testing.T, target, fieldName, filename, contentType string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="`+fieldName+`"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req, err := http.NewRequest(http.MethodPost, target, &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+// --- Test UploadAttachment ---
+func TestAttachmentHandler_UploadAttachment(t *testing.T) {
+	mockService := new(svc_mocks.AttachmentService)
+	handler := handlers.NewAttachmentHandler(mockService)
+
+	testTenantID := uuid.New()
+	testWordID := uuid.New()
+	content := []byte("\x89PNG\r\n\x1a\n fake image")
+	uploaded := &model.WordAttachment{AttachmentID: uuid.New(), Kind: model.AttachmentKindImage, ContentType: "image/png", SizeBytes: int64(len(content)), Filename: "cat.png"}
+
+	tests := []struct {
+		name           string
+		wordIDParam    string
+		newRequest     func(t *testing.T) *http.Request
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:        "正常系: ファイル名・申告された種類・内容をサービスに渡す",
+			wordIDParam: testWordID.String(),
+			newRequest: func(t *testing.T) *http.Request {
+				return newMultipartRequest(t, "/words/attachments", "file", "cat.png", "image/png", content)
+			},
+			setupMock: func() {
+				argMatcher := mock.MatchedBy(func(in *service.UploadAttachmentInput) bool {
+					body, err := io.ReadAll(in.Body)
+					return err == nil && in.Filename == "cat.png" && in.ContentType == "image/png" &&
+						in.Size == int64(len(content)) && bytes.Equal(body, content)
+				})
+				mockService.On("Upload", mock.Anything, testTenantID, testWordID, argMatcher).Return(uploaded, nil).Once()
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "異常系: 不正なWordID形式",
+			wordIDParam: "invalid-uuid",
+			newRequest: func(t *testing.T) *http.Request {
+				return newMultipartRequest(t, "/words/attachments", "file", "cat.png", "image/png", content)
+			},
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_URL_PARAM",
+		},
+		{
+			name:        "異常系: multipart/form-data ではない",
+			wordIDParam: testWordID.String(),
+			newRequest: func(t *testing.T) *http.Request {
+				return newJsonRequest(t, http.MethodPost, "/words/attachments", `{"file":"cat.png"}`)
+			},
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_REQUEST_BODY",
+		},
+		{
+			name:        "異常系: file の項目がない",
+			wordIDParam: testWordID.String(),
+			newRequest: func(t *testing.T) *http.Request {
+				return newMultipartRequest(t, "/words/attachments", "image", "cat.png", "image/png", content)
+			},
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:        "異常系: サービスエラー (対応していない種類)",
+			wordIDParam: testWordID.String(),
+			newRequest: func(t *testing.T) *http.Request {
+				return newMultipartRequest(t, "/words/attachments", "file", "doc.pdf", "application/pdf", []byte("%PDF-1.4"))
+			},
+			setupMock: func() {
+				appErr := model.NewAppError("UNSUPPORTED_ATTACHMENT_TYPE", "この種類のファイルは添付できません。", "file", model.ErrInvalidInput)
+				mockService.On("Upload", mock.Anything, testTenantID, testWordID, mock.AnythingOfType("*service.UploadAttachmentInput")).Return(nil, appErr).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "UNSUPPORTED_ATTACHMENT_TYPE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+
+			req := tt.newRequest(t)
+			req = req.WithContext(contextWithChiURLParams(contextWithTenant(testTenantID), "word_id", tt.wordIDParam))
+			rr := httptest.NewRecorder()
+			handler.UploadAttachment(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			} else {
+				assert.Contains(t, rr.Body.String(), `"attachment_id":"`+uploaded.AttachmentID.String()+`"`)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test ListAttachments ---
+func TestAttachmentHandler_ListAttachments(t *testing.T) {
+	mockService := new(svc_mocks.AttachmentService)
+	handler := handlers.NewAttachmentHandler(mockService)
+	testTenantID := uuid.New()
+	testWordID := uuid.New()
+
+	mockService.On("List", mock.Anything, testTenantID, testWordID).Return([]model.WordAttachment{
+		{AttachmentID: uuid.New(), Kind: model.AttachmentKindAudio, ContentType: "audio/mpeg", Filename: "say.mp3"},
+	}, nil).Once()
+
+	req := newJsonRequest(t, http.MethodGet, "/words/attachments", nil)
+	req = req.WithContext(contextWithChiURLParams(contextWithTenant(testTenantID), "word_id", testWordID.String()))
+	rr := httptest.NewRecorder()
+	handler.ListAttachments(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"filename":"say.mp3"`)
+	assert.NotContains(t, rr.Body.String(), "storage_key")
+	mockService.AssertExpectations(t)
+}
+
+// --- Test DeleteAttachment ---
+func TestAttachmentHandler_DeleteAttachment(t *testing.T) {
+	mockService := new(svc_mocks.AttachmentService)
+	handler := handlers.NewAttachmentHandler(mockService)
+	testTenantID := uuid.New()
+	testWordID := uuid.New()
+	testAttachmentID := uuid.New()
+
+	tests := []struct {
+		name              string
+		attachmentIDParam string
+		setupMock         func()
+		expectedStatus    int
+		expectedCode      string
+	}{
+		{
+			name:              "正常系",
+			attachmentIDParam: testAttachmentID.String(),
+			setupMock: func() {
+				mockService.On("Delete", mock.Anything, testTenantID, testWordID, testAttachmentID).Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:              "異常系: 不正なAttachmentID形式",
+			attachmentIDParam: "invalid-uuid",
+			setupMock:         func() { /* サービスは呼ばれない */ },
+			expectedStatus:    http.StatusBadRequest,
+			expectedCode:      "INVALID_URL_PARAM",
+		},
+		{
+			name:              "異常系: サービスエラー",
+			attachmentIDParam: testAttachmentID.String(),
+			setupMock: func() {
+				mockService.On("Delete", mock.Anything, testTenantID, testWordID, testAttachmentID).Return(errors.New("storage error")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+
+			req := newJsonRequest(t, http.MethodDelete, "/words/attachments/"+tt.attachmentIDParam, nil)
+			req = req.WithContext(contextWithChiURLParams(contextWithTenant(testTenantID), "word_id", testWordID.String(), "attachment_id", tt.attachmentIDParam))
+			rr := httptest.NewRecorder()
+			handler.DeleteAttachment(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
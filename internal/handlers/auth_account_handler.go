@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ChangePassword は現在のパスワードを確認してパスワードを変更します。リクエストした端末以外はログアウトされます。
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.ChangePasswordRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode change password request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for change password", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), userID, sessionID, &req); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestEmailChange は新しいメールアドレスに確認リンクを送ります。リンクを開くまでメールアドレスは変わりません。
+func (h *AuthHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.ChangeEmailRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode change email request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for change email", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	if err := h.service.RequestEmailChange(r.Context(), userID, sessionID, &req); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "新しいメールアドレスに確認メールを送信しました。メール内のリンクを開くと変更が完了します。",
+	}, logger)
+}
+
+// ConfirmEmailChange は新しいメールアドレスに送ったリンクのトークンでメールアドレスの変更を確定します
+func (h *AuthHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	var req model.ConfirmEmailChangeRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode confirm email change request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	if err := h.service.ConfirmEmailChange(r.Context(), req.Token); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "メールアドレスを変更しました。",
+	}, logger)
+}
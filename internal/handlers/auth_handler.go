@@ -2,22 +2,29 @@ package handlers
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
+	"go_4_vocab_keep/internal/config"
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
 	"go_4_vocab_keep/internal/service"
 	"go_4_vocab_keep/internal/webutil"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 type AuthHandler struct {
-	service service.AuthService
+	service       service.AuthService
+	refreshCookie config.RefreshCookieConfig
 }
 
-func NewAuthHandler(s service.AuthService) *AuthHandler {
-	return &AuthHandler{service: s}
+func NewAuthHandler(s service.AuthService, cfg *config.Config) *AuthHandler {
+	return &AuthHandler{service: s, refreshCookie: cfg.JWT.RefreshCookie}
 }
 
 // Register は新規ユーザーを登録し、有効化メールの送信をトリガーします
@@ -84,6 +91,40 @@ func (h *AuthHandler) VerifyAccount(w http.ResponseWriter, r *http.Request) {
 	}, logger)
 }
 
+// ResendVerificationEmail は有効化されていないアカウントに確認メールを送り直します
+func (h *AuthHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	var req model.ResendVerificationEmailRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode verify-email resend request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	if err := h.service.ResendVerificationEmail(r.Context(), req.Email); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	// アカウントが存在しない・有効化済みの場合でも、同じ成功メッセージを返す
+	webutil.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "有効化されていないアカウントのメールアドレスであれば、確認メールを再送しました。メールが届かない場合は、迷惑メールフォルダもご確認ください。",
+	}, logger)
+}
+
 // Login はユーザーを認証し、JWTを返します
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	logger := middleware.GetLogger(r.Context())
@@ -116,7 +157,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	webutil.RespondWithJSON(w, http.StatusOK, loginResponse, logger)
+	h.respondWithTokens(w, loginResponse, logger)
 }
 
 // GetMe は認証済みユーザー自身の情報を返します
@@ -244,5 +285,164 @@ func (h *AuthHandler) HandleGoogleLogin(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 成功したら、通常のログインと同様にJWTを返す
-	webutil.RespondWithJSON(w, http.StatusOK, loginResponse, logger)
+	h.respondWithTokens(w, loginResponse, logger)
+}
+
+// RefreshToken はリフレッシュトークンを新しいアクセストークン・リフレッシュトークンに交換します。
+// リフレッシュトークンはリクエストボディの refresh_token か、HttpOnly Cookie から受け取ります。
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	var req model.RefreshTokenRequest
+	if r.ContentLength != 0 {
+		if err := webutil.DecodeJSONBody(r, &req); err != nil {
+			logger.Warn("Failed to decode refresh request body", "error", err)
+			appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+			webutil.HandleError(w, logger, appErr)
+			return
+		}
+	}
+	token := req.RefreshToken
+	if token == "" && h.refreshCookie.Enabled {
+		if cookie, err := r.Cookie(h.refreshCookie.Name); err == nil {
+			token = cookie.Value
+		}
+	}
+	if token == "" {
+		appErr := model.NewAppError("VALIDATION_ERROR", "リフレッシュトークンが必要です。", "refresh_token", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	loginResponse, err := h.service.RefreshToken(r.Context(), token)
+	if err != nil {
+		if h.refreshCookie.Enabled && errors.Is(err, model.ErrForbidden) {
+			h.clearRefreshCookie(w) // 使えなくなったトークンは Cookie からも消す
+		}
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	h.respondWithTokens(w, loginResponse, logger)
+}
+
+// Logout はリクエストのセッションを失効させ、アクセストークン・リフレッシュトークンを使えなくします
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()), slog.String("session_id", sessionID.String()))
+
+	if err := h.service.Logout(r.Context(), userID, sessionID); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	if h.refreshCookie.Enabled {
+		h.clearRefreshCookie(w)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSessions はログイン中のセッション (端末・IPアドレス・最終利用日時) の一覧を返します
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	sessions, err := h.service.ListSessions(r.Context(), userID, sessionID)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusOK, sessions, logger)
+}
+
+// RevokeSession は指定したセッションを失効させ、その端末をログアウトさせます
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	sessionIDStr := chi.URLParam(r, "session_id")
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		logger.Warn("Invalid session ID format", "session_id_str", sessionIDStr, "error", err)
+		appErr := model.NewAppError("INVALID_URL_PARAM", "session_idの形式が正しくありません。", "session_id", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	logger = logger.With(slog.String("session_id", sessionID.String()))
+
+	if err := h.service.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondWithTokens はログイン・トークン再発行の結果を返します。
+// Cookie を使う設定の場合、リフレッシュトークンは HttpOnly Cookie で送り、レスポンスボディには含めません。
+func (h *AuthHandler) respondWithTokens(w http.ResponseWriter, res *model.LoginResponse, logger *slog.Logger) {
+	if h.refreshCookie.Enabled && res.RefreshToken != "" {
+		cookie := h.newRefreshCookie(res.RefreshToken)
+		if res.RefreshTokenExpiresAt != nil {
+			cookie.Expires = *res.RefreshTokenExpiresAt
+		}
+		http.SetCookie(w, cookie)
+		res.RefreshToken = ""
+	}
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+func (h *AuthHandler) clearRefreshCookie(w http.ResponseWriter) {
+	cookie := h.newRefreshCookie("")
+	cookie.MaxAge = -1
+	cookie.Expires = time.Unix(0, 0)
+	http.SetCookie(w, cookie)
+}
+
+func (h *AuthHandler) newRefreshCookie(value string) *http.Cookie {
+	sameSite := http.SameSiteStrictMode
+	switch strings.ToLower(h.refreshCookie.SameSite) {
+	case "lax":
+		sameSite = http.SameSiteLaxMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+	return &http.Cookie{
+		Name:     h.refreshCookie.Name,
+		Value:    value,
+		Path:     h.refreshCookie.Path,
+		Domain:   h.refreshCookie.Domain,
+		Secure:   h.refreshCookie.Secure,
+		HttpOnly: true,
+		SameSite: sameSite,
+	}
 }
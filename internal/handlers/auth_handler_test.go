@@ -0,0 +1,515 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/handlers"
+	"go_4_vocab_keep/internal/model"
+
+	svc_mocks "go_4_vocab_keep/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testRefreshCookieName = "refresh_token"
+
+// setupTestAuthHandler はリフレッシュトークンを Cookie で送るかどうかを指定して AuthHandler を作ります
+func setupTestAuthHandler(mockService *svc_mocks.AuthService, cookieEnabled bool) *handlers.AuthHandler {
+	cfg := &config.Config{JWT: config.JWTConfig{RefreshCookie: config.RefreshCookieConfig{
+		Enabled:  cookieEnabled,
+		Name:     testRefreshCookieName,
+		Path:     "/api/v1/auth",
+		SameSite: "strict",
+	}}}
+	return handlers.NewAuthHandler(mockService, cfg)
+}
+
+// findCookie はレスポンスに設定された Cookie を名前で探します
+func findCookie(rr *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// --- Test Login ---
+func TestAuthHandler_Login(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	expiresAt := time.Now().Add(24 * time.Hour)
+	loginReq := model.LoginRequest{Email: "user@example.com", Password: "password123"}
+
+	tests := []struct {
+		name           string
+		cookieEnabled  bool
+		reqBody        interface{}
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+		check          func(t *testing.T, rr *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "正常系: リフレッシュトークンをボディで返す",
+			reqBody: loginReq,
+			setupMock: func() {
+				mockService.On("Login", mock.Anything, &loginReq).Return(&model.LoginResponse{AccessToken: "access", RefreshToken: "refresh", RefreshTokenExpiresAt: &expiresAt}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var res model.LoginResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+				assert.Equal(t, "access", res.AccessToken)
+				assert.Equal(t, "refresh", res.RefreshToken)
+				assert.Nil(t, findCookie(rr, testRefreshCookieName))
+			},
+		},
+		{
+			name:          "正常系: Cookie を使う設定ではリフレッシュトークンを HttpOnly Cookie で返す",
+			cookieEnabled: true,
+			reqBody:       loginReq,
+			setupMock: func() {
+				mockService.On("Login", mock.Anything, &loginReq).Return(&model.LoginResponse{AccessToken: "access", RefreshToken: "refresh", RefreshTokenExpiresAt: &expiresAt}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var res model.LoginResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+				assert.Equal(t, "access", res.AccessToken)
+				assert.Empty(t, res.RefreshToken)
+
+				cookie := findCookie(rr, testRefreshCookieName)
+				require.NotNil(t, cookie)
+				assert.Equal(t, "refresh", cookie.Value)
+				assert.True(t, cookie.HttpOnly)
+				assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+				assert.WithinDuration(t, expiresAt, cookie.Expires, time.Second)
+			},
+		},
+		{
+			name:          "正常系: MFA が必要な場合はトークンも Cookie も発行しない",
+			cookieEnabled: true,
+			reqBody:       loginReq,
+			setupMock: func() {
+				mockService.On("Login", mock.Anything, &loginReq).Return(&model.LoginResponse{MFARequired: true, MFAToken: "mfa"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), `"mfa_required":true`)
+				assert.Nil(t, findCookie(rr, testRefreshCookieName))
+			},
+		},
+		{
+			name:           "異常系: バリデーションエラー (メールアドレスの形式)",
+			reqBody:        model.LoginRequest{Email: "not-an-email", Password: "password123"},
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:    "異常系: ロック中は Retry-After を返す",
+			reqBody: loginReq,
+			setupMock: func() {
+				appErr := model.NewAppError("ACCOUNT_LOCKED", "ログインの失敗が続いたため、一時的にログインできません。", "", model.ErrTooManyRequests)
+				appErr.RetryAfter = 90 * time.Second
+				mockService.On("Login", mock.Anything, &loginReq).Return(nil, appErr).Once()
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   "ACCOUNT_LOCKED",
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, "90", rr.Header().Get("Retry-After"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+			handler := setupTestAuthHandler(mockService, tt.cookieEnabled)
+
+			req := newJsonRequest(t, http.MethodPost, "/auth/login", tt.reqBody)
+			rr := httptest.NewRecorder()
+			handler.Login(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			if tt.check != nil {
+				tt.check(t, rr)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test RefreshToken ---
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+
+	tests := []struct {
+		name           string
+		cookieEnabled  bool
+		reqBody        interface{}
+		cookie         string
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+		check          func(t *testing.T, rr *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "正常系: ボディのリフレッシュトークンを交換する",
+			reqBody: model.RefreshTokenRequest{RefreshToken: "old"},
+			setupMock: func() {
+				mockService.On("RefreshToken", mock.Anything, "old").Return(&model.LoginResponse{AccessToken: "access", RefreshToken: "new"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), `"refresh_token":"new"`)
+			},
+		},
+		{
+			name:          "正常系: ボディがなければ Cookie のリフレッシュトークンを使う",
+			cookieEnabled: true,
+			cookie:        "old",
+			setupMock: func() {
+				mockService.On("RefreshToken", mock.Anything, "old").Return(&model.LoginResponse{AccessToken: "access", RefreshToken: "new"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				cookie := findCookie(rr, testRefreshCookieName)
+				require.NotNil(t, cookie)
+				assert.Equal(t, "new", cookie.Value)
+				assert.NotContains(t, rr.Body.String(), "refresh_token")
+			},
+		},
+		{
+			name:           "異常系: Cookie を使わない設定では Cookie のトークンを読まない",
+			cookie:         "old",
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:          "異常系: 使えなくなったトークンは Cookie からも消す",
+			cookieEnabled: true,
+			cookie:        "revoked",
+			setupMock: func() {
+				appErr := model.NewAppError("INVALID_REFRESH_TOKEN", "リフレッシュトークンが無効です。", "", model.ErrForbidden)
+				mockService.On("RefreshToken", mock.Anything, "revoked").Return(nil, appErr).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "INVALID_REFRESH_TOKEN",
+			check: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				cookie := findCookie(rr, testRefreshCookieName)
+				require.NotNil(t, cookie)
+				assert.Empty(t, cookie.Value)
+				assert.Less(t, cookie.MaxAge, 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+			handler := setupTestAuthHandler(mockService, tt.cookieEnabled)
+
+			req := newJsonRequest(t, http.MethodPost, "/auth/refresh", tt.reqBody)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: testRefreshCookieName, Value: tt.cookie})
+			}
+			rr := httptest.NewRecorder()
+			handler.RefreshToken(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			if tt.check != nil {
+				tt.check(t, rr)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test Logout ---
+func TestAuthHandler_Logout(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	handler := setupTestAuthHandler(mockService, true)
+	testTenantID := uuid.New()
+	testSessionID := uuid.New()
+
+	t.Run("正常系: セッションを失効させ Cookie を消す", func(t *testing.T) {
+		mockService.Mock = mock.Mock{}
+		mockService.On("Logout", mock.Anything, testTenantID, testSessionID).Return(nil).Once()
+
+		req := newJsonRequest(t, http.MethodPost, "/auth/logout", nil).WithContext(contextWithSession(testTenantID, testSessionID))
+		rr := httptest.NewRecorder()
+		handler.Logout(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		cookie := findCookie(rr, testRefreshCookieName)
+		require.NotNil(t, cookie)
+		assert.Less(t, cookie.MaxAge, 0)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("異常系: コンテキストにセッションIDがない", func(t *testing.T) {
+		mockService.Mock = mock.Mock{}
+
+		req := newJsonRequest(t, http.MethodPost, "/auth/logout", nil).WithContext(contextWithTenant(testTenantID))
+		rr := httptest.NewRecorder()
+		handler.Logout(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assertErrorCode(t, rr, "INTERNAL_SERVER_ERROR")
+		mockService.AssertExpectations(t)
+	})
+}
+
+// --- Test ListSessions ---
+func TestAuthHandler_ListSessions(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	handler := setupTestAuthHandler(mockService, false)
+	testTenantID := uuid.New()
+	testSessionID := uuid.New()
+
+	mockService.On("ListSessions", mock.Anything, testTenantID, testSessionID).Return([]model.SessionResponse{
+		{SessionID: testSessionID, Device: "Chrome on Windows", IPAddress: "192.0.2.1", Current: true},
+		{SessionID: uuid.New(), Device: "Safari on iOS", IPAddress: "198.51.100.7"},
+	}, nil).Once()
+
+	req := newJsonRequest(t, http.MethodGet, "/auth/sessions", nil).WithContext(contextWithSession(testTenantID, testSessionID))
+	rr := httptest.NewRecorder()
+	handler.ListSessions(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var sessions []model.SessionResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &sessions))
+	require.Len(t, sessions, 2)
+	assert.True(t, sessions[0].Current)
+	assert.Equal(t, "Safari on iOS", sessions[1].Device)
+	mockService.AssertExpectations(t)
+}
+
+// --- Test RevokeSession ---
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	handler := setupTestAuthHandler(mockService, false)
+	testTenantID := uuid.New()
+	testSessionID := uuid.New()
+
+	tests := []struct {
+		name           string
+		sessionIDParam string
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "正常系",
+			sessionIDParam: testSessionID.String(),
+			setupMock: func() {
+				mockService.On("RevokeSession", mock.Anything, testTenantID, testSessionID).Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "異常系: 不正なSessionID形式",
+			sessionIDParam: "invalid-uuid",
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_URL_PARAM",
+		},
+		{
+			name:           "異常系: セッションが見つからない",
+			sessionIDParam: testSessionID.String(),
+			setupMock: func() {
+				appErr := model.NewAppError("SESSION_NOT_FOUND", "セッションが見つかりません。", "session_id", model.ErrNotFound)
+				mockService.On("RevokeSession", mock.Anything, testTenantID, testSessionID).Return(appErr).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "SESSION_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+
+			req := newJsonRequest(t, http.MethodDelete, "/auth/sessions/"+tt.sessionIDParam, nil)
+			req = req.WithContext(contextWithChiURLParams(contextWithTenant(testTenantID), "session_id", tt.sessionIDParam))
+			rr := httptest.NewRecorder()
+			handler.RevokeSession(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test ListIdentities ---
+func TestAuthHandler_ListIdentities(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	handler := setupTestAuthHandler(mockService, false)
+	testTenantID := uuid.New()
+
+	mockService.On("ListIdentities", mock.Anything, testTenantID).Return([]model.IdentityResponse{
+		{ID: 1, Provider: model.AuthProviderLocal, ProviderID: "user@example.com", HasPassword: true},
+		{ID: 2, Provider: model.AuthProviderGoogle, ProviderID: "google-sub"},
+	}, nil).Once()
+
+	req := newJsonRequest(t, http.MethodGet, "/auth/identities", nil).WithContext(contextWithTenant(testTenantID))
+	rr := httptest.NewRecorder()
+	handler.ListIdentities(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"has_password":true`)
+	assert.Contains(t, rr.Body.String(), `"provider_id":"google-sub"`)
+	mockService.AssertExpectations(t)
+}
+
+// --- Test UnlinkIdentity ---
+func TestAuthHandler_UnlinkIdentity(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	handler := setupTestAuthHandler(mockService, false)
+	testTenantID := uuid.New()
+	testSessionID := uuid.New()
+
+	tests := []struct {
+		name           string
+		identityParam  string
+		body           interface{}
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:          "正常系: パスワードで本人を確認する",
+			identityParam: "2",
+			body:          model.UnlinkIdentityRequest{Password: "password"},
+			setupMock: func() {
+				mockService.On("UnlinkIdentity", mock.Anything, testTenantID, testSessionID, uint(2), "password").Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:          "正常系: パスワードのないアカウントはボディを省略できる",
+			identityParam: "2",
+			setupMock: func() {
+				mockService.On("UnlinkIdentity", mock.Anything, testTenantID, testSessionID, uint(2), "").Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:          "異常系: ログインし直す必要がある",
+			identityParam: "2",
+			setupMock: func() {
+				appErr := model.NewAppError("REAUTHENTICATION_REQUIRED", "安全のため、もう一度ログインしてから10分以内に操作してください。", "", model.ErrForbidden)
+				mockService.On("UnlinkIdentity", mock.Anything, testTenantID, testSessionID, uint(2), "").Return(appErr).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "REAUTHENTICATION_REQUIRED",
+		},
+		{
+			name:           "異常系: 数値ではないID",
+			identityParam:  "google",
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_URL_PARAM",
+		},
+		{
+			name:          "異常系: 最後のログイン方法は削除できない",
+			identityParam: "1",
+			setupMock: func() {
+				appErr := model.NewAppError("LAST_LOGIN_METHOD", "最後のログイン方法は削除できません。", "id", model.ErrConflict)
+				mockService.On("UnlinkIdentity", mock.Anything, testTenantID, testSessionID, uint(1), "").Return(appErr).Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "LAST_LOGIN_METHOD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+
+			req := newJsonRequest(t, http.MethodDelete, "/auth/identities/"+tt.identityParam, tt.body)
+			req = req.WithContext(contextWithChiURLParams(contextWithSession(testTenantID, testSessionID), "identity_id", tt.identityParam))
+			rr := httptest.NewRecorder()
+			handler.UnlinkIdentity(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test SetLocalPassword ---
+func TestAuthHandler_SetLocalPassword(t *testing.T) {
+	mockService := new(svc_mocks.AuthService)
+	handler := setupTestAuthHandler(mockService, false)
+	testTenantID := uuid.New()
+	testSessionID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系",
+			setupMock: func() {
+				mockService.On("SetLocalPassword", mock.Anything, testTenantID, testSessionID, "new-password").Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "異常系: ログインし直す必要がある",
+			setupMock: func() {
+				appErr := model.NewAppError("REAUTHENTICATION_REQUIRED", "安全のため、もう一度ログインしてから10分以内に操作してください。", "", model.ErrForbidden)
+				mockService.On("SetLocalPassword", mock.Anything, testTenantID, testSessionID, "new-password").Return(appErr).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "REAUTHENTICATION_REQUIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
+
+			req := newJsonRequest(t, http.MethodPost, "/auth/identities/local", model.SetPasswordRequest{Password: "new-password"})
+			req = req.WithContext(contextWithSession(testTenantID, testSessionID))
+			rr := httptest.NewRecorder()
+			handler.SetLocalPassword(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
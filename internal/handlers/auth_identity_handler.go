@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// ListIdentities はログイン方法 (パスワード・Googleなど) の一覧を返します
+func (h *AuthHandler) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	identities, err := h.service.ListIdentities(r.Context(), userID)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusOK, identities, logger)
+}
+
+// LinkGoogleIdentity はログイン中のアカウントに、認可コードを取得した Google アカウントでのログインを追加します
+func (h *AuthHandler) LinkGoogleIdentity(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.LinkIdentityRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode link identity request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	identity, err := h.service.LinkGoogleIdentity(r.Context(), userID, sessionID, req.Code, req.Password)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusCreated, identity, logger)
+}
+
+// UnlinkIdentity はログイン方法を削除します。最後に残ったログイン方法は削除できません。
+func (h *AuthHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	identityIDStr := chi.URLParam(r, "identity_id")
+	identityID, err := strconv.ParseUint(identityIDStr, 10, 0)
+	if err != nil {
+		logger.Warn("Invalid identity ID format", "identity_id_str", identityIDStr, "error", err)
+		appErr := model.NewAppError("INVALID_URL_PARAM", "idの形式が正しくありません。", "id", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	// 本人の確認に使うパスワードはボディで受け取る (パスワードのないアカウントはボディを省略できる)
+	var req model.UnlinkIdentityRequest
+	if r.ContentLength != 0 {
+		if err := webutil.DecodeJSONBody(r, &req); err != nil {
+			logger.Warn("Failed to decode unlink identity request body", "error", err)
+			appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+			webutil.HandleError(w, logger, appErr)
+			return
+		}
+	}
+
+	if err := h.service.UnlinkIdentity(r.Context(), userID, sessionID, uint(identityID), req.Password); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetLocalPassword はパスワードのないアカウント (Googleログインのみ) にパスワードを設定します
+func (h *AuthHandler) SetLocalPassword(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.SetPasswordRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode set password request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	if err := h.service.SetLocalPassword(r.Context(), userID, sessionID, req.Password); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
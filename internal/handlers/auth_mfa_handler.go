@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// EnrollTOTP は2段階認証の登録を開始し、認証アプリに登録する秘密鍵と otpauth URI を返します
+func (h *AuthHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	res, err := h.service.EnrollTOTP(r.Context(), userID)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+// ConfirmTOTP は認証アプリのコードを確認して2段階認証を有効にし、リカバリーコードを返します
+func (h *AuthHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.TOTPConfirmRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode TOTP confirmation request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for TOTP confirmation", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	res, err := h.service.ConfirmTOTP(r.Context(), userID, req.Code)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+// DisableTOTP はパスワードを確認して2段階認証を無効にします
+func (h *AuthHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.TOTPDisableRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode TOTP disable request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for TOTP disable", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), userID, req.Password); err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LoginWithMFA はログインで受け取った MFA トークンと、認証アプリのコードまたはリカバリーコードでログインを完了します
+func (h *AuthHandler) LoginWithMFA(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	var req model.MFALoginRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode MFA login request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for MFA login", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	loginResponse, err := h.service.LoginWithMFA(r.Context(), &req)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	h.respondWithTokens(w, loginResponse, logger)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// oidcBindingCookieName は OpenID Connect の認可を開始したブラウザを識別する Cookie の名前。
+// 属性 (パス以外) はリフレッシュトークンの Cookie の設定 (jwt.refresh_cookie) に合わせる
+const oidcBindingCookieName = "oidc_binding"
+
+// oidcBindingCookiePath はログインと連携の両方のコールバックに Cookie を送るためのパス
+const oidcBindingCookiePath = "/api/v1/auth"
+
+// ListOIDCProviders はログイン画面に表示する OpenID Connect のプロバイダの一覧を返します
+func (h *AuthHandler) ListOIDCProviders(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+	webutil.RespondWithJSON(w, http.StatusOK, h.service.ListOIDCProviders(r.Context()), logger)
+}
+
+// StartOIDCLogin はプロバイダでのログインを開始し、認可画面のURLと state を返します
+func (h *AuthHandler) StartOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+	providerKey := chi.URLParam(r, "provider")
+	logger = logger.With(slog.String("auth_provider", providerKey))
+
+	res, err := h.service.StartOIDCLogin(r.Context(), providerKey)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	h.setOIDCBindingCookie(w, res)
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+// HandleOIDCCallback はプロバイダから戻ってきた認可コードと state でログインを完了します
+func (h *AuthHandler) HandleOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+	providerKey := chi.URLParam(r, "provider")
+	logger = logger.With(slog.String("auth_provider", providerKey))
+
+	var req model.OIDCCallbackRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode OIDC callback request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	// 認可コードは一度しか使えないため、結果にかかわらず Cookie は消す
+	binding := oidcBinding(r)
+	h.clearOIDCBindingCookie(w)
+	loginResponse, err := h.service.HandleOIDCLogin(r.Context(), providerKey, req.Code, req.State, binding)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	h.respondWithTokens(w, loginResponse, logger)
+}
+
+// StartOIDCLink はログイン中のアカウントにプロバイダのアカウントを連携する認可を開始します
+func (h *AuthHandler) StartOIDCLink(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	providerKey := chi.URLParam(r, "provider")
+	logger = logger.With(slog.String("tenant_id", userID.String()), slog.String("auth_provider", providerKey))
+
+	res, err := h.service.StartOIDCLink(r.Context(), userID, providerKey)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	h.setOIDCBindingCookie(w, res)
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+// LinkOIDCIdentity はプロバイダから戻ってきた認可コードと state で、ログイン中のアカウントにログイン方法を追加します
+func (h *AuthHandler) LinkOIDCIdentity(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	providerKey := chi.URLParam(r, "provider")
+	logger = logger.With(slog.String("tenant_id", userID.String()), slog.String("auth_provider", providerKey))
+
+	var req model.OIDCLinkRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode OIDC link request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	binding := oidcBinding(r)
+	h.clearOIDCBindingCookie(w)
+	identity, err := h.service.LinkOIDCIdentity(r.Context(), userID, sessionID, providerKey, req.Code, req.State, binding, req.Password)
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	webutil.RespondWithJSON(w, http.StatusCreated, identity, logger)
+}
+
+// setOIDCBindingCookie は認可を開始したブラウザに、state と照合する値を HttpOnly Cookie で持たせます
+func (h *AuthHandler) setOIDCBindingCookie(w http.ResponseWriter, res *model.OIDCAuthorizeResponse) {
+	cookie := h.newRefreshCookie(res.Binding)
+	cookie.Name = oidcBindingCookieName
+	cookie.Path = oidcBindingCookiePath
+	cookie.Expires = res.BindingExpiresAt
+	http.SetCookie(w, cookie)
+}
+
+func (h *AuthHandler) clearOIDCBindingCookie(w http.ResponseWriter) {
+	cookie := h.newRefreshCookie("")
+	cookie.Name = oidcBindingCookieName
+	cookie.Path = oidcBindingCookiePath
+	cookie.MaxAge = -1
+	cookie.Expires = time.Unix(0, 0)
+	http.SetCookie(w, cookie)
+}
+
+// oidcBinding は認可を開始したブラウザの Cookie の値を返します。Cookie がない場合は空文字を返します。
+func oidcBinding(r *http.Request) string {
+	cookie, err := r.Cookie(oidcBindingCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
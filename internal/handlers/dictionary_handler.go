@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type DictionaryHandler struct {
+	service service.DictionaryService
+}
+
+// NewDictionaryHandler は DictionaryHandler の新しいインスタンスを生成します
+func NewDictionaryHandler(s service.DictionaryService) *DictionaryHandler {
+	return &DictionaryHandler{service: s}
+}
+
+// Lookup は単語の意味を辞書で調べるハンドラ (GET /dictionary/lookup?term=...)
+func (h *DictionaryHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	req := model.DictionaryLookupRequest{Term: r.URL.Query().Get("term")}
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for dictionary lookup", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			logger.Error("Unexpected error during validation for dictionary lookup", "error", err)
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	res, err := h.service.Lookup(r.Context(), req.Term)
+	if err != nil {
+		logger.Error("Error looking up dictionary in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Dictionary looked up successfully", "entries", len(res.Entries))
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
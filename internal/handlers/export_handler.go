@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// exportTimeout はエクスポート1回に許容する時間。
+// ルーター全体のタイムアウト (60秒) やサーバーの WriteTimeout では件数の多いエクスポートが途中で切れるため、このハンドラだけ延長する
+const exportTimeout = 10 * time.Minute
+
+type ExportHandler struct {
+	service service.ExportService
+}
+
+// NewExportHandler は ExportHandler の新しいインスタンスを生成します
+func NewExportHandler(s service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		service: s,
+	}
+}
+
+// ExportWords は単語と学習進捗をファイルとしてダウンロードさせるハンドラ
+// クエリ: format (csv|tsv|json|apkg, 省略時csv), level, created_from, created_to, q
+func (h *ExportHandler) ExportWords(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	q := r.URL.Query()
+	req := model.ExportWordsRequest{
+		Format:      strings.ToLower(q.Get("format")),
+		CreatedFrom: q.Get("created_from"),
+		CreatedTo:   q.Get("created_to"),
+		Query:       q.Get("q"),
+	}
+	if req.Format == "" {
+		req.Format = model.ExportFormatCSV
+	}
+	if v := q.Get("level"); v != "" {
+		level, err := strconv.Atoi(v)
+		if err != nil {
+			appErr := model.NewAppError("VALIDATION_ERROR", "levelは1から3の数値で指定してください。", "level", model.ErrInvalidInput)
+			webutil.HandleError(w, logger, appErr)
+			return
+		}
+		req.Level = level
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for ExportWords", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			logger.Error("Unexpected error during validation for ExportWords", "error", err)
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Now().Add(exportTimeout)); err != nil {
+		logger.Warn("Failed to extend write deadline", "error", err)
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), exportTimeout)
+	defer cancel()
+
+	filename := fmt.Sprintf("words_%s.%s", time.Now().Format("20060102"), req.Format)
+	sw := &streamingWriter{w: w, contentType: exportContentType(req.Format), filename: filename}
+
+	if err := h.service.ExportWords(ctx, userID, &req, sw); err != nil {
+		if !sw.started {
+			logger.Error("Error exporting words in service", "error", err)
+			webutil.HandleError(w, logger, err)
+			return
+		}
+		// 既にステータスコードとデータの一部を送信しているため、エラーレスポンスは返せない。
+		// 正常に終わったように見える不完全なファイルを渡さないよう、接続を切断する
+		logger.Error("Export aborted after streaming started", "error", err, "bytes_written", sw.written)
+		panic(http.ErrAbortHandler)
+	}
+
+	logger.Info("Words exported", "format", req.Format, "bytes_written", sw.written)
+}
+
+// exportContentType はエクスポート形式に対応する Content-Type を返します
+func exportContentType(format string) string {
+	switch format {
+	case model.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case model.ExportFormatTSV:
+		return "text/tab-separated-values; charset=utf-8"
+	case model.ExportFormatJSON:
+		return "application/json; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// streamingWriter は最初の書き込み時にレスポンスヘッダーを送信する io.Writer です。
+// 書き込み前にエラーになった場合は、通常のJSONエラーレスポンスを返せるようにするために使います。
+type streamingWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+	written     int64
+}
+
+func (sw *streamingWriter) Write(p []byte) (int, error) {
+	if !sw.started {
+		sw.started = true
+		sw.w.Header().Set("Content-Type", sw.contentType)
+		sw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", sw.filename))
+		sw.w.Header().Set("Cache-Control", "no-store")
+		sw.w.WriteHeader(http.StatusOK)
+	}
+	n, err := sw.w.Write(p)
+	sw.written += int64(n)
+	return n, err
+}
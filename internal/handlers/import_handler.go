@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxImportFileSize はアップロードを受け付けるファイルサイズの上限 (10MB)
+const maxImportFileSize = 10 << 20
+
+// maxAnkiFileSize は Anki パッケージのアップロード上限 (100MB)。メディアを含むため CSV/TSV より大きくする
+const maxAnkiFileSize = 100 << 20
+
+// ankiUploadTimeout は Anki パッケージのアップロードと取り込みに許容する時間。
+// サーバー全体の ReadTimeout/WriteTimeout では大きなパッケージを受け取りきれないため、このハンドラだけ延長する
+const ankiUploadTimeout = 5 * time.Minute
+
+type ImportHandler struct {
+	service service.ImportService
+}
+
+// NewImportHandler は ImportHandler の新しいインスタンスを生成します
+func NewImportHandler(s service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		service: s,
+	}
+}
+
+// ImportWords は multipart/form-data でアップロードされた CSV/TSV から単語を一括登録するハンドラ
+// フォーム項目: file (必須), format, encoding, term_column, definition_column, on_duplicate, dry_run
+func (h *ImportHandler) ImportWords(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		logger.Warn("Failed to parse multipart form", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "ファイルを読み込めませんでした。10MB以下のファイルをmultipart/form-data形式で送信してください。", "file", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		logger.Warn("Import file missing", "error", err)
+		appErr := model.NewAppError("VALIDATION_ERROR", "ファイルは必須項目です。", "file", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	defer file.Close()
+
+	req := model.ImportWordsRequest{
+		Format:           strings.ToLower(r.FormValue("format")),
+		Encoding:         strings.ToLower(r.FormValue("encoding")),
+		TermColumn:       r.FormValue("term_column"),
+		DefinitionColumn: r.FormValue("definition_column"),
+		OnDuplicate:      strings.ToLower(r.FormValue("on_duplicate")),
+	}
+	if req.Format == "" && strings.EqualFold(filepath.Ext(header.Filename), ".tsv") {
+		req.Format = "tsv"
+	}
+	if v := r.FormValue("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			appErr := model.NewAppError("VALIDATION_ERROR", "dry_runはtrueまたはfalseで指定してください。", "dry_run", model.ErrInvalidInput)
+			webutil.HandleError(w, logger, appErr)
+			return
+		}
+		req.DryRun = dryRun
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for ImportWords", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			logger.Error("Unexpected error during validation for ImportWords", "error", err)
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	result, err := h.service.ImportDelimited(r.Context(), userID, file, &req)
+	if err != nil {
+		logger.Error("Error importing words in service", "error", err, "filename", header.Filename)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Words imported", "filename", header.Filename, "dry_run", result.DryRun, "total", result.Total)
+	webutil.RespondWithJSON(w, http.StatusOK, result, logger)
+}
+
+// ImportAnki は multipart/form-data でアップロードされた Anki のパッケージ (.apkg/.colpkg) から単語を一括登録するハンドラ
+// フォーム項目: file (必須), term_field, definition_field, with_scheduling, on_duplicate, dry_run
+func (h *ImportHandler) ImportAnki(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(ankiUploadTimeout)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		logger.Warn("Failed to extend read deadline", "error", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		logger.Warn("Failed to extend write deadline", "error", err)
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAnkiFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		logger.Warn("Failed to parse multipart form", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "ファイルを読み込めませんでした。100MB以下のファイルをmultipart/form-data形式で送信してください。", "file", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		logger.Warn("Import file missing", "error", err)
+		appErr := model.NewAppError("VALIDATION_ERROR", "ファイルは必須項目です。", "file", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	defer file.Close()
+
+	req := model.ImportAnkiRequest{
+		TermField:       r.FormValue("term_field"),
+		DefinitionField: r.FormValue("definition_field"),
+		OnDuplicate:     strings.ToLower(r.FormValue("on_duplicate")),
+	}
+	for name, dst := range map[string]*bool{"with_scheduling": &req.WithScheduling, "dry_run": &req.DryRun} {
+		v := r.FormValue(name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			appErr := model.NewAppError("VALIDATION_ERROR", name+"はtrueまたはfalseで指定してください。", name, model.ErrInvalidInput)
+			webutil.HandleError(w, logger, appErr)
+			return
+		}
+		*dst = b
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for ImportAnki", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			logger.Error("Unexpected error during validation for ImportAnki", "error", err)
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	result, err := h.service.ImportAnki(r.Context(), userID, file, header.Size, &req)
+	if err != nil {
+		logger.Error("Error importing anki package in service", "error", err, "filename", header.Filename)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Anki package imported", "filename", header.Filename, "dry_run", result.DryRun, "total", result.Total)
+	webutil.RespondWithJSON(w, http.StatusOK, result, logger)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
@@ -39,7 +40,12 @@ func (h *ReviewHandler) GetReviewWords(w http.ResponseWriter, r *http.Request) {
 	}
 	logger = logger.With(slog.String("tenant_id", userID.String()))
 
-	reviewWords, err := h.service.GetReviewWords(r.Context(), userID)
+	opts, ok := parseReviewWordsOptions(w, r, logger)
+	if !ok {
+		return
+	}
+
+	reviewWords, err := h.service.GetReviewWords(r.Context(), userID, opts)
 	if err != nil {
 		logger.Error("Error getting review words from service", "error", err)
 		webutil.HandleError(w, logger, err)
@@ -63,7 +69,12 @@ func (h *ReviewHandler) GetReviewSummary(w http.ResponseWriter, r *http.Request)
 	}
 	logger = logger.With("tenant_id", userID.String())
 
-	count, err := h.service.GetReviewWordsCount(r.Context(), userID)
+	opts, ok := parseReviewWordsOptions(w, r, logger)
+	if !ok {
+		return
+	}
+
+	count, err := h.service.GetReviewWordsCount(r.Context(), userID, opts)
 	if err != nil {
 		webutil.HandleError(w, logger, err)
 		return
@@ -120,13 +131,38 @@ func (h *ReviewHandler) UpsertLearningProgressBasedOnReview(w http.ResponseWrite
 		return
 	}
 
-	err = h.service.UpsertLearningProgressBasedOnReview(r.Context(), userID, wordID, *req.IsCorrect)
+	direction := req.Direction
+	if direction == "" {
+		direction = model.DirectionRecognition
+	}
+
+	err = h.service.UpsertLearningProgressBasedOnReview(r.Context(), userID, wordID, direction, *req.IsCorrect)
 	if err != nil {
 		// サービス層から返されたエラーをそのまま処理
 		webutil.HandleError(w, logger, err)
 		return
 	}
 
-	logger.Info("Review result submitted successfully", "is_correct", *req.IsCorrect)
+	logger.Info("Review result submitted successfully", "is_correct", *req.IsCorrect, "direction", direction)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// parseReviewWordsOptions はクエリパラメータ (details, single_sense, cloze) から出題のオプションを読み取ります。
+// 不正な場合はエラーレスポンスを書き込み false を返します
+func parseReviewWordsOptions(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (model.ReviewWordsOptions, bool) {
+	var opts model.ReviewWordsOptions
+	for name, dst := range map[string]*bool{"details": &opts.WithDetails, "single_sense": &opts.SingleSense, "cloze": &opts.Cloze} {
+		v := r.URL.Query().Get(name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			appErr := model.NewAppError("VALIDATION_ERROR", name+"はtrueまたはfalseで指定してください。", name, model.ErrInvalidInput)
+			webutil.HandleError(w, logger, appErr)
+			return opts, false
+		}
+		*dst = b
+	}
+	return opts, true
+}
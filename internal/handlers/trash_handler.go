@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type TrashHandler struct {
+	service service.TrashService
+}
+
+// NewTrashHandler は TrashHandler の新しいインスタンスを生成します
+func NewTrashHandler(s service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		service: s,
+	}
+}
+
+// ListWords はゴミ箱の単語の一覧を取得するハンドラ
+func (h *TrashHandler) ListWords(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	words, err := h.service.ListWords(r.Context(), userID)
+	if err != nil {
+		logger.Error("Error getting trashed words in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Trashed words retrieved successfully", "count", len(words))
+	webutil.RespondWithJSON(w, http.StatusOK, words, logger)
+}
+
+// RestoreWord はゴミ箱の単語を復元するハンドラ
+func (h *TrashHandler) RestoreWord(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()))
+
+	word, err := h.service.RestoreWord(r.Context(), userID, wordID)
+	if err != nil {
+		logger.Error("Error restoring word in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Word restored successfully")
+	webutil.RespondWithJSON(w, http.StatusOK, word, logger)
+}
+
+// PurgeWord はゴミ箱の単語を完全に削除するハンドラ
+func (h *TrashHandler) PurgeWord(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()))
+
+	if err := h.service.PurgeWord(r.Context(), userID, wordID); err != nil {
+		logger.Error("Error purging word in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Word purged successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EmptyTrash はゴミ箱の単語をすべて完全に削除するハンドラ
+func (h *TrashHandler) EmptyTrash(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	purged, err := h.service.EmptyTrash(r.Context(), userID)
+	if err != nil {
+		logger.Error("Error emptying trash in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Trash emptied successfully", "purged", purged)
+	webutil.RespondWithJSON(w, http.StatusOK, model.PurgeTrashResponse{Purged: purged}, logger)
+}
+
+// parseWordIDParam はURLパラメータ word_id をパースします。不正な場合はエラーレスポンスを書き込み、false を返します
+func parseWordIDParam(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (uuid.UUID, bool) {
+	wordIDStr := chi.URLParam(r, "word_id")
+	wordID, err := uuid.Parse(wordIDStr)
+	if err != nil {
+		logger.Warn("Invalid word ID format", "word_id_str", wordIDStr, "error", err)
+		appErr := model.NewAppError("INVALID_URL_PARAM", "word_idの形式が正しくありません。", "word_id", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return uuid.Nil, false
+	}
+	return wordID, true
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
@@ -97,6 +98,75 @@ func (h *WordHandler) GetWords(w http.ResponseWriter, r *http.Request) {
 	webutil.RespondWithJSON(w, http.StatusOK, words, logger)
 }
 
+// GetDuplicates は表記がほぼ同じで重複の可能性がある単語のグループを返すハンドラ
+func (h *WordHandler) GetDuplicates(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	res, err := h.service.FindDuplicates(r.Context(), userID)
+	if err != nil {
+		logger.Error("Error finding duplicate words in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Duplicate words listed successfully", "groups", len(res.Groups))
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
+// maxExtractRequestSize は単語の候補を抽出するリクエストボディの上限 (テキストは10万文字まで。日本語は1文字3バイト)
+const maxExtractRequestSize = 1 << 20
+
+// ExtractWords は貼り付けたテキストから未登録の単語の候補を抽出するハンドラ
+func (h *WordHandler) ExtractWords(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxExtractRequestSize)
+	var req model.ExtractWordsRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			logger.Error("Unexpected error during validation", "error", err)
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	res, err := h.service.ExtractWords(r.Context(), userID, &req)
+	if err != nil {
+		logger.Error("Error extracting words in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Word candidates extracted successfully", "candidates", len(res.Candidates))
+	webutil.RespondWithJSON(w, http.StatusOK, res, logger)
+}
+
 // GetWord は特定の単語リソースを取得するためのハンドラ
 func (h *WordHandler) GetWord(w http.ResponseWriter, r *http.Request) {
 	logger := middleware.GetLogger(r.Context())
@@ -265,3 +335,110 @@ func (h *WordHandler) DeleteWord(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Word deleted successfully (or was already deleted)")
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// BulkWords は複数の単語に対する一括操作 (削除・復元・意味の置換・学習進捗のリセット) を行うハンドラ
+func (h *WordHandler) BulkWords(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	var req model.BulkWordsRequest
+	if err := webutil.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("Failed to decode request body", "error", err)
+		appErr := model.NewAppError("INVALID_REQUEST_BODY", "リクエストボディの形式が正しくありません。", "", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+
+	if err := webutil.Validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			logger.Warn("Validation failed for BulkWords", "errors", validationErrors.Error())
+			appErr := webutil.NewValidationErrorResponse(validationErrors)
+			webutil.HandleError(w, logger, appErr)
+		} else {
+			logger.Error("Unexpected error during validation for BulkWords", "error", err)
+			webutil.HandleError(w, logger, err)
+		}
+		return
+	}
+
+	result, err := h.service.BulkWords(r.Context(), userID, &req)
+	if err != nil {
+		logger.Error("Error in bulk word operation", "error", err, "action", req.Action)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Bulk word operation completed", "action", result.Action, "total", result.Total, "succeeded", result.Succeeded)
+	webutil.RespondWithJSON(w, http.StatusOK, result, logger)
+}
+
+// ListRevisions は単語の変更履歴を新しい順に返すハンドラ
+func (h *WordHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()))
+
+	revisions, err := h.service.ListRevisions(r.Context(), userID, wordID)
+	if err != nil {
+		logger.Error("Error listing word revisions in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Word revisions listed successfully", "count", len(revisions))
+	webutil.RespondWithJSON(w, http.StatusOK, revisions, logger)
+}
+
+// RevertRevision は指定した変更を取り消し、変更前の値に戻すハンドラ
+func (h *WordHandler) RevertRevision(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	userID, err := middleware.GetTenantIDFromContext(r.Context())
+	if err != nil {
+		webutil.HandleError(w, logger, err)
+		return
+	}
+	logger = logger.With(slog.String("tenant_id", userID.String()))
+
+	wordID, ok := parseWordIDParam(w, r, logger)
+	if !ok {
+		return
+	}
+	revStr := chi.URLParam(r, "rev")
+	revisionNo, err := strconv.Atoi(revStr)
+	if err != nil || revisionNo < 1 {
+		logger.Warn("Invalid revision format", "rev_str", revStr)
+		appErr := model.NewAppError("INVALID_URL_PARAM", "revの形式が正しくありません。", "rev", model.ErrInvalidInput)
+		webutil.HandleError(w, logger, appErr)
+		return
+	}
+	logger = logger.With(slog.String("word_id", wordID.String()), slog.Int("revision", revisionNo))
+
+	word, err := h.service.RevertRevision(r.Context(), userID, wordID, revisionNo)
+	if err != nil {
+		logger.Error("Error reverting word revision in service", "error", err)
+		webutil.HandleError(w, logger, err)
+		return
+	}
+
+	logger.Info("Word revision reverted successfully")
+	webutil.RespondWithJSON(w, http.StatusOK, word, logger)
+}
@@ -1,12 +1,8 @@
 package handlers_test // テスト対象とは別のパッケージ名にするのが一般的
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
-	"io"
-	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,517 +11,322 @@ import (
 	"go_4_vocab_keep/internal/handlers" // テスト対象のハンドラー
 	"go_4_vocab_keep/internal/model"
 
-	// モックサービスをインポート (適切なパスに変更してください)
 	svc_mocks "go_4_vocab_keep/internal/service/mocks"
-	// webutil をインポート
-	"github.com/go-chi/chi/v5"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"github.com/stretchr/testify/require"
 )
 
-// --- ヘルパー: モックハンドラーのセットアップ ---
-// モックサービスを引数で受け取るように変更
-func setupTestHandler(mockService *svc_mocks.WordService) *handlers.WordHandler {
-	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil)) // ログ出力を抑制
-	handler := handlers.NewWordHandler(mockService, testLogger)
-	return handler
-}
-
-// --- ヘルパー: JSONボディの作成 ---
-func newJsonRequest(t *testing.T, method string, target string, body interface{}) *http.Request {
-	var reqBody io.Reader
-	if body != nil {
-		// 文字列が来た場合はそのまま使う (不正なJSONテスト用)
-		if bodyStr, ok := body.(string); ok {
-			reqBody = strings.NewReader(bodyStr)
-		} else {
-			jsonData, err := json.Marshal(body)
-			require.NoError(t, err)
-			reqBody = bytes.NewBuffer(jsonData)
-		}
-	}
-	req, err := http.NewRequest(method, target, reqBody)
-	require.NoError(t, err)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	return req
+// wordHandlerTestCase は単語ハンドラーのテーブルテストの1ケースです。
+type wordHandlerTestCase struct {
+	name           string
+	wordIDParam    string
+	reqBody        interface{}
+	ctx            context.Context
+	setupMock      func()
+	expectedStatus int
+	expectedBody   string // 成功時にボディに含まれるべき文字列
+	expectedCode   string // エラー時のエラーコード
 }
 
-// --- ヘルパー: chi ルーターのセットアップ (テスト用) ---
-// 実際にミドルウェアを適用し、ハンドラーをルーティングするヘルパー
-func setupTestServer(t *testing.T, handler http.HandlerFunc, method string, path string, wordIDParam string) *httptest.Server {
-	r := chi.NewRouter()
-
-	// ミドルウェアを模倣 (テストコード内でのコンテキスト設定のため、実際のミドルウェアは使わない)
-	// ハンドラ自体が GetTenantIDFromContext を呼ぶことをテストする
-	// r.Use(middleware.TenantAuthMiddleware(mockAuthenticator)) // 実際のミドルウェア適用はここではしない
-
-	// ルーティング設定 (テスト対象のハンドラを登録)
-	switch method {
-	case http.MethodPost:
-		r.Post(path, handler)
-	case http.MethodGet:
-		if wordIDParam != "" {
-			r.Get(path, handler) // 例: /words/{word_id}
-		} else {
-			r.Get(path, handler) // 例: /words
-		}
-	case http.MethodPut:
-		r.Put(path, handler)
-	case http.MethodPatch:
-		r.Patch(path, handler)
-	case http.MethodDelete:
-		r.Delete(path, handler)
-	}
+// runWordHandlerTests はケースごとにモックをリセットしてハンドラーを呼び出し、結果を検証します。
+func runWordHandlerTests(t *testing.T, mockService *svc_mocks.WordService, method string, handle http.HandlerFunc, tests []wordHandlerTestCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.Mock = mock.Mock{}
+			tt.setupMock()
 
-	return httptest.NewServer(r)
-}
+			req := newJsonRequest(t, method, "/words/"+tt.wordIDParam, tt.reqBody)
+			req = req.WithContext(contextWithChiURLParams(tt.ctx, "word_id", tt.wordIDParam))
+			rr := httptest.NewRecorder()
+			handle(rr, req)
 
-// --- ヘルパー: コンテキストに chi の RouteContext を設定 ---
-func contextWithChiURLParam(ctx context.Context, key, value string) context.Context {
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add(key, value)
-	return context.WithValue(ctx, chi.RouteCtxKey, rctx)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
 }
 
-// --- Test PostWord ---
 func TestWordHandler_PostWord(t *testing.T) {
 	mockService := new(svc_mocks.WordService)
-	handler := setupTestHandler(mockService) // モックサービスを渡す
+	handler := handlers.NewWordHandler(mockService)
 
 	testTenantID := uuid.New()
-	// middleware.TenantIDKey がエクスポートされていない場合は直接文字列を使うなど調整
-	ctxWithTenant := context.WithValue(context.Background(), model.TenantIDKey, testTenantID)
+	ctxWithTenant := contextWithTenant(testTenantID)
 	testReqBody := model.PostWordRequest{Term: "test", Definition: "def"}
 	expectedWord := &model.Word{WordID: uuid.New(), TenantID: testTenantID, Term: "test", Definition: "def"}
 
-	tests := []struct {
-		name           string
-		reqBody        interface{}
-		setupContext   func() context.Context // コンテキスト設定用関数
-		setupMock      func()                 // モック設定用関数
-		expectedStatus int
-		expectedBody   string
-	}{
+	runWordHandlerTests(t, mockService, http.MethodPost, handler.PostWord, []wordHandlerTestCase{
 		{
-			name:         "正常系",
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:    "正常系",
+			reqBody: testReqBody,
+			ctx:     ctxWithTenant,
 			setupMock: func() {
-				// mock.AnythingOfType("*context.valueCtx") などでコンテキストを柔軟にマッチング
 				mockService.On("PostWord", mock.Anything, testTenantID, &testReqBody).Return(expectedWord, nil).Once()
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody:   `"word_id":"` + expectedWord.WordID.String() + `"`,
 		},
 		{
-			name:           "異常系: 認証エラー (TenantIDなし)",
+			name:    "正常系: autofill の場合は定義を省略できる",
+			reqBody: `{"term":"test","autofill":true}`,
+			ctx:     ctxWithTenant,
+			setupMock: func() {
+				argMatcher := mock.MatchedBy(func(req *model.PostWordRequest) bool {
+					return req.Term == "test" && req.Definition == "" && req.Autofill
+				})
+				mockService.On("PostWord", mock.Anything, testTenantID, argMatcher).Return(expectedWord, nil).Once()
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "異常系: コンテキストにテナントIDがない",
 			reqBody:        testReqBody,
-			setupContext:   func() context.Context { return context.Background() }, // TenantIDなし
+			ctx:            context.Background(),
 			setupMock:      func() { /* サービスは呼ばれない */ },
-			expectedStatus: http.StatusUnauthorized,
-			expectedBody:   "tenant not found or invalid", // GetTenantIDFromContext が返すエラーメッセージ
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "INTERNAL_SERVER_ERROR",
 		},
 		{
 			name:           "異常系: 不正なリクエストボディ (JSONデコードエラー)",
-			reqBody:        `{"invalid json`, // 不正なJSON文字列
-			setupContext:   func() context.Context { return ctxWithTenant },
+			reqBody:        `{"invalid json`,
+			ctx:            ctxWithTenant,
 			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid request body",
+			expectedCode:   "INVALID_REQUEST_BODY",
 		},
 		{
-			name: "異常系: バリデーションエラー (Term空)",
-			reqBody: &model.PostWordRequest{
-				Term:       "", // Term が空
-				Definition: "def",
-			},
-			setupContext:   func() context.Context { return ctxWithTenant },
+			name:           "異常系: 未知のフィールド",
+			reqBody:        `{"term":"test","definition":"def","unknown":1}`,
+			ctx:            ctxWithTenant,
 			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Term and definition are required",
+			expectedCode:   "INVALID_REQUEST_BODY",
 		},
 		{
-			name:         "異常系: サービスエラー (Conflict)",
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:           "異常系: バリデーションエラー (Term空)",
+			reqBody:        &model.PostWordRequest{Term: "", Definition: "def"},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "異常系: バリデーションエラー (Termが255文字を超える)",
+			reqBody:        &model.PostWordRequest{Term: strings.Repeat("a", 256), Definition: "def"},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "異常系: バリデーションエラー (autofill なしで定義が空)",
+			reqBody:        &model.PostWordRequest{Term: "test"},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "異常系: バリデーションエラー (品詞が不正)",
+			reqBody:        &model.PostWordRequest{Term: "test", Definition: "def", PartOfSpeech: "unknown"},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:    "異常系: サービスエラー (Conflict)",
+			reqBody: testReqBody,
+			ctx:     ctxWithTenant,
 			setupMock: func() {
-				mockService.On("PostWord", mock.Anything, testTenantID, &testReqBody).Return(nil, model.ErrConflict).Once()
+				appErr := model.NewAppError("DUPLICATE_TERM", "同じ単語が既に登録されています。", "term", model.ErrConflict)
+				mockService.On("PostWord", mock.Anything, testTenantID, &testReqBody).Return(nil, appErr).Once()
 			},
-			expectedStatus: http.StatusConflict, // MapErrorToStatusCode で変換される想定
-			expectedBody:   "Failed to create word",
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "DUPLICATE_TERM",
 		},
 		{
-			name:         "異常系: サービスエラー (Internal)",
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:    "異常系: サービスエラー (Internal)",
+			reqBody: testReqBody,
+			ctx:     ctxWithTenant,
 			setupMock: func() {
 				mockService.On("PostWord", mock.Anything, testTenantID, &testReqBody).Return(nil, errors.New("internal service error")).Once()
 			},
-			expectedStatus: http.StatusInternalServerError, // MapErrorToStatusCode で変換される想定
-			expectedBody:   "Failed to create word",
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "INTERNAL_SERVER_ERROR",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// 各テスト実行前にモックの状態をリセット
-			mockService.Mock = mock.Mock{}
-			// モックを設定
-			tt.setupMock()
-
-			// リクエスト作成
-			req := newJsonRequest(t, http.MethodPost, "/words", tt.reqBody) // パスはダミーでOK
-			// コンテキストを設定
-			req = req.WithContext(tt.setupContext())
-
-			rr := httptest.NewRecorder()
-			// ハンドラ関数を直接呼び出し
-			handler.PostWord(rr, req)
-
-			// 結果検証
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			if tt.expectedBody != "" {
-				assert.Contains(t, rr.Body.String(), tt.expectedBody)
-			}
-
-			// モックの呼び出し検証
-			mockService.AssertExpectations(t)
-		})
-	}
+	})
 }
 
-// --- Test GetWords ---
 func TestWordHandler_GetWords(t *testing.T) {
 	mockService := new(svc_mocks.WordService)
-	handler := setupTestHandler(mockService)
+	handler := handlers.NewWordHandler(mockService)
 
 	testTenantID := uuid.New()
-	ctxWithTenant := context.WithValue(context.Background(), model.TenantIDKey, testTenantID)
+	ctxWithTenant := contextWithTenant(testTenantID)
 	expectedWords := []*model.Word{
-		{WordID: uuid.New(), TenantID: testTenantID, Term: "word1"},
-		{WordID: uuid.New(), TenantID: testTenantID, Term: "word2"},
+		{WordID: uuid.New(), TenantID: testTenantID, Term: "word1", Definition: "def1"},
+		{WordID: uuid.New(), TenantID: testTenantID, Term: "word2", Definition: "def2"},
 	}
 
-	tests := []struct {
-		name           string
-		setupContext   func() context.Context
-		setupMock      func()
-		expectedStatus int
-		expectedBody   string
-	}{
+	runWordHandlerTests(t, mockService, http.MethodGet, handler.GetWords, []wordHandlerTestCase{
 		{
-			name:         "正常系: 複数件取得",
-			setupContext: func() context.Context { return ctxWithTenant },
+			name: "正常系: 複数件取得",
+			ctx:  ctxWithTenant,
 			setupMock: func() {
 				mockService.On("GetWords", mock.Anything, testTenantID).Return(expectedWords, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"word_id":"`, // 配列で始まる
+			expectedBody:   `"term":"word2"`,
 		},
 		{
-			name:         "正常系: 0件取得",
-			setupContext: func() context.Context { return ctxWithTenant },
-			setupMock: func() {
-				mockService.On("GetWords", mock.Anything, testTenantID).Return([]*model.Word{}, nil).Once()
-			},
-			expectedStatus: http.StatusOK,
-			expectedBody:   `[]`, // 空の配列
-		},
-		{
-			name:         "正常系: サービスがnilを返す",
-			setupContext: func() context.Context { return ctxWithTenant },
+			name: "正常系: サービスがnilを返す",
+			ctx:  ctxWithTenant,
 			setupMock: func() {
 				mockService.On("GetWords", mock.Anything, testTenantID).Return(nil, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[]`, // ハンドラで空配列に変換
+			expectedBody:   `[]`,
 		},
 		{
-			name:           "異常系: 認証エラー",
-			setupContext:   func() context.Context { return context.Background() },
-			setupMock:      func() {},
-			expectedStatus: http.StatusUnauthorized,
-			expectedBody:   "tenant not found or invalid",
-		},
-		{
-			name:         "異常系: サービスエラー",
-			setupContext: func() context.Context { return ctxWithTenant },
+			name: "異常系: サービスエラー",
+			ctx:  ctxWithTenant,
 			setupMock: func() {
 				mockService.On("GetWords", mock.Anything, testTenantID).Return(nil, errors.New("internal service error")).Once()
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   "Failed to list words",
+			expectedCode:   "INTERNAL_SERVER_ERROR",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockService.Mock = mock.Mock{}
-			tt.setupMock()
-
-			req := newJsonRequest(t, http.MethodGet, "/words", nil) // ボディなし
-			req = req.WithContext(tt.setupContext())
-
-			rr := httptest.NewRecorder()
-			handler.GetWords(rr, req)
-
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			if tt.expectedBody != "" {
-				assert.Contains(t, rr.Body.String(), tt.expectedBody)
-			}
-
-			mockService.AssertExpectations(t)
-		})
-	}
+	})
 }
 
-// --- Test GetWord ---
 func TestWordHandler_GetWord(t *testing.T) {
 	mockService := new(svc_mocks.WordService)
-	handler := setupTestHandler(mockService)
+	handler := handlers.NewWordHandler(mockService)
 
 	testTenantID := uuid.New()
 	testWordID := uuid.New()
-	validWordIDStr := testWordID.String()
-	invalidWordIDStr := "invalid-uuid"
-	ctxWithTenant := context.WithValue(context.Background(), model.TenantIDKey, testTenantID)
+	ctxWithTenant := contextWithTenant(testTenantID)
 	expectedWord := &model.Word{WordID: testWordID, TenantID: testTenantID, Term: "found", Definition: "def"}
 
-	tests := []struct {
-		name           string
-		wordIDParam    string                 // URLパラメータの値
-		setupContext   func() context.Context // ベースコンテキストの設定
-		setupMock      func()
-		expectedStatus int
-		expectedBody   string
-	}{
+	runWordHandlerTests(t, mockService, http.MethodGet, handler.GetWord, []wordHandlerTestCase{
 		{
-			name:         "正常系",
-			wordIDParam:  validWordIDStr,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "正常系",
+			wordIDParam: testWordID.String(),
+			ctx:         ctxWithTenant,
 			setupMock: func() {
 				mockService.On("GetWord", mock.Anything, testTenantID, testWordID).Return(expectedWord, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `"word_id":"` + validWordIDStr + `"`,
-		},
-		{
-			name:           "異常系: 認証エラー",
-			wordIDParam:    validWordIDStr,
-			setupContext:   func() context.Context { return context.Background() }, // TenantIDなし
-			setupMock:      func() {},
-			expectedStatus: http.StatusUnauthorized,
-			expectedBody:   "tenant not found or invalid",
+			expectedBody:   `"term":"found"`,
 		},
 		{
 			name:           "異常系: 不正なWordID形式",
-			wordIDParam:    invalidWordIDStr, // 不正なUUID文字列
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {},
+			wordIDParam:    "invalid-uuid",
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid word ID format",
+			expectedCode:   "INVALID_URL_PARAM",
 		},
 		{
-			name:         "異常系: サービスエラー (NotFound)",
-			wordIDParam:  validWordIDStr,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "異常系: サービスエラー (NotFound)",
+			wordIDParam: testWordID.String(),
+			ctx:         ctxWithTenant,
 			setupMock: func() {
-				mockService.On("GetWord", mock.Anything, testTenantID, testWordID).Return(nil, model.ErrNotFound).Once()
+				appErr := model.NewAppError("WORD_NOT_FOUND", "単語が見つかりません。", "word_id", model.ErrNotFound)
+				mockService.On("GetWord", mock.Anything, testTenantID, testWordID).Return(nil, appErr).Once()
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   "Failed to get word",
+			expectedCode:   "WORD_NOT_FOUND",
 		},
-		{
-			name:         "異常系: サービスエラー (Internal)",
-			wordIDParam:  validWordIDStr,
-			setupContext: func() context.Context { return ctxWithTenant },
-			setupMock: func() {
-				mockService.On("GetWord", mock.Anything, testTenantID, testWordID).Return(nil, errors.New("internal service error")).Once()
-			},
-			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   "Failed to get word",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockService.Mock = mock.Mock{}
-			tt.setupMock()
-
-			// ベースコンテキスト設定
-			baseCtx := tt.setupContext()
-			// chi ルートコンテキストを追加
-			chiCtx := contextWithChiURLParam(baseCtx, "word_id", tt.wordIDParam)
-
-			req := newJsonRequest(t, http.MethodGet, "/words/"+tt.wordIDParam, nil)
-			req = req.WithContext(chiCtx) // chiコンテキストを含んだものをセット
-
-			rr := httptest.NewRecorder()
-			handler.GetWord(rr, req)
-
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			if tt.expectedBody != "" {
-				assert.Contains(t, rr.Body.String(), tt.expectedBody)
-			}
-
-			mockService.AssertExpectations(t)
-		})
-	}
+	})
 }
 
-// --- Test PutWord ---
 func TestWordHandler_PutWord(t *testing.T) {
 	mockService := new(svc_mocks.WordService)
-	handler := setupTestHandler(mockService)
+	handler := handlers.NewWordHandler(mockService)
 
 	testTenantID := uuid.New()
 	testWordID := uuid.New()
 	validWordIDStr := testWordID.String()
-	ctxWithTenant := context.WithValue(context.Background(), model.TenantIDKey, testTenantID)
-	testReqBody := model.PutWordRequest{Term: "updated", Definition: "updated def"} // 値型DTO
+	ctxWithTenant := contextWithTenant(testTenantID)
+	testReqBody := model.PutWordRequest{Term: "updated", Definition: "updated def"}
 	expectedWord := &model.Word{WordID: testWordID, TenantID: testTenantID, Term: "updated", Definition: "updated def"}
 
-	tests := []struct {
-		name           string
-		wordIDParam    string
-		reqBody        interface{}
-		setupContext   func() context.Context
-		setupMock      func()
-		expectedStatus int
-		expectedBody   string
-	}{
+	runWordHandlerTests(t, mockService, http.MethodPut, handler.PutWord, []wordHandlerTestCase{
 		{
-			name:         "正常系",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "正常系",
+			wordIDParam: validWordIDStr,
+			reqBody:     testReqBody,
+			ctx:         ctxWithTenant,
 			setupMock: func() {
 				mockService.On("PutWord", mock.Anything, testTenantID, testWordID, &testReqBody).Return(expectedWord, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `"word_id":"` + validWordIDStr + `"`,
-		},
-		{
-			name:           "異常系: 認証エラー",
-			wordIDParam:    validWordIDStr,
-			reqBody:        testReqBody,
-			setupContext:   func() context.Context { return context.Background() },
-			setupMock:      func() {},
-			expectedStatus: http.StatusUnauthorized,
-			expectedBody:   "tenant not found or invalid",
+			expectedBody:   `"term":"updated"`,
 		},
 		{
 			name:           "異常系: 不正なWordID形式",
 			wordIDParam:    "invalid-uuid",
 			reqBody:        testReqBody,
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid word ID format",
+			expectedCode:   "INVALID_URL_PARAM",
 		},
 		{
-			name:           "異常系: 不正なリクエストボディ",
+			name:           "異常系: バリデーションエラー (Definition空)",
 			wordIDParam:    validWordIDStr,
-			reqBody:        `{"invalid`,
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {},
+			reqBody:        &model.PutWordRequest{Term: "updated"},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid request body",
-		},
-		{
-			name:         "異常系: サービスエラー (NotFound)",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
-			setupMock: func() {
-				mockService.On("PutWord", mock.Anything, testTenantID, testWordID, &testReqBody).Return(nil, model.ErrNotFound).Once()
-			},
-			expectedStatus: http.StatusNotFound,
-			expectedBody:   "Failed to update word",
+			expectedCode:   "VALIDATION_ERROR",
 		},
 		{
-			name:         "異常系: サービスエラー (Conflict)",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "異常系: サービスエラー (Conflict)",
+			wordIDParam: validWordIDStr,
+			reqBody:     testReqBody,
+			ctx:         ctxWithTenant,
 			setupMock: func() {
-				mockService.On("PutWord", mock.Anything, testTenantID, testWordID, &testReqBody).Return(nil, model.ErrConflict).Once()
+				appErr := model.NewAppError("DUPLICATE_TERM", "同じ単語が既に登録されています。", "term", model.ErrConflict)
+				mockService.On("PutWord", mock.Anything, testTenantID, testWordID, &testReqBody).Return(nil, appErr).Once()
 			},
 			expectedStatus: http.StatusConflict,
-			expectedBody:   "Failed to update word",
-		},
-		{
-			name:         "異常系: サービスエラー (Internal)",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
-			setupMock: func() {
-				mockService.On("PutWord", mock.Anything, testTenantID, testWordID, &testReqBody).Return(nil, errors.New("internal service error")).Once()
-			},
-			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   "Failed to update word",
+			expectedCode:   "DUPLICATE_TERM",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockService.Mock = mock.Mock{}
-			tt.setupMock()
-
-			baseCtx := tt.setupContext()
-			chiCtx := contextWithChiURLParam(baseCtx, "word_id", tt.wordIDParam)
-
-			req := newJsonRequest(t, http.MethodPut, "/words/"+tt.wordIDParam, tt.reqBody)
-			req = req.WithContext(chiCtx)
-
-			rr := httptest.NewRecorder()
-			handler.PutWord(rr, req)
-
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			if tt.expectedBody != "" {
-				assert.Contains(t, rr.Body.String(), tt.expectedBody)
-			}
-
-			mockService.AssertExpectations(t)
-		})
-	}
+	})
 }
 
-// --- Test PatchWord ---
 func TestWordHandler_PatchWord(t *testing.T) {
 	mockService := new(svc_mocks.WordService)
-	handler := setupTestHandler(mockService)
+	handler := handlers.NewWordHandler(mockService)
 
 	testTenantID := uuid.New()
 	testWordID := uuid.New()
 	validWordIDStr := testWordID.String()
-	ctxWithTenant := context.WithValue(context.Background(), model.TenantIDKey, testTenantID)
+	ctxWithTenant := contextWithTenant(testTenantID)
 	newTerm := "patched term"
-	newDef := "patched def"
-	testReqBody := model.PatchWordRequest{Term: &newTerm} // ポインタ型DTO
 	expectedWord := &model.Word{WordID: testWordID, TenantID: testTenantID, Term: newTerm, Definition: "original def"}
 
-	tests := []struct {
-		name           string
-		wordIDParam    string
-		reqBody        interface{}
-		setupContext   func() context.Context
-		setupMock      func()
-		expectedStatus int
-		expectedBody   string
-	}{
+	runWordHandlerTests(t, mockService, http.MethodPatch, handler.PatchWord, []wordHandlerTestCase{
 		{
-			name:         "正常系: Termのみ更新",
-			wordIDParam:  validWordIDStr,
-			reqBody:      &model.PatchWordRequest{Term: &newTerm},
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "正常系: Termのみ更新",
+			wordIDParam: validWordIDStr,
+			reqBody:     &model.PatchWordRequest{Term: &newTerm},
+			ctx:         ctxWithTenant,
 			setupMock: func() {
 				argMatcher := mock.MatchedBy(func(req *model.PatchWordRequest) bool {
-					return req.Term != nil && *req.Term == newTerm && req.Definition == nil
+					return req.Term != nil && *req.Term == newTerm && req.Definition == nil && req.Senses == nil
 				})
 				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, argMatcher).Return(expectedWord, nil).Once()
 			},
@@ -533,88 +334,126 @@ func TestWordHandler_PatchWord(t *testing.T) {
 			expectedBody:   `"term":"` + newTerm + `"`,
 		},
 		{
-			name:         "正常系: Definitionのみ更新",
-			wordIDParam:  validWordIDStr,
-			reqBody:      &model.PatchWordRequest{Definition: &newDef},
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "正常系: 空の読みは自動生成の読みに戻すため nil ではなく空文字で渡す",
+			wordIDParam: validWordIDStr,
+			reqBody:     `{"reading":""}`,
+			ctx:         ctxWithTenant,
 			setupMock: func() {
 				argMatcher := mock.MatchedBy(func(req *model.PatchWordRequest) bool {
-					return req.Term == nil && req.Definition != nil && *req.Definition == newDef
+					return req.Reading != nil && *req.Reading == "" && req.Term == nil
 				})
-				updatedWord := &model.Word{WordID: testWordID, TenantID: testTenantID, Term: "original term", Definition: newDef}
-				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, argMatcher).Return(updatedWord, nil).Once()
+				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, argMatcher).Return(expectedWord, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `"definition":"` + newDef + `"`,
 		},
 		{
-			name:           "異常系: 認証エラー",
+			name:           "異常系: バリデーションエラー (Termを空文字に更新)",
 			wordIDParam:    validWordIDStr,
-			reqBody:        testReqBody,
-			setupContext:   func() context.Context { return context.Background() },
-			setupMock:      func() {},
-			expectedStatus: http.StatusUnauthorized,
-			expectedBody:   "tenant not found or invalid",
-		},
-		{
-			name:           "異常系: 不正なWordID形式",
-			wordIDParam:    "invalid-uuid",
-			reqBody:        testReqBody,
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {},
-			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid word ID format",
-		},
-		{
-			name:           "異常系: 不正なリクエストボディ",
-			wordIDParam:    validWordIDStr,
-			reqBody:        `{"invalid`,
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {},
+			reqBody:        `{"term":""}`,
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid request body",
+			expectedCode:   "VALIDATION_ERROR",
 		},
 		{
-			name:           "異常系: 更新フィールドなし",
+			name:           "異常系: バリデーションエラー (Termが255文字を超える)",
 			wordIDParam:    validWordIDStr,
-			reqBody:        &model.PatchWordRequest{Term: nil, Definition: nil}, // 更新フィールドなし
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {}, // バリデーションで弾かれる
+			reqBody:        `{"term":"` + strings.Repeat("a", 256) + `"}`,
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "No fields provided for update",
+			expectedCode:   "VALIDATION_ERROR",
 		},
 		{
-			name:         "異常系: サービスエラー (NotFound)",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "異常系: サービスエラー (NotFound)",
+			wordIDParam: validWordIDStr,
+			reqBody:     &model.PatchWordRequest{Term: &newTerm},
+			ctx:         ctxWithTenant,
 			setupMock: func() {
-				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, mock.AnythingOfType("*model.PatchWordRequest")).Return(nil, model.ErrNotFound).Once()
+				appErr := model.NewAppError("WORD_NOT_FOUND", "単語が見つかりません。", "word_id", model.ErrNotFound)
+				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, mock.AnythingOfType("*model.PatchWordRequest")).Return(nil, appErr).Once()
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   "Failed to patch word",
+			expectedCode:   "WORD_NOT_FOUND",
 		},
+	})
+}
+
+func TestWordHandler_DeleteWord(t *testing.T) {
+	mockService := new(svc_mocks.WordService)
+	handler := handlers.NewWordHandler(mockService)
+
+	testTenantID := uuid.New()
+	testWordID := uuid.New()
+	ctxWithTenant := contextWithTenant(testTenantID)
+
+	runWordHandlerTests(t, mockService, http.MethodDelete, handler.DeleteWord, []wordHandlerTestCase{
 		{
-			name:         "異常系: サービスエラー (Conflict)",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:        "正常系",
+			wordIDParam: testWordID.String(),
+			ctx:         ctxWithTenant,
 			setupMock: func() {
-				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, mock.AnythingOfType("*model.PatchWordRequest")).Return(nil, model.ErrConflict).Once()
+				mockService.On("DeleteWord", mock.Anything, testTenantID, testWordID).Return(nil).Once()
 			},
-			expectedStatus: http.StatusConflict,
-			expectedBody:   "Failed to patch word",
+			expectedStatus: http.StatusNoContent,
 		},
 		{
-			name:         "異常系: サービスエラー (Internal)",
-			wordIDParam:  validWordIDStr,
-			reqBody:      testReqBody,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:           "異常系: 不正なWordID形式",
+			wordIDParam:    "invalid-uuid",
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_URL_PARAM",
+		},
+		{
+			name:        "異常系: サービスエラー",
+			wordIDParam: testWordID.String(),
+			ctx:         ctxWithTenant,
 			setupMock: func() {
-				mockService.On("PatchWord", mock.Anything, testTenantID, testWordID, mock.AnythingOfType("*model.PatchWordRequest")).Return(nil, errors.New("internal service error")).Once()
+				mockService.On("DeleteWord", mock.Anything, testTenantID, testWordID).Return(errors.New("internal service error")).Once()
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   "Failed to patch word",
+			expectedCode:   "INTERNAL_SERVER_ERROR",
+		},
+	})
+}
+
+func TestWordHandler_RevertRevision(t *testing.T) {
+	mockService := new(svc_mocks.WordService)
+	handler := handlers.NewWordHandler(mockService)
+
+	testTenantID := uuid.New()
+	testWordID := uuid.New()
+	expectedWord := &model.Word{WordID: testWordID, TenantID: testTenantID, Term: "reverted", Definition: "def"}
+
+	tests := []struct {
+		name           string
+		rev            string
+		setupMock      func()
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系",
+			rev:  "2",
+			setupMock: func() {
+				mockService.On("RevertRevision", mock.Anything, testTenantID, testWordID, 2).Return(expectedWord, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: revが数値ではない",
+			rev:            "latest",
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_URL_PARAM",
+		},
+		{
+			name:           "異常系: revが0",
+			rev:            "0",
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_URL_PARAM",
 		},
 	}
 
@@ -623,114 +462,110 @@ func TestWordHandler_PatchWord(t *testing.T) {
 			mockService.Mock = mock.Mock{}
 			tt.setupMock()
 
-			baseCtx := tt.setupContext()
-			chiCtx := contextWithChiURLParam(baseCtx, "word_id", tt.wordIDParam)
-
-			req := newJsonRequest(t, http.MethodPatch, "/words/"+tt.wordIDParam, tt.reqBody)
-			req = req.WithContext(chiCtx)
-
+			req := newJsonRequest(t, http.MethodPost, "/words/"+testWordID.String()+"/revisions/"+tt.rev+"/revert", nil)
+			req = req.WithContext(contextWithChiURLParams(contextWithTenant(testTenantID), "word_id", testWordID.String(), "rev", tt.rev))
 			rr := httptest.NewRecorder()
-			handler.PatchWord(rr, req)
+			handler.RevertRevision(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
-			if tt.expectedBody != "" {
-				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+			if tt.expectedCode != "" {
+				assertErrorCode(t, rr, tt.expectedCode)
 			}
-
 			mockService.AssertExpectations(t)
 		})
 	}
 }
 
-// --- Test DeleteWord ---
-func TestWordHandler_DeleteWord(t *testing.T) {
+func TestWordHandler_BulkWords(t *testing.T) {
 	mockService := new(svc_mocks.WordService)
-	handler := setupTestHandler(mockService)
+	handler := handlers.NewWordHandler(mockService)
 
 	testTenantID := uuid.New()
 	testWordID := uuid.New()
-	validWordIDStr := testWordID.String()
-	ctxWithTenant := context.WithValue(context.Background(), model.TenantIDKey, testTenantID)
+	ctxWithTenant := contextWithTenant(testTenantID)
+	deleteReq := &model.BulkWordsRequest{WordIDs: []uuid.UUID{testWordID}, Action: model.BulkActionDelete}
+	expectedResp := &model.BulkWordsResponse{
+		Action:    model.BulkActionDelete,
+		Total:     1,
+		Succeeded: 1,
+		Results:   []model.BulkItemResult{{WordID: testWordID, Status: model.BulkStatusSucceeded}},
+	}
 
-	tests := []struct {
-		name           string
-		wordIDParam    string
-		setupContext   func() context.Context
-		setupMock      func()
-		expectedStatus int
-		expectedBody   string // 基本的に空のはず
-	}{
+	runWordHandlerTests(t, mockService, http.MethodPost, handler.BulkWords, []wordHandlerTestCase{
 		{
-			name:         "正常系",
-			wordIDParam:  validWordIDStr,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:    "正常系: word_ids で対象を指定",
+			reqBody: deleteReq,
+			ctx:     ctxWithTenant,
 			setupMock: func() {
-				mockService.On("DeleteWord", mock.Anything, testTenantID, testWordID).Return(nil).Once()
+				mockService.On("BulkWords", mock.Anything, testTenantID, deleteReq).Return(expectedResp, nil).Once()
 			},
-			expectedStatus: http.StatusNoContent,
-			expectedBody:   "",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"succeeded":1`,
 		},
 		{
-			name:         "正常系: 削除対象が見つからない (冪等性)",
-			wordIDParam:  validWordIDStr,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:    "正常系: filter で対象を指定",
+			reqBody: `{"filter":{"level":1,"q":"apple"},"action":"reset_progress"}`,
+			ctx:     ctxWithTenant,
 			setupMock: func() {
-				// DeleteWordサービスはErrNotFoundでもnilを返す仕様を前提
-				mockService.On("DeleteWord", mock.Anything, testTenantID, testWordID).Return(nil).Once()
+				want := &model.BulkWordsRequest{Filter: &model.BulkWordFilter{Level: 1, Query: "apple"}, Action: model.BulkActionResetProgress}
+				mockService.On("BulkWords", mock.Anything, testTenantID, want).
+					Return(&model.BulkWordsResponse{Action: model.BulkActionResetProgress, Results: []model.BulkItemResult{}}, nil).Once()
 			},
-			expectedStatus: http.StatusNoContent,
-			expectedBody:   "",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"action":"reset_progress"`,
 		},
 		{
-			name:           "異常系: 認証エラー",
-			wordIDParam:    validWordIDStr,
-			setupContext:   func() context.Context { return context.Background() },
-			setupMock:      func() {},
-			expectedStatus: http.StatusUnauthorized,
-			expectedBody:   "tenant not found or invalid",
+			name:           "異常系: コンテキストにテナントIDがない",
+			reqBody:        deleteReq,
+			ctx:            context.Background(),
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "INTERNAL_SERVER_ERROR",
 		},
 		{
-			name:           "異常系: 不正なWordID形式",
-			wordIDParam:    "invalid-uuid",
-			setupContext:   func() context.Context { return ctxWithTenant },
-			setupMock:      func() {},
+			name:           "異常系: バリデーションエラー (word_ids と filter の両方を指定)",
+			reqBody:        `{"word_ids":["` + testWordID.String() + `"],"filter":{"level":1},"action":"delete"}`,
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "異常系: バリデーションエラー (replace_definition で find がない)",
+			reqBody:        &model.BulkWordsRequest{WordIDs: []uuid.UUID{testWordID}, Action: model.BulkActionReplaceDefinition},
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "異常系: バリデーションエラー (操作が不正)",
+			reqBody:        `{"word_ids":["` + testWordID.String() + `"],"action":"archive"}`,
+			ctx:            ctxWithTenant,
+			setupMock:      func() { /* サービスは呼ばれない */ },
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   "Invalid word ID format",
+			expectedCode:   "VALIDATION_ERROR",
 		},
 		{
-			name:         "異常系: サービスエラー (Internal)",
-			wordIDParam:  validWordIDStr,
-			setupContext: func() context.Context { return ctxWithTenant },
+			name:    "異常系: filter に合う単語が多すぎる",
+			reqBody: `{"filter":{"q":"a"},"action":"delete"}`,
+			ctx:     ctxWithTenant,
 			setupMock: func() {
-				mockService.On("DeleteWord", mock.Anything, testTenantID, testWordID).Return(errors.New("internal service error")).Once()
+				appErr := model.NewAppError("TOO_MANY_ITEMS", "条件に合う単語が多すぎます。", "filter", model.ErrInvalidInput)
+				mockService.On("BulkWords", mock.Anything, testTenantID, mock.AnythingOfType("*model.BulkWordsRequest")).Return(nil, appErr).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "TOO_MANY_ITEMS",
+		},
+		{
+			name:    "異常系: サービスエラー",
+			reqBody: deleteReq,
+			ctx:     ctxWithTenant,
+			setupMock: func() {
+				mockService.On("BulkWords", mock.Anything, testTenantID, deleteReq).Return(nil, errors.New("internal service error")).Once()
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   "Failed to delete word",
+			expectedCode:   "INTERNAL_SERVER_ERROR",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockService.Mock = mock.Mock{}
-			tt.setupMock()
-
-			baseCtx := tt.setupContext()
-			chiCtx := contextWithChiURLParam(baseCtx, "word_id", tt.wordIDParam)
-
-			req := newJsonRequest(t, http.MethodDelete, "/words/"+tt.wordIDParam, nil)
-			req = req.WithContext(chiCtx)
-
-			rr := httptest.NewRecorder()
-			handler.DeleteWord(rr, req)
-
-			assert.Equal(t, tt.expectedStatus, rr.Code)
-			if tt.expectedBody != "" {
-				assert.Contains(t, rr.Body.String(), tt.expectedBody)
-			} else {
-				assert.Empty(t, rr.Body.String()) // 204 No Content はボディ空
-			}
-
-			mockService.AssertExpectations(t)
-		})
-	}
+	})
 }
@@ -16,8 +16,14 @@ import (
 
 // ★★★ ここからが新しいJWT認証ミドルウェア ★★★
 
-// JWTAuthMiddleware は Authorization ヘッダーの Bearer トークンを検証するミドルウェア
-func JWTAuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+// SessionChecker はアクセストークンのセッションがログアウト・失効されていないかを確認します
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, tenantID, sessionID uuid.UUID) (bool, error)
+}
+
+// JWTAuthMiddleware は Authorization ヘッダーの Bearer トークンを検証するミドルウェア。
+// トークンの jti (セッションID) のセッションが失効している場合は、有効期限内でも拒否します。
+func JWTAuthMiddleware(cfg *config.Config, sessions SessionChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			logger := GetLogger(r.Context())
@@ -79,9 +85,32 @@ func JWTAuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 					return
 				}
 
+				// 5. jti (セッションID) のセッションが失効していないかを確認
+				jti, _ := claims["jti"].(string)
+				sessionID, err := uuid.Parse(jti)
+				if err != nil {
+					logger.Warn("JWT auth failed: Invalid or missing jti claim", "jti", jti)
+					appErr := model.NewAppError("INVALID_TOKEN", "トークンが無効です。", "", model.ErrForbidden)
+					webutil.HandleError(w, logger, appErr)
+					return
+				}
+				active, err := sessions.IsSessionActive(r.Context(), userID, sessionID)
+				if err != nil {
+					logger.Error("JWT auth failed: Error checking session", "error", err, "session_id", sessionID)
+					webutil.HandleError(w, logger, err)
+					return
+				}
+				if !active {
+					logger.Warn("JWT auth failed: Session revoked", "session_id", sessionID)
+					appErr := model.NewAppError("SESSION_REVOKED", "このセッションはログアウトされています。再度ログインしてください。", "", model.ErrForbidden)
+					webutil.HandleError(w, logger, appErr)
+					return
+				}
+
 				// ★ リクエストコンテキストにユーザーIDをセット
 				// これまでの TenantIDKey とは別に UserIDKey を使うと、責務が明確になる
 				ctx := context.WithValue(r.Context(), model.TenantIDKey, userID)
+				ctx = context.WithValue(ctx, model.SessionIDKey, sessionID)
 
 				// 成功。次のハンドラに処理を渡す
 				next.ServeHTTP(w, r.WithContext(ctx))
@@ -104,3 +133,12 @@ func GetTenantIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	}
 	return value, nil
 }
+
+// GetSessionIDFromContext はアクセストークンのセッションIDを取得します
+func GetSessionIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	value, ok := ctx.Value(model.SessionIDKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil, model.NewAppError("INTERNAL_SERVER_ERROR", "コンテキストからセッション情報を取得できませんでした。", "", model.ErrInternalServer)
+	}
+	return value, nil
+}
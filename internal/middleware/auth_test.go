@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSessionChecker は決まった結果を返す SessionChecker です
+type stubSessionChecker struct {
+	active bool
+	err    error
+	called bool
+}
+
+func (c *stubSessionChecker) IsSessionActive(ctx context.Context, tenantID, sessionID uuid.UUID) (bool, error) {
+	c.called = true
+	return c.active, c.err
+}
+
+func TestJWTAuthMiddleware_Session(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret"}}
+	tenantID := uuid.New()
+	sessionID := uuid.New()
+
+	sign := func(t *testing.T, jti string) string {
+		t.Helper()
+		claims := jwt.RegisteredClaims{
+			Subject:   tenantID.String(),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWT.SecretKey))
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := []struct {
+		name           string
+		jti            string
+		checker        *stubSessionChecker
+		expectedStatus int
+		expectedCode   string
+		wantChecked    bool
+	}{
+		{
+			name:           "正常系: 有効なセッションならテナントIDとセッションIDをコンテキストに入れる",
+			jti:            sessionID.String(),
+			checker:        &stubSessionChecker{active: true},
+			expectedStatus: http.StatusNoContent,
+			wantChecked:    true,
+		},
+		{
+			name:           "異常系: jti がないトークンは拒否する",
+			jti:            "",
+			checker:        &stubSessionChecker{active: true},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "INVALID_TOKEN",
+		},
+		{
+			name:           "異常系: ログアウト・失効したセッションは有効期限内でも拒否する",
+			jti:            sessionID.String(),
+			checker:        &stubSessionChecker{active: false},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "SESSION_REVOKED",
+			wantChecked:    true,
+		},
+		{
+			name:           "異常系: セッションの確認でエラー",
+			jti:            sessionID.String(),
+			checker:        &stubSessionChecker{err: errors.New("db error")},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "INTERNAL_SERVER_ERROR",
+			wantChecked:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := middleware.JWTAuthMiddleware(cfg, tt.checker)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotTenantID, err := middleware.GetTenantIDFromContext(r.Context())
+				require.NoError(t, err)
+				assert.Equal(t, tenantID, gotTenantID)
+				gotSessionID, err := middleware.GetSessionIDFromContext(r.Context())
+				require.NoError(t, err)
+				assert.Equal(t, sessionID, gotSessionID)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/words", nil)
+			req.Header.Set("Authorization", "Bearer "+sign(t, tt.jti))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				var errResp model.APIErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+				assert.Equal(t, tt.expectedCode, errResp.Error.Code)
+			}
+			assert.Equal(t, tt.wantChecked, tt.checker.called)
+		})
+	}
+}
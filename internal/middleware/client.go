@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"go_4_vocab_keep/internal/model"
+)
+
+// clientInfoCtxKey はコンテキストにリクエスト元の端末の情報を格納するためのキーです。
+type clientInfoCtxKey struct{}
+
+// maxUserAgentLength は記録する User-Agent の最大長
+const maxUserAgentLength = 512
+
+// ParseTrustedProxies は server.trusted_proxies の値 (CIDR または IP アドレス) を解析します
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("server.trusted_proxies: invalid CIDR %q: %w", v, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("server.trusted_proxies: invalid IP address %q: %w", v, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ClientInfoMiddleware はリクエスト元の IP アドレスと User-Agent をコンテキストに格納します。
+// IP アドレスは接続元 (RemoteAddr) とし、接続元が trustedProxies のプロキシの場合に限り X-Forwarded-For を参照します。
+// ログイン試行の制限やリクエスト数の制限はこの IP アドレスで数えるため、利用者が自由に書けるヘッダーは信用しません。
+func ClientInfoMiddleware(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r, trustedProxies)
+			ua := r.UserAgent()
+			if len(ua) > maxUserAgentLength {
+				ua = ua[:maxUserAgentLength]
+			}
+			ctx := context.WithValue(r.Context(), clientInfoCtxKey{}, model.ClientInfo{UserAgent: ua, IPAddress: ip})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP はリクエスト元の IP アドレスを返します。
+// X-Forwarded-For は右 (接続元に近いプロキシが追加した値) から順にたどり、信頼するプロキシ以外で最初に現れたアドレスを使います。
+// 左側の値はクライアントが自由に書けるため、信頼するプロキシを経由していない値は使いません。
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	addr, err := netip.ParseAddr(remote)
+	if err != nil || !isTrustedProxy(addr, trustedProxies) {
+		return remote
+	}
+
+	ip := addr
+	hops := r.Header.Values("X-Forwarded-For")
+	for i := len(hops) - 1; i >= 0; i-- {
+		parts := strings.Split(hops[i], ",")
+		for j := len(parts) - 1; j >= 0; j-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(parts[j]))
+			if err != nil {
+				// 解析できない値より先はプロキシが追加した値か判断できないため、直前のアドレスを使う
+				return ip.String()
+			}
+			ip = hop.Unmap()
+			if !isTrustedProxy(ip, trustedProxies) {
+				return ip.String()
+			}
+		}
+	}
+	return ip.String()
+}
+
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, p := range trustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientInfo はコンテキストからリクエスト元の端末の情報を取得します。見つからない場合は空の値を返します。
+func GetClientInfo(ctx context.Context) model.ClientInfo {
+	info, _ := ctx.Value(clientInfoCtxKey{}).(model.ClientInfo)
+	return info
+}
@@ -0,0 +1,99 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_4_vocab_keep/internal/middleware"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientInfoMiddleware_IPAddress(t *testing.T) {
+	trusted, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "正常系: プロキシを経由しない場合は接続元を使う",
+			remoteAddr: "198.51.100.7:4321",
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "正常系: 信頼するプロキシの場合は X-Forwarded-For の最後のクライアントを使う",
+			remoteAddr: "10.0.0.5:4321",
+			forwarded:  []string{"203.0.113.9"},
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "正常系: 信頼するプロキシを複数経由した場合も右からたどる",
+			remoteAddr: "10.0.0.5:4321",
+			forwarded:  []string{"203.0.113.9, 192.0.2.1", "10.1.2.3"},
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "異常系: 信頼しない接続元の X-Forwarded-For は無視する",
+			remoteAddr: "198.51.100.7:4321",
+			forwarded:  []string{"203.0.113.9"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "異常系: クライアントが書いた左側の値は使わない",
+			remoteAddr: "10.0.0.5:4321",
+			forwarded:  []string{"1.2.3.4, 203.0.113.9"},
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "異常系: 解析できない値があればその直前のアドレスを使う",
+			remoteAddr: "10.0.0.5:4321",
+			forwarded:  []string{"203.0.113.9, unknown, 10.1.2.3"},
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "正常系: X-Forwarded-For がなければプロキシのアドレスを使う",
+			remoteAddr: "10.0.0.5:4321",
+			want:       "10.0.0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := middleware.ClientInfoMiddleware(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = middleware.GetClientInfo(r.Context()).IPAddress
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	t.Run("正常系: CIDR と IP アドレスを受け付ける", func(t *testing.T) {
+		prefixes, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8", " 192.0.2.1 ", "::1", ""})
+		require.NoError(t, err)
+		require.Len(t, prefixes, 3)
+		assert.Equal(t, "192.0.2.1/32", prefixes[1].String())
+		assert.Equal(t, "::1/128", prefixes[2].String())
+	})
+
+	t.Run("異常系: 不正な値はエラー", func(t *testing.T) {
+		_, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/33"})
+		assert.Error(t, err)
+		_, err = middleware.ParseTrustedProxies([]string{"proxy.local"})
+		assert.Error(t, err)
+	})
+}
@@ -24,11 +24,16 @@ var sensitiveHeaders = map[string]bool{
 	"x-csrf-token":  true,
 }
 
+// maxLoggedBodySize はデバッグログ用に保持するレスポンスボディの上限。
+// エクスポートのように大きなレスポンスをストリーミングする場合に全体をメモリに溜めないようにする。
+const maxLoggedBodySize = 64 << 10
+
 // responseLogger は http.ResponseWriter をラップし、ステータスコードとレスポンスボディを記録します。
 type responseLogger struct {
 	http.ResponseWriter
 	statusCode int
-	body       *bytes.Buffer
+	body       *bytes.Buffer // 先頭 maxLoggedBodySize バイトまで
+	bytesOut   int64
 }
 
 // newResponseLogger は新しい responseLogger を作成します。
@@ -46,8 +51,17 @@ func (rl *responseLogger) WriteHeader(statusCode int) {
 }
 
 func (rl *responseLogger) Write(b []byte) (int, error) {
-	rl.body.Write(b) // レスポンスボディをキャプチャ
-	return rl.ResponseWriter.Write(b)
+	if remaining := maxLoggedBodySize - rl.body.Len(); remaining > 0 {
+		rl.body.Write(b[:min(len(b), remaining)]) // レスポンスボディをキャプチャ
+	}
+	n, err := rl.ResponseWriter.Write(b)
+	rl.bytesOut += int64(n)
+	return n, err
+}
+
+// Unwrap は元の ResponseWriter を返します (http.ResponseController から読み書きの期限を変更できるようにするため)
+func (rl *responseLogger) Unwrap() http.ResponseWriter {
+	return rl.ResponseWriter
 }
 
 // LoggingMiddleware はリクエスト/レスポンスのログ出力を一元管理するミドルウェアです。
@@ -61,8 +75,7 @@ func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 
 			// リクエストID付きのロガーを生成し、コンテキストに格納
 			requestLogger := logger.With("req_id", middleware.GetReqID(r.Context()))
-			ctx := context.WithValue(r.Context(), logCtxKey{}, requestLogger)
-			r = r.WithContext(ctx)
+			r = r.WithContext(WithLogger(r.Context(), requestLogger))
 
 			// ★★★ 開始ログの出力 ★★★
 			requestLogger.Info("Request started",
@@ -101,7 +114,7 @@ func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 			requestLogger.Log(r.Context(), logLevel, "Request completed",
 				"status", statusCode,
 				"latency_ms", float64(latency.Nanoseconds())/1e6,
-				"bytes_out", rl.body.Len(),
+				"bytes_out", rl.bytesOut,
 			)
 
 			// ★★★ 詳細ログの出力 (デバッグレベル) ★★★
@@ -128,6 +141,16 @@ func GetLogger(ctx context.Context) *slog.Logger {
 	return slog.Default()
 }
 
+// WithLogger はロガーを格納したコンテキストを返します (HTTPリクエスト以外から GetLogger を使う処理を呼ぶ場合に使用)。
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, logCtxKey{}, logger)
+}
+
+// GetRequestID はコンテキストからリクエストIDを取得します (HTTPリクエスト以外では空文字)。
+func GetRequestID(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
+
 // formatHeaders はヘッダー情報をログ出力用に整形・マスキングするヘルパー関数
 func formatHeaders(headers http.Header) map[string]string {
 	result := make(map[string]string)
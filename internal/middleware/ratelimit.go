@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/ratelimit"
+	"go_4_vocab_keep/internal/webutil"
+
+	"github.com/google/uuid"
+)
+
+// 制限の単位 (rate_limit.policies.*.key)
+const (
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyTenant = "tenant" // JWTAuthMiddleware の後で使う
+	RateLimitKeyEmail  = "email"  // リクエストボディの JSON の email
+)
+
+// maxRateLimitBodySize はメールアドレスを取り出すために読み込むリクエストボディの最大サイズ
+const maxRateLimitBodySize = 64 << 10
+
+// RateLimiter は設定したポリシーごとにリクエスト数を制限するミドルウェアを作ります
+type RateLimiter struct {
+	store    ratelimit.Store
+	enabled  bool
+	policies map[string]rateLimitPolicy
+	logger   *slog.Logger
+}
+
+type rateLimitPolicy struct {
+	ratelimit.Policy
+	key string
+}
+
+// NewRateLimiter は RateLimiter を返します。不正なポリシーがある場合はエラーを返します。
+func NewRateLimiter(store ratelimit.Store, cfg *config.RateLimitConfig, logger *slog.Logger) (*RateLimiter, error) {
+	l := &RateLimiter{store: store, enabled: cfg.Enabled, policies: make(map[string]rateLimitPolicy), logger: logger}
+	for name, p := range cfg.Policies {
+		policy := rateLimitPolicy{
+			Policy: ratelimit.Policy{Limit: p.Limit, Period: p.Period, Burst: p.Burst},
+			key:    strings.ToLower(p.Key),
+		}
+		if !policy.Valid() {
+			return nil, fmt.Errorf("rate_limit.policies.%s: limit and period must be positive", name)
+		}
+		switch policy.key {
+		case RateLimitKeyIP, RateLimitKeyTenant, RateLimitKeyEmail:
+		default:
+			return nil, fmt.Errorf("rate_limit.policies.%s: unsupported key %q", name, p.Key)
+		}
+		l.policies[name] = policy
+	}
+	return l, nil
+}
+
+// Limit はポリシー name でリクエスト数を制限するミドルウェアを返します。
+// 制限が無効か、ポリシーが設定されていない場合は何もしません。
+// 受け付けたリクエストにも拒否したリクエストにも RateLimit-* ヘッダーを付け、拒否した場合は 429 と Retry-After を返します。
+func (l *RateLimiter) Limit(name string) func(http.Handler) http.Handler {
+	policy, ok := l.policies[name]
+	if !l.enabled || !ok {
+		if l.enabled {
+			l.logger.Warn("Rate limit policy not configured, requests are not limited", "policy", name)
+		}
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := GetLogger(r.Context())
+
+			subject := rateLimitSubject(r, policy.key)
+			res, err := l.store.Take(r.Context(), name+":"+subject, policy.Policy, time.Now())
+			if err != nil {
+				// 制限の状態を確認できない場合はリクエストを止めない
+				logger.Error("Rate limit check failed", "error", err, "policy", name)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.FormatInt(int64((res.ResetAfter+time.Second-1)/time.Second), 10))
+			h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Capacity(), int64(policy.Period/time.Second)))
+
+			if !res.Allowed {
+				logger.Warn("Rate limit exceeded", "policy", name, "key", policy.key)
+				appErr := model.NewAppError("RATE_LIMITED", "リクエストが多すぎます。しばらく待ってから再度お試しください。", "", model.ErrTooManyRequests)
+				appErr.RetryAfter = res.RetryAfter
+				webutil.HandleError(w, logger, appErr)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitSubject はリクエストを数える単位の値を返します。テナント・メールアドレスが分からない場合は IP アドレスで数えます。
+// IP アドレスは ClientInfoMiddleware が信頼するプロキシの情報だけから決めたものを使い、X-Forwarded-For などを直接は参照しません。
+func rateLimitSubject(r *http.Request, key string) string {
+	switch key {
+	case RateLimitKeyTenant:
+		if tenantID, ok := r.Context().Value(model.TenantIDKey).(uuid.UUID); ok {
+			return "tenant:" + tenantID.String()
+		}
+	case RateLimitKeyEmail:
+		if email := peekEmail(r); email != "" {
+			return "email:" + email
+		}
+	}
+	return "ip:" + GetClientInfo(r.Context()).IPAddress
+}
+
+// peekEmail はリクエストボディの JSON から email を取り出します。ボディは後続のハンドラがそのまま読めるように戻します。
+func peekEmail(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRateLimitBodySize))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(payload.Email))
+}
@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_LimitByIP(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	limiter, err := middleware.NewRateLimiter(ratelimit.NewMemoryStore(), &config.RateLimitConfig{
+		Enabled: true,
+		Policies: map[string]config.RateLimitPolicyConfig{
+			"reset_password": {Key: "ip", Limit: 2, Period: time.Hour},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	trusted, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	handler := middleware.ClientInfoMiddleware(trusted)(limiter.Limit("reset_password")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	send := func(remoteAddr, forwarded string) int {
+		req := httptest.NewRequest(http.MethodPost, "/reset-password", nil)
+		req.RemoteAddr = remoteAddr
+		if forwarded != "" {
+			req.Header.Set("X-Forwarded-For", forwarded)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("異常系: X-Forwarded-For を変えても接続元が同じなら同じ回数として数える", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, send("198.51.100.7:1000", "203.0.113.1"))
+		assert.Equal(t, http.StatusNoContent, send("198.51.100.7:1001", "203.0.113.2"))
+		assert.Equal(t, http.StatusTooManyRequests, send("198.51.100.7:1002", "203.0.113.3"))
+	})
+
+	t.Run("正常系: 信頼するプロキシ経由ではクライアントごとに数える", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, send("10.0.0.5:1000", "203.0.113.1"))
+		assert.Equal(t, http.StatusNoContent, send("10.0.0.5:1001", "203.0.113.2"))
+		assert.Equal(t, http.StatusNoContent, send("10.0.0.5:1002", "203.0.113.1"))
+		assert.Equal(t, http.StatusTooManyRequests, send("10.0.0.5:1003", "203.0.113.1"))
+	})
+}
@@ -0,0 +1,49 @@
+// internal/model/attachment.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 添付ファイルの種類
+const (
+	AttachmentKindAudio = "audio" // 発音などの音声
+	AttachmentKindImage = "image"
+)
+
+// WordAttachment は単語に添付したファイル (音声・画像) のメタデータです。
+// ファイル本体はブロブストレージの StorageKey に保存されています。
+type WordAttachment struct {
+	AttachmentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"attachment_id"`
+	TenantID     uuid.UUID `gorm:"type:uuid;not null" json:"-"`
+	WordID       uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Kind         string    `gorm:"not null" json:"kind"` // AttachmentKindXxx
+	ContentType  string    `gorm:"not null" json:"content_type"`
+	SizeBytes    int64     `gorm:"not null" json:"size_bytes"`
+	Filename     string    `gorm:"not null;default:''" json:"filename"` // アップロード時の元のファイル名
+	StorageKey   string    `gorm:"not null" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (WordAttachment) TableName() string {
+	return "word_attachments"
+}
+
+// AttachmentStorageKey は添付ファイルを保存するブロブストレージ上のキーを返します。
+// 単語ごとにプレフィックスを分け、単語の完全削除時にまとめて削除できるようにしています。
+func AttachmentStorageKey(wordID, attachmentID uuid.UUID) string {
+	return AttachmentStoragePrefix(wordID) + attachmentID.String()
+}
+
+// AttachmentStoragePrefix は単語の添付ファイルに共通するキーのプレフィックスを返します
+func AttachmentStoragePrefix(wordID uuid.UUID) string {
+	return "words/" + wordID.String() + "/"
+}
+
+// AttachmentURLResponse は添付ファイルのダウンロード用の署名付きURLです
+type AttachmentURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
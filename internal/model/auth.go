@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -13,7 +15,19 @@ type LoginRequest struct {
 // LoginResponse はログイン成功時のレスポンス
 type LoginResponse struct {
 	AccessToken string `json:"access_token"`
-	// RefreshToken string `json:"refresh_token,omitempty"` // リフレッシュトークンを実装する場合
+	// RefreshToken はリフレッシュトークンです。Cookie で送る設定の場合はレスポンスボディには含めません。
+	RefreshToken          string     `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt *time.Time `json:"refresh_token_expires_at,omitempty"`
+
+	// MFARequired が true の場合はトークンを発行せず、MFAToken と認証アプリのコードで /login/mfa を呼び出す必要があります
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"` // 2段階目の認証に使う有効期限の短いトークン
+}
+
+// RefreshTokenRequest はトークン再発行APIのリクエストボディ。
+// Cookie でリフレッシュトークンを送る場合はボディを省略できます。
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // JWTCustomClaims はJWTに含めるカスタムクレーム（ペイロード）
@@ -26,6 +40,28 @@ type JWTCustomClaims struct {
 type ForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ChangePasswordRequest はログイン中のパスワードの変更APIのリクエスト
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+// ChangeEmailRequest はメールアドレスの変更APIのリクエスト。パスワードが設定されている場合は password も必要です。
+// パスワードが設定されていない場合は、直近にログインしたセッションからのみ変更できます。
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email"`
+	Password string `json:"password"`
+}
+
+// ConfirmEmailChangeRequest は新しいメールアドレスに送ったリンクのトークンでメールアドレスの変更を確定するAPIのリクエスト
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
 type ResetPasswordRequest struct {
 	Token    string `json:"token" validate:"required"`
 	Password string `json:"password" validate:"required,min=8,max=72"`
@@ -0,0 +1,58 @@
+// internal/model/bulk.go
+package model
+
+import "github.com/google/uuid"
+
+// 一括操作の種類
+const (
+	BulkActionDelete            = "delete"             // 削除 (論理削除)
+	BulkActionRestore           = "restore"            // 削除した単語の復元
+	BulkActionReplaceDefinition = "replace_definition" // 意味の文字列置換
+	BulkActionResetProgress     = "reset_progress"     // 学習進捗を Level1 に戻す
+)
+
+// 一括操作の項目ごとのステータス
+const (
+	BulkStatusSucceeded = "succeeded"
+	BulkStatusUnchanged = "unchanged" // 対象だが変更がなかった (置換対象の文字列を含まない等)
+	BulkStatusNotFound  = "not_found"
+	BulkStatusFailed    = "failed" // 操作できなかった (復元しようとした単語が既に登録されている等。理由は message に入る)
+)
+
+// MaxBulkItems は一度の一括操作で扱える単語数の上限
+const MaxBulkItems = 1000
+
+// BulkWordFilter は一括操作の対象を条件で指定する場合の絞り込み条件 (エクスポートと同じ条件を指定できる)
+type BulkWordFilter struct {
+	Level       int    `json:"level" validate:"omitempty,min=1,max=3"`
+	CreatedFrom string `json:"created_from" validate:"omitempty,max=35"`
+	CreatedTo   string `json:"created_to" validate:"omitempty,max=35"`
+	Query       string `json:"q" validate:"omitempty,max=100"`
+}
+
+// BulkWordsRequest は単語の一括操作リクエストDTO。対象は word_ids と filter のどちらか一方で指定する
+type BulkWordsRequest struct {
+	WordIDs []uuid.UUID     `json:"word_ids" validate:"required_without=Filter,excluded_with=Filter,max=1000,unique"`
+	Filter  *BulkWordFilter `json:"filter" validate:"omitempty"`
+	Action  string          `json:"action" validate:"required,oneof=delete restore replace_definition reset_progress"`
+	Find    string          `json:"find" validate:"required_if=Action replace_definition,max=1000"` // replace_definition で置換する文字列
+	Replace string          `json:"replace" validate:"max=1000"`                                    // replace_definition の置換後の文字列 (空文字なら削除)
+}
+
+// BulkItemResult は一括操作の単語ごとの結果
+type BulkItemResult struct {
+	WordID  uuid.UUID `json:"word_id"`
+	Status  string    `json:"status"`
+	Message string    `json:"message,omitempty"`
+}
+
+// BulkWordsResponse は一括操作のレスポンスDTO
+type BulkWordsResponse struct {
+	Action    string           `json:"action"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Unchanged int              `json:"unchanged"`
+	NotFound  int              `json:"not_found"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
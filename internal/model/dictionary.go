@@ -0,0 +1,20 @@
+package model
+
+// DictionaryEntry は辞書の見出しのレスポンスDTO
+type DictionaryEntry struct {
+	Term         string   `json:"term"`
+	Reading      string   `json:"reading"`
+	Definitions  []string `json:"definitions"`    // 語義ごとの意味
+	PartOfSpeech string   `json:"part_of_speech"` // 品詞 (PartOfSpeechXxx。不明なら空)
+}
+
+// 辞書検索のリクエスト (クエリパラメータ)
+type DictionaryLookupRequest struct {
+	Term string `json:"term" validate:"required,max=255"`
+}
+
+// 辞書検索のレスポンスDTO。見出しは辞書の優先度の順に並ぶ (見つからない場合は空)
+type DictionaryLookupResponse struct {
+	Term    string            `json:"term"`
+	Entries []DictionaryEntry `json:"entries"`
+}
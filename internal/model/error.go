@@ -4,6 +4,7 @@ package model
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // アプリケーション固有のエラー型。errors.Newの代わりに独自の型を定義することもありますが、
@@ -16,12 +17,14 @@ func (e sentinelError) Error() string {
 
 // アプリケーション固有のエラー
 var (
-	ErrNotFound       = errors.New("resource not found")
-	ErrInvalidInput   = errors.New("invalid input")
-	ErrInternalServer = errors.New("internal server error")
-	ErrForbidden      = errors.New("forbidden")
-	ErrTenantNotFound = errors.New("tenant not found or invalid")
-	ErrConflict       = errors.New("resource conflict") // 重複エラー用
+	ErrNotFound        = errors.New("resource not found")
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrInternalServer  = errors.New("internal server error")
+	ErrForbidden       = errors.New("forbidden")
+	ErrTenantNotFound  = errors.New("tenant not found or invalid")
+	ErrConflict        = errors.New("resource conflict")   // 重複エラー用
+	ErrTooManyRequests = errors.New("too many requests")   // 試行回数・リクエスト数の制限
+	ErrUnavailable     = errors.New("service unavailable") // 設定されていない・利用できない外部サービス
 )
 
 // APIError はAPIエラーレスポンスの構造体
@@ -40,6 +43,8 @@ type AppError struct {
 	Detail ErrorDetail
 	// エラーの根本原因（ラップしたエラー）
 	UnwrapErr error
+	// RetryAfter が0より大きい場合、Retry-After ヘッダーで再試行できるまでの時間を返す
+	RetryAfter time.Duration
 }
 
 // Error はerrorインターフェースを実装するためのメソッド
@@ -0,0 +1,47 @@
+// internal/model/export.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// エクスポート形式
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatTSV  = "tsv"
+	ExportFormatJSON = "json"
+	ExportFormatApkg = "apkg"
+)
+
+// WordFilter は単語一覧の絞り込み条件 (すべて任意)
+type WordFilter struct {
+	Level       *ProgressLevel // 学習レベル
+	CreatedFrom *time.Time     // 作成日時の下限 (この日時を含む)
+	CreatedTo   *time.Time     // 作成日時の上限 (この日時を含まない)
+	Query       string         // 単語・意味の部分一致 (大文字小文字を区別しない)
+	OnlyDeleted bool           // true の場合、論理削除済みの単語だけを対象にする
+}
+
+// ExportWordsRequest はエクスポートAPIのクエリパラメータ
+type ExportWordsRequest struct {
+	Format      string `json:"format" validate:"required,oneof=csv tsv json apkg"`
+	Level       int    `json:"level" validate:"omitempty,min=1,max=3"`
+	CreatedFrom string `json:"created_from" validate:"omitempty,max=35"`
+	CreatedTo   string `json:"created_to" validate:"omitempty,max=35"`
+	Query       string `json:"q" validate:"omitempty,max=100"`
+}
+
+// WordWithProgress は単語とその学習進捗を結合した行 (エクスポート用)
+type WordWithProgress struct {
+	WordID         uuid.UUID      `json:"word_id"`
+	Term           string         `json:"term"`
+	Definition     string         `json:"definition"`
+	Tags           pq.StringArray `gorm:"type:text[]" json:"tags"`
+	Level          *ProgressLevel `json:"level"`            // 学習進捗がない場合は nil
+	NextReviewDate *time.Time     `json:"next_review_date"` // 学習進捗がない場合は nil
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
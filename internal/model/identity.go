@@ -23,3 +23,43 @@ type Identity struct {
 func (Identity) TableName() string {
 	return "identities"
 }
+
+// IsUsableLoginMethod はこの認証情報でログインできるかどうかを返します。ローカル認証はパスワードが設定されている場合のみログインに使えます。
+func (i *Identity) IsUsableLoginMethod() bool {
+	return i.AuthProvider != AuthProviderLocal || i.PasswordHash != nil
+}
+
+// IdentityResponse はログインに使う認証情報 (ログイン方法) の一覧APIのレスポンスです
+type IdentityResponse struct {
+	ID          uint   `json:"id"`
+	Provider    string `json:"provider"`
+	ProviderID  string `json:"provider_id"`  // local の場合はメールアドレス
+	HasPassword bool   `json:"has_password"` // local の場合にパスワードが設定されているか
+}
+
+// NewIdentityResponse は認証情報から IdentityResponse を作ります
+func NewIdentityResponse(i *Identity) IdentityResponse {
+	return IdentityResponse{
+		ID:          i.ID,
+		Provider:    i.AuthProvider,
+		ProviderID:  i.ProviderID,
+		HasPassword: i.PasswordHash != nil,
+	}
+}
+
+// LinkIdentityRequest はログイン中のアカウントに外部のログイン方法を追加するAPIのリクエスト。
+// パスワードが設定されている場合は password も必要です。設定されていない場合は、直近にログインしたセッションからのみ追加できます。
+type LinkIdentityRequest struct {
+	Code     string `json:"code" validate:"required"`
+	Password string `json:"password"`
+}
+
+// UnlinkIdentityRequest はログイン方法を削除するAPIのリクエスト。password の扱いは LinkIdentityRequest と同じです。
+type UnlinkIdentityRequest struct {
+	Password string `json:"password"`
+}
+
+// SetPasswordRequest はパスワードのないアカウント (Googleログインのみ) にパスワードを設定するAPIのリクエスト
+type SetPasswordRequest struct {
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
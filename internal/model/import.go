@@ -0,0 +1,60 @@
+// internal/model/import.go
+package model
+
+import "github.com/google/uuid"
+
+// 既存の単語と重複した場合の扱い
+const (
+	DuplicateStrategySkip      = "skip"      // 取り込まない
+	DuplicateStrategyOverwrite = "overwrite" // 既存の単語の意味を上書きする
+	DuplicateStrategySuffix    = "suffix"    // "term (2)" のように連番を付けて別の単語として取り込む
+)
+
+// インポート結果の行ごとのステータス
+const (
+	ImportStatusCreated     = "created"
+	ImportStatusOverwritten = "overwritten"
+	ImportStatusRenamed     = "renamed"
+	ImportStatusSkipped     = "skipped"
+	ImportStatusFailed      = "failed"
+)
+
+// ImportWordsRequest は CSV/TSV インポートのオプション (multipart のフォーム値から組み立てる)
+type ImportWordsRequest struct {
+	Format           string `json:"format" validate:"omitempty,oneof=csv tsv"`
+	Encoding         string `json:"encoding" validate:"omitempty,oneof=utf-8 shift_jis"`
+	TermColumn       string `json:"term_column" validate:"omitempty,max=100"`
+	DefinitionColumn string `json:"definition_column" validate:"omitempty,max=100"`
+	OnDuplicate      string `json:"on_duplicate" validate:"omitempty,oneof=skip overwrite suffix"`
+	DryRun           bool   `json:"dry_run"`
+}
+
+// ImportRowResult はインポートの1行ごとの処理結果
+type ImportRowResult struct {
+	Row     int        `json:"row"`
+	Term    string     `json:"term"`
+	Status  string     `json:"status"`
+	WordID  *uuid.UUID `json:"word_id,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// ImportWordsResponse はインポートAPIのレスポンスDTO
+type ImportWordsResponse struct {
+	DryRun      bool              `json:"dry_run"`
+	Total       int               `json:"total"`
+	Created     int               `json:"created"`
+	Overwritten int               `json:"overwritten"`
+	Renamed     int               `json:"renamed"`
+	Skipped     int               `json:"skipped"`
+	Failed      int               `json:"failed"`
+	Rows        []ImportRowResult `json:"rows"`
+}
+
+// ImportAnkiRequest は Anki (.apkg/.colpkg) インポートのオプション (multipart のフォーム値から組み立てる)
+type ImportAnkiRequest struct {
+	TermField       string `json:"term_field" validate:"omitempty,max=100"`
+	DefinitionField string `json:"definition_field" validate:"omitempty,max=100"`
+	WithScheduling  bool   `json:"with_scheduling"` // true の場合、Ankiの復習間隔から学習レベルと次回復習日を引き継ぐ
+	OnDuplicate     string `json:"on_duplicate" validate:"omitempty,oneof=skip overwrite suffix"`
+	DryRun          bool   `json:"dry_run"`
+}
@@ -0,0 +1,17 @@
+// internal/model/login_attempt.go
+package model
+
+import "time"
+
+// LoginAttempt はログインに失敗した回数とロックの状態です。
+// Key はアカウント単位 ("account:{メールアドレス}") や IP アドレス単位 ("ip:{IPアドレス}") で分けます。
+type LoginAttempt struct {
+	Key          string     `gorm:"primaryKey"`
+	Failures     int        `gorm:"not null;default:0"` // 連続して失敗した回数
+	LastFailedAt time.Time  `gorm:"not null"`
+	LockedUntil  *time.Time // この日時まではパスワードを確認せずに拒否する
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
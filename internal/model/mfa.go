@@ -0,0 +1,63 @@
+// internal/model/mfa.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantTOTP はテナントの TOTP (認証アプリ) による2段階認証の設定です。
+// EnabledAt が nil の間は登録手続き中で、ログインには使いません。
+type TenantTOTP struct {
+	TenantID     uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Secret       string     `gorm:"not null"` // 暗号化した秘密鍵
+	EnabledAt    *time.Time // 確認コードで有効化した日時
+	LastUsedStep int64      `gorm:"not null;default:0"` // 最後に受け付けたコードの時間ステップ (同じコードの再利用を防ぐ)
+	CreatedAt    time.Time
+}
+
+func (TenantTOTP) TableName() string {
+	return "tenant_totp"
+}
+
+// MFARecoveryCode は認証アプリを使えなくなった場合のリカバリーコードです。コードそのものは保存せず、ハッシュだけを保存します。
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CodeHash  string     `gorm:"not null"`
+	UsedAt    *time.Time // 使用済みのコードは再び使えない
+	CreatedAt time.Time
+}
+
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
+
+// TOTPEnrollResponse は2段階認証の登録開始APIのレスポンス
+type TOTPEnrollResponse struct {
+	Secret     string `json:"secret"`      // 認証アプリに手入力する場合の秘密鍵 (Base32)
+	OTPAuthURI string `json:"otpauth_uri"` // QRコードにして認証アプリに読み取らせる URI
+}
+
+// TOTPConfirmRequest は2段階認証の有効化APIのリクエストボディ
+type TOTPConfirmRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+// TOTPDisableRequest は2段階認証の無効化APIのリクエストボディ
+type TOTPDisableRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// RecoveryCodesResponse は発行したリカバリーコードです。コードはこのレスポンスでしか確認できません。
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFALoginRequest は2段階認証のログインAPIのリクエストボディ。
+// Code には認証アプリのコードかリカバリーコードを指定します。
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,max=32"`
+}
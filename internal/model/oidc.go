@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// OIDCProviderResponse はログインに使える OpenID Connect のプロバイダの一覧APIのレスポンス
+type OIDCProviderResponse struct {
+	Key  string `json:"key"`  // 認可・コールバックのURLと、ログイン方法の provider に使う識別子
+	Name string `json:"name"` // ログイン画面に表示する名前
+}
+
+// OIDCAuthorizeResponse は OpenID Connect の認可開始APIのレスポンス。
+// フロントエンドは state を保存してから authorization_url にリダイレクトし、戻ってきた state が一致することを確認してからコールバックAPIを呼び出します。
+// Binding は認可を開始したブラウザの HttpOnly Cookie に保存する値で、レスポンスボディには含めません。
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	Binding          string    `json:"-"`
+	BindingExpiresAt time.Time `json:"-"`
+}
+
+// OIDCCallbackRequest はプロバイダから戻ってきた認可コードと state を受け取るAPIのリクエスト
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+// OIDCLinkRequest はプロバイダから戻ってきた認可コードと state で、ログイン中のアカウントにログイン方法を追加するAPIのリクエスト。
+// password の扱いは LinkIdentityRequest と同じです。
+type OIDCLinkRequest struct {
+	Code     string `json:"code" validate:"required"`
+	State    string `json:"state" validate:"required"`
+	Password string `json:"password"`
+}
@@ -15,11 +15,18 @@ const (
 	Level3                          // 3
 )
 
+// 出題形式 (学習進捗は単語と出題形式の組ごとに持つ)
+const (
+	DirectionRecognition = "recognition" // 単語を見て意味を答える
+	DirectionCloze       = "cloze"       // 例文の空欄に入る単語を答える (穴埋め)
+)
+
 // LearningProgress は単語の学習進捗を表します
 type LearningProgress struct {
 	ProgressID     uuid.UUID     `gorm:"type:uuid;primaryKey"`
-	TenantID       uuid.UUID     `gorm:"type:uuid;not null;index:idx_tenant_word,unique"` // 複合ユニークインデックスの一部
-	WordID         uuid.UUID     `gorm:"type:uuid;not null;index:idx_tenant_word,unique"` // 複合ユニークインデックスの一部
+	TenantID       uuid.UUID     `gorm:"type:uuid;not null;index:idx_tenant_word_direction,unique"`           // 複合ユニークインデックスの一部
+	WordID         uuid.UUID     `gorm:"type:uuid;not null;index:idx_tenant_word_direction,unique"`           // 複合ユニークインデックスの一部
+	Direction      string        `gorm:"not null;default:recognition;index:idx_tenant_word_direction,unique"` // 出題形式 (DirectionXxx)
 	Level          ProgressLevel `gorm:"not null;default:1"`
 	NextReviewDate time.Time     `gorm:"not null;index"`
 	LastReviewedAt *time.Time
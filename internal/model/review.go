@@ -6,12 +6,41 @@ import "github.com/google/uuid"
 // ReviewWordResponse は復習単語リストのレスポンスDTO
 type ReviewWordResponse struct {
 	WordID     uuid.UUID     `json:"word_id"`
+	Direction  string        `json:"direction"` // 出題形式 (DirectionXxx)。結果の送信時にそのまま指定する
 	Term       string        `json:"term"`
 	Definition string        `json:"definition"`
 	Level      ProgressLevel `json:"level"` // ★ 型を model.ProgressLevel に変更
+
+	// カード裏面に表示する詳細情報 (ReviewWordsOptions.WithDetails の場合のみ、値があるものを含める)
+	Reading      string        `json:"reading,omitempty"`
+	PartOfSpeech string        `json:"part_of_speech,omitempty"`
+	Examples     []WordExample `json:"examples,omitempty"`
+	Notes        string        `json:"notes,omitempty"`
+	Senses       []WordSense   `json:"senses,omitempty"` // 全語義
+
+	// 出題する語義 (ReviewWordsOptions.SingleSense で語義がある単語の場合のみ)。Definition にはこの語義の意味が入る
+	Sense *WordSense `json:"sense,omitempty"`
+
+	// 穴埋め問題 (Direction が cloze の場合のみ)
+	Cloze *ClozeCard `json:"cloze,omitempty"`
+}
+
+// ClozeCard は例文から作った穴埋め問題です
+type ClozeCard struct {
+	Text        string `json:"text"`                  // 単語を "___" に置き換えた例文
+	Answer      string `json:"answer"`                // 空欄に入る、例文中での表記 (活用形の場合もある)
+	Translation string `json:"translation,omitempty"` // 例文の訳
+}
+
+// ReviewWordsOptions は復習単語リスト取得時のオプションです
+type ReviewWordsOptions struct {
+	WithDetails bool // true の場合、読み・品詞・例文・メモ・全語義を含める
+	SingleSense bool // true の場合、語義がある単語は語義を1つ選んで出題する (学習進捗は単語単位のまま)
+	Cloze       bool // true の場合、例文がある単語の穴埋め問題も出題する (学習進捗は通常の出題とは別に持つ)
 }
 
 // SubmitReviewRequest は復習結果送信リクエストのDTO
 type SubmitReviewRequest struct {
-	IsCorrect *bool `json:"is_correct" validate:"required"`
+	IsCorrect *bool  `json:"is_correct" validate:"required"`
+	Direction string `json:"direction" validate:"omitempty,oneof=recognition cloze"` // 省略時は recognition
 }
@@ -0,0 +1,77 @@
+// internal/model/revision.go
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WordRevision は単語の変更履歴の1件を表します
+type WordRevision struct {
+	RevisionID uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	TenantID   uuid.UUID          `gorm:"type:uuid;not null"`
+	WordID     uuid.UUID          `gorm:"type:uuid;not null"`
+	RevisionNo int                `gorm:"not null"` // 単語ごとの通し番号 (1始まり)
+	OldValues  WordRevisionValues `gorm:"type:jsonb;not null"`
+	NewValues  WordRevisionValues `gorm:"type:jsonb;not null"`
+	RequestID  string             `gorm:"not null;default:''"`
+	CreatedAt  time.Time
+}
+
+func (WordRevision) TableName() string {
+	return "word_revisions"
+}
+
+// WordRevisionValues は変更履歴に記録する単語の項目です。変更されなかった項目は nil になります。
+type WordRevisionValues struct {
+	Term         *string        `json:"term,omitempty"`
+	Definition   *string        `json:"definition,omitempty"`
+	Tags         *[]string      `json:"tags,omitempty"`
+	Reading      *string        `json:"reading,omitempty"`
+	PartOfSpeech *string        `json:"part_of_speech,omitempty"`
+	Examples     *[]WordExample `json:"examples,omitempty"`
+	Notes        *string        `json:"notes,omitempty"`
+}
+
+// IsEmpty は記録する項目がないかを返します
+func (v WordRevisionValues) IsEmpty() bool {
+	return v.Term == nil && v.Definition == nil && v.Tags == nil &&
+		v.Reading == nil && v.PartOfSpeech == nil && v.Examples == nil && v.Notes == nil
+}
+
+// Value は jsonb 列に保存する値を返します
+func (v WordRevisionValues) Value() (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan は jsonb 列の値を読み込みます
+func (v *WordRevisionValues) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		return json.Unmarshal(s, v)
+	case string:
+		return json.Unmarshal([]byte(s), v)
+	case nil:
+		*v = WordRevisionValues{}
+		return nil
+	default:
+		return fmt.Errorf("WordRevisionValues.Scan: unsupported type %T", src)
+	}
+}
+
+// 単語の変更履歴のレスポンスDTO
+type WordRevisionResponse struct {
+	Revision  int                `json:"revision"`
+	OldValues WordRevisionValues `json:"old_values"`
+	NewValues WordRevisionValues `json:"new_values"`
+	RequestID string             `json:"request_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+// internal/model/sense.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxWordSenses は1つの単語に登録できる語義の数
+const MaxWordSenses = 20
+
+// WordSense は単語の語義 (意味の1つ) を表します
+type WordSense struct {
+	SenseID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"sense_id"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null" json:"-"`
+	WordID     uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Position   int       `gorm:"not null" json:"position"` // 表示順 (0始まり)
+	Definition string    `gorm:"not null" json:"definition"`
+	Example    string    `gorm:"not null;default:''" json:"example"`
+	Tag        string    `gorm:"not null;default:''" json:"tag"` // 語義の分野などの任意のラベル
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (WordSense) TableName() string {
+	return "word_senses"
+}
+
+// 語義の登録・更新リクエストDTO。
+// 単語の senses は配列全体で置き換え、配列の順序がそのまま表示順になる。
+// 既存の語義を残す場合は sense_id を指定する (省略した語義は新規作成、配列にない既存の語義は削除される)。
+type WordSenseRequest struct {
+	SenseID    *uuid.UUID `json:"sense_id,omitempty"`
+	Definition string     `json:"definition" validate:"required,max=1000"`
+	Example    string     `json:"example" validate:"max=1000"`
+	Tag        string     `json:"tag" validate:"max=50"`
+}
@@ -0,0 +1,42 @@
+// internal/model/session.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session はログインごとのセッションです。アクセストークンの jti にはセッションIDを埋め込み、
+// リフレッシュトークンのファミリーID (RefreshToken.FamilyID) にもセッションIDを使います。
+// 失効させたセッションのアクセストークン・リフレッシュトークンは使えなくなります。
+type Session struct {
+	SessionID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null"`
+	UserAgent  string    `gorm:"not null;default:''"`
+	IPAddress  string    `gorm:"not null;default:''"`
+	CreatedAt  time.Time
+	LastSeenAt time.Time  `gorm:"not null"`
+	RevokedAt  *time.Time // ログアウト・失効させた日時
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// ClientInfo はリクエスト元の端末の情報です。ログイン時にセッションに記録します。
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// SessionResponse はセッション一覧APIのレスポンスの1件分です
+type SessionResponse struct {
+	SessionID  uuid.UUID `json:"session_id"`
+	Device     string    `json:"device"` // User-Agent から推定した端末 (例: "Chrome on Windows")
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"` // このリクエストのセッションかどうか
+}
@@ -28,7 +28,8 @@ func (Tenant) TableName() string {
 type ContextKey string
 
 const (
-	TenantIDKey ContextKey = "tenantID"
+	TenantIDKey  ContextKey = "tenantID"
+	SessionIDKey ContextKey = "sessionID" // アクセストークンの jti (セッションID)
 )
 
 // RegisterRequest は新規登録APIのリクエストボディの構造体 (DTO)
@@ -9,14 +9,33 @@ import (
 // UserVerificationToken はアカウント有効化用のトークン情報を保持します
 type UserVerificationToken struct {
 	Token     string    `gorm:"primaryKey"`
-	TenantID  uuid.UUID `gorm:"type:uuid;not null"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index"`
 	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time // 確認メールを送り直せる間隔の判定に使う
+
+	// 有効化されていないアカウントに登録し直した場合の名前とパスワードのハッシュ。
+	// 先に他人が登録したアカウントを乗っ取れないよう、このトークンで有効化した時に限り反映する
+	PendingName         *string
+	PendingPasswordHash *string
 }
 
 func (UserVerificationToken) TableName() string {
 	return "user_verification_tokens"
 }
 
+// EmailChangeToken はメールアドレスの変更の確認用トークンです。新しいメールアドレスに送ったリンクを開くまで変更しません。
+type EmailChangeToken struct {
+	Token     string    `gorm:"primaryKey"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	NewEmail  string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (EmailChangeToken) TableName() string {
+	return "email_change_tokens"
+}
+
 type PasswordResetToken struct {
 	Token     string    `gorm:"primaryKey"`
 	TenantID  uuid.UUID `gorm:"type:uuid;not null"`
@@ -26,3 +45,22 @@ type PasswordResetToken struct {
 func (PasswordResetToken) TableName() string {
 	return "password_reset_tokens"
 }
+
+// RefreshToken はアクセストークンを再発行するためのリフレッシュトークンです。
+// トークンそのものは保存せず、SHA-256 のハッシュだけを保存します。
+// 使うたびに同じ FamilyID の新しいトークンに交換 (ローテーション) し、使用済みのトークンが再び使われた場合は
+// 漏洩したものとみなしてファミリー全体を失効させます。
+type RefreshToken struct {
+	TokenID   uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID  uuid.UUID  `gorm:"type:uuid;not null"`
+	FamilyID  uuid.UUID  `gorm:"type:uuid;not null;index"` // ログインごとに発行し、ローテーションで引き継ぐ
+	TokenHash string     `gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // ローテーションで新しいトークンに交換した日時
+	RevokedAt *time.Time // 失効させた日時
+	CreatedAt time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -2,44 +2,213 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 // Word は単語とその定義を表します
 type Word struct {
-	WordID     uuid.UUID      `gorm:"type:uuid;primaryKey" json:"word_id"`
-	TenantID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"-"`
-	Term       string         `gorm:"not null" json:"term"`       // 単語
-	Definition string         `gorm:"not null" json:"definition"` // 単語の定義
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"` // 論理削除用
+	WordID         uuid.UUID      `gorm:"type:uuid;primaryKey" json:"word_id"`
+	TenantID       uuid.UUID      `gorm:"type:uuid;not null;index" json:"-"`
+	Term           string         `gorm:"not null" json:"term"`                          // 単語
+	NormalizedTerm string         `gorm:"not null" json:"-"`                             // 重複判定用に正規化した単語 (リポジトリが Term から設定する)
+	Definition     string         `gorm:"not null" json:"definition"`                    // 単語の定義
+	Tags           pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"tags"` // タグ (インポート元のタグなど)
+	Reading        string         `gorm:"not null;default:''" json:"reading"`            // 読み (ふりがな・発音記号など)
+	ReadingManual  bool           `gorm:"not null;default:false" json:"reading_manual"`  // 読みを利用者が入力したか (false なら単語から自動生成した読み)
+	PartOfSpeech   string         `gorm:"not null;default:''" json:"part_of_speech"`     // 品詞 (PartOfSpeechXxx)
+	Examples       WordExamples   `gorm:"type:jsonb;not null;default:'[]'" json:"examples"`
+	Notes          string         `gorm:"not null;default:''" json:"notes"` // 自由記述のメモ
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"` // 論理削除用
 
 	// 関連 (Preload用)
 	LearningProgress *LearningProgress `gorm:"foreignKey:WordID;references:WordID" json:"-"`
+	Senses           []WordSense       `gorm:"foreignKey:WordID;references:WordID" json:"senses"`      // 語義 (Position 順)
+	Attachments      []WordAttachment  `gorm:"foreignKey:WordID;references:WordID" json:"attachments"` // 添付ファイル (作成順)
 }
 
 func (Word) TableName() string {
 	return "words"
 }
 
+// 品詞
+const (
+	PartOfSpeechNoun         = "noun"
+	PartOfSpeechVerb         = "verb"
+	PartOfSpeechAdjective    = "adjective"
+	PartOfSpeechAdverb       = "adverb"
+	PartOfSpeechPronoun      = "pronoun"
+	PartOfSpeechPreposition  = "preposition"
+	PartOfSpeechConjunction  = "conjunction"
+	PartOfSpeechInterjection = "interjection"
+	PartOfSpeechParticle     = "particle"  // 助詞
+	PartOfSpeechAuxiliary    = "auxiliary" // 助動詞
+	PartOfSpeechPhrase       = "phrase"    // 句・慣用句
+	PartOfSpeechOther        = "other"
+)
+
+// partsOfSpeech は登録できる品詞の一覧
+var partsOfSpeech = map[string]bool{
+	PartOfSpeechNoun: true, PartOfSpeechVerb: true, PartOfSpeechAdjective: true, PartOfSpeechAdverb: true,
+	PartOfSpeechPronoun: true, PartOfSpeechPreposition: true, PartOfSpeechConjunction: true, PartOfSpeechInterjection: true,
+	PartOfSpeechParticle: true, PartOfSpeechAuxiliary: true, PartOfSpeechPhrase: true, PartOfSpeechOther: true,
+}
+
+// IsValidPartOfSpeech は品詞として登録できる値かを返します (未設定を表す空文字も有効)
+func IsValidPartOfSpeech(s string) bool {
+	return s == "" || partsOfSpeech[s]
+}
+
+// MaxWordExamples は1つの単語に登録できる例文の数
+const MaxWordExamples = 20
+
+// WordExample は単語の例文と、その訳です
+type WordExample struct {
+	Sentence    string `json:"sentence" validate:"required,max=1000"`
+	Translation string `json:"translation" validate:"max=1000"`
+}
+
+// WordExamples は jsonb 列に保存する例文の一覧です
+type WordExamples []WordExample
+
+// Value は jsonb 列に保存する値を返します (nil の場合は空の配列)
+func (e WordExamples) Value() (driver.Value, error) {
+	if e == nil {
+		e = WordExamples{}
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan は jsonb 列の値を読み込みます
+func (e *WordExamples) Scan(src any) error {
+	switch s := src.(type) {
+	case []byte:
+		return json.Unmarshal(s, e)
+	case string:
+		return json.Unmarshal([]byte(s), e)
+	case nil:
+		*e = WordExamples{}
+		return nil
+	default:
+		return fmt.Errorf("WordExamples.Scan: unsupported type %T", src)
+	}
+}
+
 // 単語作成リクエストDTO
 type PostWordRequest struct {
-	Term       string `json:"term" validate:"required"`
-	Definition string `json:"definition" validate:"required"`
+	Term         string        `json:"term" validate:"required,max=255"`
+	Definition   string        `json:"definition" validate:"required_unless=Autofill true"` // autofill の場合は省略できる
+	Reading      string        `json:"reading" validate:"max=255"`                          // 空の場合は単語から自動生成する
+	PartOfSpeech string        `json:"part_of_speech" validate:"part_of_speech"`
+	Examples     []WordExample `json:"examples" validate:"max=20,dive"`
+	Notes        string        `json:"notes" validate:"max=10000"`
+
+	Senses []WordSenseRequest `json:"senses" validate:"max=20,dive"`
+
+	// Autofill が true の場合、省略した意味・読み・品詞を辞書で調べて補う
+	Autofill bool `json:"autofill"`
 }
 
-// 単語更新（全体）リクエストDTO
+// 単語更新（全体）リクエストDTO。省略した詳細情報 (読み・品詞・例文・メモ) は空になる
 type PutWordRequest struct {
-	Term       string `json:"term" validate:"required"`
-	Definition string `json:"definition" validate:"required"`
+	Term         string        `json:"term" validate:"required,max=255"`
+	Definition   string        `json:"definition" validate:"required"`
+	Reading      string        `json:"reading" validate:"max=255"` // 空の場合は単語から自動生成する
+	PartOfSpeech string        `json:"part_of_speech" validate:"part_of_speech"`
+	Examples     []WordExample `json:"examples" validate:"max=20,dive"`
+	Notes        string        `json:"notes" validate:"max=10000"`
+
+	Senses []WordSenseRequest `json:"senses" validate:"max=20,dive"` // 省略した場合は語義をすべて削除する
 }
 
 // 単語更新（部分）リクエストDTO
 type PatchWordRequest struct {
-	Term       *string `json:"term,omitempty" validate:"omitempty,min=1"` // omitempty を付けるとJSONでnilの場合省略される
-	Definition *string `json:"definition,omitempty" validate:"omitempty,min=1"`
+	Term         *string        `json:"term,omitempty" validate:"omitempty,min=1,max=255"` // omitempty を付けるとJSONでnilの場合省略される
+	Definition   *string        `json:"definition,omitempty" validate:"omitempty,min=1"`
+	Reading      *string        `json:"reading,omitempty" validate:"omitempty,max=255"`               // 空文字で自動生成の読みに戻す
+	PartOfSpeech *string        `json:"part_of_speech,omitempty" validate:"omitempty,part_of_speech"` // 空文字で品詞を消す
+	Examples     *[]WordExample `json:"examples,omitempty" validate:"omitempty,max=20,dive"`
+	Notes        *string        `json:"notes,omitempty" validate:"omitempty,max=10000"`
+
+	Senses *[]WordSenseRequest `json:"senses,omitempty" validate:"omitempty,max=20,dive"` // 指定した場合は語義全体を置き換える
+}
+
+// ゴミ箱 (論理削除済み) の単語のレスポンスDTO
+type TrashedWordResponse struct {
+	WordID     uuid.UUID `json:"word_id"`
+	Term       string    `json:"term"`
+	Definition string    `json:"definition"`
+	Tags       []string  `json:"tags"`
+	CreatedAt  time.Time `json:"created_at"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at"` // この日時を過ぎると完全に削除される
+}
+
+// ゴミ箱を空にした結果のレスポンスDTO
+type PurgeTrashResponse struct {
+	Purged int64 `json:"purged"`
+}
+
+// 重複の可能性がある単語のグループ
+type DuplicateWordGroup struct {
+	Key   string  `json:"key"`   // グループをまとめた比較用のキー
+	Words []*Word `json:"words"` // 登録日時の古い順
+}
+
+// 重複の可能性がある単語の一覧のレスポンスDTO
+type DuplicateWordsResponse struct {
+	Groups []DuplicateWordGroup `json:"groups"`
+}
+
+// 読みを自動生成し直した結果
+type GenerateReadingsResult struct {
+	Scanned    int `json:"scanned"`    // 確認した単語の数
+	Updated    int `json:"updated"`    // 読みを更新した数
+	Unresolved int `json:"unresolved"` // 辞書にない漢字を含むなど、読みを決められなかった数
+}
+
+// 正規化済みの単語を再計算した結果
+type RenormalizeTermsResult struct {
+	Scanned   int `json:"scanned"`   // 確認した単語の数
+	Updated   int `json:"updated"`   // 正規化済みの単語を更新した数
+	Conflicts int `json:"conflicts"` // 更新すると他の有効な単語と重複するため、更新しなかった数
+}
+
+// DefaultExtractLimit は単語の候補の抽出で limit を省略した場合に返す候補の数
+const DefaultExtractLimit = 100
+
+// テキストから単語の候補を抽出するリクエストDTO
+type ExtractWordsRequest struct {
+	Text  string `json:"text" validate:"required,max=100000"`
+	Limit int    `json:"limit" validate:"omitempty,min=1,max=500"` // 返す候補の数 (省略時は DefaultExtractLimit)
+}
+
+// WordCandidate は抽出した単語の候補です。
+// count 以外は PostWordRequest と同じ形のため、意味を入力した上でそのまま登録リクエストに使えます。
+type WordCandidate struct {
+	Term         string        `json:"term"`
+	Definition   string        `json:"definition"` // 抽出時は常に空
+	Reading      string        `json:"reading"`
+	PartOfSpeech string        `json:"part_of_speech"`
+	Examples     []WordExample `json:"examples"` // 最初に出現した文 (長すぎる場合は空)
+	Count        int           `json:"count"`    // テキスト中の出現回数
+}
+
+// 単語の候補の抽出結果のレスポンスDTO。候補は出現回数の多い順に並ぶ
+type ExtractWordsResponse struct {
+	Candidates      []WordCandidate `json:"candidates"`
+	TotalCandidates int             `json:"total_candidates"` // 登録済みの単語を除いた候補の総数 (limit で切り捨てる前)
+	SkippedExisting int             `json:"skipped_existing"` // 登録済みのため除いた候補の数
 }
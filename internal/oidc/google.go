@@ -0,0 +1,58 @@
+package oidc
+
+import (
+	"net/http"
+
+	"go_4_vocab_keep/internal/config"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// GoogleIssuer は Google の ID トークンの発行者
+	GoogleIssuer = "https://accounts.google.com"
+	// GoogleJWKSURL は Google の ID トークンの署名を検証する公開鍵の JWKS
+	GoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+)
+
+// googleIssuers は Google の ID トークンの iss クレームとして受け付ける値 (スキームのない形式で発行されることがある)
+var googleIssuers = []string{GoogleIssuer, "accounts.google.com"}
+
+// NewGoogleProvider は Google の Provider を返します。エンドポイントは固定なのでディスカバリは行いません。
+// keys が nil の場合は Google の JWKS から取得した公開鍵をキャッシュして使います。
+func NewGoogleProvider(cfg Config, client *http.Client, keys KeySource) (*Provider, error) {
+	cfg.Issuer = GoogleIssuer
+	p, err := NewProvider(cfg, client)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = NewRemoteKeySet(GoogleJWKSURL, p.client)
+	}
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+	p.oauth = &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       scopes,
+		Endpoint:     google.Endpoint,
+	}
+	p.verifier = NewVerifier(keys, cfg.ClientID, googleIssuers...)
+	return p, nil
+}
+
+// NewGoogleProviderFromConfig は設定から Google の Provider を返します。client_id が設定されていない場合は nil を返します。
+func NewGoogleProviderFromConfig(cfg *config.GoogleOAuthConfig, client *http.Client) (*Provider, error) {
+	if cfg.ClientID == "" {
+		return nil, nil
+	}
+	return NewGoogleProvider(Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+	}, client, nil)
+}
@@ -0,0 +1,198 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksCacheTTL は取得した公開鍵を使い続ける時間
+	jwksCacheTTL = time.Hour
+	// jwksMinRefreshInterval は未知の kid のために公開鍵を取得し直す最短の間隔 (不正なトークンで取得を繰り返させないため)
+	jwksMinRefreshInterval = 30 * time.Second
+	// maxJWKSSize は JWKS のレスポンスとして読み込む最大サイズ
+	maxJWKSSize = 1 << 20
+)
+
+// ErrKeyNotFound は ID トークンの kid に対応する公開鍵が見つからない場合のエラーです
+var ErrKeyNotFound = errors.New("oidc: signing key not found")
+
+// KeySource は ID トークンの署名を検証する公開鍵を kid から探します
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// StaticKeySet は固定の公開鍵を返す KeySource です (テストや、鍵を別の方法で配布する場合に使う)
+type StaticKeySet map[string]crypto.PublicKey
+
+func (s StaticKeySet) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	return lookupKey(s, kid)
+}
+
+// RemoteKeySet は JWKS (jwks_uri) から取得した公開鍵をキャッシュする KeySource です。
+// キャッシュにない kid は鍵のローテーションとみなして取得し直します。
+type RemoteKeySet struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewRemoteKeySet は RemoteKeySet を返します。client が nil の場合は http.DefaultClient を使います。
+func NewRemoteKeySet(jwksURL string, client *http.Client) *RemoteKeySet {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RemoteKeySet{url: jwksURL, client: client}
+}
+
+func (s *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, err := lookupKey(s.keys, kid)
+	fetched := !s.fetchedAt.IsZero()
+	if err == nil && fetched && time.Since(s.fetchedAt) < jwksCacheTTL {
+		return key, nil
+	}
+	if err != nil && fetched && time.Since(s.fetchedAt) < jwksMinRefreshInterval {
+		return nil, err
+	}
+
+	keys, fetchErr := fetchJWKS(ctx, s.client, s.url)
+	if fetchErr != nil {
+		if key != nil {
+			return key, nil // 取得できない間は期限切れのキャッシュを使う
+		}
+		return nil, fetchErr
+	}
+	s.keys, s.fetchedAt = keys, time.Now()
+	return lookupKey(s.keys, kid)
+}
+
+// lookupKey は kid の公開鍵を返します。kid のないトークンは、鍵が1つだけの場合にその鍵で検証します。
+func lookupKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, error) {
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// jsonWebKey は JWKS の鍵 (RFC 7517) のうち、署名の検証に使う項目です
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func fetchJWKS(ctx context.Context, client *http.Client, jwksURL string) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: fetch jwks: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("oidc: decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(body.Keys))
+	for _, k := range body.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		// 対応していない種類の鍵は無視する (他の鍵で署名されたトークンは検証できる)
+		if key, err := k.publicKey(); err == nil {
+			keys[k.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("oidc: invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("oidc: unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		if _, err := key.ECDH(); err != nil { // 曲線上の点かどうかを確認する
+			return nil, fmt.Errorf("oidc: invalid ec key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("oidc: unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("oidc: invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
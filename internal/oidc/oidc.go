@@ -0,0 +1,194 @@
+// Package oidc は OpenID Connect のプロバイダ (ディスカバリ・認可コードフロー・ID トークンの検証) を扱います。
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultHTTPTimeout はプロバイダとの通信のタイムアウト
+const defaultHTTPTimeout = 10 * time.Second
+
+// maxDiscoverySize はディスカバリのレスポンスとして読み込む最大サイズ
+const maxDiscoverySize = 1 << 20
+
+// defaultScopes は Config.Scopes を指定しなかった場合に要求するスコープ
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// providerKeyPattern はプロバイダのキー (Identity.AuthProvider に保存する) として使える文字列
+var providerKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// Config は OpenID Connect のプロバイダの設定です
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string // openid は常に含める。省略時は openid, email, profile
+}
+
+// Metadata はディスカバリ (/.well-known/openid-configuration) で取得するプロバイダの情報です
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// Provider は OpenID Connect のプロバイダです。ディスカバリは最初に使う時に行い、成功した結果を使い続けます。
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *Verifier
+}
+
+// NewProvider は Provider を返します。client が nil の場合はタイムアウトを設定した http.Client を使います。
+func NewProvider(cfg Config, client *http.Client) (*Provider, error) {
+	u, err := url.Parse(cfg.Issuer)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("oidc: invalid issuer %q", cfg.Issuer)
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("oidc: client id is not configured")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &Provider{cfg: cfg, client: client}, nil
+}
+
+// NewProvidersFromConfig は設定されたプロバイダをキーごとに返します
+func NewProvidersFromConfig(cfg *config.OIDCConfig, client *http.Client) (map[string]*Provider, error) {
+	providers := make(map[string]*Provider, len(cfg.Providers))
+	for key, pc := range cfg.Providers {
+		// キーは Identity.AuthProvider として保存するため、組み込みのログイン方法と同じ値は使えない
+		if !providerKeyPattern.MatchString(key) || key == model.AuthProviderLocal || key == model.AuthProviderGoogle {
+			return nil, fmt.Errorf("oidc: invalid provider key %q", key)
+		}
+		p, err := NewProvider(Config{
+			Issuer:       pc.Issuer,
+			ClientID:     pc.ClientID,
+			ClientSecret: pc.ClientSecret,
+			RedirectURL:  pc.RedirectURL,
+			Scopes:       pc.Scopes,
+		}, client)
+		if err != nil {
+			return nil, fmt.Errorf("oidc.providers.%s: %w", key, err)
+		}
+		providers[key] = p
+	}
+	return providers, nil
+}
+
+// Discover はプロバイダのディスカバリを行います。レスポンスの issuer が issuer と一致しない場合はエラーを返します。
+func Discover(ctx context.Context, client *http.Client, issuer string) (*Metadata, error) {
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery: unexpected status %d", resp.StatusCode)
+	}
+
+	var md Metadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDiscoverySize)).Decode(&md); err != nil {
+		return nil, fmt.Errorf("oidc: discovery: decode response: %w", err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("oidc: discovery: issuer %q does not match %q", md.Issuer, issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery: required endpoints are missing")
+	}
+	return &md, nil
+}
+
+// setup はディスカバリを行い、認可コードフローの設定と ID トークンの Verifier を作ります
+func (p *Provider) setup(ctx context.Context) (*oauth2.Config, *Verifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	md, err := Discover(ctx, p.client, p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	} else if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       scopes,
+		Endpoint:     oauth2.Endpoint{AuthURL: md.AuthorizationEndpoint, TokenURL: md.TokenEndpoint},
+	}
+	p.verifier = NewVerifier(NewRemoteKeySet(md.JWKSURI, p.client), p.cfg.ClientID, md.Issuer)
+	return p.oauth, p.verifier, nil
+}
+
+// AuthCodeURL は利用者をリダイレクトさせる認可エンドポイントの URL を返します。
+// verifier が空でなければ PKCE (S256) のチャレンジを付けます。
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	oc, _, err := p.setup(ctx)
+	if err != nil {
+		return "", err
+	}
+	opts := []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("nonce", nonce)}
+	if verifier != "" {
+		opts = append(opts, oauth2.S256ChallengeOption(verifier))
+	}
+	return oc.AuthCodeURL(state, opts...), nil
+}
+
+// Exchange は認可コードをトークンに交換し、ID トークンを検証してクレームを返します
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	oc, v, err := p.setup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var opts []oauth2.AuthCodeOption
+	if verifier != "" {
+		opts = append(opts, oauth2.VerifierOption(verifier))
+	}
+	token, err := oc.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: exchange code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("oidc: token response has no id_token")
+	}
+	return v.Verify(ctx, rawIDToken, nonce)
+}
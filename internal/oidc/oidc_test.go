@@ -0,0 +1,407 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const testClientID = "test-client"
+
+// fakeIdP はテスト用の OpenID Connect のプロバイダです (ディスカバリ・JWKS・トークンエンドポイント)
+type fakeIdP struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+	issuer string // 空なら URL
+
+	mu    sync.Mutex
+	codes map[string]authRequest
+}
+
+type authRequest struct {
+	nonce     string
+	challenge string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idp := &fakeIdP{key: key, kid: "key-1", codes: map[string]authRequest{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": idp.kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		idp.mu.Lock()
+		req, ok := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		idp.mu.Unlock()
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || (req.challenge != "" && base64.RawURLEncoding.EncodeToString(sum[:]) != req.challenge) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idp.sign(t, idp.claims(req.nonce)),
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+// authorize は認可画面での同意を省略し、認可URLのパラメータから認可コードを発行します
+func (idp *fakeIdP) authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	code = "code-" + q.Get("state")[:8]
+	idp.codes[code] = authRequest{nonce: q.Get("nonce"), challenge: q.Get("code_challenge")}
+	return code, q.Get("state")
+}
+
+// issueCode は PKCE・nonce なしの認可コードを発行します
+func (idp *fakeIdP) issueCode(code string) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.codes[code] = authRequest{}
+}
+
+func (idp *fakeIdP) claims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	issuer := idp.issuer
+	if issuer == "" {
+		issuer = idp.URL
+	}
+	claims := jwt.MapClaims{
+		"iss":            issuer,
+		"sub":            "user-123",
+		"aud":            testClientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "Test User",
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	return claims
+}
+
+func (idp *fakeIdP) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.kid
+	signed, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestProvider(t *testing.T, idp *fakeIdP) *oidc.Provider {
+	t.Helper()
+	p, err := oidc.NewProvider(oidc.Config{
+		Issuer:       idp.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+	}, idp.Client())
+	require.NoError(t, err)
+	return p
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	p := newTestProvider(t, idp)
+
+	t.Run("正常系: 認可コードを交換して ID トークンを検証する", func(t *testing.T) {
+		verifier := oauth2.GenerateVerifier()
+		authURL, err := p.AuthCodeURL(ctx, "state-0123456789", "nonce-1", verifier)
+		require.NoError(t, err)
+		u, err := url.Parse(authURL)
+		require.NoError(t, err)
+		assert.Equal(t, idp.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+		assert.Equal(t, testClientID, u.Query().Get("client_id"))
+		assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+		assert.Equal(t, "nonce-1", u.Query().Get("nonce"))
+
+		code, state := idp.authorize(t, authURL)
+		assert.Equal(t, "state-0123456789", state)
+		claims, err := p.Exchange(ctx, code, verifier, "nonce-1")
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", claims.Subject)
+		assert.Equal(t, "user@example.com", claims.Email)
+		assert.True(t, bool(claims.EmailVerified))
+		assert.Equal(t, "Test User", claims.Name)
+	})
+
+	t.Run("異常系: PKCE の code_verifier が一致しない", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state-abcdefghij", "nonce-2", oauth2.GenerateVerifier())
+		require.NoError(t, err)
+		code, _ := idp.authorize(t, authURL)
+		_, err = p.Exchange(ctx, code, oauth2.GenerateVerifier(), "nonce-2")
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: nonce が一致しない", func(t *testing.T) {
+		verifier := oauth2.GenerateVerifier()
+		authURL, err := p.AuthCodeURL(ctx, "state-klmnopqrst", "nonce-3", verifier)
+		require.NoError(t, err)
+		code, _ := idp.authorize(t, authURL)
+		_, err = p.Exchange(ctx, code, verifier, "other-nonce")
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	idp := newFakeIdP(t)
+	p, err := oidc.NewProvider(oidc.Config{Issuer: idp.URL + "/other", ClientID: testClientID}, idp.Client())
+	require.NoError(t, err)
+
+	_, err = p.AuthCodeURL(context.Background(), "state", "nonce", "")
+	assert.Error(t, err)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	keys := oidc.StaticKeySet{idp.kid: &idp.key.PublicKey}
+	v := oidc.NewVerifier(keys, testClientID, idp.URL)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{name: "正常系: 有効な ID トークン", token: func() string { return idp.sign(t, idp.claims("n")) }},
+		{name: "正常系: email_verified が文字列", token: func() string {
+			c := idp.claims("n")
+			c["email_verified"] = "true"
+			return idp.sign(t, c)
+		}},
+		{name: "異常系: 対象者が違う", wantErr: true, token: func() string {
+			c := idp.claims("n")
+			c["aud"] = "other-client"
+			return idp.sign(t, c)
+		}},
+		{name: "異常系: 複数の対象者で azp が違う", wantErr: true, token: func() string {
+			c := idp.claims("n")
+			c["aud"] = []string{testClientID, "other-client"}
+			c["azp"] = "other-client"
+			return idp.sign(t, c)
+		}},
+		{name: "異常系: 発行者が違う", wantErr: true, token: func() string {
+			c := idp.claims("n")
+			c["iss"] = "https://evil.example.com"
+			return idp.sign(t, c)
+		}},
+		{name: "異常系: 有効期限切れ", wantErr: true, token: func() string {
+			c := idp.claims("n")
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return idp.sign(t, c)
+		}},
+		{name: "異常系: nonce が違う", wantErr: true, token: func() string { return idp.sign(t, idp.claims("other")) }},
+		{name: "異常系: 別の鍵で署名", wantErr: true, token: func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims("n"))
+			token.Header["kid"] = idp.kid
+			signed, err := token.SignedString(otherKey)
+			require.NoError(t, err)
+			return signed
+		}},
+		{name: "異常系: HS256 は受け付けない", wantErr: true, token: func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.claims("n"))
+			token.Header["kid"] = idp.kid
+			signed, err := token.SignedString([]byte("secret"))
+			require.NoError(t, err)
+			return signed
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(ctx, tt.token(), "n")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-123", claims.Subject)
+			assert.True(t, bool(claims.EmailVerified))
+		})
+	}
+}
+
+func TestRemoteKeySet_Key(t *testing.T) {
+	idp := newFakeIdP(t)
+	keys := oidc.NewRemoteKeySet(idp.URL+"/jwks", idp.Client())
+
+	key, err := keys.Key(context.Background(), idp.kid)
+	require.NoError(t, err)
+	assert.True(t, idp.key.PublicKey.Equal(key))
+
+	_, err = keys.Key(context.Background(), "unknown")
+	assert.ErrorIs(t, err, oidc.ErrKeyNotFound)
+}
+
+func TestNewProvidersFromConfig(t *testing.T) {
+	valid := config.OIDCProviderConfig{Issuer: "https://id.example.com", ClientID: "client"}
+
+	providers, err := oidc.NewProvidersFromConfig(&config.OIDCConfig{Providers: map[string]config.OIDCProviderConfig{"example": valid}}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, providers, "example")
+
+	for _, key := range []string{"local", "google", "Upper", "has space"} {
+		_, err := oidc.NewProvidersFromConfig(&config.OIDCConfig{Providers: map[string]config.OIDCProviderConfig{key: valid}}, nil)
+		assert.Error(t, err, key)
+	}
+	_, err = oidc.NewProvidersFromConfig(&config.OIDCConfig{Providers: map[string]config.OIDCProviderConfig{"example": {Issuer: "id.example.com", ClientID: "client"}}}, nil)
+	assert.Error(t, err)
+}
+
+// rewriteTransport は Google のエンドポイントへのリクエストを fakeIdP に送ります
+type rewriteTransport struct {
+	idp   *fakeIdP
+	paths map[string]string // ホスト名 -> fakeIdP のパス
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path, ok := rt.paths[req.URL.Host]
+	if !ok {
+		return nil, fmt.Errorf("unexpected request to %s", req.URL)
+	}
+	target, err := url.Parse(rt.idp.URL + path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL, req.Host = target, target.Host
+	return rt.idp.Client().Transport.RoundTrip(req)
+}
+
+func TestGoogleProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	idp.issuer = oidc.GoogleIssuer
+	client := &http.Client{Transport: rewriteTransport{idp: idp, paths: map[string]string{
+		"oauth2.googleapis.com": "/token",
+		"www.googleapis.com":    "/jwks",
+	}}}
+	cfg := oidc.Config{ClientID: testClientID, ClientSecret: "secret", RedirectURL: "http://localhost/callback"}
+
+	t.Run("正常系: Google の JWKS で ID トークンを検証する", func(t *testing.T) {
+		p, err := oidc.NewGoogleProvider(cfg, client, nil)
+		require.NoError(t, err)
+		idp.issueCode("google-code-1")
+
+		claims, err := p.Exchange(ctx, "google-code-1", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", claims.Subject)
+		assert.Equal(t, "user@example.com", claims.Email)
+		assert.True(t, bool(claims.EmailVerified))
+	})
+
+	t.Run("正常系: スキームのない発行者も受け付ける", func(t *testing.T) {
+		idp.issuer = "accounts.google.com"
+		t.Cleanup(func() { idp.issuer = oidc.GoogleIssuer })
+		p, err := oidc.NewGoogleProvider(cfg, client, oidc.StaticKeySet{idp.kid: &idp.key.PublicKey})
+		require.NoError(t, err)
+		idp.issueCode("google-code-2")
+
+		_, err = p.Exchange(ctx, "google-code-2", "", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 署名の鍵が一致しない", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		p, err := oidc.NewGoogleProvider(cfg, client, oidc.StaticKeySet{idp.kid: &otherKey.PublicKey})
+		require.NoError(t, err)
+		idp.issueCode("google-code-3")
+
+		_, err = p.Exchange(ctx, "google-code-3", "", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: 対象者が別のクライアント", func(t *testing.T) {
+		other := cfg
+		other.ClientID = "other-client"
+		p, err := oidc.NewGoogleProvider(other, client, oidc.StaticKeySet{idp.kid: &idp.key.PublicKey})
+		require.NoError(t, err)
+		idp.issueCode("google-code-4")
+
+		_, err = p.Exchange(ctx, "google-code-4", "", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: Google 以外の発行者", func(t *testing.T) {
+		idp.issuer = "https://evil.example.com"
+		t.Cleanup(func() { idp.issuer = oidc.GoogleIssuer })
+		p, err := oidc.NewGoogleProvider(cfg, client, oidc.StaticKeySet{idp.kid: &idp.key.PublicKey})
+		require.NoError(t, err)
+		idp.issueCode("google-code-5")
+
+		_, err = p.Exchange(ctx, "google-code-5", "", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewGoogleProviderFromConfig(t *testing.T) {
+	p, err := oidc.NewGoogleProviderFromConfig(&config.GoogleOAuthConfig{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = oidc.NewGoogleProviderFromConfig(&config.GoogleOAuthConfig{ClientID: "client"}, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, p)
+}
@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// clockSkew は ID トークンの有効期限・発行日時の検証で許す時計のずれ
+const clockSkew = time.Minute
+
+// signingMethods は ID トークンの署名として受け付けるアルゴリズム (none や HS256 は受け付けない)
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Claims は ID トークンのクレームです
+type Claims struct {
+	jwt.RegisteredClaims
+	Nonce           string       `json:"nonce,omitempty"`
+	AuthorizedParty string       `json:"azp,omitempty"`
+	Email           string       `json:"email,omitempty"`
+	EmailVerified   FlexibleBool `json:"email_verified,omitempty"`
+	Name            string       `json:"name,omitempty"`
+}
+
+// FlexibleBool は true/false のほか、"true"/"false" の文字列も受け付ける bool です
+// (email_verified を文字列で返すプロバイダがあるため)
+type FlexibleBool bool
+
+func (b *FlexibleBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = FlexibleBool(v)
+	case string:
+		*b = v == "true"
+	case nil:
+		*b = false
+	default:
+		return fmt.Errorf("oidc: invalid boolean %s", data)
+	}
+	return nil
+}
+
+// Verifier は ID トークンの署名・発行者・対象者・有効期限・nonce を検証します
+type Verifier struct {
+	keys     KeySource
+	clientID string
+	issuers  []string
+	now      func() time.Time
+}
+
+// NewVerifier は Verifier を返します。issuers には iss クレームとして受け付ける値をすべて指定します。
+func NewVerifier(keys KeySource, clientID string, issuers ...string) *Verifier {
+	return &Verifier{keys: keys, clientID: clientID, issuers: issuers, now: time.Now}
+}
+
+// WithClock は現在時刻の取得方法を差し替えた Verifier を返します (テスト用)
+func (v *Verifier) WithClock(now func() time.Time) *Verifier {
+	c := *v
+	c.now = now
+	return &c
+}
+
+// Verify は ID トークンを検証し、クレームを返します。nonce が空でなければ nonce クレームと一致することを確認します。
+func (v *Verifier) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithAudience(v.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(v.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid id token: %w", err)
+	}
+
+	if !slices.Contains(v.issuers, claims.Issuer) {
+		return nil, fmt.Errorf("oidc: unexpected issuer %q", claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("oidc: id token has no subject")
+	}
+	// 複数の対象者に発行されたトークンは、このクライアントに渡されたものであることを azp で確認する
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != v.clientID {
+		return nil, fmt.Errorf("oidc: unexpected authorized party %q", claims.AuthorizedParty)
+	}
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("oidc: nonce mismatch")
+	}
+	return &claims, nil
+}
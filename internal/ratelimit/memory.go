@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval は満杯に戻ったバケットを捨てる間隔
+const memorySweepInterval = time.Minute
+
+// MemoryStore はプロセス内のメモリにバケットを保存する Store です。
+// サーバーが複数ある場合は制限がサーバーごとになるため、共有したい場合は PostgresStore を使ってください。
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	bucket
+	fullAt time.Time // この時刻以降は満杯に戻っているので捨ててよい
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*memoryBucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, p Policy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.sweepLocked(now)
+	}
+
+	var current *bucket
+	if mb, ok := s.buckets[key]; ok {
+		current = &mb.bucket
+	}
+	next, res := take(current, p, now)
+	s.buckets[key] = &memoryBucket{bucket: next, fullAt: now.Add(res.ResetAfter)}
+	return res, nil
+}
+
+// Len は保持しているバケットの数を返します
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
+
+func (s *MemoryStore) sweepLocked(now time.Time) {
+	for key, mb := range s.buckets {
+		if !now.Before(mb.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresStore はバケットを rate_limit_buckets テーブルに保存する Store です。複数のサーバーで制限を共有できます。
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+// bucketRow は rate_limit_buckets テーブルの行です
+type bucketRow struct {
+	Key       string    `gorm:"primaryKey"`
+	Tokens    float64   `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime:false"`
+}
+
+func (bucketRow) TableName() string {
+	return "rate_limit_buckets"
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take はバケットの行をロックしてトークンを取り出します。同じキーへの同時のリクエストは直列化されます。
+func (s *PostgresStore) Take(ctx context.Context, key string, p Policy, now time.Time) (Result, error) {
+	var res Result
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 行がなければ満杯のバケットとして作る
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&bucketRow{Key: key, Tokens: float64(p.Capacity()), UpdatedAt: now}).Error
+		if err != nil {
+			return err
+		}
+		var row bucketRow
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&row).Error; err != nil {
+			return err
+		}
+
+		var next bucket
+		next, res = take(&bucket{tokens: row.Tokens, updatedAt: row.UpdatedAt}, p, now)
+		return tx.Model(&bucketRow{}).Where("key = ?", key).
+			Updates(map[string]interface{}{"tokens": next.tokens, "updated_at": next.updatedAt}).Error
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit.PostgresStore.Take: %w", err)
+	}
+	return res, nil
+}
+
+// DeleteIdle は before 以降に使われていないバケットを削除し、削除した件数を返します。
+// 削除したバケットは次に使われたときに満杯の状態で作り直されるため、before は最も長い Period より前にしてください。
+func (s *PostgresStore) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("updated_at < ?", before).Delete(&bucketRow{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("ratelimit.PostgresStore.DeleteIdle: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
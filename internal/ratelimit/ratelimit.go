@@ -0,0 +1,103 @@
+// Package ratelimit はトークンバケット方式のリクエスト数の制限と、バケットの状態を保存するバックエンドを提供します。
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+
+	"gorm.io/gorm"
+)
+
+// バックエンドの種類 (rate_limit.backend)
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// Policy はトークンバケットの設定です。Period ごとに Limit 個のトークンが補充され、最大で Burst 個まで貯まります。
+type Policy struct {
+	Limit  int
+	Period time.Duration
+	Burst  int // 0 なら Limit
+}
+
+// Capacity はバケットに貯まるトークンの最大数です
+func (p Policy) Capacity() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return p.Limit
+}
+
+// rate は1秒あたりに補充されるトークンの数です
+func (p Policy) rate() float64 {
+	return float64(p.Limit) / p.Period.Seconds()
+}
+
+// Valid は Limit と Period が正の値かどうかを返します
+func (p Policy) Valid() bool {
+	return p.Limit > 0 && p.Period > 0
+}
+
+// Result はトークンを1つ取り出した結果です
+type Result struct {
+	Allowed    bool
+	Limit      int           // バケットの容量
+	Remaining  int           // 残りのトークン数
+	RetryAfter time.Duration // 拒否した場合、次のトークンが補充されるまでの時間
+	ResetAfter time.Duration // バケットが満杯に戻るまでの時間
+}
+
+// Store はキーごとのバケットの状態を保存し、トークンを取り出します
+type Store interface {
+	Take(ctx context.Context, key string, p Policy, now time.Time) (Result, error)
+}
+
+// NewFromConfig は設定に応じた Store を返します。postgres の場合は db にバケットの状態を保存します。
+func NewFromConfig(cfg *config.RateLimitConfig, db *gorm.DB) (Store, error) {
+	switch cfg.Backend {
+	case BackendMemory, "":
+		return NewMemoryStore(), nil
+	case BackendPostgres:
+		return NewPostgresStore(db), nil
+	default:
+		return nil, fmt.Errorf("ratelimit: unsupported backend %q", cfg.Backend)
+	}
+}
+
+// bucket はバケットの状態です
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// take は b の状態で now にトークンを1つ取り出し、新しい状態と結果を返します。b が nil なら満杯のバケットとして扱います。
+func take(b *bucket, p Policy, now time.Time) (bucket, Result) {
+	capacity := float64(p.Capacity())
+	rate := p.rate()
+
+	tokens := capacity
+	if b != nil {
+		elapsed := now.Sub(b.updatedAt).Seconds()
+		tokens = math.Min(capacity, b.tokens+math.Max(0, elapsed)*rate)
+	}
+
+	res := Result{Limit: p.Capacity()}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	res.Remaining = int(math.Floor(tokens))
+	res.ResetAfter = seconds((capacity - tokens) / rate)
+	return bucket{tokens: tokens, updatedAt: now}, res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,63 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 1分に6回 (10秒に1つ補充)、最大3回まで連続して受け付ける
+	policy := ratelimit.Policy{Limit: 6, Period: time.Minute, Burst: 3}
+
+	s := ratelimit.NewMemoryStore()
+	for i := range 3 {
+		res, err := s.Take(ctx, "k", policy, now)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed, i)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	t.Run("異常系: バケットが空なら拒否して補充までの時間を返す", func(t *testing.T) {
+		res, err := s.Take(ctx, "k", policy, now.Add(4*time.Second))
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+		assert.Equal(t, 6*time.Second, res.RetryAfter)
+		assert.Equal(t, 26*time.Second, res.ResetAfter)
+	})
+
+	t.Run("正常系: 補充されたトークンで受け付ける", func(t *testing.T) {
+		res, err := s.Take(ctx, "k", policy, now.Add(10*time.Second))
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+	})
+
+	t.Run("正常系: キーごとに別のバケットを使う", func(t *testing.T) {
+		res, err := s.Take(ctx, "other", policy, now.Add(10*time.Second))
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2, res.Remaining)
+	})
+
+	t.Run("正常系: 満杯に戻ったバケットは捨てる", func(t *testing.T) {
+		_, err := s.Take(ctx, "new", policy, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, s.Len())
+	})
+}
+
+func TestPolicy_Capacity(t *testing.T) {
+	assert.Equal(t, 10, ratelimit.Policy{Limit: 10, Period: time.Minute}.Capacity())
+	assert.Equal(t, 3, ratelimit.Policy{Limit: 10, Period: time.Minute, Burst: 3}.Capacity())
+	assert.False(t, ratelimit.Policy{Limit: 10}.Valid())
+}
@@ -0,0 +1,119 @@
+//go:generate mockery --name AttachmentRepository --output ./mocks --outpkg mocks --case=underscore
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository は単語の添付ファイルのメタデータを扱います。
+// ファイル本体は blobstore.BlobStore が扱うため、ここではDBの行だけを操作します。
+type AttachmentRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, attachment *model.WordAttachment) error
+	FindByID(ctx context.Context, db *gorm.DB, tenantID, wordID, attachmentID uuid.UUID) (*model.WordAttachment, error)
+	FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) ([]model.WordAttachment, error)
+	CountByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) (int64, error)
+	Delete(ctx context.Context, tx *gorm.DB, tenantID, wordID, attachmentID uuid.UUID) error
+}
+
+type gormAttachmentRepository struct{}
+
+func NewGormAttachmentRepository() AttachmentRepository {
+	return &gormAttachmentRepository{}
+}
+
+func (r *gormAttachmentRepository) Create(ctx context.Context, tx *gorm.DB, attachment *model.WordAttachment) error {
+	logger := middleware.GetLogger(ctx)
+	if err := tx.WithContext(ctx).Create(attachment).Error; err != nil {
+		logger.Error("Error creating word attachment in DB",
+			"error", err,
+			"tenant_id", attachment.TenantID.String(),
+			"word_id", attachment.WordID.String(),
+		)
+		return fmt.Errorf("gormAttachmentRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *gormAttachmentRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID, wordID, attachmentID uuid.UUID) (*model.WordAttachment, error) {
+	logger := middleware.GetLogger(ctx)
+	var attachment model.WordAttachment
+	result := db.WithContext(ctx).
+		Where("tenant_id = ? AND word_id = ? AND attachment_id = ?", tenantID, wordID, attachmentID).
+		First(&attachment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Error finding word attachment in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"attachment_id", attachmentID.String(),
+		)
+		return nil, fmt.Errorf("gormAttachmentRepository.FindByID: %w", result.Error)
+	}
+	return &attachment, nil
+}
+
+// FindByWordID は単語の添付ファイルを作成順に返します
+func (r *gormAttachmentRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) ([]model.WordAttachment, error) {
+	logger := middleware.GetLogger(ctx)
+	attachments := []model.WordAttachment{}
+	result := db.WithContext(ctx).
+		Where("tenant_id = ? AND word_id = ?", tenantID, wordID).
+		Order("created_at ASC").
+		Find(&attachments)
+	if result.Error != nil {
+		logger.Error("Error finding word attachments in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return nil, fmt.Errorf("gormAttachmentRepository.FindByWordID: %w", result.Error)
+	}
+	return attachments, nil
+}
+
+// CountByWordID は単語の添付ファイルの件数を返します
+func (r *gormAttachmentRepository) CountByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) (int64, error) {
+	logger := middleware.GetLogger(ctx)
+	var count int64
+	result := db.WithContext(ctx).Model(&model.WordAttachment{}).
+		Where("tenant_id = ? AND word_id = ?", tenantID, wordID).
+		Count(&count)
+	if result.Error != nil {
+		logger.Error("Error counting word attachments in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return 0, fmt.Errorf("gormAttachmentRepository.CountByWordID: %w", result.Error)
+	}
+	return count, nil
+}
+
+func (r *gormAttachmentRepository) Delete(ctx context.Context, tx *gorm.DB, tenantID, wordID, attachmentID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).
+		Where("tenant_id = ? AND word_id = ? AND attachment_id = ?", tenantID, wordID, attachmentID).
+		Delete(&model.WordAttachment{})
+	if result.Error != nil {
+		logger.Error("Error deleting word attachment in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"attachment_id", attachmentID.String(),
+		)
+		return fmt.Errorf("gormAttachmentRepository.Delete: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"errors"
 	"log/slog"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	slogGorm "github.com/orandin/slog-gorm" // slogGormはエイリアス
 	"gorm.io/driver/postgres"               // postgresドライバ
 	"gorm.io/gorm"
@@ -75,3 +77,12 @@ func NewDB(databaseURL string, appLogger *slog.Logger) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// pgUniqueViolation は PostgreSQL の一意制約違反のエラーコード
+const pgUniqueViolation = "23505"
+
+// isUniqueViolation はエラーが一意制約違反かどうかを返します
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
@@ -8,12 +8,20 @@ import (
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IdentityRepository interface {
 	Create(ctx context.Context, db *gorm.DB, identity *model.Identity) error
 	FindByProvider(ctx context.Context, db *gorm.DB, authProvider string, providerID string) (*model.Identity, error)
+	FindByTenantIDAndProvider(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, authProvider string) (*model.Identity, error)
+	UpdateProviderID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, authProvider string, providerID string) error
+	UpdatePasswordHash(ctx context.Context, db *gorm.DB, identityID uint, passwordHash string) error
+	FindByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Identity, error)
+	FindByTenantIDForUpdate(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) ([]*model.Identity, error)
+	Delete(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, identityID uint) error
 }
 
 type gormIdentityRepository struct{}
@@ -59,3 +67,113 @@ func (r *gormIdentityRepository) FindByProvider(ctx context.Context, db *gorm.DB
 	}
 	return &identity, nil
 }
+
+// FindByTenantIDAndProvider はテナントの指定したプロバイダの認証情報を取得します
+func (r *gormIdentityRepository) FindByTenantIDAndProvider(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, authProvider string) (*model.Identity, error) {
+	logger := middleware.GetLogger(ctx)
+	var identity model.Identity
+
+	result := db.WithContext(ctx).
+		Where("tenant_id = ? AND auth_provider = ?", tenantID, authProvider).
+		First(&identity)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error(
+			"Error finding identity by tenant in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"auth_provider", authProvider,
+		)
+		return nil, fmt.Errorf("gormIdentityRepository.FindByTenantIDAndProvider: %w", result.Error)
+	}
+	return &identity, nil
+}
+
+// UpdateProviderID はテナントの指定したプロバイダの認証情報の ProviderID を変更します。
+// 他の認証情報が使っている場合は model.ErrConflict を、認証情報が見つからない場合は model.ErrNotFound を返します。
+func (r *gormIdentityRepository) UpdateProviderID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, authProvider string, providerID string) error {
+	logger := middleware.GetLogger(ctx)
+
+	result := db.WithContext(ctx).Model(&model.Identity{}).
+		Where("tenant_id = ? AND auth_provider = ?", tenantID, authProvider).
+		Update("provider_id", providerID)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			logger.Warn("Duplicate provider id on update identity", "auth_provider", authProvider, "provider_id", providerID)
+			return model.ErrConflict
+		}
+		logger.Error(
+			"Error updating identity provider id in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"auth_provider", authProvider,
+		)
+		return fmt.Errorf("gormIdentityRepository.UpdateProviderID: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// UpdatePasswordHash は認証情報のパスワードのハッシュを変更します
+func (r *gormIdentityRepository) UpdatePasswordHash(ctx context.Context, db *gorm.DB, identityID uint, passwordHash string) error {
+	logger := middleware.GetLogger(ctx)
+
+	result := db.WithContext(ctx).Model(&model.Identity{}).Where("id = ?", identityID).Update("password_hash", passwordHash)
+	if result.Error != nil {
+		logger.Error("Error updating identity password in DB", "error", result.Error, "identity_id", identityID)
+		return fmt.Errorf("gormIdentityRepository.UpdatePasswordHash: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// FindByTenantID はテナントの認証情報を登録した順に取得します
+func (r *gormIdentityRepository) FindByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Identity, error) {
+	logger := middleware.GetLogger(ctx)
+	var identities []*model.Identity
+
+	if err := db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("id").Find(&identities).Error; err != nil {
+		logger.Error("Error finding identities by tenant in DB", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormIdentityRepository.FindByTenantID: %w", err)
+	}
+	return identities, nil
+}
+
+// FindByTenantIDForUpdate はテナントの認証情報を行ロックを取って取得します。
+// 最後のログイン方法を同時に削除しないよう、トランザクション内で呼び出してください。
+func (r *gormIdentityRepository) FindByTenantIDForUpdate(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) ([]*model.Identity, error) {
+	logger := middleware.GetLogger(ctx)
+	var identities []*model.Identity
+
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ?", tenantID).
+		Order("id").
+		Find(&identities).Error
+	if err != nil {
+		logger.Error("Error finding identities by tenant for update in DB", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormIdentityRepository.FindByTenantIDForUpdate: %w", err)
+	}
+	return identities, nil
+}
+
+// Delete はテナントの認証情報を削除します。見つからない場合は model.ErrNotFound を返します。
+func (r *gormIdentityRepository) Delete(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, identityID uint) error {
+	logger := middleware.GetLogger(ctx)
+
+	result := db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, identityID).Delete(&model.Identity{})
+	if result.Error != nil {
+		logger.Error("Error deleting identity in DB", "error", result.Error, "tenant_id", tenantID.String(), "identity_id", identityID)
+		return fmt.Errorf("gormIdentityRepository.Delete: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:generate mockery --name LoginAttemptRepository --output ./mocks --outpkg mocks --case=underscore
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptRepository はログインに失敗した回数とロックの状態を扱います
+type LoginAttemptRepository interface {
+	FindByKeys(ctx context.Context, db *gorm.DB, keys []string) ([]*model.LoginAttempt, error)
+	FindOrCreateForUpdate(ctx context.Context, tx *gorm.DB, key string) (*model.LoginAttempt, error)
+	Save(ctx context.Context, tx *gorm.DB, attempt *model.LoginAttempt) error
+	Delete(ctx context.Context, db *gorm.DB, key string) error
+	DeleteStale(ctx context.Context, db *gorm.DB, before time.Time) (int64, error)
+}
+
+type gormLoginAttemptRepository struct{}
+
+func NewGormLoginAttemptRepository() LoginAttemptRepository {
+	return &gormLoginAttemptRepository{}
+}
+
+func (r *gormLoginAttemptRepository) FindByKeys(ctx context.Context, db *gorm.DB, keys []string) ([]*model.LoginAttempt, error) {
+	logger := middleware.GetLogger(ctx)
+	var attempts []*model.LoginAttempt
+	if err := db.WithContext(ctx).Where("key IN ?", keys).Find(&attempts).Error; err != nil {
+		logger.Error("Error finding login attempts in DB", "error", err)
+		return nil, fmt.Errorf("gormLoginAttemptRepository.FindByKeys: %w", err)
+	}
+	return attempts, nil
+}
+
+// FindOrCreateForUpdate は key の行を (なければ作成して) 行ロックを取って取得します。トランザクション内で呼び出してください。
+func (r *gormLoginAttemptRepository) FindOrCreateForUpdate(ctx context.Context, tx *gorm.DB, key string) (*model.LoginAttempt, error) {
+	logger := middleware.GetLogger(ctx)
+	err := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.LoginAttempt{Key: key, LastFailedAt: time.Now()}).Error
+	if err != nil {
+		logger.Error("Error creating login attempt in DB", "error", err)
+		return nil, fmt.Errorf("gormLoginAttemptRepository.FindOrCreateForUpdate: %w", err)
+	}
+	var attempt model.LoginAttempt
+	err = tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("key = ?", key).
+		First(&attempt).Error
+	if err != nil {
+		logger.Error("Error finding login attempt in DB", "error", err)
+		return nil, fmt.Errorf("gormLoginAttemptRepository.FindOrCreateForUpdate: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (r *gormLoginAttemptRepository) Save(ctx context.Context, tx *gorm.DB, attempt *model.LoginAttempt) error {
+	logger := middleware.GetLogger(ctx)
+	if err := tx.WithContext(ctx).Save(attempt).Error; err != nil {
+		logger.Error("Error saving login attempt in DB", "error", err)
+		return fmt.Errorf("gormLoginAttemptRepository.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *gormLoginAttemptRepository) Delete(ctx context.Context, db *gorm.DB, key string) error {
+	logger := middleware.GetLogger(ctx)
+	if err := db.WithContext(ctx).Where("key = ?", key).Delete(&model.LoginAttempt{}).Error; err != nil {
+		logger.Error("Error deleting login attempt in DB", "error", err)
+		return fmt.Errorf("gormLoginAttemptRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+// DeleteStale は before より前に最後に失敗し、ロックも解除されている行を削除し、削除した件数を返します
+func (r *gormLoginAttemptRepository) DeleteStale(ctx context.Context, db *gorm.DB, before time.Time) (int64, error) {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).
+		Where("last_failed_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).
+		Delete(&model.LoginAttempt{})
+	if result.Error != nil {
+		logger.Error("Error deleting stale login attempts in DB", "error", result.Error)
+		return 0, fmt.Errorf("gormLoginAttemptRepository.DeleteStale: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
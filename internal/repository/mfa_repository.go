@@ -0,0 +1,140 @@
+//go:generate mockery --name MFARepository --output ./mocks --outpkg mocks --case=underscore
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MFARepository は2段階認証 (TOTP の設定とリカバリーコード) を扱います
+type MFARepository interface {
+	FindTOTP(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*model.TenantTOTP, error)
+	SaveTOTP(ctx context.Context, db *gorm.DB, t *model.TenantTOTP) error
+	EnableTOTP(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, step int64) error
+	UseTOTPStep(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, step int64) error
+	DeleteTOTP(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, codeHash string) error
+}
+
+type gormMFARepository struct{}
+
+func NewGormMFARepository() MFARepository {
+	return &gormMFARepository{}
+}
+
+func (r *gormMFARepository) FindTOTP(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*model.TenantTOTP, error) {
+	logger := middleware.GetLogger(ctx)
+	var t model.TenantTOTP
+	if err := db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Error finding TOTP settings in DB", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormMFARepository.FindTOTP: %w", err)
+	}
+	return &t, nil
+}
+
+// SaveTOTP は TOTP の設定を保存します。登録手続き中の設定が既にある場合は置き換えます。
+func (r *gormMFARepository) SaveTOTP(ctx context.Context, db *gorm.DB, t *model.TenantTOTP) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "created_at"}),
+	}).Create(t)
+	if result.Error != nil {
+		logger.Error("Error saving TOTP settings in DB", "error", result.Error, "tenant_id", t.TenantID.String())
+		return fmt.Errorf("gormMFARepository.SaveTOTP: %w", result.Error)
+	}
+	return nil
+}
+
+// EnableTOTP は登録手続き中の TOTP を有効にし、確認に使ったコードの時間ステップを記録します。
+// 登録手続き中の設定がない場合は model.ErrNotFound を返します。
+func (r *gormMFARepository) EnableTOTP(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, step int64) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Model(&model.TenantTOTP{}).
+		Where("tenant_id = ? AND enabled_at IS NULL", tenantID).
+		Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step})
+	if result.Error != nil {
+		logger.Error("Error enabling TOTP in DB", "error", result.Error, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormMFARepository.EnableTOTP: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// UseTOTPStep はコードの時間ステップを使用済みとして記録します。
+// 同じかそれより前のステップのコードが既に使われている場合は model.ErrNotFound を返します。
+func (r *gormMFARepository) UseTOTPStep(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, step int64) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Model(&model.TenantTOTP{}).
+		Where("tenant_id = ? AND enabled_at IS NOT NULL AND last_used_step < ?", tenantID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		logger.Error("Error updating TOTP step in DB", "error", result.Error, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormMFARepository.UseTOTPStep: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormMFARepository) DeleteTOTP(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	if err := tx.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&model.TenantTOTP{}).Error; err != nil {
+		logger.Error("Error deleting TOTP settings in DB", "error", err, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormMFARepository.DeleteTOTP: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes はリカバリーコードをすべて削除し、codeHashes で置き換えます。codeHashes が空なら削除だけを行います。
+func (r *gormMFARepository) ReplaceRecoveryCodes(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, codeHashes []string) error {
+	logger := middleware.GetLogger(ctx)
+	if err := tx.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&model.MFARecoveryCode{}).Error; err != nil {
+		logger.Error("Error deleting recovery codes in DB", "error", err, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormMFARepository.ReplaceRecoveryCodes: %w", err)
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	codes := make([]model.MFARecoveryCode, 0, len(codeHashes))
+	for _, h := range codeHashes {
+		codes = append(codes, model.MFARecoveryCode{ID: uuid.New(), TenantID: tenantID, CodeHash: h})
+	}
+	if err := tx.WithContext(ctx).Create(&codes).Error; err != nil {
+		logger.Error("Error creating recovery codes in DB", "error", err, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormMFARepository.ReplaceRecoveryCodes: %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode は未使用のリカバリーコードを使用済みにします。該当するコードがない場合は model.ErrNotFound を返します。
+func (r *gormMFARepository) UseRecoveryCode(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, codeHash string) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Model(&model.MFARecoveryCode{}).
+		Where("tenant_id = ? AND code_hash = ? AND used_at IS NULL", tenantID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logger.Error("Error using recovery code in DB", "error", result.Error, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormMFARepository.UseRecoveryCode: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
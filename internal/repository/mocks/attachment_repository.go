@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// AttachmentRepository is an autogenerated mock type for the AttachmentRepository type
+type AttachmentRepository struct {
+	mock.Mock
+}
+
+// CountByWordID provides a mock function with given fields: ctx, db, tenantID, wordID
+func (_m *AttachmentRepository) CountByWordID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByWordID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, db, tenantID, wordID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) int64); ok {
+		r0 = rf(ctx, db, tenantID, wordID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, tx, attachment
+func (_m *AttachmentRepository) Create(ctx context.Context, tx *gorm.DB, attachment *model.WordAttachment) error {
+	ret := _m.Called(ctx, tx, attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.WordAttachment) error); ok {
+		r0 = rf(ctx, tx, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, tx, tenantID, wordID, attachmentID
+func (_m *AttachmentRepository) Delete(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, attachmentID uuid.UUID) error {
+	ret := _m.Called(ctx, tx, tenantID, wordID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, tx, tenantID, wordID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, db, tenantID, wordID, attachmentID
+func (_m *AttachmentRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, attachmentID uuid.UUID) (*model.WordAttachment, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *model.WordAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, uuid.UUID) (*model.WordAttachment, error)); ok {
+		return rf(ctx, db, tenantID, wordID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, uuid.UUID) *model.WordAttachment); ok {
+		r0 = rf(ctx, db, tenantID, wordID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WordAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByWordID provides a mock function with given fields: ctx, db, tenantID, wordID
+func (_m *AttachmentRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) ([]model.WordAttachment, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByWordID")
+	}
+
+	var r0 []model.WordAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) ([]model.WordAttachment, error)); ok {
+		return rf(ctx, db, tenantID, wordID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) []model.WordAttachment); ok {
+		r0 = rf(ctx, db, tenantID, wordID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WordAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentRepository creates a new instance of AttachmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentRepository {
+	mock := &AttachmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,226 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// IdentityRepository is an autogenerated mock type for the IdentityRepository type
+type IdentityRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, db, identity
+func (_m *IdentityRepository) Create(ctx context.Context, db *gorm.DB, identity *model.Identity) error {
+	ret := _m.Called(ctx, db, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.Identity) error); ok {
+		r0 = rf(ctx, db, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, db, tenantID, identityID
+func (_m *IdentityRepository) Delete(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, identityID uint) error {
+	ret := _m.Called(ctx, db, tenantID, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uint) error); ok {
+		r0 = rf(ctx, db, tenantID, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByProvider provides a mock function with given fields: ctx, db, authProvider, providerID
+func (_m *IdentityRepository) FindByProvider(ctx context.Context, db *gorm.DB, authProvider string, providerID string) (*model.Identity, error) {
+	ret := _m.Called(ctx, db, authProvider, providerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByProvider")
+	}
+
+	var r0 *model.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, string) (*model.Identity, error)); ok {
+		return rf(ctx, db, authProvider, providerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, string) *model.Identity); ok {
+		r0 = rf(ctx, db, authProvider, providerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string, string) error); ok {
+		r1 = rf(ctx, db, authProvider, providerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByTenantID provides a mock function with given fields: ctx, db, tenantID
+func (_m *IdentityRepository) FindByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Identity, error) {
+	ret := _m.Called(ctx, db, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTenantID")
+	}
+
+	var r0 []*model.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) ([]*model.Identity, error)); ok {
+		return rf(ctx, db, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) []*model.Identity); ok {
+		r0 = rf(ctx, db, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByTenantIDAndProvider provides a mock function with given fields: ctx, db, tenantID, authProvider
+func (_m *IdentityRepository) FindByTenantIDAndProvider(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, authProvider string) (*model.Identity, error) {
+	ret := _m.Called(ctx, db, tenantID, authProvider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTenantIDAndProvider")
+	}
+
+	var r0 *model.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) (*model.Identity, error)); ok {
+		return rf(ctx, db, tenantID, authProvider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) *model.Identity); ok {
+		r0 = rf(ctx, db, tenantID, authProvider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, db, tenantID, authProvider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByTenantIDForUpdate provides a mock function with given fields: ctx, tx, tenantID
+func (_m *IdentityRepository) FindByTenantIDForUpdate(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) ([]*model.Identity, error) {
+	ret := _m.Called(ctx, tx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTenantIDForUpdate")
+	}
+
+	var r0 []*model.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) ([]*model.Identity, error)); ok {
+		return rf(ctx, tx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) []*model.Identity); ok {
+		r0 = rf(ctx, tx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r1 = rf(ctx, tx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePasswordHash provides a mock function with given fields: ctx, db, identityID, passwordHash
+func (_m *IdentityRepository) UpdatePasswordHash(ctx context.Context, db *gorm.DB, identityID uint, passwordHash string) error {
+	ret := _m.Called(ctx, db, identityID, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uint, string) error); ok {
+		r0 = rf(ctx, db, identityID, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProviderID provides a mock function with given fields: ctx, db, tenantID, authProvider, providerID
+func (_m *IdentityRepository) UpdateProviderID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, authProvider string, providerID string) error {
+	ret := _m.Called(ctx, db, tenantID, authProvider, providerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProviderID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, db, tenantID, authProvider, providerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityRepository {
+	mock := &IdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
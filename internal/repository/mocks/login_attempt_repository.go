@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	time "time"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, db, key
+func (_m *LoginAttemptRepository) Delete(ctx context.Context, db *gorm.DB, key string) error {
+	ret := _m.Called(ctx, db, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) error); ok {
+		r0 = rf(ctx, db, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteStale provides a mock function with given fields: ctx, db, before
+func (_m *LoginAttemptRepository) DeleteStale(ctx context.Context, db *gorm.DB, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, db, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStale")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, time.Time) (int64, error)); ok {
+		return rf(ctx, db, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, time.Time) int64); ok {
+		r0 = rf(ctx, db, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, time.Time) error); ok {
+		r1 = rf(ctx, db, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByKeys provides a mock function with given fields: ctx, db, keys
+func (_m *LoginAttemptRepository) FindByKeys(ctx context.Context, db *gorm.DB, keys []string) ([]*model.LoginAttempt, error) {
+	ret := _m.Called(ctx, db, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByKeys")
+	}
+
+	var r0 []*model.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, []string) ([]*model.LoginAttempt, error)); ok {
+		return rf(ctx, db, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, []string) []*model.LoginAttempt); ok {
+		r0 = rf(ctx, db, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, []string) error); ok {
+		r1 = rf(ctx, db, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOrCreateForUpdate provides a mock function with given fields: ctx, tx, key
+func (_m *LoginAttemptRepository) FindOrCreateForUpdate(ctx context.Context, tx *gorm.DB, key string) (*model.LoginAttempt, error) {
+	ret := _m.Called(ctx, tx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrCreateForUpdate")
+	}
+
+	var r0 *model.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) (*model.LoginAttempt, error)); ok {
+		return rf(ctx, tx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) *model.LoginAttempt); ok {
+		r0 = rf(ctx, tx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string) error); ok {
+		r1 = rf(ctx, tx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, tx, attempt
+func (_m *LoginAttemptRepository) Save(ctx context.Context, tx *gorm.DB, attempt *model.LoginAttempt) error {
+	ret := _m.Called(ctx, tx, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.LoginAttempt) error); ok {
+		r0 = rf(ctx, tx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttemptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
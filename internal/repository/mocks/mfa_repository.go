@@ -0,0 +1,172 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// MFARepository is an autogenerated mock type for the MFARepository type
+type MFARepository struct {
+	mock.Mock
+}
+
+// DeleteTOTP provides a mock function with given fields: ctx, tx, tenantID
+func (_m *MFARepository) DeleteTOTP(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) error {
+	ret := _m.Called(ctx, tx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r0 = rf(ctx, tx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTOTP provides a mock function with given fields: ctx, tx, tenantID, step
+func (_m *MFARepository) EnableTOTP(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, step int64) error {
+	ret := _m.Called(ctx, tx, tenantID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, tx, tenantID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindTOTP provides a mock function with given fields: ctx, db, tenantID
+func (_m *MFARepository) FindTOTP(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*model.TenantTOTP, error) {
+	ret := _m.Called(ctx, db, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTOTP")
+	}
+
+	var r0 *model.TenantTOTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) (*model.TenantTOTP, error)); ok {
+		return rf(ctx, db, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) *model.TenantTOTP); ok {
+		r0 = rf(ctx, db, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TenantTOTP)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, tx, tenantID, codeHashes
+func (_m *MFARepository) ReplaceRecoveryCodes(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, codeHashes []string) error {
+	ret := _m.Called(ctx, tx, tenantID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceRecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []string) error); ok {
+		r0 = rf(ctx, tx, tenantID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveTOTP provides a mock function with given fields: ctx, db, t
+func (_m *MFARepository) SaveTOTP(ctx context.Context, db *gorm.DB, t *model.TenantTOTP) error {
+	ret := _m.Called(ctx, db, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.TenantTOTP) error); ok {
+		r0 = rf(ctx, db, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, db, tenantID, codeHash
+func (_m *MFARepository) UseRecoveryCode(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, codeHash string) error {
+	ret := _m.Called(ctx, db, tenantID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, db, tenantID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseTOTPStep provides a mock function with given fields: ctx, db, tenantID, step
+func (_m *MFARepository) UseTOTPStep(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, step int64) error {
+	ret := _m.Called(ctx, db, tenantID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, db, tenantID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMFARepository creates a new instance of MFARepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMFARepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MFARepository {
+	mock := &MFARepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -39,6 +39,34 @@ func (_m *ProgressRepository) Create(ctx context.Context, tx *gorm.DB, progress
 	return r0
 }
 
+// CreateMissingCloze provides a mock function with given fields: ctx, tx, tenantID, nextReviewDate
+func (_m *ProgressRepository) CreateMissingCloze(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, nextReviewDate time.Time) (int64, error) {
+	ret := _m.Called(ctx, tx, tenantID, nextReviewDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMissingCloze")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, time.Time) (int64, error)); ok {
+		return rf(ctx, tx, tenantID, nextReviewDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, time.Time) int64); ok {
+		r0 = rf(ctx, tx, tenantID, nextReviewDate)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, tx, tenantID, nextReviewDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteByWordID provides a mock function with given fields: ctx, tx, tenantID, wordID
 func (_m *ProgressRepository) DeleteByWordID(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) error {
 	ret := _m.Called(ctx, tx, tenantID, wordID)
@@ -57,9 +85,9 @@ func (_m *ProgressRepository) DeleteByWordID(ctx context.Context, tx *gorm.DB, t
 	return r0
 }
 
-// FindByWordID provides a mock function with given fields: ctx, db, tenantID, wordID
-func (_m *ProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) (*model.LearningProgress, error) {
-	ret := _m.Called(ctx, db, tenantID, wordID)
+// FindByWordID provides a mock function with given fields: ctx, db, tenantID, wordID, direction
+func (_m *ProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, direction string) (*model.LearningProgress, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID, direction)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByWordID")
@@ -67,19 +95,19 @@ func (_m *ProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB, ten
 
 	var r0 *model.LearningProgress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) (*model.LearningProgress, error)); ok {
-		return rf(ctx, db, tenantID, wordID)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, string) (*model.LearningProgress, error)); ok {
+		return rf(ctx, db, tenantID, wordID, direction)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) *model.LearningProgress); ok {
-		r0 = rf(ctx, db, tenantID, wordID)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, string) *model.LearningProgress); ok {
+		r0 = rf(ctx, db, tenantID, wordID, direction)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.LearningProgress)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
-		r1 = rf(ctx, db, tenantID, wordID)
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID, direction)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -87,9 +115,9 @@ func (_m *ProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB, ten
 	return r0, r1
 }
 
-// FindReviewableByTenant provides a mock function with given fields: ctx, db, tenantID, today, limit
-func (_m *ProgressRepository) FindReviewableByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, today time.Time, limit int) ([]*model.LearningProgress, error) {
-	ret := _m.Called(ctx, db, tenantID, today, limit)
+// FindReviewableByTenant provides a mock function with given fields: ctx, db, tenantID, directions, today, limit
+func (_m *ProgressRepository) FindReviewableByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, directions []string, today time.Time, limit int) ([]*model.LearningProgress, error) {
+	ret := _m.Called(ctx, db, tenantID, directions, today, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindReviewableByTenant")
@@ -97,19 +125,19 @@ func (_m *ProgressRepository) FindReviewableByTenant(ctx context.Context, db *go
 
 	var r0 []*model.LearningProgress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, time.Time, int) ([]*model.LearningProgress, error)); ok {
-		return rf(ctx, db, tenantID, today, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []string, time.Time, int) ([]*model.LearningProgress, error)); ok {
+		return rf(ctx, db, tenantID, directions, today, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, time.Time, int) []*model.LearningProgress); ok {
-		r0 = rf(ctx, db, tenantID, today, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []string, time.Time, int) []*model.LearningProgress); ok {
+		r0 = rf(ctx, db, tenantID, directions, today, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.LearningProgress)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, time.Time, int) error); ok {
-		r1 = rf(ctx, db, tenantID, today, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, []string, time.Time, int) error); ok {
+		r1 = rf(ctx, db, tenantID, directions, today, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -117,6 +145,24 @@ func (_m *ProgressRepository) FindReviewableByTenant(ctx context.Context, db *go
 	return r0, r1
 }
 
+// ResetLevel provides a mock function with given fields: ctx, tx, tenantID, wordID, nextReviewDate
+func (_m *ProgressRepository) ResetLevel(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, nextReviewDate time.Time) error {
+	ret := _m.Called(ctx, tx, tenantID, wordID, nextReviewDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, tx, tenantID, wordID, nextReviewDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, tx, progress
 func (_m *ProgressRepository) Update(ctx context.Context, tx *gorm.DB, progress *model.LearningProgress) error {
 	ret := _m.Called(ctx, tx, progress)
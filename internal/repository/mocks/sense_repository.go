@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// SenseRepository is an autogenerated mock type for the SenseRepository type
+type SenseRepository struct {
+	mock.Mock
+}
+
+// FindByWordID provides a mock function with given fields: ctx, db, tenantID, wordID
+func (_m *SenseRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) ([]model.WordSense, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByWordID")
+	}
+
+	var r0 []model.WordSense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) ([]model.WordSense, error)); ok {
+		return rf(ctx, db, tenantID, wordID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) []model.WordSense); ok {
+		r0 = rf(ctx, db, tenantID, wordID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WordSense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceForWord provides a mock function with given fields: ctx, tx, tenantID, wordID, senses
+func (_m *SenseRepository) ReplaceForWord(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, senses []model.WordSense) error {
+	ret := _m.Called(ctx, tx, tenantID, wordID, senses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceForWord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, []model.WordSense) error); ok {
+		r0 = rf(ctx, tx, tenantID, wordID, senses)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSenseRepository creates a new instance of SenseRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSenseRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SenseRepository {
+	mock := &SenseRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// SessionRepository is an autogenerated mock type for the SessionRepository type
+type SessionRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, db, session
+func (_m *SessionRepository) Create(ctx context.Context, db *gorm.DB, session *model.Session) error {
+	ret := _m.Called(ctx, db, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.Session) error); ok {
+		r0 = rf(ctx, db, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindActiveByTenantID provides a mock function with given fields: ctx, db, tenantID, since
+func (_m *SessionRepository) FindActiveByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, since time.Time) ([]*model.Session, error) {
+	ret := _m.Called(ctx, db, tenantID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByTenantID")
+	}
+
+	var r0 []*model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, time.Time) ([]*model.Session, error)); ok {
+		return rf(ctx, db, tenantID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, time.Time) []*model.Session); ok {
+		r0 = rf(ctx, db, tenantID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, db, tenantID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, db, tenantID, sessionID
+func (_m *SessionRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, sessionID uuid.UUID) (*model.Session, error) {
+	ret := _m.Called(ctx, db, tenantID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) (*model.Session, error)); ok {
+		return rf(ctx, db, tenantID, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) *model.Session); ok {
+		r0 = rf(ctx, db, tenantID, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, db, tenantID, sessionID
+func (_m *SessionRepository) Revoke(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, db, tenantID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, db, tenantID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOthers provides a mock function with given fields: ctx, tx, tenantID, keepSessionID
+func (_m *SessionRepository) RevokeOthers(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, keepSessionID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, tx, tenantID, keepSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOthers")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, tx, tenantID, keepSessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, tx, tenantID, keepSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, tx, tenantID, keepSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Touch provides a mock function with given fields: ctx, db, sessionID, client
+func (_m *SessionRepository) Touch(ctx context.Context, db *gorm.DB, sessionID uuid.UUID, client model.ClientInfo) error {
+	ret := _m.Called(ctx, db, sessionID, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, model.ClientInfo) error); ok {
+		r0 = rf(ctx, db, sessionID, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSessionRepository creates a new instance of SessionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRepository {
+	mock := &SessionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
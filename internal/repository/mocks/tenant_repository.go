@@ -145,6 +145,24 @@ func (_m *TenantRepository) FindByName(ctx context.Context, db *gorm.DB, name st
 	return r0, r1
 }
 
+// UpdateEmail provides a mock function with given fields: ctx, db, tenantID, email
+func (_m *TenantRepository) UpdateEmail(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, email string) error {
+	ret := _m.Called(ctx, db, tenantID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, db, tenantID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTenantRepository creates a new instance of TenantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTenantRepository(t interface {
@@ -10,6 +10,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "go_4_vocab_keep/internal/model"
+
+	uuid "github.com/google/uuid"
 )
 
 // TokenRepository is an autogenerated mock type for the TokenRepository type
@@ -17,6 +19,24 @@ type TokenRepository struct {
 	mock.Mock
 }
 
+// CreateEmailChangeToken provides a mock function with given fields: ctx, db, token
+func (_m *TokenRepository) CreateEmailChangeToken(ctx context.Context, db *gorm.DB, token *model.EmailChangeToken) error {
+	ret := _m.Called(ctx, db, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailChangeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.EmailChangeToken) error); ok {
+		r0 = rf(ctx, db, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreatePasswordResetToken provides a mock function with given fields: ctx, db, token
 func (_m *TokenRepository) CreatePasswordResetToken(ctx context.Context, db *gorm.DB, token *model.PasswordResetToken) error {
 	ret := _m.Called(ctx, db, token)
@@ -35,6 +55,24 @@ func (_m *TokenRepository) CreatePasswordResetToken(ctx context.Context, db *gor
 	return r0
 }
 
+// CreateRefreshToken provides a mock function with given fields: ctx, db, token
+func (_m *TokenRepository) CreateRefreshToken(ctx context.Context, db *gorm.DB, token *model.RefreshToken) error {
+	ret := _m.Called(ctx, db, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.RefreshToken) error); ok {
+		r0 = rf(ctx, db, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateVerificationToken provides a mock function with given fields: ctx, db, token
 func (_m *TokenRepository) CreateVerificationToken(ctx context.Context, db *gorm.DB, token *model.UserVerificationToken) error {
 	ret := _m.Called(ctx, db, token)
@@ -53,6 +91,24 @@ func (_m *TokenRepository) CreateVerificationToken(ctx context.Context, db *gorm
 	return r0
 }
 
+// DeleteEmailChangeTokensByTenantID provides a mock function with given fields: ctx, db, tenantID
+func (_m *TokenRepository) DeleteEmailChangeTokensByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error {
+	ret := _m.Called(ctx, db, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailChangeTokensByTenantID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r0 = rf(ctx, db, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePasswordResetToken provides a mock function with given fields: ctx, db, token
 func (_m *TokenRepository) DeletePasswordResetToken(ctx context.Context, db *gorm.DB, token string) error {
 	ret := _m.Called(ctx, db, token)
@@ -89,6 +145,84 @@ func (_m *TokenRepository) DeleteVerificationToken(ctx context.Context, db *gorm
 	return r0
 }
 
+// DeleteVerificationTokensByTenantID provides a mock function with given fields: ctx, db, tenantID
+func (_m *TokenRepository) DeleteVerificationTokensByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error {
+	ret := _m.Called(ctx, db, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVerificationTokensByTenantID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r0 = rf(ctx, db, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindEmailChangeToken provides a mock function with given fields: ctx, db, token
+func (_m *TokenRepository) FindEmailChangeToken(ctx context.Context, db *gorm.DB, token string) (*model.EmailChangeToken, error) {
+	ret := _m.Called(ctx, db, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEmailChangeToken")
+	}
+
+	var r0 *model.EmailChangeToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) (*model.EmailChangeToken, error)); ok {
+		return rf(ctx, db, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) *model.EmailChangeToken); ok {
+		r0 = rf(ctx, db, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailChangeToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string) error); ok {
+		r1 = rf(ctx, db, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestVerificationTokenByTenantID provides a mock function with given fields: ctx, db, tenantID
+func (_m *TokenRepository) FindLatestVerificationTokenByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*model.UserVerificationToken, error) {
+	ret := _m.Called(ctx, db, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestVerificationTokenByTenantID")
+	}
+
+	var r0 *model.UserVerificationToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) (*model.UserVerificationToken, error)); ok {
+		return rf(ctx, db, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) *model.UserVerificationToken); ok {
+		r0 = rf(ctx, db, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserVerificationToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPasswordResetToken provides a mock function with given fields: ctx, db, token
 func (_m *TokenRepository) FindPasswordResetToken(ctx context.Context, db *gorm.DB, token string) (*model.PasswordResetToken, error) {
 	ret := _m.Called(ctx, db, token)
@@ -119,6 +253,36 @@ func (_m *TokenRepository) FindPasswordResetToken(ctx context.Context, db *gorm.
 	return r0, r1
 }
 
+// FindRefreshTokenByHashForUpdate provides a mock function with given fields: ctx, tx, tokenHash
+func (_m *TokenRepository) FindRefreshTokenByHashForUpdate(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, tx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRefreshTokenByHashForUpdate")
+	}
+
+	var r0 *model.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) (*model.RefreshToken, error)); ok {
+		return rf(ctx, tx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) *model.RefreshToken); ok {
+		r0 = rf(ctx, tx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string) error); ok {
+		r1 = rf(ctx, tx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindVerificationToken provides a mock function with given fields: ctx, db, token
 func (_m *TokenRepository) FindVerificationToken(ctx context.Context, db *gorm.DB, token string) (*model.UserVerificationToken, error) {
 	ret := _m.Called(ctx, db, token)
@@ -149,6 +313,42 @@ func (_m *TokenRepository) FindVerificationToken(ctx context.Context, db *gorm.D
 	return r0, r1
 }
 
+// MarkRefreshTokenUsed provides a mock function with given fields: ctx, tx, tokenID
+func (_m *TokenRepository) MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) error {
+	ret := _m.Called(ctx, tx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRefreshTokenUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r0 = rf(ctx, tx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: ctx, db, familyID
+func (_m *TokenRepository) RevokeRefreshTokenFamily(ctx context.Context, db *gorm.DB, familyID uuid.UUID) error {
+	ret := _m.Called(ctx, db, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r0 = rf(ctx, db, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTokenRepository creates a new instance of TokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenRepository(t interface {
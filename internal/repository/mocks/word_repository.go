@@ -11,6 +11,8 @@ import (
 
 	model "go_4_vocab_keep/internal/model"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -19,27 +21,27 @@ type WordRepository struct {
 	mock.Mock
 }
 
-// CheckTermExists provides a mock function with given fields: ctx, db, tenantID, term, excludeWordID
-func (_m *WordRepository) CheckTermExists(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, term string, excludeWordID *uuid.UUID) (bool, error) {
-	ret := _m.Called(ctx, db, tenantID, term, excludeWordID)
+// CheckNormalizedTermExists provides a mock function with given fields: ctx, db, tenantID, normalizedTerm, excludeWordID
+func (_m *WordRepository) CheckNormalizedTermExists(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerm string, excludeWordID *uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, db, tenantID, normalizedTerm, excludeWordID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckTermExists")
+		panic("no return value specified for CheckNormalizedTermExists")
 	}
 
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string, *uuid.UUID) (bool, error)); ok {
-		return rf(ctx, db, tenantID, term, excludeWordID)
+		return rf(ctx, db, tenantID, normalizedTerm, excludeWordID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string, *uuid.UUID) bool); ok {
-		r0 = rf(ctx, db, tenantID, term, excludeWordID)
+		r0 = rf(ctx, db, tenantID, normalizedTerm, excludeWordID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, string, *uuid.UUID) error); ok {
-		r1 = rf(ctx, db, tenantID, term, excludeWordID)
+		r1 = rf(ctx, db, tenantID, normalizedTerm, excludeWordID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -83,6 +85,36 @@ func (_m *WordRepository) Delete(ctx context.Context, tx *gorm.DB, tenantID uuid
 	return r0
 }
 
+// FindAllAfter provides a mock function with given fields: ctx, db, afterWordID, limit
+func (_m *WordRepository) FindAllAfter(ctx context.Context, db *gorm.DB, afterWordID uuid.UUID, limit int) ([]*model.Word, error) {
+	ret := _m.Called(ctx, db, afterWordID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllAfter")
+	}
+
+	var r0 []*model.Word
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, int) ([]*model.Word, error)); ok {
+		return rf(ctx, db, afterWordID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, int) []*model.Word); ok {
+		r0 = rf(ctx, db, afterWordID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Word)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, db, afterWordID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, db, tenantID, wordID
 func (_m *WordRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) (*model.Word, error) {
 	ret := _m.Called(ctx, db, tenantID, wordID)
@@ -113,6 +145,66 @@ func (_m *WordRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID uu
 	return r0, r1
 }
 
+// FindByIDs provides a mock function with given fields: ctx, db, tenantID, wordIDs
+func (_m *WordRepository) FindByIDs(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordIDs []uuid.UUID) ([]*model.Word, error) {
+	ret := _m.Called(ctx, db, tenantID, wordIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDs")
+	}
+
+	var r0 []*model.Word
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []uuid.UUID) ([]*model.Word, error)); ok {
+		return rf(ctx, db, tenantID, wordIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []uuid.UUID) []*model.Word); ok {
+		r0 = rf(ctx, db, tenantID, wordIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Word)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, []uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByNormalizedTerm provides a mock function with given fields: ctx, db, tenantID, normalizedTerm
+func (_m *WordRepository) FindByNormalizedTerm(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerm string) (*model.Word, error) {
+	ret := _m.Called(ctx, db, tenantID, normalizedTerm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByNormalizedTerm")
+	}
+
+	var r0 *model.Word
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) (*model.Word, error)); ok {
+		return rf(ctx, db, tenantID, normalizedTerm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) *model.Word); ok {
+		r0 = rf(ctx, db, tenantID, normalizedTerm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Word)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, db, tenantID, normalizedTerm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByTenant provides a mock function with given fields: ctx, db, tenantID
 func (_m *WordRepository) FindByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Word, error) {
 	ret := _m.Called(ctx, db, tenantID)
@@ -143,6 +235,240 @@ func (_m *WordRepository) FindByTenant(ctx context.Context, db *gorm.DB, tenantI
 	return r0, r1
 }
 
+// FindDeletedByIDs provides a mock function with given fields: ctx, db, tenantID, wordIDs
+func (_m *WordRepository) FindDeletedByIDs(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordIDs []uuid.UUID) ([]*model.Word, error) {
+	ret := _m.Called(ctx, db, tenantID, wordIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDeletedByIDs")
+	}
+
+	var r0 []*model.Word
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []uuid.UUID) ([]*model.Word, error)); ok {
+		return rf(ctx, db, tenantID, wordIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []uuid.UUID) []*model.Word); ok {
+		r0 = rf(ctx, db, tenantID, wordIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Word)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, []uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDeletedByTenant provides a mock function with given fields: ctx, db, tenantID
+func (_m *WordRepository) FindDeletedByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Word, error) {
+	ret := _m.Called(ctx, db, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDeletedByTenant")
+	}
+
+	var r0 []*model.Word
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) ([]*model.Word, error)); ok {
+		return rf(ctx, db, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) []*model.Word); ok {
+		r0 = rf(ctx, db, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Word)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExistingNormalizedTerms provides a mock function with given fields: ctx, db, tenantID, normalizedTerms
+func (_m *WordRepository) FindExistingNormalizedTerms(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerms []string) ([]string, error) {
+	ret := _m.Called(ctx, db, tenantID, normalizedTerms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExistingNormalizedTerms")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []string) ([]string, error)); ok {
+		return rf(ctx, db, tenantID, normalizedTerms)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, []string) []string); ok {
+		r0 = rf(ctx, db, tenantID, normalizedTerms)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, []string) error); ok {
+		r1 = rf(ctx, db, tenantID, normalizedTerms)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindIDsByFilter provides a mock function with given fields: ctx, db, tenantID, filter, limit
+func (_m *WordRepository) FindIDsByFilter(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, filter model.WordFilter, limit int) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, db, tenantID, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIDsByFilter")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, model.WordFilter, int) ([]uuid.UUID, error)); ok {
+		return rf(ctx, db, tenantID, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, model.WordFilter, int) []uuid.UUID); ok {
+		r0 = rf(ctx, db, tenantID, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, model.WordFilter, int) error); ok {
+		r1 = rf(ctx, db, tenantID, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, tx, tenantID, wordID
+func (_m *WordRepository) Purge(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) error {
+	ret := _m.Called(ctx, tx, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, tx, tenantID, wordID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeAllDeleted provides a mock function with given fields: ctx, tx, tenantID
+func (_m *WordRepository) PurgeAllDeleted(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, tx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeAllDeleted")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, tx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, tx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID) error); ok {
+		r1 = rf(ctx, tx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedBefore provides a mock function with given fields: ctx, tx, before, limit
+func (_m *WordRepository) PurgeDeletedBefore(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, tx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBefore")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, time.Time, int) ([]uuid.UUID, error)); ok {
+		return rf(ctx, tx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, time.Time, int) []uuid.UUID); ok {
+		r0 = rf(ctx, tx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, time.Time, int) error); ok {
+		r1 = rf(ctx, tx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, tx, tenantID, wordID
+func (_m *WordRepository) Restore(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) error {
+	ret := _m.Called(ctx, tx, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, tx, tenantID, wordID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamWithProgress provides a mock function with given fields: ctx, db, tenantID, filter, fn
+func (_m *WordRepository) StreamWithProgress(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, filter model.WordFilter, fn func(*model.WordWithProgress) error) error {
+	ret := _m.Called(ctx, db, tenantID, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamWithProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, model.WordFilter, func(*model.WordWithProgress) error) error); ok {
+		r0 = rf(ctx, db, tenantID, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, tx, tenantID, wordID, updates
 func (_m *WordRepository) Update(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, updates map[string]interface{}) error {
 	ret := _m.Called(ctx, tx, tenantID, wordID, updates)
@@ -161,6 +487,42 @@ func (_m *WordRepository) Update(ctx context.Context, tx *gorm.DB, tenantID uuid
 	return r0
 }
 
+// UpdateAutoReading provides a mock function with given fields: ctx, tx, wordID, reading
+func (_m *WordRepository) UpdateAutoReading(ctx context.Context, tx *gorm.DB, wordID uuid.UUID, reading string) error {
+	ret := _m.Called(ctx, tx, wordID, reading)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAutoReading")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, tx, wordID, reading)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateNormalizedTerm provides a mock function with given fields: ctx, tx, wordID, normalizedTerm
+func (_m *WordRepository) UpdateNormalizedTerm(ctx context.Context, tx *gorm.DB, wordID uuid.UUID, normalizedTerm string) error {
+	ret := _m.Called(ctx, tx, wordID, normalizedTerm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNormalizedTerm")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, tx, wordID, normalizedTerm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewWordRepository creates a new instance of WordRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewWordRepository(t interface {
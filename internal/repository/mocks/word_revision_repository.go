@@ -0,0 +1,112 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "go_4_vocab_keep/internal/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// WordRevisionRepository is an autogenerated mock type for the WordRevisionRepository type
+type WordRevisionRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, tx, revision
+func (_m *WordRevisionRepository) Create(ctx context.Context, tx *gorm.DB, revision *model.WordRevision) error {
+	ret := _m.Called(ctx, tx, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, *model.WordRevision) error); ok {
+		r0 = rf(ctx, tx, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByRevisionNo provides a mock function with given fields: ctx, db, tenantID, wordID, revisionNo
+func (_m *WordRevisionRepository) FindByRevisionNo(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID, revisionNo int) (*model.WordRevision, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID, revisionNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByRevisionNo")
+	}
+
+	var r0 *model.WordRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, int) (*model.WordRevision, error)); ok {
+		return rf(ctx, db, tenantID, wordID, revisionNo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, int) *model.WordRevision); ok {
+		r0 = rf(ctx, db, tenantID, wordID, revisionNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WordRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID, revisionNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByWordID provides a mock function with given fields: ctx, db, tenantID, wordID
+func (_m *WordRevisionRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordID uuid.UUID) ([]*model.WordRevision, error) {
+	ret := _m.Called(ctx, db, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByWordID")
+	}
+
+	var r0 []*model.WordRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) ([]*model.WordRevision, error)); ok {
+		return rf(ctx, db, tenantID, wordID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) []*model.WordRevision); ok {
+		r0 = rf(ctx, db, tenantID, wordID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.WordRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, db, tenantID, wordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWordRevisionRepository creates a new instance of WordRevisionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWordRevisionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WordRevisionRepository {
+	mock := &WordRevisionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
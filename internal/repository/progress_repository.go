@@ -12,15 +12,18 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProgressRepository インターフェース (変更なし)
 type ProgressRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, progress *model.LearningProgress) error
-	FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) (*model.LearningProgress, error)
+	FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID, direction string) (*model.LearningProgress, error)
 	Update(ctx context.Context, tx *gorm.DB, progress *model.LearningProgress) error
-	FindReviewableByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, today time.Time, limit int) ([]*model.LearningProgress, error)
+	FindReviewableByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, directions []string, today time.Time, limit int) ([]*model.LearningProgress, error)
+	CreateMissingCloze(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, nextReviewDate time.Time) (int64, error)
 	DeleteByWordID(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error
+	ResetLevel(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, nextReviewDate time.Time) error
 }
 
 // gormProgressRepository 構造体から logger フィールドを削除
@@ -31,8 +34,12 @@ func NewGormProgressRepository() ProgressRepository {
 	return &gormProgressRepository{}
 }
 
+// Create は学習進捗を作成します。Direction が未設定の場合は通常の出題 (recognition) の進捗として作成します。
 func (r *gormProgressRepository) Create(ctx context.Context, tx *gorm.DB, progress *model.LearningProgress) error {
 	logger := middleware.GetLogger(ctx)
+	if progress.Direction == "" {
+		progress.Direction = model.DirectionRecognition
+	}
 	result := tx.WithContext(ctx).Create(progress)
 	if result.Error != nil {
 		logger.Error("Error creating progress in DB",
@@ -45,14 +52,15 @@ func (r *gormProgressRepository) Create(ctx context.Context, tx *gorm.DB, progre
 	return nil
 }
 
-func (r *gormProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) (*model.LearningProgress, error) {
+// FindByWordID は単語の、指定した出題形式の学習進捗を返します
+func (r *gormProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID, direction string) (*model.LearningProgress, error) {
 	logger := middleware.GetLogger(ctx)
 	var progress model.LearningProgress
 
 	// 関連するWordが論理削除されていないProgressレコードのみを検索
 	result := db.WithContext(ctx).
 		Joins("JOIN words ON words.word_id = learning_progress.word_id AND words.deleted_at IS NULL").
-		Where("learning_progress.tenant_id = ? AND learning_progress.word_id = ?", tenantID, wordID).
+		Where("learning_progress.tenant_id = ? AND learning_progress.word_id = ? AND learning_progress.direction = ?", tenantID, wordID, direction).
 		First(&progress)
 
 	if result.Error != nil {
@@ -63,6 +71,7 @@ func (r *gormProgressRepository) FindByWordID(ctx context.Context, db *gorm.DB,
 			"error", result.Error,
 			"tenant_id", tenantID.String(),
 			"word_id", wordID.String(),
+			"direction", direction,
 		)
 		return nil, fmt.Errorf("gormProgressRepository.FindByWordID: %w", result.Error)
 	}
@@ -92,7 +101,8 @@ func (r *gormProgressRepository) Update(ctx context.Context, tx *gorm.DB, progre
 	return nil
 }
 
-func (r *gormProgressRepository) FindReviewableByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, today time.Time, limit int) ([]*model.LearningProgress, error) {
+// FindReviewableByTenant は復習日を迎えた学習進捗のうち、directions の出題形式のものをランダムに最大 limit 件返します
+func (r *gormProgressRepository) FindReviewableByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, directions []string, today time.Time, limit int) ([]*model.LearningProgress, error) {
 	logger := middleware.GetLogger(ctx)
 	var progresses []*model.LearningProgress
 
@@ -101,8 +111,12 @@ func (r *gormProgressRepository) FindReviewableByTenant(ctx context.Context, db
 
 	result := db.WithContext(ctx).
 		Preload("Word"). // 関連するWordの情報も取得
+		Preload("Word.Senses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("word_senses.position ASC")
+		}).
 		Joins("JOIN words ON words.word_id = learning_progress.word_id AND words.deleted_at IS NULL").
 		Where("learning_progress.tenant_id = ? AND learning_progress.next_review_date <= ?", tenantID, todayDate).
+		Where("learning_progress.direction IN ?", directions).
 		Order("RANDOM()").
 		Limit(limit).
 		Find(&progresses)
@@ -134,3 +148,67 @@ func (r *gormProgressRepository) DeleteByWordID(ctx context.Context, tx *gorm.DB
 	// 削除対象がなくてもエラーにしない（冪等性）
 	return nil
 }
+
+// CreateMissingCloze は例文がある単語のうち、穴埋め (cloze) の学習進捗がまだないものに Level1 の進捗を作成し、作成した件数を返します。
+// 例文に単語が含まれるか (穴埋め問題を作れるか) までは確認しないため、出題時に確認してください。
+func (r *gormProgressRepository) CreateMissingCloze(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, nextReviewDate time.Time) (int64, error) {
+	logger := middleware.GetLogger(ctx)
+
+	var wordIDs []uuid.UUID
+	result := tx.WithContext(ctx).Model(&model.Word{}).
+		Where("words.tenant_id = ? AND jsonb_array_length(words.examples) > 0", tenantID).
+		Where("NOT EXISTS (SELECT 1 FROM learning_progress lp WHERE lp.word_id = words.word_id AND lp.direction = ?)", model.DirectionCloze).
+		Pluck("words.word_id", &wordIDs)
+	if result.Error != nil {
+		logger.Error("Error finding words without cloze progress in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return 0, fmt.Errorf("gormProgressRepository.CreateMissingCloze: %w", result.Error)
+	}
+	if len(wordIDs) == 0 {
+		return 0, nil
+	}
+
+	progresses := make([]*model.LearningProgress, 0, len(wordIDs))
+	for _, wordID := range wordIDs {
+		progresses = append(progresses, &model.LearningProgress{
+			ProgressID:     uuid.New(),
+			TenantID:       tenantID,
+			WordID:         wordID,
+			Direction:      model.DirectionCloze,
+			Level:          model.Level1,
+			NextReviewDate: nextReviewDate,
+		})
+	}
+	// 同時に復習を始めた場合に備え、既に作成済みのものは無視する
+	result = tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(progresses, 500)
+	if result.Error != nil {
+		logger.Error("Error creating cloze progress in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return 0, fmt.Errorf("gormProgressRepository.CreateMissingCloze: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ResetLevel は単語の学習進捗 (すべての出題形式) を Level1 に戻し、次回復習日を nextReviewDate にします
+func (r *gormProgressRepository) ResetLevel(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, nextReviewDate time.Time) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Model(&model.LearningProgress{}).
+		Where("tenant_id = ? AND word_id = ?", tenantID, wordID).
+		Updates(map[string]interface{}{"level": model.Level1, "next_review_date": nextReviewDate})
+	if result.Error != nil {
+		logger.Error("Error resetting progress level in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormProgressRepository.ResetLevel: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+//go:generate mockery --name WordRevisionRepository --output ./mocks --outpkg mocks --case=underscore
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WordRevisionRepository は単語の変更履歴を扱います。
+// 履歴の追加は WordRepository.Update が行うため、サービスからは参照のみを行います。
+type WordRevisionRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, revision *model.WordRevision) error
+	FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) ([]*model.WordRevision, error)
+	FindByRevisionNo(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID, revisionNo int) (*model.WordRevision, error)
+}
+
+type gormWordRevisionRepository struct{}
+
+func NewGormWordRevisionRepository() WordRevisionRepository {
+	return &gormWordRevisionRepository{}
+}
+
+// Create は変更履歴を追加します。RevisionNo は単語ごとの次の番号を自動で設定します。
+// 同じ単語への同時更新で番号が重複しないよう、呼び出し側で単語の行をロックしておく必要があります。
+func (r *gormWordRevisionRepository) Create(ctx context.Context, tx *gorm.DB, revision *model.WordRevision) error {
+	logger := middleware.GetLogger(ctx)
+
+	var last int
+	if err := tx.WithContext(ctx).Model(&model.WordRevision{}).
+		Where("word_id = ?", revision.WordID).
+		Select("COALESCE(MAX(revision_no), 0)").
+		Scan(&last).Error; err != nil {
+		logger.Error("Error finding last revision number in DB",
+			"error", err,
+			"word_id", revision.WordID.String(),
+		)
+		return fmt.Errorf("gormWordRevisionRepository.Create: %w", err)
+	}
+	revision.RevisionNo = last + 1
+
+	if err := tx.WithContext(ctx).Create(revision).Error; err != nil {
+		logger.Error("Error creating word revision in DB",
+			"error", err,
+			"tenant_id", revision.TenantID.String(),
+			"word_id", revision.WordID.String(),
+		)
+		return fmt.Errorf("gormWordRevisionRepository.Create: %w", err)
+	}
+	return nil
+}
+
+// FindByWordID は単語の変更履歴を新しい順に返します
+func (r *gormWordRevisionRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) ([]*model.WordRevision, error) {
+	logger := middleware.GetLogger(ctx)
+	var revisions []*model.WordRevision
+	result := db.WithContext(ctx).
+		Where("tenant_id = ? AND word_id = ?", tenantID, wordID).
+		Order("revision_no DESC").
+		Find(&revisions)
+	if result.Error != nil {
+		logger.Error("Error finding word revisions in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRevisionRepository.FindByWordID: %w", result.Error)
+	}
+	return revisions, nil
+}
+
+func (r *gormWordRevisionRepository) FindByRevisionNo(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID, revisionNo int) (*model.WordRevision, error) {
+	logger := middleware.GetLogger(ctx)
+	var revision model.WordRevision
+	result := db.WithContext(ctx).
+		Where("tenant_id = ? AND word_id = ? AND revision_no = ?", tenantID, wordID, revisionNo).
+		First(&revision)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Error finding word revision in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+			"revision_no", revisionNo,
+		)
+		return nil, fmt.Errorf("gormWordRevisionRepository.FindByRevisionNo: %w", result.Error)
+	}
+	return &revision, nil
+}
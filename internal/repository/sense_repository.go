@@ -0,0 +1,90 @@
+//go:generate mockery --name SenseRepository --output ./mocks --outpkg mocks --case=underscore
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SenseRepository は単語の語義を扱います
+type SenseRepository interface {
+	FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) ([]model.WordSense, error)
+	ReplaceForWord(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, senses []model.WordSense) error
+}
+
+type gormSenseRepository struct{}
+
+func NewGormSenseRepository() SenseRepository {
+	return &gormSenseRepository{}
+}
+
+// FindByWordID は単語の語義を表示順に返します
+func (r *gormSenseRepository) FindByWordID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) ([]model.WordSense, error) {
+	logger := middleware.GetLogger(ctx)
+	var senses []model.WordSense
+	result := db.WithContext(ctx).
+		Where("tenant_id = ? AND word_id = ?", tenantID, wordID).
+		Order("position ASC").
+		Find(&senses)
+	if result.Error != nil {
+		logger.Error("Error finding word senses in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return nil, fmt.Errorf("gormSenseRepository.FindByWordID: %w", result.Error)
+	}
+	return senses, nil
+}
+
+// ReplaceForWord は単語の語義を senses で置き換えます。
+// senses にない既存の語義は削除し、SenseID が既存のものは内容と表示順を更新、それ以外は追加します。
+func (r *gormSenseRepository) ReplaceForWord(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, senses []model.WordSense) error {
+	logger := middleware.GetLogger(ctx)
+
+	keep := make([]uuid.UUID, 0, len(senses))
+	for _, s := range senses {
+		keep = append(keep, s.SenseID)
+	}
+	query := tx.WithContext(ctx).Where("tenant_id = ? AND word_id = ?", tenantID, wordID)
+	if len(keep) > 0 {
+		query = query.Where("sense_id NOT IN ?", keep)
+	}
+	if err := query.Delete(&model.WordSense{}).Error; err != nil {
+		logger.Error("Error deleting word senses in DB",
+			"error", err,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormSenseRepository.ReplaceForWord: %w", err)
+	}
+	if len(senses) == 0 {
+		return nil
+	}
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sense_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"position", "definition", "example", "tag", "updated_at"}),
+		// 他の単語の語義を書き換えないよう、同じ単語の語義の場合のみ更新する
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: "word_senses", Name: "tenant_id"}, Value: tenantID},
+			clause.Eq{Column: clause.Column{Table: "word_senses", Name: "word_id"}, Value: wordID},
+		}},
+	}).Create(&senses)
+	if result.Error != nil {
+		logger.Error("Error upserting word senses in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormSenseRepository.ReplaceForWord: %w", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+//go:generate mockery --name SessionRepository --output ./mocks --outpkg mocks --case=underscore
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SessionRepository はログインごとのセッションを扱います
+type SessionRepository interface {
+	Create(ctx context.Context, db *gorm.DB, session *model.Session) error
+	FindByID(ctx context.Context, db *gorm.DB, tenantID, sessionID uuid.UUID) (*model.Session, error)
+	FindActiveByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, since time.Time) ([]*model.Session, error)
+	Touch(ctx context.Context, db *gorm.DB, sessionID uuid.UUID, client model.ClientInfo) error
+	Revoke(ctx context.Context, db *gorm.DB, tenantID, sessionID uuid.UUID) error
+	RevokeOthers(ctx context.Context, tx *gorm.DB, tenantID, keepSessionID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type gormSessionRepository struct{}
+
+func NewGormSessionRepository() SessionRepository {
+	return &gormSessionRepository{}
+}
+
+func (r *gormSessionRepository) Create(ctx context.Context, db *gorm.DB, session *model.Session) error {
+	logger := middleware.GetLogger(ctx)
+	if err := db.WithContext(ctx).Create(session).Error; err != nil {
+		logger.Error("Error creating session in DB", "error", err, "tenant_id", session.TenantID.String())
+		return fmt.Errorf("gormSessionRepository.Create: %w", err)
+	}
+	return nil
+}
+
+// FindByID は失効済みのものも含めてセッションを取得します
+func (r *gormSessionRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID, sessionID uuid.UUID) (*model.Session, error) {
+	logger := middleware.GetLogger(ctx)
+	var session model.Session
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND session_id = ?", tenantID, sessionID).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Error finding session in DB", "error", err, "tenant_id", tenantID.String(), "session_id", sessionID.String())
+		return nil, fmt.Errorf("gormSessionRepository.FindByID: %w", err)
+	}
+	return &session, nil
+}
+
+// FindActiveByTenantID は失効しておらず、since 以降に使われたセッションを最後に使われた順に返します
+func (r *gormSessionRepository) FindActiveByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, since time.Time) ([]*model.Session, error) {
+	logger := middleware.GetLogger(ctx)
+	var sessions []*model.Session
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND revoked_at IS NULL AND last_seen_at >= ?", tenantID, since).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		logger.Error("Error finding sessions in DB", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormSessionRepository.FindActiveByTenantID: %w", err)
+	}
+	return sessions, nil
+}
+
+// Touch はセッションの最終利用日時と、分かっていれば IP アドレス・User-Agent を更新します
+func (r *gormSessionRepository) Touch(ctx context.Context, db *gorm.DB, sessionID uuid.UUID, client model.ClientInfo) error {
+	logger := middleware.GetLogger(ctx)
+	updates := map[string]interface{}{"last_seen_at": time.Now()}
+	if client.IPAddress != "" {
+		updates["ip_address"] = client.IPAddress
+	}
+	if client.UserAgent != "" {
+		updates["user_agent"] = client.UserAgent
+	}
+	result := db.WithContext(ctx).Model(&model.Session{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Updates(updates)
+	if result.Error != nil {
+		logger.Error("Error touching session in DB", "error", result.Error, "session_id", sessionID.String())
+		return fmt.Errorf("gormSessionRepository.Touch: %w", result.Error)
+	}
+	return nil
+}
+
+// Revoke はセッションを失効させます。見つからないか既に失効済みの場合は model.ErrNotFound を返します。
+func (r *gormSessionRepository) Revoke(ctx context.Context, db *gorm.DB, tenantID, sessionID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Model(&model.Session{}).
+		Where("tenant_id = ? AND session_id = ? AND revoked_at IS NULL", tenantID, sessionID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		logger.Error("Error revoking session in DB", "error", result.Error, "tenant_id", tenantID.String(), "session_id", sessionID.String())
+		return fmt.Errorf("gormSessionRepository.Revoke: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// RevokeOthers は keepSessionID 以外のまだ失効していないセッションをすべて失効させ、失効させたセッションのIDを返します。
+// トランザクション内で呼び出してください。
+func (r *gormSessionRepository) RevokeOthers(ctx context.Context, tx *gorm.DB, tenantID, keepSessionID uuid.UUID) ([]uuid.UUID, error) {
+	logger := middleware.GetLogger(ctx)
+	var sessionIDs []uuid.UUID
+	err := tx.WithContext(ctx).Model(&model.Session{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ? AND session_id <> ? AND revoked_at IS NULL", tenantID, keepSessionID).
+		Pluck("session_id", &sessionIDs).Error
+	if err != nil {
+		logger.Error("Error finding other sessions in DB", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormSessionRepository.RevokeOthers: %w", err)
+	}
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+
+	err = tx.WithContext(ctx).Model(&model.Session{}).
+		Where("session_id IN ?", sessionIDs).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		logger.Error("Error revoking other sessions in DB", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormSessionRepository.RevokeOthers: %w", err)
+	}
+	return sessionIDs, nil
+}
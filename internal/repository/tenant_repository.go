@@ -19,6 +19,7 @@ type TenantRepository interface {
 	FindByName(ctx context.Context, db *gorm.DB, name string) (*model.Tenant, error)
 	FindByEmail(ctx context.Context, db *gorm.DB, email string) (*model.Tenant, error)
 	Delete(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error
+	UpdateEmail(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, email string) error
 }
 
 type gormTenantRepository struct{}
@@ -133,3 +134,27 @@ func (r *gormTenantRepository) FindByEmail(ctx context.Context, db *gorm.DB, ema
 	}
 	return &tenant, nil
 }
+
+// UpdateEmail はテナントのメールアドレスを変更します。
+// 他のテナントが使っている場合は model.ErrConflict を、テナントが見つからない場合は model.ErrNotFound を返します。
+func (r *gormTenantRepository) UpdateEmail(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, email string) error {
+	logger := middleware.GetLogger(ctx)
+
+	result := db.WithContext(ctx).Model(&model.Tenant{}).Where("tenant_id = ?", tenantID).Update("email", email)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			logger.Warn("Duplicate email on update tenant", "tenant_id", tenantID.String(), "email", email)
+			return model.ErrConflict
+		}
+		logger.Error(
+			"Error updating tenant email in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return fmt.Errorf("gormTenantRepository.UpdateEmail: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
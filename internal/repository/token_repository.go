@@ -5,20 +5,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TokenRepository interface {
 	CreateVerificationToken(ctx context.Context, db *gorm.DB, token *model.UserVerificationToken) error
 	FindVerificationToken(ctx context.Context, db *gorm.DB, token string) (*model.UserVerificationToken, error)
 	DeleteVerificationToken(ctx context.Context, db *gorm.DB, token string) error
+	FindLatestVerificationTokenByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*model.UserVerificationToken, error)
+	DeleteVerificationTokensByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error
+	CreateEmailChangeToken(ctx context.Context, db *gorm.DB, token *model.EmailChangeToken) error
+	FindEmailChangeToken(ctx context.Context, db *gorm.DB, token string) (*model.EmailChangeToken, error)
+	DeleteEmailChangeTokensByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error
 	CreatePasswordResetToken(ctx context.Context, db *gorm.DB, token *model.PasswordResetToken) error
 	FindPasswordResetToken(ctx context.Context, db *gorm.DB, token string) (*model.PasswordResetToken, error)
 	DeletePasswordResetToken(ctx context.Context, db *gorm.DB, token string) error
+	CreateRefreshToken(ctx context.Context, db *gorm.DB, token *model.RefreshToken) error
+	FindRefreshTokenByHashForUpdate(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) error
+	RevokeRefreshTokenFamily(ctx context.Context, db *gorm.DB, familyID uuid.UUID) error
 }
 
 type gormTokenRepository struct{}
@@ -59,6 +71,64 @@ func (r *gormTokenRepository) DeleteVerificationToken(ctx context.Context, db *g
 	return nil
 }
 
+// FindLatestVerificationTokenByTenantID はテナントに最後に発行した有効化トークンを返します
+func (r *gormTokenRepository) FindLatestVerificationTokenByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*model.UserVerificationToken, error) {
+	logger := middleware.GetLogger(ctx)
+	var token model.UserVerificationToken
+	if err := db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("created_at DESC").First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Failed to find latest verification token", "error", err, "tenant_id", tenantID.String())
+		return nil, fmt.Errorf("gormTokenRepository.FindLatestVerificationTokenByTenantID: %w", err)
+	}
+	return &token, nil
+}
+
+// DeleteVerificationTokensByTenantID はテナントの有効化トークンをすべて削除します
+func (r *gormTokenRepository) DeleteVerificationTokensByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&model.UserVerificationToken{})
+	if result.Error != nil {
+		logger.Error("Failed to delete verification tokens", "error", result.Error, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormTokenRepository.DeleteVerificationTokensByTenantID: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *gormTokenRepository) CreateEmailChangeToken(ctx context.Context, db *gorm.DB, token *model.EmailChangeToken) error {
+	logger := middleware.GetLogger(ctx)
+	if err := db.WithContext(ctx).Create(token).Error; err != nil {
+		logger.Error("Failed to create email change token", "error", err, "tenant_id", token.TenantID.String())
+		return fmt.Errorf("gormTokenRepository.CreateEmailChangeToken: %w", err)
+	}
+	return nil
+}
+
+func (r *gormTokenRepository) FindEmailChangeToken(ctx context.Context, db *gorm.DB, tokenStr string) (*model.EmailChangeToken, error) {
+	logger := middleware.GetLogger(ctx)
+	var token model.EmailChangeToken
+	if err := db.WithContext(ctx).Where("token = ?", tokenStr).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Failed to find email change token", "error", err)
+		return nil, fmt.Errorf("gormTokenRepository.FindEmailChangeToken: %w", err)
+	}
+	return &token, nil
+}
+
+// DeleteEmailChangeTokensByTenantID はテナントのメールアドレスの変更の確認用トークンをすべて削除します
+func (r *gormTokenRepository) DeleteEmailChangeTokensByTenantID(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Where("tenant_id = ?", tenantID).Delete(&model.EmailChangeToken{})
+	if result.Error != nil {
+		logger.Error("Failed to delete email change tokens", "error", result.Error, "tenant_id", tenantID.String())
+		return fmt.Errorf("gormTokenRepository.DeleteEmailChangeTokensByTenantID: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *gormTokenRepository) CreatePasswordResetToken(ctx context.Context, db *gorm.DB, token *model.PasswordResetToken) error {
 	logger := middleware.GetLogger(ctx)
 	if err := db.WithContext(ctx).Create(token).Error; err != nil {
@@ -90,3 +160,59 @@ func (r *gormTokenRepository) DeletePasswordResetToken(ctx context.Context, db *
 	}
 	return nil
 }
+
+func (r *gormTokenRepository) CreateRefreshToken(ctx context.Context, db *gorm.DB, token *model.RefreshToken) error {
+	logger := middleware.GetLogger(ctx)
+	if err := db.WithContext(ctx).Create(token).Error; err != nil {
+		logger.Error("Failed to create refresh token", "error", err, "tenant_id", token.TenantID.String())
+		return fmt.Errorf("gormTokenRepository.CreateRefreshToken: %w", err)
+	}
+	return nil
+}
+
+// FindRefreshTokenByHashForUpdate はハッシュが一致するリフレッシュトークンを行ロックを取って取得します。
+// 同じトークンによる同時のローテーションを直列化するため、トランザクション内で呼び出してください。
+func (r *gormTokenRepository) FindRefreshTokenByHashForUpdate(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.RefreshToken, error) {
+	logger := middleware.GetLogger(ctx)
+	var token model.RefreshToken
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Failed to find refresh token", "error", err)
+		return nil, fmt.Errorf("gormTokenRepository.FindRefreshTokenByHashForUpdate: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed はリフレッシュトークンを使用済みにします。既に使用済みの場合は model.ErrNotFound を返します。
+func (r *gormTokenRepository) MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Model(&model.RefreshToken{}).
+		Where("token_id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logger.Error("Failed to mark refresh token as used", "error", result.Error, "token_id", tokenID.String())
+		return fmt.Errorf("gormTokenRepository.MarkRefreshTokenUsed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily は同じファミリーのまだ失効していないリフレッシュトークンをすべて失効させます
+func (r *gormTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, db *gorm.DB, familyID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := db.WithContext(ctx).Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		logger.Error("Failed to revoke refresh token family", "error", result.Error, "family_id", familyID.String())
+		return fmt.Errorf("gormTokenRepository.RevokeRefreshTokenFamily: %w", result.Error)
+	}
+	return nil
+}
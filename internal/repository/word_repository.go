@@ -5,37 +5,72 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	// middleware.GetLoggerが返す型として必要
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// WordRepository インターフェース (変更なし)
+// WordRepository は単語の永続化を扱います
 type WordRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, word *model.Word) error
 	FindByID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) (*model.Word, error)
 	FindByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Word, error)
+	FindByNormalizedTerm(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerm string) (*model.Word, error)
+	FindExistingNormalizedTerms(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerms []string) ([]string, error)
 	Update(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error
-	CheckTermExists(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, term string, excludeWordID *uuid.UUID) (bool, error)
+	CheckNormalizedTermExists(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerm string, excludeWordID *uuid.UUID) (bool, error)
+	StreamWithProgress(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, filter model.WordFilter, fn func(*model.WordWithProgress) error) error
+	FindIDsByFilter(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, filter model.WordFilter, limit int) ([]uuid.UUID, error)
+	FindByIDs(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordIDs []uuid.UUID) ([]*model.Word, error)
+	FindDeletedByIDs(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordIDs []uuid.UUID) ([]*model.Word, error)
+	Restore(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error
+	FindDeletedByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Word, error)
+	Purge(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error
+	PurgeAllDeleted(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) ([]uuid.UUID, error)
+	PurgeDeletedBefore(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]uuid.UUID, error)
+	FindAllAfter(ctx context.Context, db *gorm.DB, afterWordID uuid.UUID, limit int) ([]*model.Word, error)
+	UpdateNormalizedTerm(ctx context.Context, tx *gorm.DB, wordID uuid.UUID, normalizedTerm string) error
+	UpdateAutoReading(ctx context.Context, tx *gorm.DB, wordID uuid.UUID, reading string) error
 }
 
 // gormWordRepository 構造体から logger フィールドを削除
-type gormWordRepository struct{}
+type gormWordRepository struct {
+	revisions WordRevisionRepository
+}
 
-// NewGormWordRepository コンストラクタから logger 引数を削除
+// NewGormWordRepository は単語のリポジトリを返します。
+// 重複判定に使う正規化済みの単語 (normalized_term) は呼び出し側 (サービス層) で計算して渡します。
 func NewGormWordRepository() WordRepository {
-	return &gormWordRepository{}
+	return &gormWordRepository{
+		revisions: NewGormWordRevisionRepository(),
+	}
 }
 
+// Create は単語を作成します。word.NormalizedTerm は呼び出し側で設定しておく必要があります。
 func (r *gormWordRepository) Create(ctx context.Context, tx *gorm.DB, word *model.Word) error {
 	logger := middleware.GetLogger(ctx)
-	result := tx.WithContext(ctx).Create(word)
+	result := tx.WithContext(ctx).Omit("Senses", "Attachments").Create(word) // 語義・添付ファイルはそれぞれのリポジトリで作成する
 	if result.Error != nil {
+		// 有効な単語の (tenant_id, normalized_term) には部分一意インデックスがあるため、
+		// 事前の重複チェックをすり抜けた同時登録はここで検出される
+		if isUniqueViolation(result.Error) {
+			logger.Warn("Duplicate term on create word",
+				"error", result.Error,
+				"tenant_id", word.TenantID.String(),
+				"term", word.Term,
+			)
+			return model.ErrConflict
+		}
 		logger.Error("Error creating word in DB",
 			"error", result.Error,
 			"tenant_id", word.TenantID.String(),
@@ -49,7 +84,7 @@ func (r *gormWordRepository) Create(ctx context.Context, tx *gorm.DB, word *mode
 func (r *gormWordRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID, wordID uuid.UUID) (*model.Word, error) {
 	logger := middleware.GetLogger(ctx)
 	var word model.Word
-	result := db.WithContext(ctx).Where("tenant_id = ? AND word_id = ?", tenantID, wordID).First(&word)
+	result := preloadWordDetails(db.WithContext(ctx)).Where("tenant_id = ? AND word_id = ?", tenantID, wordID).First(&word)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound
@@ -67,7 +102,7 @@ func (r *gormWordRepository) FindByID(ctx context.Context, db *gorm.DB, tenantID
 func (r *gormWordRepository) FindByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Word, error) {
 	logger := middleware.GetLogger(ctx)
 	var words []*model.Word
-	result := db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("created_at DESC").Find(&words)
+	result := preloadWordDetails(db.WithContext(ctx)).Where("tenant_id = ?", tenantID).Order("created_at DESC").Find(&words)
 	if result.Error != nil {
 		logger.Error("Error finding words by tenant in DB",
 			"error", result.Error,
@@ -78,24 +113,158 @@ func (r *gormWordRepository) FindByTenant(ctx context.Context, db *gorm.DB, tena
 	return words, nil
 }
 
-func (r *gormWordRepository) Update(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, updates map[string]interface{}) error {
+func (r *gormWordRepository) FindByNormalizedTerm(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerm string) (*model.Word, error) {
 	logger := middleware.GetLogger(ctx)
-	if len(updates) == 0 {
-		return nil
+	var word model.Word
+	result := db.WithContext(ctx).Where("tenant_id = ? AND normalized_term = ?", tenantID, normalizedTerm).First(&word)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound
+		}
+		logger.Error("Error finding word by term in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"normalized_term", normalizedTerm,
+		)
+		return nil, fmt.Errorf("gormWordRepository.FindByNormalizedTerm: %w", result.Error)
+	}
+	return &word, nil
+}
+
+// FindExistingNormalizedTerms は normalizedTerms のうち、有効な単語として登録済みのものを返します
+func (r *gormWordRepository) FindExistingNormalizedTerms(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerms []string) ([]string, error) {
+	logger := middleware.GetLogger(ctx)
+	var existing []string
+	if len(normalizedTerms) == 0 {
+		return existing, nil
 	}
-	result := tx.WithContext(ctx).Model(&model.Word{}).Where("tenant_id = ? AND word_id = ?", tenantID, wordID).Updates(updates)
+	result := db.WithContext(ctx).Model(&model.Word{}).
+		Where("tenant_id = ? AND normalized_term IN ?", tenantID, normalizedTerms).
+		Distinct().
+		Pluck("normalized_term", &existing)
 	if result.Error != nil {
-		logger.Error("Error updating word in DB",
+		logger.Error("Error finding existing normalized terms in DB",
 			"error", result.Error,
 			"tenant_id", tenantID.String(),
-			"word_id", wordID.String(),
 		)
-		return fmt.Errorf("gormWordRepository.Update: %w", result.Error)
+		return nil, fmt.Errorf("gormWordRepository.FindExistingNormalizedTerms: %w", result.Error)
 	}
-	if result.RowsAffected == 0 {
-		return model.ErrNotFound
+	return existing, nil
+}
+
+// Update は単語を更新し、変更があった項目 (単語・意味・タグ・詳細情報) を変更履歴 (word_revisions) に記録します。
+// 更新と履歴の追加は同一トランザクション内で行われます。Term を更新する場合は NormalizedTerm も指定する必要があります。
+func (r *gormWordRepository) Update(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID, updates map[string]interface{}) error {
+	logger := middleware.GetLogger(ctx)
+	if len(updates) == 0 {
+		return nil
 	}
-	return nil
+	if _, ok := updates["Term"]; ok {
+		if _, ok := updates["NormalizedTerm"]; !ok {
+			return errors.New("gormWordRepository.Update: NormalizedTerm is required when Term is updated")
+		}
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 変更前の値を記録するため、更新対象の行をロックして読み込む (同じ単語の履歴番号の採番もこのロックで直列化される)
+		var current model.Word
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tenant_id = ? AND word_id = ?", tenantID, wordID).
+			First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrNotFound
+			}
+			logger.Error("Error locking word for update in DB",
+				"error", err,
+				"tenant_id", tenantID.String(),
+				"word_id", wordID.String(),
+			)
+			return fmt.Errorf("gormWordRepository.Update: %w", err)
+		}
+		oldValues, newValues := diffWordRevision(&current, updates)
+
+		result := tx.Model(&model.Word{}).Where("tenant_id = ? AND word_id = ?", tenantID, wordID).Updates(updates)
+		if result.Error != nil {
+			if isUniqueViolation(result.Error) {
+				logger.Warn("Duplicate term on update word",
+					"error", result.Error,
+					"tenant_id", tenantID.String(),
+					"word_id", wordID.String(),
+				)
+				return model.ErrConflict
+			}
+			logger.Error("Error updating word in DB",
+				"error", result.Error,
+				"tenant_id", tenantID.String(),
+				"word_id", wordID.String(),
+			)
+			return fmt.Errorf("gormWordRepository.Update: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrNotFound
+		}
+
+		if newValues.IsEmpty() {
+			return nil
+		}
+		return r.revisions.Create(ctx, tx, &model.WordRevision{
+			RevisionID: uuid.New(),
+			TenantID:   tenantID,
+			WordID:     wordID,
+			OldValues:  oldValues,
+			NewValues:  newValues,
+			RequestID:  middleware.GetRequestID(ctx),
+		})
+	})
+}
+
+// diffWordRevision は updates のうち現在の値から変わる項目について、変更前後の値を返します
+func diffWordRevision(current *model.Word, updates map[string]interface{}) (oldValues, newValues model.WordRevisionValues) {
+	oldValues.Term, newValues.Term = diffString(current.Term, updates["Term"])
+	oldValues.Definition, newValues.Definition = diffString(current.Definition, updates["Definition"])
+	oldValues.Reading, newValues.Reading = diffString(current.Reading, updates["Reading"])
+	oldValues.PartOfSpeech, newValues.PartOfSpeech = diffString(current.PartOfSpeech, updates["PartOfSpeech"])
+	oldValues.Notes, newValues.Notes = diffString(current.Notes, updates["Notes"])
+
+	if v, ok := updates["Tags"]; ok {
+		var tags []string
+		switch v := v.(type) {
+		case pq.StringArray:
+			tags = v
+		case []string:
+			tags = v
+		}
+		if !slices.Equal(tags, current.Tags) {
+			oldTags := append([]string{}, current.Tags...)
+			newTags := append([]string{}, tags...)
+			oldValues.Tags, newValues.Tags = &oldTags, &newTags
+		}
+	}
+
+	if v, ok := updates["Examples"]; ok {
+		var examples []model.WordExample
+		switch v := v.(type) {
+		case model.WordExamples:
+			examples = v
+		case []model.WordExample:
+			examples = v
+		}
+		if !slices.Equal(examples, current.Examples) {
+			oldExamples := append([]model.WordExample{}, current.Examples...)
+			newExamples := append([]model.WordExample{}, examples...)
+			oldValues.Examples, newValues.Examples = &oldExamples, &newExamples
+		}
+	}
+	return oldValues, newValues
+}
+
+// diffString は文字列の項目が更新で変わる場合に、変更前後の値を返します (変わらない場合は nil)
+func diffString(current string, update interface{}) (*string, *string) {
+	v, ok := update.(string)
+	if !ok || v == current {
+		return nil, nil
+	}
+	return &current, &v
 }
 
 func (r *gormWordRepository) Delete(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error {
@@ -115,10 +284,10 @@ func (r *gormWordRepository) Delete(ctx context.Context, tx *gorm.DB, tenantID,
 	return nil
 }
 
-func (r *gormWordRepository) CheckTermExists(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, term string, excludeWordID *uuid.UUID) (bool, error) {
+func (r *gormWordRepository) CheckNormalizedTermExists(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, normalizedTerm string, excludeWordID *uuid.UUID) (bool, error) {
 	logger := middleware.GetLogger(ctx)
 	var count int64
-	query := db.WithContext(ctx).Model(&model.Word{}).Where("tenant_id = ? AND term = ?", tenantID, term)
+	query := db.WithContext(ctx).Model(&model.Word{}).Where("tenant_id = ? AND normalized_term = ?", tenantID, normalizedTerm)
 	if excludeWordID != nil {
 		query = query.Where("word_id != ?", *excludeWordID)
 	}
@@ -127,9 +296,327 @@ func (r *gormWordRepository) CheckTermExists(ctx context.Context, db *gorm.DB, t
 		logger.Error("Error checking term existence in DB",
 			"error", result.Error,
 			"tenant_id", tenantID.String(),
-			"term", term,
+			"normalized_term", normalizedTerm,
 		)
-		return false, fmt.Errorf("gormWordRepository.CheckTermExists: %w", result.Error)
+		return false, fmt.Errorf("gormWordRepository.CheckNormalizedTermExists: %w", result.Error)
 	}
 	return count > 0, nil
 }
+
+// StreamWithProgress は条件に合う単語を学習進捗と結合して1行ずつ fn に渡します。
+// 全件をメモリに載せないよう、DBのカーソルから読み進めます。fn がエラーを返した時点で中断します。
+func (r *gormWordRepository) StreamWithProgress(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, filter model.WordFilter, fn func(*model.WordWithProgress) error) error {
+	logger := middleware.GetLogger(ctx)
+
+	query := wordQuery(db.WithContext(ctx), filter).
+		Select("words.word_id, words.term, words.definition, words.tags, learning_progress.level, learning_progress.next_review_date, words.created_at, words.updated_at").
+		Joins(joinRecognitionProgress, model.DirectionRecognition).
+		Where("words.tenant_id = ?", tenantID)
+	query = applyWordFilter(query, filter).Order("words.created_at ASC, words.word_id ASC")
+
+	rows, err := query.Rows()
+	if err != nil {
+		logger.Error("Error streaming words in DB",
+			"error", err,
+			"tenant_id", tenantID.String(),
+		)
+		return fmt.Errorf("gormWordRepository.StreamWithProgress: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row model.WordWithProgress
+		if err := db.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("gormWordRepository.StreamWithProgress: %w", err)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error iterating words in DB",
+			"error", err,
+			"tenant_id", tenantID.String(),
+		)
+		return fmt.Errorf("gormWordRepository.StreamWithProgress: %w", err)
+	}
+	return nil
+}
+
+// FindIDsByFilter は条件に合う単語のIDを作成日時の古い順に最大 limit 件返します
+func (r *gormWordRepository) FindIDsByFilter(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, filter model.WordFilter, limit int) ([]uuid.UUID, error) {
+	logger := middleware.GetLogger(ctx)
+	var ids []uuid.UUID
+	query := wordQuery(db.WithContext(ctx), filter).
+		Joins(joinRecognitionProgress, model.DirectionRecognition).
+		Where("words.tenant_id = ?", tenantID)
+	result := applyWordFilter(query, filter).
+		Order("words.created_at ASC, words.word_id ASC").
+		Limit(limit).
+		Pluck("words.word_id", &ids)
+	if result.Error != nil {
+		logger.Error("Error finding word IDs by filter in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRepository.FindIDsByFilter: %w", result.Error)
+	}
+	return ids, nil
+}
+
+func (r *gormWordRepository) FindByIDs(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordIDs []uuid.UUID) ([]*model.Word, error) {
+	logger := middleware.GetLogger(ctx)
+	var words []*model.Word
+	result := db.WithContext(ctx).Where("tenant_id = ? AND word_id IN ?", tenantID, wordIDs).Find(&words)
+	if result.Error != nil {
+		logger.Error("Error finding words by IDs in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRepository.FindByIDs: %w", result.Error)
+	}
+	return words, nil
+}
+
+// FindDeletedByIDs は論理削除済みの単語のうち、指定したIDのものを返します
+func (r *gormWordRepository) FindDeletedByIDs(ctx context.Context, db *gorm.DB, tenantID uuid.UUID, wordIDs []uuid.UUID) ([]*model.Word, error) {
+	logger := middleware.GetLogger(ctx)
+	var words []*model.Word
+	result := db.WithContext(ctx).Unscoped().
+		Where("tenant_id = ? AND word_id IN ? AND deleted_at IS NOT NULL", tenantID, wordIDs).
+		Find(&words)
+	if result.Error != nil {
+		logger.Error("Error finding deleted words by IDs in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRepository.FindDeletedByIDs: %w", result.Error)
+	}
+	return words, nil
+}
+
+// Restore は論理削除済みの単語を復元します
+func (r *gormWordRepository) Restore(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Unscoped().Model(&model.Word{}).
+		Where("tenant_id = ? AND word_id = ? AND deleted_at IS NOT NULL", tenantID, wordID).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		// 復元すると有効な単語と重複する場合
+		if isUniqueViolation(result.Error) {
+			logger.Warn("Duplicate term on restore word",
+				"error", result.Error,
+				"tenant_id", tenantID.String(),
+				"word_id", wordID.String(),
+			)
+			return model.ErrConflict
+		}
+		logger.Error("Error restoring word in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormWordRepository.Restore: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// FindDeletedByTenant はゴミ箱 (論理削除済み) の単語を削除日時の新しい順に返します
+func (r *gormWordRepository) FindDeletedByTenant(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) ([]*model.Word, error) {
+	logger := middleware.GetLogger(ctx)
+	var words []*model.Word
+	result := db.WithContext(ctx).Unscoped().
+		Where("tenant_id = ? AND deleted_at IS NOT NULL", tenantID).
+		Order("deleted_at DESC").
+		Find(&words)
+	if result.Error != nil {
+		logger.Error("Error finding deleted words by tenant in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRepository.FindDeletedByTenant: %w", result.Error)
+	}
+	return words, nil
+}
+
+// Purge は論理削除済みの単語を物理削除します (学習進捗は外部キーの ON DELETE CASCADE で削除される)
+func (r *gormWordRepository) Purge(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Unscoped().
+		Where("tenant_id = ? AND word_id = ? AND deleted_at IS NOT NULL", tenantID, wordID).
+		Delete(&model.Word{})
+	if result.Error != nil {
+		logger.Error("Error purging word in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormWordRepository.Purge: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// PurgeAllDeleted はテナントの論理削除済みの単語をすべて物理削除し、削除した単語のIDを返します
+func (r *gormWordRepository) PurgeAllDeleted(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) ([]uuid.UUID, error) {
+	logger := middleware.GetLogger(ctx)
+	var purged []model.Word
+	result := tx.WithContext(ctx).Unscoped().
+		Clauses(returningWordID).
+		Where("tenant_id = ? AND deleted_at IS NOT NULL", tenantID).
+		Delete(&purged)
+	if result.Error != nil {
+		logger.Error("Error purging all deleted words in DB",
+			"error", result.Error,
+			"tenant_id", tenantID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRepository.PurgeAllDeleted: %w", result.Error)
+	}
+	return wordIDs(purged), nil
+}
+
+// PurgeDeletedBefore は全テナントを対象に、before より前に論理削除された単語を最大 limit 件物理削除し、削除した単語のIDを返します。
+// 一度に大量の行をロックしないよう、呼び出し側で件数が0になるまで繰り返すことを想定しています。
+func (r *gormWordRepository) PurgeDeletedBefore(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]uuid.UUID, error) {
+	logger := middleware.GetLogger(ctx)
+	targets := tx.Unscoped().Model(&model.Word{}).
+		Select("word_id").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Limit(limit)
+	var purged []model.Word
+	result := tx.WithContext(ctx).Unscoped().
+		Clauses(returningWordID).
+		Where("word_id IN (?)", targets).
+		Delete(&purged)
+	if result.Error != nil {
+		logger.Error("Error purging expired deleted words in DB",
+			"error", result.Error,
+			"before", before,
+		)
+		return nil, fmt.Errorf("gormWordRepository.PurgeDeletedBefore: %w", result.Error)
+	}
+	return wordIDs(purged), nil
+}
+
+// returningWordID は物理削除した単語のIDを受け取るための RETURNING 句。
+// 添付ファイルの本体はDBの外にあるため、呼び出し側で削除した単語のIDを使って後片付けする
+var returningWordID = clause.Returning{Columns: []clause.Column{{Name: "word_id"}}}
+
+func wordIDs(words []model.Word) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(words))
+	for _, w := range words {
+		ids = append(ids, w.WordID)
+	}
+	return ids
+}
+
+// FindAllAfter は全テナントの単語 (論理削除済みを含む) を word_id の昇順に、afterWordID より後から最大 limit 件返します。
+// 全件を走査するバッチ処理でキーセットページネーションに使います。
+func (r *gormWordRepository) FindAllAfter(ctx context.Context, db *gorm.DB, afterWordID uuid.UUID, limit int) ([]*model.Word, error) {
+	logger := middleware.GetLogger(ctx)
+	var words []*model.Word
+	result := db.WithContext(ctx).Unscoped().
+		Where("word_id > ?", afterWordID).
+		Order("word_id ASC").
+		Limit(limit).
+		Find(&words)
+	if result.Error != nil {
+		logger.Error("Error finding words after ID in DB",
+			"error", result.Error,
+			"after_word_id", afterWordID.String(),
+		)
+		return nil, fmt.Errorf("gormWordRepository.FindAllAfter: %w", result.Error)
+	}
+	return words, nil
+}
+
+// UpdateNormalizedTerm は正規化済みの単語だけを更新します (updated_at は変更しない)
+func (r *gormWordRepository) UpdateNormalizedTerm(ctx context.Context, tx *gorm.DB, wordID uuid.UUID, normalizedTerm string) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Unscoped().Model(&model.Word{}).
+		Where("word_id = ?", wordID).
+		UpdateColumn("normalized_term", normalizedTerm)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return model.ErrConflict
+		}
+		logger.Error("Error updating normalized term in DB",
+			"error", result.Error,
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormWordRepository.UpdateNormalizedTerm: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateAutoReading は自動生成した読みだけを更新します (updated_at は変更せず、変更履歴にも記録しない)。
+// 利用者が入力した読み (reading_manual) は更新せず、ErrNotFound を返します。
+func (r *gormWordRepository) UpdateAutoReading(ctx context.Context, tx *gorm.DB, wordID uuid.UUID, reading string) error {
+	logger := middleware.GetLogger(ctx)
+	result := tx.WithContext(ctx).Unscoped().Model(&model.Word{}).
+		Where("word_id = ? AND reading_manual = ?", wordID, false).
+		UpdateColumn("reading", reading)
+	if result.Error != nil {
+		logger.Error("Error updating auto reading in DB",
+			"error", result.Error,
+			"word_id", wordID.String(),
+		)
+		return fmt.Errorf("gormWordRepository.UpdateAutoReading: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// preloadWordDetails は単語の語義を表示順に、添付ファイルを作成順に読み込むよう設定したクエリを返します
+func preloadWordDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Senses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("word_senses.position ASC")
+	}).Preload("Attachments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("word_attachments.created_at ASC")
+	})
+}
+
+// wordQuery は filter.OnlyDeleted に応じて、有効な単語または論理削除済みの単語を対象にしたクエリを返します
+func wordQuery(db *gorm.DB, filter model.WordFilter) *gorm.DB {
+	if filter.OnlyDeleted {
+		return db.Unscoped().Model(&model.Word{}).Where("words.deleted_at IS NOT NULL")
+	}
+	return db.Model(&model.Word{})
+}
+
+// joinRecognitionProgress は単語に通常の出題 (recognition) の学習進捗を結合します。
+// 穴埋めなど他の出題形式の進捗も同じテーブルにあるため、出題形式で絞らないと単語が重複する
+const joinRecognitionProgress = "LEFT JOIN learning_progress ON learning_progress.word_id = words.word_id AND learning_progress.tenant_id = words.tenant_id AND learning_progress.direction = ?"
+
+// applyWordFilter は words (と LEFT JOIN した learning_progress) に対するクエリへ絞り込み条件を追加します
+func applyWordFilter(query *gorm.DB, filter model.WordFilter) *gorm.DB {
+	if filter.Level != nil {
+		query = query.Where("learning_progress.level = ?", *filter.Level)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("words.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("words.created_at < ?", *filter.CreatedTo)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where("(words.term ILIKE ? OR words.definition ILIKE ?)", pattern, pattern)
+	}
+	return query
+}
+
+// escapeLike は LIKE/ILIKE のパターンで特別な意味を持つ文字をエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
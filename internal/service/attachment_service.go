@@ -0,0 +1,254 @@
+//go:generate mockery --name AttachmentService --output ./mocks --outpkg mocks --case=underscore
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"go_4_vocab_keep/internal/blobstore"
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// sniffLen は http.DetectContentType が参照するファイル先頭のバイト数
+const sniffLen = 512
+
+// allowedAttachmentTypes はアップロードを受け付ける Content-Type と、その添付ファイルの種類
+var allowedAttachmentTypes = map[string]string{
+	"audio/mpeg": model.AttachmentKindAudio,
+	"audio/mp4":  model.AttachmentKindAudio,
+	"audio/ogg":  model.AttachmentKindAudio,
+	"audio/wav":  model.AttachmentKindAudio,
+	"audio/webm": model.AttachmentKindAudio,
+	"image/png":  model.AttachmentKindImage,
+	"image/jpeg": model.AttachmentKindImage,
+	"image/gif":  model.AttachmentKindImage,
+	"image/webp": model.AttachmentKindImage,
+}
+
+// sniffedTypeAliases は http.DetectContentType の判定結果を allowedAttachmentTypes の表記に揃えるための対応表。
+// コンテナ形式 (mp4/webm/ogg) は映像か音声かを先頭だけでは区別できないため、音声として扱う
+var sniffedTypeAliases = map[string]string{
+	"audio/wave":      "audio/wav",
+	"application/ogg": "audio/ogg",
+	"video/mp4":       "audio/mp4",
+	"video/webm":      "audio/webm",
+}
+
+// UploadAttachmentInput はアップロードされたファイルです
+type UploadAttachmentInput struct {
+	Filename    string
+	ContentType string // クライアントが申告した Content-Type
+	Size        int64
+	Body        io.Reader
+}
+
+// AttachmentService は単語の添付ファイル (発音の音声・画像) を扱います
+type AttachmentService interface {
+	Upload(ctx context.Context, tenantID, wordID uuid.UUID, input *UploadAttachmentInput) (*model.WordAttachment, error)
+	List(ctx context.Context, tenantID, wordID uuid.UUID) ([]model.WordAttachment, error)
+	GetURL(ctx context.Context, tenantID, wordID, attachmentID uuid.UUID) (*model.AttachmentURLResponse, error)
+	Delete(ctx context.Context, tenantID, wordID, attachmentID uuid.UUID) error
+}
+
+type attachmentService struct {
+	db             *gorm.DB
+	wordRepo       repository.WordRepository
+	attachmentRepo repository.AttachmentRepository
+	blobs          blobstore.BlobStore
+	cfg            *config.Config
+}
+
+func NewAttachmentService(db *gorm.DB, wordRepo repository.WordRepository, attachmentRepo repository.AttachmentRepository, blobs blobstore.BlobStore, cfg *config.Config) AttachmentService {
+	return &attachmentService{
+		db:             db,
+		wordRepo:       wordRepo,
+		attachmentRepo: attachmentRepo,
+		blobs:          blobs,
+		cfg:            cfg,
+	}
+}
+
+// Upload はファイルの種類とサイズを確認してストレージに保存し、単語の添付ファイルとして登録します。
+// 種類は申告された Content-Type とファイルの先頭の内容の両方で判定し、食い違う場合は受け付けません。
+func (s *attachmentService) Upload(ctx context.Context, tenantID, wordID uuid.UUID, input *UploadAttachmentInput) (*model.WordAttachment, error) {
+	logger := middleware.GetLogger(ctx)
+
+	declared, _, err := mime.ParseMediaType(input.ContentType)
+	if err != nil {
+		declared = ""
+	}
+	kind, ok := allowedAttachmentTypes[declared]
+	if !ok {
+		return nil, model.NewAppError("UNSUPPORTED_MEDIA_TYPE", "対応していないファイル形式です。音声 (mp3, m4a, ogg, wav, webm) または画像 (png, jpeg, gif, webp) を指定してください。", "file", model.ErrInvalidInput)
+	}
+
+	maxSize := s.cfg.Attachment.MaxImageSize
+	if kind == model.AttachmentKindAudio {
+		maxSize = s.cfg.Attachment.MaxAudioSize
+	}
+	if input.Size <= 0 {
+		return nil, model.NewAppError("VALIDATION_ERROR", "空のファイルはアップロードできません。", "file", model.ErrInvalidInput)
+	}
+	if input.Size > maxSize {
+		return nil, model.NewAppError("FILE_TOO_LARGE", fmt.Sprintf("ファイルサイズが大きすぎます。%dKB以下のファイルを指定してください。", maxSize>>10), "file", model.ErrInvalidInput)
+	}
+
+	// 先頭を読んで実際の形式を判定し、読んだ分を戻して保存する
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(input.Body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, model.NewAppError("INVALID_REQUEST_BODY", "ファイルを読み込めませんでした。", "file", model.ErrInvalidInput)
+	}
+	head = head[:n]
+	contentType, ok := sniffAttachmentType(head, declared)
+	if !ok || allowedAttachmentTypes[contentType] != kind {
+		logger.Warn("Attachment content does not match declared type", "declared", declared, "sniffed", http.DetectContentType(head))
+		return nil, model.NewAppError("UNSUPPORTED_MEDIA_TYPE", "ファイルの内容が指定された形式と一致しません。", "file", model.ErrInvalidInput)
+	}
+
+	if err := s.ensureWordExists(ctx, tenantID, wordID); err != nil {
+		return nil, err
+	}
+	count, err := s.attachmentRepo.CountByWordID(ctx, s.db, tenantID, wordID)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "添付ファイルの取得に失敗しました。", "", err)
+	}
+	if count >= int64(s.cfg.Attachment.MaxPerWord) {
+		return nil, model.NewAppError("TOO_MANY_ATTACHMENTS", fmt.Sprintf("1つの単語に添付できるファイルは%d件までです。", s.cfg.Attachment.MaxPerWord), "file", model.ErrInvalidInput)
+	}
+
+	attachment := &model.WordAttachment{
+		AttachmentID: uuid.New(),
+		TenantID:     tenantID,
+		WordID:       wordID,
+		Kind:         kind,
+		ContentType:  contentType,
+		SizeBytes:    input.Size,
+		Filename:     input.Filename,
+	}
+	attachment.StorageKey = model.AttachmentStorageKey(wordID, attachment.AttachmentID)
+
+	body := io.MultiReader(bytes.NewReader(head), input.Body)
+	if err := s.blobs.Put(ctx, attachment.StorageKey, body, input.Size, contentType); err != nil {
+		logger.Error("Failed to store attachment", "error", err, "word_id", wordID)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "ファイルの保存に失敗しました。", "", err)
+	}
+
+	if err := s.attachmentRepo.Create(ctx, s.db, attachment); err != nil {
+		// 行を作れなかったファイルは参照されないため、すぐに消しておく
+		if delErr := s.blobs.Delete(context.WithoutCancel(ctx), attachment.StorageKey); delErr != nil {
+			logger.Warn("Failed to delete orphaned attachment blob", "error", delErr, "key", attachment.StorageKey)
+		}
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "添付ファイルの登録に失敗しました。", "", err)
+	}
+
+	logger.Info("Attachment uploaded", "word_id", wordID, "attachment_id", attachment.AttachmentID, "kind", kind, "size", input.Size)
+	return attachment, nil
+}
+
+// sniffAttachmentType はファイルの先頭から判定した Content-Type を返します。
+// MP3 はID3タグがないと判定できないため、判定できなかった場合に限り申告された audio/mpeg を信用します。
+func sniffAttachmentType(head []byte, declared string) (string, bool) {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if alias, ok := sniffedTypeAliases[sniffed]; ok {
+		sniffed = alias
+	}
+	if _, ok := allowedAttachmentTypes[sniffed]; ok {
+		return sniffed, true
+	}
+	if sniffed == "application/octet-stream" && declared == "audio/mpeg" {
+		return declared, true
+	}
+	return "", false
+}
+
+// List は単語の添付ファイルを作成順に返します
+func (s *attachmentService) List(ctx context.Context, tenantID, wordID uuid.UUID) ([]model.WordAttachment, error) {
+	if err := s.ensureWordExists(ctx, tenantID, wordID); err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.FindByWordID(ctx, s.db, tenantID, wordID)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "添付ファイルの取得に失敗しました。", "", err)
+	}
+	return attachments, nil
+}
+
+// GetURL は添付ファイルをダウンロードするための、有効期限付きのURLを返します
+func (s *attachmentService) GetURL(ctx context.Context, tenantID, wordID, attachmentID uuid.UUID) (*model.AttachmentURLResponse, error) {
+	logger := middleware.GetLogger(ctx)
+	attachment, err := s.findAttachment(ctx, tenantID, wordID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.cfg.Storage.SignedURLTTL
+	url, err := s.blobs.SignedURL(ctx, attachment.StorageKey, ttl)
+	if err != nil {
+		logger.Error("Failed to sign attachment URL", "error", err, "attachment_id", attachmentID)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "ダウンロードURLの発行に失敗しました。", "", err)
+	}
+	return &model.AttachmentURLResponse{URL: url, ExpiresAt: time.Now().Add(ttl).UTC()}, nil
+}
+
+// Delete は添付ファイルを削除します。ストレージのファイルの削除に失敗しても、添付ファイルの登録は削除済みとして扱います。
+func (s *attachmentService) Delete(ctx context.Context, tenantID, wordID, attachmentID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	attachment, err := s.findAttachment(ctx, tenantID, wordID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(ctx, s.db, tenantID, wordID, attachmentID); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("NOT_FOUND", "指定された添付ファイルが見つかりません。", "attachment_id", model.ErrNotFound)
+		}
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "添付ファイルの削除に失敗しました。", "", err)
+	}
+	if err := s.blobs.Delete(context.WithoutCancel(ctx), attachment.StorageKey); err != nil {
+		logger.Warn("Failed to delete attachment blob", "error", err, "key", attachment.StorageKey)
+	}
+
+	logger.Info("Attachment deleted", "word_id", wordID, "attachment_id", attachmentID)
+	return nil
+}
+
+// ensureWordExists はゴミ箱にない単語であることを確認します
+func (s *attachmentService) ensureWordExists(ctx context.Context, tenantID, wordID uuid.UUID) error {
+	words, err := s.wordRepo.FindByIDs(ctx, s.db, tenantID, []uuid.UUID{wordID})
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の取得に失敗しました。", "", err)
+	}
+	if len(words) == 0 {
+		return model.NewAppError("NOT_FOUND", "指定された単語が見つかりません。", "word_id", model.ErrNotFound)
+	}
+	return nil
+}
+
+// findAttachment はゴミ箱にない単語の添付ファイルを返します
+func (s *attachmentService) findAttachment(ctx context.Context, tenantID, wordID, attachmentID uuid.UUID) (*model.WordAttachment, error) {
+	if err := s.ensureWordExists(ctx, tenantID, wordID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.FindByID(ctx, s.db, tenantID, wordID, attachmentID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.NewAppError("NOT_FOUND", "指定された添付ファイルが見つかりません。", "attachment_id", model.ErrNotFound)
+		}
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "添付ファイルの取得に失敗しました。", "", err)
+	}
+	return attachment, nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"go_4_vocab_keep/internal/blobstore"
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// pngHeader は http.DetectContentType が image/png と判定するファイルの先頭
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func setupAttachmentServiceWithMocks(t *testing.T) (AttachmentService, *gorm.DB, *mocks.WordRepository, *mocks.AttachmentRepository, blobstore.BlobStore) {
+	db := setupTestDBWord()
+	mockWordRepo := new(mocks.WordRepository)
+	mockAttachmentRepo := new(mocks.AttachmentRepository)
+	blobs, err := blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/api/v1/blobs", []byte("test-signing-key"))
+	require.NoError(t, err)
+	cfg := &config.Config{Attachment: config.AttachmentConfig{MaxAudioSize: 1 << 20, MaxImageSize: 1 << 10, MaxPerWord: 2}}
+	return NewAttachmentService(db, mockWordRepo, mockAttachmentRepo, blobs, cfg), db, mockWordRepo, mockAttachmentRepo, blobs
+}
+
+// --- Test Upload ---
+func Test_attachmentService_Upload(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	wordID := uuid.New()
+	pngBody := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 100)...)
+
+	tests := []struct {
+		name        string
+		input       func() *UploadAttachmentInput
+		setupMock   func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository)
+		wantErrCode string
+		wantKind    string
+		wantType    string
+		wantStored  bool // ストレージにファイルが残っているか
+	}{
+		{
+			name: "正常系: 画像をアップロード",
+			input: func() *UploadAttachmentInput {
+				return &UploadAttachmentInput{Filename: "a.png", ContentType: "image/png", Size: int64(len(pngBody)), Body: bytes.NewReader(pngBody)}
+			},
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+				attachmentRepo.On("CountByWordID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(int64(1), nil).Once()
+				attachmentRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.WordAttachment")).Return(nil).Once()
+			},
+			wantKind:   model.AttachmentKindImage,
+			wantType:   "image/png",
+			wantStored: true,
+		},
+		{
+			name: "正常系: 内容から判定できない MP3 は申告された形式を使う",
+			input: func() *UploadAttachmentInput {
+				body := bytes.Repeat([]byte{0xff, 0xfb, 0x90, 0x00}, 50)
+				return &UploadAttachmentInput{Filename: "a.mp3", ContentType: "audio/mpeg", Size: int64(len(body)), Body: bytes.NewReader(body)}
+			},
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+				attachmentRepo.On("CountByWordID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(int64(0), nil).Once()
+				attachmentRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.WordAttachment")).Return(nil).Once()
+			},
+			wantKind:   model.AttachmentKindAudio,
+			wantType:   "audio/mpeg",
+			wantStored: true,
+		},
+		{
+			name: "異常系: 対応していない形式",
+			input: func() *UploadAttachmentInput {
+				return &UploadAttachmentInput{Filename: "a.txt", ContentType: "text/plain", Size: 5, Body: bytes.NewReader([]byte("hello"))}
+			},
+			wantErrCode: "UNSUPPORTED_MEDIA_TYPE",
+		},
+		{
+			name: "異常系: 内容が申告された形式と一致しない",
+			input: func() *UploadAttachmentInput {
+				body := []byte("<html><body>not an image</body></html>")
+				return &UploadAttachmentInput{Filename: "a.png", ContentType: "image/png", Size: int64(len(body)), Body: bytes.NewReader(body)}
+			},
+			wantErrCode: "UNSUPPORTED_MEDIA_TYPE",
+		},
+		{
+			name: "異常系: ファイルサイズが上限を超える",
+			input: func() *UploadAttachmentInput {
+				return &UploadAttachmentInput{Filename: "a.png", ContentType: "image/png", Size: 2 << 10, Body: bytes.NewReader(pngBody)}
+			},
+			wantErrCode: "FILE_TOO_LARGE",
+		},
+		{
+			name: "異常系: 単語が見つからない (ゴミ箱を含む)",
+			input: func() *UploadAttachmentInput {
+				return &UploadAttachmentInput{Filename: "a.png", ContentType: "image/png", Size: int64(len(pngBody)), Body: bytes.NewReader(pngBody)}
+			},
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{}, nil).Once()
+			},
+			wantErrCode: "NOT_FOUND",
+		},
+		{
+			name: "異常系: 単語の添付ファイルが上限に達している",
+			input: func() *UploadAttachmentInput {
+				return &UploadAttachmentInput{Filename: "a.png", ContentType: "image/png", Size: int64(len(pngBody)), Body: bytes.NewReader(pngBody)}
+			},
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+				attachmentRepo.On("CountByWordID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(int64(2), nil).Once()
+			},
+			wantErrCode: "TOO_MANY_ATTACHMENTS",
+		},
+		{
+			name: "異常系: 登録に失敗したらストレージのファイルを消す",
+			input: func() *UploadAttachmentInput {
+				return &UploadAttachmentInput{Filename: "a.png", ContentType: "image/png", Size: int64(len(pngBody)), Body: bytes.NewReader(pngBody)}
+			},
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+				attachmentRepo.On("CountByWordID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(int64(0), nil).Once()
+				attachmentRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.WordAttachment")).Return(errors.New("db error")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+			wantStored:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentService, _, mockWordRepo, mockAttachmentRepo, blobs := setupAttachmentServiceWithMocks(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockWordRepo, mockAttachmentRepo)
+			}
+
+			attachment, err := attachmentService.Upload(ctx, tenantID, wordID, tt.input())
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				assert.Nil(t, attachment)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, attachment)
+				assert.Equal(t, tt.wantKind, attachment.Kind)
+				assert.Equal(t, tt.wantType, attachment.ContentType)
+				assert.Equal(t, model.AttachmentStorageKey(wordID, attachment.AttachmentID), attachment.StorageKey)
+			}
+
+			// 登録に失敗した場合に限り、Create に渡されたキーのファイルが消えていることを確認する
+			for _, call := range mockAttachmentRepo.Calls {
+				if call.Method != "Create" {
+					continue
+				}
+				key := call.Arguments.Get(2).(*model.WordAttachment).StorageKey
+				obj, openErr := blobs.Open(ctx, key)
+				if tt.wantStored {
+					require.NoError(t, openErr)
+					obj.Body.Close()
+				} else {
+					assert.ErrorIs(t, openErr, blobstore.ErrNotFound)
+				}
+			}
+
+			mockWordRepo.AssertExpectations(t)
+			mockAttachmentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test List ---
+func Test_attachmentService_List(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	wordID := uuid.New()
+	attachments := []model.WordAttachment{
+		{AttachmentID: uuid.New(), TenantID: tenantID, WordID: wordID, Kind: model.AttachmentKindAudio},
+		{AttachmentID: uuid.New(), TenantID: tenantID, WordID: wordID, Kind: model.AttachmentKindImage},
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository)
+		wantErrCode string
+		wantLen     int
+	}{
+		{
+			name: "正常系: 添付ファイルを取得",
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+				attachmentRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(attachments, nil).Once()
+			},
+			wantLen: 2,
+		},
+		{
+			name: "異常系: 単語が見つからない",
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{}, nil).Once()
+			},
+			wantErrCode: "NOT_FOUND",
+		},
+		{
+			name: "異常系: リポジトリでDBエラー",
+			setupMock: func(wordRepo *mocks.WordRepository, attachmentRepo *mocks.AttachmentRepository) {
+				wordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+				attachmentRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, errors.New("db error")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentService, _, mockWordRepo, mockAttachmentRepo, _ := setupAttachmentServiceWithMocks(t)
+			tt.setupMock(mockWordRepo, mockAttachmentRepo)
+
+			res, err := attachmentService.List(ctx, tenantID, wordID)
+
+			if tt.wantErrCode != "" {
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, res, tt.wantLen)
+			}
+			mockWordRepo.AssertExpectations(t)
+			mockAttachmentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test Delete ---
+func Test_attachmentService_Delete(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	wordID := uuid.New()
+	attachmentID := uuid.New()
+	key := model.AttachmentStorageKey(wordID, attachmentID)
+
+	t.Run("正常系: 添付ファイルとストレージのファイルを削除", func(t *testing.T) {
+		attachmentService, _, mockWordRepo, mockAttachmentRepo, blobs := setupAttachmentServiceWithMocks(t)
+		require.NoError(t, blobs.Put(ctx, key, bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png"))
+		mockWordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+		mockAttachmentRepo.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, attachmentID).
+			Return(&model.WordAttachment{AttachmentID: attachmentID, WordID: wordID, StorageKey: key}, nil).Once()
+		mockAttachmentRepo.On("Delete", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, attachmentID).Return(nil).Once()
+
+		err := attachmentService.Delete(ctx, tenantID, wordID, attachmentID)
+
+		require.NoError(t, err)
+		_, openErr := blobs.Open(ctx, key)
+		assert.ErrorIs(t, openErr, blobstore.ErrNotFound)
+		mockWordRepo.AssertExpectations(t)
+		mockAttachmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 添付ファイルが見つからない", func(t *testing.T) {
+		attachmentService, _, mockWordRepo, mockAttachmentRepo, _ := setupAttachmentServiceWithMocks(t)
+		mockWordRepo.On("FindByIDs", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, []uuid.UUID{wordID}).Return([]*model.Word{{WordID: wordID}}, nil).Once()
+		mockAttachmentRepo.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, attachmentID).Return(nil, model.ErrNotFound).Once()
+
+		err := attachmentService.Delete(ctx, tenantID, wordID, attachmentID)
+
+		assertAppErrorCode(t, err, "NOT_FOUND")
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockWordRepo.AssertExpectations(t)
+		mockAttachmentRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// emailChangeTokenTTL はメールアドレスの変更の確認リンクの有効期限
+const emailChangeTokenTTL = 24 * time.Hour
+
+// recentLoginWindow はパスワードのないアカウントで、ログインし直さずにメールアドレスやログイン方法を変更できるログインからの時間
+const recentLoginWindow = 10 * time.Minute
+
+// ChangePassword は現在のパスワードを確認してパスワードを変更し、リクエストのセッション以外をすべてログアウトさせます
+func (s *authService) ChangePassword(ctx context.Context, tenantID, sessionID uuid.UUID, req *model.ChangePasswordRequest) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	identity, err := s.verifyCurrentPassword(ctx, tenantID, req.CurrentPassword, "current_password")
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "パスワードの処理中にエラーが発生しました。", "", err)
+	}
+
+	var revoked []uuid.UUID
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.identityRepo.UpdatePasswordHash(ctx, tx, identity.ID, string(hashedPassword)); err != nil {
+			return err
+		}
+		revoked, err = s.revokeOtherSessions(ctx, tx, tenantID, sessionID)
+		return err
+	})
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "パスワードの変更に失敗しました。", "", err)
+	}
+	for _, id := range revoked {
+		s.sessionCache.Invalidate(id)
+	}
+	logger.Info("Password changed", "revoked_sessions", len(revoked))
+
+	// 本人以外による変更に気づけるよう通知する (送信に失敗しても変更は取り消さない)
+	if tenant, err := s.tenantRepo.FindByID(ctx, s.db, tenantID); err == nil {
+		subject := "【Kioku】パスワードが変更されました"
+		body := "Kiokuのパスワードが変更されました。他の端末はログアウトされています。\n\nお心当たりがない場合は、パスワードの再設定を行ってください。"
+		if err := s.mailer.Send(ctx, tenant.Email, subject, body); err != nil {
+			logger.Error("Failed to send password change notice", "error", err)
+		}
+	}
+	return nil
+}
+
+// RequestEmailChange は新しいメールアドレスに確認リンクを、現在のメールアドレスに変更の通知を送ります。
+// リンクを開くまでメールアドレスは変わりません。パスワードが設定されている場合はパスワードの確認が必要です。
+// パスワードのないアカウントは、盗まれたトークンで変更されないよう、直近にログインしたセッションからの要求に限ります。
+func (s *authService) RequestEmailChange(ctx context.Context, tenantID, sessionID uuid.UUID, req *model.ChangeEmailRequest) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	tenant, err := s.tenantRepo.FindByID(ctx, s.db, tenantID)
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザー情報の取得に失敗しました。", "", err)
+	}
+	if strings.EqualFold(tenant.Email, req.NewEmail) {
+		return model.NewAppError("SAME_EMAIL", "現在と同じメールアドレスです。", "new_email", model.ErrInvalidInput)
+	}
+
+	if err := s.confirmAccountOwner(ctx, tenantID, sessionID, req.Password, "password"); err != nil {
+		return err
+	}
+
+	if _, err := s.tenantRepo.FindByEmail(ctx, s.db, req.NewEmail); err == nil {
+		return model.NewAppError("EMAIL_ALREADY_IN_USE", "このメールアドレスは既に使用されています。", "new_email", model.ErrConflict)
+	} else if !errors.Is(err, model.ErrNotFound) {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの生成に失敗しました。", "", err)
+	}
+	tokenString := hex.EncodeToString(tokenBytes)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 確認待ちの変更は最新の1件だけを有効にする
+		if err := s.tokenRepo.DeleteEmailChangeTokensByTenantID(ctx, tx, tenantID); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの削除に失敗しました。", "", err)
+		}
+		token := &model.EmailChangeToken{Token: tokenString, TenantID: tenantID, NewEmail: req.NewEmail, ExpiresAt: time.Now().Add(emailChangeTokenTTL)}
+		if err := s.tokenRepo.CreateEmailChangeToken(ctx, tx, token); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの保存に失敗しました。", "", err)
+		}
+
+		confirmURL := fmt.Sprintf("%s/confirm-email-change?token=%s", s.cfg.App.FrontendURL, tokenString)
+		subject := "【Kioku】メールアドレスの変更の確認"
+		body := fmt.Sprintf("Kiokuのメールアドレスをこのアドレスに変更するには、以下のリンクをクリックしてください:\n%s\n\nこのリンクの有効期限は24時間です。お心当たりがない場合は、このメールを破棄してください。", confirmURL)
+		if err := s.mailer.Send(ctx, req.NewEmail, subject, body); err != nil {
+			return model.NewAppError("EMAIL_SEND_FAILED", "確認メールの送信に失敗しました。", "", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	subject := "【Kioku】メールアドレスの変更が要求されました"
+	body := fmt.Sprintf("Kiokuのメールアドレスを %s に変更する手続きが行われました。新しいメールアドレスに送信したリンクを開くと変更が完了します。\n\nお心当たりがない場合は、パスワードを変更してください。", req.NewEmail)
+	if err := s.mailer.Send(ctx, tenant.Email, subject, body); err != nil {
+		logger.Error("Failed to send email change notice to old address", "error", err)
+	}
+
+	logger.Info("Email change requested", "new_email", req.NewEmail)
+	return nil
+}
+
+// confirmAccountOwner はログイン方法やメールアドレスなど、アカウントを乗っ取れる設定を変更する前に本人であることを確認します。
+// パスワードが設定されている場合はパスワードを確認し、パスワードのないアカウント (外部のプロバイダでのログインのみ) は
+// ログインし直したことを本人の確認とします。
+func (s *authService) confirmAccountOwner(ctx context.Context, tenantID, sessionID uuid.UUID, password, field string) error {
+	identity, err := s.identityRepo.FindByTenantIDAndProvider(ctx, s.db, tenantID, model.AuthProviderLocal)
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if identity != nil && identity.PasswordHash != nil {
+		_, err := s.verifyCurrentPassword(ctx, tenantID, password, field)
+		return err
+	}
+	return s.requireRecentLogin(ctx, tenantID, sessionID)
+}
+
+// requireRecentLogin はセッションのログインが recentLoginWindow 以内であることを確認します。
+// リフレッシュトークンで更新してもセッションの作成日時は変わらないため、ログインした日時として使えます。
+func (s *authService) requireRecentLogin(ctx context.Context, tenantID, sessionID uuid.UUID) error {
+	session, err := s.sessionRepo.FindByID(ctx, s.db, tenantID, sessionID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("SESSION_NOT_FOUND", "セッションが見つかりません。もう一度ログインしてください。", "", model.ErrForbidden)
+		}
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if time.Since(session.CreatedAt) > recentLoginWindow {
+		middleware.GetLogger(ctx).Warn("Re-authentication required", "tenant_id", tenantID, "session_id", sessionID, "logged_in_at", session.CreatedAt)
+		return model.NewAppError("REAUTHENTICATION_REQUIRED", fmt.Sprintf("安全のため、もう一度ログインしてから%d分以内に操作してください。", int(recentLoginWindow/time.Minute)), "", model.ErrForbidden)
+	}
+	return nil
+}
+
+// ConfirmEmailChange は新しいメールアドレスに送ったリンクのトークンでメールアドレスの変更を確定します。
+// テナントのメールアドレスとローカル認証のログインID (ProviderID) を同じトランザクションで変更します。
+func (s *authService) ConfirmEmailChange(ctx context.Context, tokenString string) error {
+	logger := middleware.GetLogger(ctx)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		token, err := s.tokenRepo.FindEmailChangeToken(ctx, tx, tokenString)
+		if err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				return model.NewAppError("INVALID_TOKEN", "このリンクは無効か、既に使用されています。", "token", model.ErrInvalidInput)
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "エラーが発生しました。", "", err)
+		}
+		if time.Now().After(token.ExpiresAt) {
+			return model.NewAppError("INVALID_TOKEN", "このリンクの有効期限が切れています。もう一度メールアドレスの変更を行ってください。", "token", model.ErrInvalidInput)
+		}
+		logger := logger.With("tenant_id", token.TenantID, "new_email", token.NewEmail)
+
+		inUseErr := model.NewAppError("EMAIL_ALREADY_IN_USE", "このメールアドレスは既に使用されています。", "token", model.ErrConflict)
+		if err := s.tenantRepo.UpdateEmail(ctx, tx, token.TenantID, token.NewEmail); err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				return inUseErr
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "メールアドレスの変更に失敗しました。", "", err)
+		}
+		// パスワードでログインするアカウントは、新しいメールアドレスでログインできるようにする
+		if err := s.identityRepo.UpdateProviderID(ctx, tx, token.TenantID, model.AuthProviderLocal, token.NewEmail); err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				return inUseErr
+			}
+			if !errors.Is(err, model.ErrNotFound) {
+				return model.NewAppError("INTERNAL_SERVER_ERROR", "メールアドレスの変更に失敗しました。", "", err)
+			}
+		}
+		if err := s.tokenRepo.DeleteEmailChangeTokensByTenantID(ctx, tx, token.TenantID); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの削除に失敗しました。", "", err)
+		}
+
+		logger.Info("Email changed")
+		return nil
+	})
+}
+
+// verifyCurrentPassword はログイン中のテナントのパスワードを確認し、ローカル認証の認証情報を返します。
+// 盗まれたアクセストークンでのパスワードの総当たりを防ぐため、ログインと同様に失敗回数を数えます。
+func (s *authService) verifyCurrentPassword(ctx context.Context, tenantID uuid.UUID, password, field string) (*model.Identity, error) {
+	account := "password:" + tenantID.String()
+	if err := s.loginGuard.Check(ctx, account); err != nil {
+		return nil, err
+	}
+
+	identity, err := s.identityRepo.FindByTenantIDAndProvider(ctx, s.db, tenantID, model.AuthProviderLocal)
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if identity == nil || identity.PasswordHash == nil {
+		return nil, model.NewAppError("PASSWORD_NOT_SET", "パスワードが設定されていません。", field, model.ErrInvalidInput)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(*identity.PasswordHash), []byte(password)); err != nil {
+		middleware.GetLogger(ctx).Warn("Invalid current password", "tenant_id", tenantID)
+		if err := s.loginGuard.RecordFailure(ctx, account); err != nil {
+			return nil, err
+		}
+		return nil, model.NewAppError("INVALID_PASSWORD", "パスワードが正しくありません。", field, model.ErrInvalidInput)
+	}
+	if err := s.loginGuard.Reset(ctx, account); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- メールアドレスの変更のテスト ---
+func (s *AuthServiceTestSuite) TestRequestEmailChange_WithoutPassword() {
+	tenantID := uuid.New()
+	sessionID := uuid.New()
+	req := &model.ChangeEmailRequest{NewEmail: "new@example.com"}
+
+	testCases := []struct {
+		name       string
+		session    *model.Session
+		sessionErr error
+		wantCode   string
+	}{
+		{
+			name:    "Success - 直近にログインしたセッションからは変更を要求できる",
+			session: &model.Session{SessionID: sessionID, TenantID: tenantID, CreatedAt: time.Now().Add(-time.Minute)},
+		},
+		{
+			name:     "Failure - ログインから時間が経ったセッションはログインし直す必要がある",
+			session:  &model.Session{SessionID: sessionID, TenantID: tenantID, CreatedAt: time.Now().Add(-time.Hour)},
+			wantCode: "REAUTHENTICATION_REQUIRED",
+		},
+		{
+			name:       "Failure - セッションが見つからない",
+			sessionErr: model.ErrNotFound,
+			wantCode:   "SESSION_NOT_FOUND",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockTenantRepo.On("FindByID", mock.Anything, mock.Anything, tenantID).Return(&model.Tenant{TenantID: tenantID, Email: "old@example.com", IsActive: true}, nil).Once()
+			// Google ログインのみのアカウント
+			s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(nil, model.ErrNotFound).Once()
+			s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(tc.session, tc.sessionErr).Once()
+			if tc.wantCode == "" {
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "new@example.com").Return(nil, model.ErrNotFound).Once()
+				s.mockTokenRepo.On("DeleteEmailChangeTokensByTenantID", mock.Anything, mock.Anything, tenantID).Return(nil).Once()
+				s.mockTokenRepo.On("CreateEmailChangeToken", mock.Anything, mock.Anything, mock.MatchedBy(func(t *model.EmailChangeToken) bool {
+					return t.NewEmail == "new@example.com"
+				})).Return(nil).Once()
+				s.mockMailer.On("Send", mock.Anything, "new@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+				s.mockMailer.On("Send", mock.Anything, "old@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			err := s.authService.RequestEmailChange(context.Background(), tenantID, sessionID, req)
+
+			if tc.wantCode != "" {
+				s.assertAppErrorCode(err, tc.wantCode)
+				s.ErrorIs(err, model.ErrForbidden)
+			} else {
+				s.NoError(err)
+			}
+			s.assertExpectations()
+		})
+	}
+}
+
+// --- ConfirmEmailChangeメソッドのテスト ---
+func (s *AuthServiceTestSuite) TestConfirmEmailChange() {
+	tenantID := uuid.New()
+	validToken := func() *model.EmailChangeToken {
+		return &model.EmailChangeToken{Token: "token", TenantID: tenantID, NewEmail: "new@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		wantCode   string
+	}{
+		{
+			name: "Success - メールアドレスとログインIDを同じトランザクションで変更する",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindEmailChangeToken", mock.Anything, mock.Anything, "token").Return(validToken(), nil).Once()
+				s.mockTenantRepo.On("UpdateEmail", mock.Anything, mock.Anything, tenantID, "new@example.com").Return(nil).Once()
+				s.mockIdentityRepo.On("UpdateProviderID", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal, "new@example.com").Return(nil).Once()
+				s.mockTokenRepo.On("DeleteEmailChangeTokensByTenantID", mock.Anything, mock.Anything, tenantID).Return(nil).Once()
+			},
+		},
+		{
+			name: "Success - パスワードのないアカウントはメールアドレスだけを変更する",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindEmailChangeToken", mock.Anything, mock.Anything, "token").Return(validToken(), nil).Once()
+				s.mockTenantRepo.On("UpdateEmail", mock.Anything, mock.Anything, tenantID, "new@example.com").Return(nil).Once()
+				s.mockIdentityRepo.On("UpdateProviderID", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal, "new@example.com").Return(model.ErrNotFound).Once()
+				s.mockTokenRepo.On("DeleteEmailChangeTokensByTenantID", mock.Anything, mock.Anything, tenantID).Return(nil).Once()
+			},
+		},
+		{
+			name: "Failure - ログインIDが他のアカウントと重複したらメールアドレスの変更も取り消す",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindEmailChangeToken", mock.Anything, mock.Anything, "token").Return(validToken(), nil).Once()
+				s.mockTenantRepo.On("UpdateEmail", mock.Anything, mock.Anything, tenantID, "new@example.com").Return(nil).Once()
+				s.mockIdentityRepo.On("UpdateProviderID", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal, "new@example.com").Return(model.ErrConflict).Once()
+				// トークンは削除しない (トランザクションごとロールバックされる)
+			},
+			wantCode: "EMAIL_ALREADY_IN_USE",
+		},
+		{
+			name: "Failure - ログインIDの変更でDBエラー",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindEmailChangeToken", mock.Anything, mock.Anything, "token").Return(validToken(), nil).Once()
+				s.mockTenantRepo.On("UpdateEmail", mock.Anything, mock.Anything, tenantID, "new@example.com").Return(nil).Once()
+				s.mockIdentityRepo.On("UpdateProviderID", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal, "new@example.com").Return(errors.New("db error")).Once()
+			},
+			wantCode: "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name: "Failure - 確認の間に他のアカウントが同じメールアドレスになった",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindEmailChangeToken", mock.Anything, mock.Anything, "token").Return(validToken(), nil).Once()
+				s.mockTenantRepo.On("UpdateEmail", mock.Anything, mock.Anything, tenantID, "new@example.com").Return(model.ErrConflict).Once()
+			},
+			wantCode: "EMAIL_ALREADY_IN_USE",
+		},
+		{
+			name: "Failure - 有効期限切れのリンク",
+			setupMocks: func() {
+				token := validToken()
+				token.ExpiresAt = time.Now().Add(-time.Minute)
+				s.mockTokenRepo.On("FindEmailChangeToken", mock.Anything, mock.Anything, "token").Return(token, nil).Once()
+			},
+			wantCode: "INVALID_TOKEN",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			err := s.authService.ConfirmEmailChange(context.Background(), "token")
+
+			if tc.wantCode != "" {
+				s.assertAppErrorCode(err, tc.wantCode)
+			} else {
+				s.NoError(err)
+			}
+			s.assertExpectations()
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// ListIdentities はテナントのログイン方法 (認証情報) の一覧を返します
+func (s *authService) ListIdentities(ctx context.Context, tenantID uuid.UUID) ([]model.IdentityResponse, error) {
+	identities, err := s.identityRepo.FindByTenantID(ctx, s.db, tenantID)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "ログイン方法の取得に失敗しました。", "", err)
+	}
+	res := make([]model.IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		res = append(res, model.NewIdentityResponse(identity))
+	}
+	return res, nil
+}
+
+// LinkGoogleIdentity はログイン中のテナントに Google アカウントでのログインを追加します。
+// メールアドレスが一致するかどうかに関わらず、認可コードを取得した Google アカウントを紐付けます。
+// 盗まれたアクセストークンで攻撃者のアカウントを紐付けられないよう、本人の確認が必要です。
+func (s *authService) LinkGoogleIdentity(ctx context.Context, tenantID, sessionID uuid.UUID, code, password string) (*model.IdentityResponse, error) {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	if err := s.confirmAccountOwner(ctx, tenantID, sessionID, password, "password"); err != nil {
+		return nil, err
+	}
+
+	googleUser, err := s.fetchGoogleUser(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.With("google_email", googleUser.Email, "google_id", googleUser.Subject)
+
+	identity := &model.Identity{
+		TenantID:     tenantID,
+		AuthProvider: model.AuthProviderGoogle,
+		ProviderID:   googleUser.Subject,
+	}
+	if err := s.linkIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Google identity linked")
+	res := model.NewIdentityResponse(identity)
+	return &res, nil
+}
+
+// linkIdentity はテナントに外部のログイン方法を追加します。
+// 同じプロバイダのログイン方法が既にある場合や、他のテナントに紐付いている場合はエラーを返します。
+func (s *authService) linkIdentity(ctx context.Context, identity *model.Identity) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		linked, err := s.identityRepo.FindByProvider(ctx, tx, identity.AuthProvider, identity.ProviderID)
+		if err == nil {
+			if linked.TenantID == identity.TenantID {
+				return model.NewAppError("IDENTITY_ALREADY_LINKED", "このアカウントは既に連携されています。", "", model.ErrConflict)
+			}
+			return model.NewAppError("IDENTITY_LINKED_TO_OTHER_ACCOUNT", "このアカウントは別のユーザーに連携されています。", "", model.ErrConflict)
+		}
+		if !errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+
+		if _, err := s.identityRepo.FindByTenantIDAndProvider(ctx, tx, identity.TenantID, identity.AuthProvider); err == nil {
+			return model.NewAppError("PROVIDER_ALREADY_LINKED", "このログイン方法は既に連携されています。連携を解除してから再度お試しください。", "", model.ErrConflict)
+		} else if !errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+
+		if err := s.identityRepo.Create(ctx, tx, identity); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "ログイン方法の追加に失敗しました。", "", err)
+		}
+		return nil
+	})
+}
+
+// UnlinkIdentity はテナントのログイン方法を削除します。ログインできなくなるため、最後に残ったログイン方法は削除できません。
+// 本人のログイン方法を削除されないよう、本人の確認が必要です。
+func (s *authService) UnlinkIdentity(ctx context.Context, tenantID, sessionID uuid.UUID, identityID uint, password string) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID, "identity_id", identityID)
+
+	if err := s.confirmAccountOwner(ctx, tenantID, sessionID, password, "password"); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		identities, err := s.identityRepo.FindByTenantIDForUpdate(ctx, tx, tenantID)
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "ログイン方法の取得に失敗しました。", "", err)
+		}
+
+		var target *model.Identity
+		usableOthers := 0
+		for _, identity := range identities {
+			if identity.ID == identityID {
+				target = identity
+			} else if identity.IsUsableLoginMethod() {
+				usableOthers++
+			}
+		}
+		if target == nil {
+			return model.NewAppError("IDENTITY_NOT_FOUND", "ログイン方法が見つかりません。", "id", model.ErrNotFound)
+		}
+		if target.IsUsableLoginMethod() && usableOthers == 0 {
+			return model.NewAppError("LAST_LOGIN_METHOD", "最後のログイン方法は削除できません。他のログイン方法を追加してから再度お試しください。", "id", model.ErrConflict)
+		}
+
+		if err := s.identityRepo.Delete(ctx, tx, tenantID, identityID); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "ログイン方法の削除に失敗しました。", "", err)
+		}
+		logger.Info("Identity unlinked", "auth_provider", target.AuthProvider)
+		return nil
+	})
+}
+
+// SetLocalPassword はパスワードのないテナント (Googleログインのみ) にパスワードを設定し、メールアドレスとパスワードでログインできるようにします。
+// 既にパスワードがある場合は現在のパスワードの確認が必要な ChangePassword を使います。
+// 確認するパスワードがないため、直近にログインしたセッションからの要求に限ります。
+func (s *authService) SetLocalPassword(ctx context.Context, tenantID, sessionID uuid.UUID, password string) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	// パスワードがあれば ChangePassword を使うため、ここではパスワードのないアカウントとしてログインし直したことを確認する
+	if identity, err := s.identityRepo.FindByTenantIDAndProvider(ctx, s.db, tenantID, model.AuthProviderLocal); err == nil && identity.PasswordHash != nil {
+		return model.NewAppError("PASSWORD_ALREADY_SET", "パスワードは既に設定されています。", "password", model.ErrConflict)
+	} else if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if err := s.requireRecentLogin(ctx, tenantID, sessionID); err != nil {
+		return err
+	}
+
+	tenant, err := s.tenantRepo.FindByID(ctx, s.db, tenantID)
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザー情報の取得に失敗しました。", "", err)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "パスワードの処理中にエラーが発生しました。", "", err)
+	}
+	hashedPasswordStr := string(hashedPassword)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		identity, err := s.identityRepo.FindByTenantIDAndProvider(ctx, tx, tenantID, model.AuthProviderLocal)
+		switch {
+		case err == nil && identity.PasswordHash != nil:
+			return model.NewAppError("PASSWORD_ALREADY_SET", "パスワードは既に設定されています。", "password", model.ErrConflict)
+		case err == nil:
+			if err := s.identityRepo.UpdatePasswordHash(ctx, tx, identity.ID, hashedPasswordStr); err != nil {
+				return model.NewAppError("INTERNAL_SERVER_ERROR", "パスワードの設定に失敗しました。", "", err)
+			}
+			return nil
+		case !errors.Is(err, model.ErrNotFound):
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+
+		// 他のテナントがこのメールアドレスでパスワードログインしている場合は設定できない
+		if _, err := s.identityRepo.FindByProvider(ctx, tx, model.AuthProviderLocal, tenant.Email); err == nil {
+			return model.NewAppError("EMAIL_ALREADY_IN_USE", "このメールアドレスは既に使用されています。", "", model.ErrConflict)
+		} else if !errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+
+		identity = &model.Identity{
+			TenantID:     tenantID,
+			AuthProvider: model.AuthProviderLocal,
+			ProviderID:   tenant.Email,
+			PasswordHash: &hashedPasswordStr,
+		}
+		if err := s.identityRepo.Create(ctx, tx, identity); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "パスワードの設定に失敗しました。", "", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Local password set")
+	return nil
+}
@@ -0,0 +1,254 @@
+package service_test
+
+import (
+	"context"
+	"time"
+
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// --- ログイン方法 (認証情報) の管理のテスト ---
+func (s *AuthServiceTestSuite) TestUnlinkIdentity() {
+	tenantID := uuid.New()
+	sessionID := uuid.New()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	s.Require().NoError(err)
+	hashStr := string(hash)
+	account := "account:password:" + tenantID.String()
+	local := &model.Identity{ID: 1, TenantID: tenantID, AuthProvider: model.AuthProviderLocal, ProviderID: "test@example.com", PasswordHash: &hashStr}
+	localWithoutPassword := &model.Identity{ID: 1, TenantID: tenantID, AuthProvider: model.AuthProviderLocal, ProviderID: "test@example.com"}
+	google := &model.Identity{ID: 2, TenantID: tenantID, AuthProvider: model.AuthProviderGoogle, ProviderID: "google-sub"}
+
+	testCases := []struct {
+		name       string
+		identityID uint
+		identities []*model.Identity
+		password   string
+		loggedInAt time.Time // パスワードのないアカウントのセッションのログイン日時
+		wantDelete bool
+		wantCode   string
+	}{
+		{
+			name:       "Success - 他のログイン方法があれば削除できる",
+			identityID: 2,
+			identities: []*model.Identity{local, google},
+			password:   "password",
+			wantDelete: true,
+		},
+		{
+			name:       "Failure - 最後のログイン方法は削除できない",
+			identityID: 2,
+			identities: []*model.Identity{google},
+			loggedInAt: time.Now(),
+			wantCode:   "LAST_LOGIN_METHOD",
+		},
+		{
+			name:       "Failure - パスワードのないローカル認証はログイン方法に数えない",
+			identityID: 2,
+			identities: []*model.Identity{localWithoutPassword, google},
+			loggedInAt: time.Now(),
+			wantCode:   "LAST_LOGIN_METHOD",
+		},
+		{
+			name:       "Success - ログインに使えない認証情報は最後の1つでも削除できる",
+			identityID: 1,
+			identities: []*model.Identity{localWithoutPassword, google},
+			loggedInAt: time.Now(),
+			wantDelete: true,
+		},
+		{
+			name:       "Failure - 他のテナントのログイン方法は見つからない",
+			identityID: 3,
+			identities: []*model.Identity{local, google},
+			password:   "password",
+			wantCode:   "IDENTITY_NOT_FOUND",
+		},
+		{
+			name:       "Failure - パスワードが正しくなければ削除しない",
+			identityID: 2,
+			identities: []*model.Identity{local, google},
+			password:   "wrong-password",
+			wantCode:   "INVALID_PASSWORD",
+		},
+		{
+			name:       "Failure - パスワードのないアカウントはログインし直す必要がある",
+			identityID: 1,
+			identities: []*model.Identity{localWithoutPassword, google},
+			loggedInAt: time.Now().Add(-time.Hour),
+			wantCode:   "REAUTHENTICATION_REQUIRED",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			var localIdentity *model.Identity
+			for _, identity := range tc.identities {
+				if identity.AuthProvider == model.AuthProviderLocal {
+					localIdentity = identity
+				}
+			}
+			if localIdentity != nil && localIdentity.PasswordHash != nil {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(localIdentity, nil).Twice()
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				if tc.wantCode == "INVALID_PASSWORD" {
+					s.mockLoginAttemptRepo.On("FindOrCreateForUpdate", mock.Anything, mock.Anything, account).Return(&model.LoginAttempt{Key: account}, nil).Once()
+					s.mockLoginAttemptRepo.On("Save", mock.Anything, mock.Anything, mock.AnythingOfType("*model.LoginAttempt")).Return(nil).Once()
+				} else {
+					s.mockLoginAttemptRepo.On("Delete", mock.Anything, mock.Anything, account).Return(nil).Once()
+				}
+			} else {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(localIdentity, nil).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(&model.Session{SessionID: sessionID, TenantID: tenantID, CreatedAt: tc.loggedInAt}, nil).Once()
+			}
+			// 本人の確認に失敗した場合はログイン方法を読み込まない
+			if tc.wantCode != "INVALID_PASSWORD" && tc.wantCode != "REAUTHENTICATION_REQUIRED" {
+				s.mockIdentityRepo.On("FindByTenantIDForUpdate", mock.Anything, mock.Anything, tenantID).Return(tc.identities, nil).Once()
+			}
+			if tc.wantDelete {
+				s.mockIdentityRepo.On("Delete", mock.Anything, mock.Anything, tenantID, tc.identityID).Return(nil).Once()
+			}
+
+			err := s.authService.UnlinkIdentity(context.Background(), tenantID, sessionID, tc.identityID, tc.password)
+
+			if tc.wantCode != "" {
+				s.assertAppErrorCode(err, tc.wantCode)
+			} else {
+				s.NoError(err)
+			}
+			s.assertExpectations()
+		})
+	}
+}
+
+// --- SetLocalPasswordメソッドのテスト ---
+func (s *AuthServiceTestSuite) TestSetLocalPassword() {
+	tenantID := uuid.New()
+	sessionID := uuid.New()
+	hash := "hash"
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		wantCode   string
+	}{
+		{
+			name: "Success - 直近にログインしたセッションからはパスワードを設定できる",
+			setupMocks: func() {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(nil, model.ErrNotFound).Twice()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(&model.Session{SessionID: sessionID, TenantID: tenantID, CreatedAt: time.Now()}, nil).Once()
+				s.mockTenantRepo.On("FindByID", mock.Anything, mock.Anything, tenantID).Return(&model.Tenant{TenantID: tenantID, Email: "test@example.com"}, nil).Once()
+				s.mockIdentityRepo.On("FindByProvider", mock.Anything, mock.Anything, model.AuthProviderLocal, "test@example.com").Return(nil, model.ErrNotFound).Once()
+				s.mockIdentityRepo.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(i *model.Identity) bool {
+					return i.TenantID == tenantID && i.ProviderID == "test@example.com" && i.PasswordHash != nil
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "Failure - ログインから時間が経ったセッションはログインし直す必要がある",
+			setupMocks: func() {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(nil, model.ErrNotFound).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(&model.Session{SessionID: sessionID, TenantID: tenantID, CreatedAt: time.Now().Add(-time.Hour)}, nil).Once()
+			},
+			wantCode: "REAUTHENTICATION_REQUIRED",
+		},
+		{
+			name: "Failure - パスワードが設定済みなら本人の確認の前に断る",
+			setupMocks: func() {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).
+					Return(&model.Identity{ID: 1, TenantID: tenantID, AuthProvider: model.AuthProviderLocal, PasswordHash: &hash}, nil).Once()
+			},
+			wantCode: "PASSWORD_ALREADY_SET",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			err := s.authService.SetLocalPassword(context.Background(), tenantID, sessionID, "new-password")
+
+			if tc.wantCode != "" {
+				s.assertAppErrorCode(err, tc.wantCode)
+			} else {
+				s.NoError(err)
+			}
+			s.assertExpectations()
+		})
+	}
+}
+
+// --- LinkGoogleIdentity / LinkOIDCIdentity の本人の確認のテスト ---
+func (s *AuthServiceTestSuite) TestLinkIdentity_RequiresReauthentication() {
+	tenantID := uuid.New()
+	sessionID := uuid.New()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	s.Require().NoError(err)
+	hashStr := string(hash)
+	account := "account:password:" + tenantID.String()
+
+	// 本人の確認に失敗した場合は、認可コードを交換せずに断る
+	testCases := []struct {
+		name       string
+		password   string
+		setupMocks func()
+		wantCode   string
+	}{
+		{
+			name:     "Failure - パスワードが正しくない",
+			password: "wrong-password",
+			setupMocks: func() {
+				local := &model.Identity{ID: 1, TenantID: tenantID, AuthProvider: model.AuthProviderLocal, PasswordHash: &hashStr}
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(local, nil).Twice()
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				s.mockLoginAttemptRepo.On("FindOrCreateForUpdate", mock.Anything, mock.Anything, account).Return(&model.LoginAttempt{Key: account}, nil).Once()
+				s.mockLoginAttemptRepo.On("Save", mock.Anything, mock.Anything, mock.AnythingOfType("*model.LoginAttempt")).Return(nil).Once()
+			},
+			wantCode: "INVALID_PASSWORD",
+		},
+		{
+			name: "Failure - パスワードのないアカウントでログインから時間が経っている",
+			setupMocks: func() {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(nil, model.ErrNotFound).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(&model.Session{SessionID: sessionID, TenantID: tenantID, CreatedAt: time.Now().Add(-time.Hour)}, nil).Once()
+			},
+			wantCode: "REAUTHENTICATION_REQUIRED",
+		},
+		{
+			name: "Failure - セッションが見つからない",
+			setupMocks: func() {
+				s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(nil, model.ErrNotFound).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(nil, model.ErrNotFound).Once()
+			},
+			wantCode: "SESSION_NOT_FOUND",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run("Google: "+tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			res, err := s.authService.LinkGoogleIdentity(context.Background(), tenantID, sessionID, "code", tc.password)
+
+			s.Nil(res)
+			s.assertAppErrorCode(err, tc.wantCode)
+			s.assertExpectations()
+		})
+		s.Run("OIDC: "+tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			res, err := s.authService.LinkOIDCIdentity(context.Background(), tenantID, sessionID, "example", "code", "state", "binding", tc.password)
+
+			s.Nil(res)
+			s.assertAppErrorCode(err, tc.wantCode)
+			s.assertExpectations()
+		})
+	}
+}
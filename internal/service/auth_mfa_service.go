@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/totp"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultMFAChallengeTTL は mfa.challenge_ttl が未設定の場合の2段階目の認証の有効期限
+	defaultMFAChallengeTTL = 5 * time.Minute
+	// mfaChallengeAudience は2段階目の認証用トークンの aud。アクセストークンとして使われないように区別する
+	mfaChallengeAudience = "mfa_challenge"
+	// totpSkew はコードの入力の遅れを考慮して受け付ける前後の時間ステップ数
+	totpSkew = 1
+	// recoveryCodeCount は一度に発行するリカバリーコードの数
+	recoveryCodeCount = 10
+)
+
+// recoveryCodeEncoding はリカバリーコードの文字 (読み間違えにくいよう小文字の Base32)
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// EnrollTOTP は2段階認証の登録を開始し、認証アプリに登録する秘密鍵を返します。
+// ConfirmTOTP で認証アプリのコードを確認するまでは有効になりません。
+func (s *authService) EnrollTOTP(ctx context.Context, tenantID uuid.UUID) (*model.TOTPEnrollResponse, error) {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	existing, err := s.mfaRepo.FindTOTP(ctx, s.db, tenantID)
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if existing != nil && existing.EnabledAt != nil {
+		return nil, model.NewAppError("MFA_ALREADY_ENABLED", "2段階認証は既に有効です。", "", model.ErrConflict)
+	}
+
+	tenant, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "秘密鍵の生成に失敗しました。", "", err)
+	}
+	sealed, err := s.sealTOTPSecret(secret)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "秘密鍵の保存に失敗しました。", "", err)
+	}
+	if err := s.mfaRepo.SaveTOTP(ctx, s.db, &model.TenantTOTP{TenantID: tenantID, Secret: sealed, CreatedAt: time.Now()}); err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "秘密鍵の保存に失敗しました。", "", err)
+	}
+
+	logger.Info("TOTP enrollment started")
+	return &model.TOTPEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.mfaIssuer(), tenant.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP は認証アプリのコードを確認して2段階認証を有効にし、リカバリーコードを発行します
+func (s *authService) ConfirmTOTP(ctx context.Context, tenantID uuid.UUID, code string) (*model.RecoveryCodesResponse, error) {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	t, err := s.mfaRepo.FindTOTP(ctx, s.db, tenantID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.NewAppError("MFA_NOT_ENROLLED", "2段階認証の登録が開始されていません。", "", model.ErrInvalidInput)
+		}
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if t.EnabledAt != nil {
+		return nil, model.NewAppError("MFA_ALREADY_ENABLED", "2段階認証は既に有効です。", "", model.ErrConflict)
+	}
+	secret, err := s.openTOTPSecret(t.Secret)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "秘密鍵の読み込みに失敗しました。", "", err)
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, model.NewAppError("INVALID_MFA_CODE", "認証コードが正しくありません。", "code", model.ErrInvalidInput)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "リカバリーコードの生成に失敗しました。", "", err)
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.mfaRepo.EnableTOTP(ctx, tx, tenantID, step); err != nil {
+			return err
+		}
+		return s.mfaRepo.ReplaceRecoveryCodes(ctx, tx, tenantID, hashes)
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.NewAppError("MFA_ALREADY_ENABLED", "2段階認証は既に有効です。", "", model.ErrConflict)
+		}
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "2段階認証の有効化に失敗しました。", "", err)
+	}
+
+	logger.Info("TOTP enabled")
+	return &model.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP はパスワードを確認して2段階認証を無効にし、リカバリーコードを削除します
+func (s *authService) DisableTOTP(ctx context.Context, tenantID uuid.UUID, password string) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	// パスワードの総当たりで2段階認証を外されないよう、パスワードの変更と同じく失敗回数を数える
+	if _, err := s.verifyCurrentPassword(ctx, tenantID, password, "password"); err != nil {
+		return err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.mfaRepo.DeleteTOTP(ctx, tx, tenantID); err != nil {
+			return err
+		}
+		return s.mfaRepo.ReplaceRecoveryCodes(ctx, tx, tenantID, nil)
+	})
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "2段階認証の無効化に失敗しました。", "", err)
+	}
+
+	logger.Info("TOTP disabled")
+	return nil
+}
+
+// LoginWithMFA はパスワード認証後に発行した MFA トークンと、認証アプリのコードまたはリカバリーコードでログインを完了します
+func (s *authService) LoginWithMFA(ctx context.Context, req *model.MFALoginRequest) (*model.LoginResponse, error) {
+	tenantID, err := s.parseMFAChallenge(req.MFAToken)
+	if err != nil {
+		return nil, model.NewAppError("INVALID_MFA_TOKEN", "認証の有効期限が切れています。もう一度ログインしてください。", "mfa_token", model.ErrForbidden)
+	}
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	// 認証コードの総当たりを防ぐため、パスワードと同様に失敗回数を数える
+	account := "mfa:" + tenantID.String()
+	if err := s.loginGuard.Check(ctx, account); err != nil {
+		return nil, err
+	}
+	invalidErr := func() error {
+		if err := s.loginGuard.RecordFailure(ctx, account); err != nil {
+			return err
+		}
+		return model.NewAppError("INVALID_MFA_CODE", "認証コードが正しくありません。", "code", model.ErrInvalidInput)
+	}
+
+	t, err := s.mfaRepo.FindTOTP(ctx, s.db, tenantID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.NewAppError("INVALID_MFA_TOKEN", "2段階認証が有効ではありません。もう一度ログインしてください。", "mfa_token", model.ErrForbidden)
+		}
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if t.EnabledAt == nil {
+		return nil, model.NewAppError("INVALID_MFA_TOKEN", "2段階認証が有効ではありません。もう一度ログインしてください。", "mfa_token", model.ErrForbidden)
+	}
+
+	if code := strings.ReplaceAll(strings.TrimSpace(req.Code), " ", ""); len(code) == totp.Digits && isDigits(code) {
+		secret, err := s.openTOTPSecret(t.Secret)
+		if err != nil {
+			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "秘密鍵の読み込みに失敗しました。", "", err)
+		}
+		step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+		if !ok {
+			logger.Warn("Invalid TOTP code")
+			return nil, invalidErr()
+		}
+		if err := s.mfaRepo.UseTOTPStep(ctx, s.db, tenantID, step); err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				logger.Warn("TOTP code reused")
+				return nil, invalidErr()
+			}
+			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+	} else {
+		if err := s.mfaRepo.UseRecoveryCode(ctx, s.db, tenantID, hashRecoveryCode(req.Code)); err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				logger.Warn("Invalid recovery code")
+				return nil, invalidErr()
+			}
+			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+		logger.Info("Recovery code used")
+	}
+	if err := s.loginGuard.Reset(ctx, account); err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.IsActive {
+		return nil, model.NewAppError("ACCOUNT_NOT_ACTIVE", "アカウントが有効化されていません。", "", model.ErrForbidden)
+	}
+	logger.Info("MFA login successful")
+	return s.startSession(ctx, tenant)
+}
+
+// completeLogin は1段階目の認証に成功したテナントのログインを進めます。
+// 2段階認証が有効な場合はトークンを発行せず、2段階目の認証に使う MFA トークンを返します。
+func (s *authService) completeLogin(ctx context.Context, tenant *model.Tenant) (*model.LoginResponse, error) {
+	t, err := s.mfaRepo.FindTOTP(ctx, s.db, tenant.TenantID)
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	if t == nil || t.EnabledAt == nil {
+		return s.startSession(ctx, tenant)
+	}
+
+	ttl := s.cfg.MFA.ChallengeTTL
+	if ttl <= 0 {
+		ttl = defaultMFAChallengeTTL
+	}
+	claims := &jwt.RegisteredClaims{
+		Issuer:    s.cfg.App.Name,
+		Subject:   tenant.TenantID.String(),
+		Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWT.SecretKey))
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの生成に失敗しました。", "", err)
+	}
+	middleware.GetLogger(ctx).Info("MFA challenge issued", "tenant_id", tenant.TenantID)
+	return &model.LoginResponse{MFARequired: true, MFAToken: token}, nil
+}
+
+// parseMFAChallenge は MFA トークンを検証し、テナントIDを返します
+func (s *authService) parseMFAChallenge(tokenString string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.cfg.JWT.SecretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(mfaChallengeAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(claims.Subject)
+}
+
+func (s *authService) mfaIssuer() string {
+	if s.cfg.MFA.Issuer != "" {
+		return s.cfg.MFA.Issuer
+	}
+	return s.cfg.App.Name
+}
+
+// totpCipher は TOTP の秘密鍵を暗号化する AES-256-GCM を返します
+func (s *authService) totpCipher() (cipher.AEAD, error) {
+	key := s.cfg.MFA.EncryptionKey
+	if key == "" {
+		key = s.cfg.JWT.SecretKey
+	}
+	return newAEAD("totp-secret", key)
+}
+
+// newAEAD は key から用途ごとに導出した鍵の AES-256-GCM を返します
+func newAEAD(purpose, key string) (cipher.AEAD, error) {
+	sum := sha256.Sum256([]byte(purpose + ":" + key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *authService) sealTOTPSecret(secret string) (string, error) {
+	aead, err := s.totpCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+func (s *authService) openTOTPSecret(sealed string) (string, error) {
+	aead, err := s.totpCipher()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid sealed totp secret")
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt totp secret: %w", err)
+	}
+	return string(plain), nil
+}
+
+// generateRecoveryCodes はリカバリーコード ("xxxxx-xxxxx" 形式) と、保存するハッシュを返します
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := recoveryCodeEncoding.EncodeToString(buf)[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode はリカバリーコードのハッシュを返します。区切りの "-"・空白と大文字小文字の違いは無視します。
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
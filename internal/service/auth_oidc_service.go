@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/oidc"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// oidcStateTTL はプロバイダの認可画面から戻ってくるまでの有効期限
+const oidcStateTTL = 10 * time.Minute
+
+// oidcState は認可の開始から完了までに引き継ぐ情報です。
+// サーバーに保存せず、暗号化して state パラメータとして利用者に持たせます (PKCE の code_verifier を含むため署名だけでは不十分)。
+// 他人のブラウザで認可を完了させられないよう (ログインCSRF)、Binding と同じ値を認可を開始したブラウザの Cookie に持たせ、完了時に照合します。
+type oidcState struct {
+	Provider  string    `json:"provider"`
+	TenantID  uuid.UUID `json:"tenant_id"` // ログイン中のアカウントへの連携の場合はそのテナント。ログインの場合は uuid.Nil
+	Nonce     string    `json:"nonce"`
+	Verifier  string    `json:"verifier"`
+	Binding   string    `json:"binding"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// externalUser は外部のプロバイダで認証した利用者です
+type externalUser struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// ListOIDCProviders はログインに使える OpenID Connect のプロバイダをキーの順に返します
+func (s *authService) ListOIDCProviders(ctx context.Context) []model.OIDCProviderResponse {
+	res := make([]model.OIDCProviderResponse, 0, len(s.oidcProviders))
+	for key := range s.oidcProviders {
+		name := s.cfg.OIDC.Providers[key].Name
+		if name == "" {
+			name = key
+		}
+		res = append(res, model.OIDCProviderResponse{Key: key, Name: name})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+	return res
+}
+
+// StartOIDCLogin は OpenID Connect のプロバイダでのログインを開始し、認可画面のURLと state を返します
+func (s *authService) StartOIDCLogin(ctx context.Context, providerKey string) (*model.OIDCAuthorizeResponse, error) {
+	return s.startOIDCAuthorization(ctx, providerKey, uuid.Nil)
+}
+
+// StartOIDCLink はログイン中のアカウントに OpenID Connect のプロバイダのアカウントを連携する認可を開始します
+func (s *authService) StartOIDCLink(ctx context.Context, tenantID uuid.UUID, providerKey string) (*model.OIDCAuthorizeResponse, error) {
+	return s.startOIDCAuthorization(ctx, providerKey, tenantID)
+}
+
+// HandleOIDCLogin は認可コードを ID トークンに交換して検証し、プロバイダのアカウントでログインします。
+// binding には認可を開始したブラウザの Cookie の値を指定します。
+// 初めてのアカウントは、確認済みのメールアドレスが一致するアカウントに連携するか、新しいアカウントを作成します。
+func (s *authService) HandleOIDCLogin(ctx context.Context, providerKey, code, state, binding string) (*model.LoginResponse, error) {
+	claims, err := s.exchangeOIDCCode(ctx, providerKey, code, state, binding, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.findOrCreateExternalTenant(ctx, &externalUser{
+		Provider:      providerKey,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.completeLogin(ctx, tenant)
+}
+
+// LinkOIDCIdentity はログイン中のアカウントに、認可したプロバイダのアカウントでのログインを追加します
+func (s *authService) LinkOIDCIdentity(ctx context.Context, tenantID, sessionID uuid.UUID, providerKey, code, state, binding, password string) (*model.IdentityResponse, error) {
+	// Google と同様に、紐付ける前に本人であることを確認する
+	if err := s.confirmAccountOwner(ctx, tenantID, sessionID, password, "password"); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.exchangeOIDCCode(ctx, providerKey, code, state, binding, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &model.Identity{
+		TenantID:     tenantID,
+		AuthProvider: providerKey,
+		ProviderID:   claims.Subject,
+	}
+	if err := s.linkIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	middleware.GetLogger(ctx).Info("OIDC identity linked", "tenant_id", tenantID, "auth_provider", providerKey)
+	res := model.NewIdentityResponse(identity)
+	return &res, nil
+}
+
+func (s *authService) oidcProvider(providerKey string) (*oidc.Provider, error) {
+	provider, ok := s.oidcProviders[providerKey]
+	if !ok {
+		return nil, model.NewAppError("OIDC_PROVIDER_NOT_FOUND", "このログイン方法は利用できません。", "provider", model.ErrNotFound)
+	}
+	return provider, nil
+}
+
+func (s *authService) startOIDCAuthorization(ctx context.Context, providerKey string, tenantID uuid.UUID) (*model.OIDCAuthorizeResponse, error) {
+	provider, err := s.oidcProvider(providerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 32)
+	binding := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "認可の開始に失敗しました。", "", err)
+	}
+	if _, err := rand.Read(binding); err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "認可の開始に失敗しました。", "", err)
+	}
+	st := &oidcState{
+		Provider:  providerKey,
+		TenantID:  tenantID,
+		Nonce:     base64.RawURLEncoding.EncodeToString(nonce),
+		Verifier:  oauth2.GenerateVerifier(),
+		Binding:   base64.RawURLEncoding.EncodeToString(binding),
+		ExpiresAt: time.Now().Add(oidcStateTTL),
+	}
+	state, err := s.sealOIDCState(st)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "認可の開始に失敗しました。", "", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, st.Nonce, st.Verifier)
+	if err != nil {
+		middleware.GetLogger(ctx).Error("Failed to start OIDC authorization", "error", err, "auth_provider", providerKey)
+		return nil, model.NewAppError("OIDC_PROVIDER_UNAVAILABLE", "ログイン先のサービスに接続できませんでした。しばらくしてから再度お試しください。", "", err)
+	}
+	return &model.OIDCAuthorizeResponse{AuthorizationURL: authURL, State: state, Binding: st.Binding, BindingExpiresAt: st.ExpiresAt}, nil
+}
+
+// exchangeOIDCCode は state と、認可を開始したブラウザの Cookie の値 (binding) を確認して認可コードを交換し、ID トークンのクレームを返します。
+// tenantID には、認可を開始したテナント (ログインの場合は uuid.Nil) を指定します。
+func (s *authService) exchangeOIDCCode(ctx context.Context, providerKey, code, state, binding string, tenantID uuid.UUID) (*oidc.Claims, error) {
+	logger := middleware.GetLogger(ctx).With("auth_provider", providerKey)
+
+	provider, err := s.oidcProvider(providerKey)
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.openOIDCState(state)
+	if err != nil || st.Provider != providerKey || st.TenantID != tenantID || time.Now().After(st.ExpiresAt) {
+		logger.Warn("Invalid OIDC state", "error", err)
+		return nil, model.NewAppError("INVALID_OIDC_STATE", "認証の有効期限が切れています。もう一度やり直してください。", "state", model.ErrInvalidInput)
+	}
+	if binding == "" || subtle.ConstantTimeCompare([]byte(binding), []byte(st.Binding)) != 1 {
+		logger.Warn("OIDC state is not bound to this browser", "has_binding", binding != "")
+		return nil, model.NewAppError("INVALID_OIDC_STATE", "認証を開始したブラウザで操作してください。もう一度やり直してください。", "state", model.ErrInvalidInput)
+	}
+
+	claims, err := provider.Exchange(ctx, code, st.Verifier, st.Nonce)
+	if err != nil {
+		logger.Warn("OIDC code exchange failed", "error", err)
+		return nil, model.NewAppError("OIDC_AUTH_FAILED", "外部サービスでの認証に失敗しました。", "", model.ErrForbidden)
+	}
+	return claims, nil
+}
+
+// findOrCreateExternalTenant は外部のプロバイダで認証した利用者のテナントを返します。
+// 連携済みでなければ、確認済みのメールアドレスが一致するアカウントに連携するか、新しいアカウントを作成します。
+func (s *authService) findOrCreateExternalTenant(ctx context.Context, user *externalUser) (*model.Tenant, error) {
+	logger := middleware.GetLogger(ctx).With("auth_provider", user.Provider, "provider_id", user.Subject, "email", user.Email)
+
+	identity, err := s.identityRepo.FindByProvider(ctx, s.db, user.Provider, user.Subject)
+	if err == nil {
+		tenant, err := s.tenantRepo.FindByID(ctx, s.db, identity.TenantID)
+		if err != nil {
+			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザー情報の取得に失敗しました。", "", err)
+		}
+		logger.Info("External user already exists, logging in", "tenant_id", tenant.TenantID)
+		return tenant, nil
+	}
+	if !errors.Is(err, model.ErrNotFound) {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "DBエラーが発生しました。", "", err)
+	}
+
+	// 確認されていないメールアドレスで他人のアカウントに連携・メールアドレスを横取りされないよう、確認済みの場合のみ受け付ける
+	if user.Email == "" || !user.EmailVerified {
+		logger.Warn("External login without verified email")
+		return nil, model.NewAppError("EMAIL_NOT_VERIFIED", "メールアドレスが確認されていないため、このアカウントではログインできません。", "", model.ErrForbidden)
+	}
+
+	var target *model.Tenant
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := s.tenantRepo.FindByEmail(ctx, tx, user.Email)
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			name := user.Name
+			if name == "" {
+				name = user.Email
+			}
+			target = &model.Tenant{TenantID: uuid.New(), Name: name, Email: user.Email, IsActive: true}
+			if err := s.tenantRepo.Create(ctx, tx, target); err != nil {
+				return err
+			}
+			logger.Info("No existing email, creating new tenant", "tenant_id", target.TenantID)
+		case err != nil:
+			return err
+		default:
+			target = existing
+			if !existing.IsActive {
+				// 有効化されていないアカウントのパスワードは本人が設定したとは限らないので削除し、メールアドレスを確認できたものとして有効化する
+				if err := s.takeOverInactiveTenant(ctx, tx, existing); err != nil {
+					return err
+				}
+			}
+			logger.Info("Existing email found, linking external identity to tenant", "tenant_id", existing.TenantID)
+		}
+
+		return s.identityRepo.Create(ctx, tx, &model.Identity{
+			TenantID:     target.TenantID,
+			AuthProvider: user.Provider,
+			ProviderID:   user.Subject,
+		})
+	})
+	if err != nil {
+		return nil, model.NewAppError("DB_OPERATION_FAILED", "ユーザー処理中にエラーが発生しました。", "", err)
+	}
+	return target, nil
+}
+
+// takeOverInactiveTenant は有効化されていないアカウントを、外部のプロバイダでメールアドレスを確認できた利用者のものとして有効化します
+func (s *authService) takeOverInactiveTenant(ctx context.Context, tx *gorm.DB, tenant *model.Tenant) error {
+	local, err := s.identityRepo.FindByTenantIDAndProvider(ctx, tx, tenant.TenantID, model.AuthProviderLocal)
+	if err == nil {
+		if err := s.identityRepo.Delete(ctx, tx, tenant.TenantID, local.ID); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, model.ErrNotFound) {
+		return err
+	}
+	if err := s.tokenRepo.DeleteVerificationTokensByTenantID(ctx, tx, tenant.TenantID); err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Tenant{}).Where("tenant_id = ?", tenant.TenantID).Update("is_active", true).Error; err != nil {
+		return err
+	}
+	tenant.IsActive = true
+	return nil
+}
+
+func (s *authService) sealOIDCState(st *oidcState) (string, error) {
+	plain, err := json.Marshal(st)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD("oidc-state", s.cfg.JWT.SecretKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, plain, nil)), nil
+}
+
+func (s *authService) openOIDCState(state string) (*oidcState, error) {
+	aead, err := newAEAD("oidc-state", s.cfg.JWT.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.RawURLEncoding.DecodeString(state)
+	if err != nil || len(data) < aead.NonceSize() {
+		return nil, errors.New("invalid oidc state")
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, err
+	}
+	var st oidcState
+	if err := json.Unmarshal(plain, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultRefreshTokenTTL は jwt.refresh_token_ttl が未設定の場合のリフレッシュトークンの有効期限
+const defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshToken はリフレッシュトークンを新しいアクセストークン・リフレッシュトークンに交換します。
+// 使用済みのリフレッシュトークンが再び使われた場合は、同じファミリーのトークンをすべて失効させます。
+func (s *authService) RefreshToken(ctx context.Context, tokenString string) (*model.LoginResponse, error) {
+	logger := middleware.GetLogger(ctx)
+	invalidErr := model.NewAppError("INVALID_REFRESH_TOKEN", "リフレッシュトークンが無効です。再度ログインしてください。", "refresh_token", model.ErrForbidden)
+
+	var res *model.LoginResponse
+	var reused *model.RefreshToken
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		token, err := s.tokenRepo.FindRefreshTokenByHashForUpdate(ctx, tx, hashRefreshToken(tokenString))
+		if err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				logger.Warn("Refresh token not found")
+				return invalidErr
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+		logger := logger.With("tenant_id", token.TenantID, "family_id", token.FamilyID)
+
+		if token.RevokedAt != nil {
+			logger.Warn("Revoked refresh token presented")
+			return invalidErr
+		}
+		if token.UsedAt != nil {
+			reused = token
+			return invalidErr
+		}
+		if time.Now().After(token.ExpiresAt) {
+			logger.Warn("Refresh token expired", "expires_at", token.ExpiresAt)
+			return model.NewAppError("INVALID_REFRESH_TOKEN", "ログインの有効期限が切れています。再度ログインしてください。", "refresh_token", model.ErrForbidden)
+		}
+
+		// ファミリーIDはセッションIDを兼ねる。ログアウト・失効させたセッションでは再発行しない
+		session, err := s.sessionRepo.FindByID(ctx, tx, token.TenantID, token.FamilyID)
+		if err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				logger.Warn("Session for refresh token not found")
+				return invalidErr
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+		if session.RevokedAt != nil {
+			logger.Warn("Refresh token presented for revoked session")
+			return invalidErr
+		}
+
+		if err := s.tokenRepo.MarkRefreshTokenUsed(ctx, tx, token.TokenID); err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				reused = token
+				return invalidErr
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの更新に失敗しました。", "", err)
+		}
+
+		tenant, err := s.tenantRepo.FindByID(ctx, tx, token.TenantID)
+		if err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				return invalidErr
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザー情報の取得に失敗しました。", "", err)
+		}
+		if !tenant.IsActive {
+			return model.NewAppError("ACCOUNT_NOT_ACTIVE", "アカウントが有効化されていません。", "", model.ErrForbidden)
+		}
+
+		if err := s.sessionRepo.Touch(ctx, tx, session.SessionID, middleware.GetClientInfo(ctx)); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "セッションの更新に失敗しました。", "", err)
+		}
+
+		res, err = s.issueTokens(ctx, tx, tenant, session.SessionID)
+		return err
+	})
+
+	// 再利用を検知した場合の失効はロールバックされないよう、トランザクションの外で行う。
+	// トークンが盗まれた可能性があるため、同じセッションのアクセストークンも使えなくする
+	if reused != nil {
+		logger.Warn("Refresh token reuse detected, revoking token family", "tenant_id", reused.TenantID, "family_id", reused.FamilyID)
+		if revokeErr := s.revokeSession(ctx, reused.TenantID, reused.FamilyID); revokeErr != nil && !errors.Is(revokeErr, model.ErrNotFound) {
+			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの失効に失敗しました。", "", revokeErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// issueTokens はセッションのアクセストークンと、セッションIDをファミリーIDとする新しいリフレッシュトークンを発行します
+func (s *authService) issueTokens(ctx context.Context, db *gorm.DB, tenant *model.Tenant, sessionID uuid.UUID) (*model.LoginResponse, error) {
+	res, err := s.generateAppJWT(ctx, tenant, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの生成に失敗しました。", "", err)
+	}
+	tokenString := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	ttl := s.cfg.JWT.RefreshTokenTTL
+	if ttl <= 0 {
+		ttl = defaultRefreshTokenTTL
+	}
+	refreshToken := &model.RefreshToken{
+		TokenID:   uuid.New(),
+		TenantID:  tenant.TenantID,
+		FamilyID:  sessionID,
+		TokenHash: hashRefreshToken(tokenString),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.tokenRepo.CreateRefreshToken(ctx, db, refreshToken); err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの保存に失敗しました。", "", err)
+	}
+
+	res.RefreshToken = tokenString
+	res.RefreshTokenExpiresAt = &refreshToken.ExpiresAt
+	return res, nil
+}
+
+// hashRefreshToken はDBに保存・検索するリフレッシュトークンのハッシュを返します
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
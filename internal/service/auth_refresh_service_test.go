@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- RefreshTokenメソッドのテスト ---
+func (s *AuthServiceTestSuite) TestRefreshToken() {
+	tenantID := uuid.New()
+	familyID := uuid.New() // セッションIDを兼ねる
+	tokenID := uuid.New()
+	presented := "presented-refresh-token"
+	sum := sha256.Sum256([]byte(presented))
+	tokenHash := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	newToken := func() *model.RefreshToken {
+		return &model.RefreshToken{TokenID: tokenID, TenantID: tenantID, FamilyID: familyID, TokenHash: tokenHash, ExpiresAt: now.Add(time.Hour)}
+	}
+	activeSession := &model.Session{SessionID: familyID, TenantID: tenantID}
+
+	// 再利用を検知した場合は、セッションと同じファミリーのリフレッシュトークンをすべて失効させる
+	expectFamilyRevoked := func() {
+		s.mockSessionRepo.On("Revoke", mock.Anything, mock.Anything, tenantID, familyID).Return(nil).Once()
+		s.mockTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, familyID).Return(nil).Once()
+	}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func()
+		checkResult func(res *model.LoginResponse, err error)
+	}{
+		{
+			name: "Success - 使用済みにして同じファミリーの新しいトークンを発行する",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(newToken(), nil).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, familyID).Return(activeSession, nil).Once()
+				s.mockTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, mock.Anything, tokenID).Return(nil).Once()
+				s.mockTenantRepo.On("FindByID", mock.Anything, mock.Anything, tenantID).Return(&model.Tenant{TenantID: tenantID, IsActive: true}, nil).Once()
+				s.mockSessionRepo.On("Touch", mock.Anything, mock.Anything, familyID, mock.Anything).Return(nil).Once()
+				s.mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.MatchedBy(func(t *model.RefreshToken) bool {
+					return t.FamilyID == familyID && t.TenantID == tenantID && t.TokenHash != tokenHash && t.UsedAt == nil
+				})).Return(nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Require().NoError(err)
+				s.NotEmpty(res.AccessToken)
+				s.NotEmpty(res.RefreshToken)
+				s.NotEqual(presented, res.RefreshToken)
+				s.Require().NotNil(res.RefreshTokenExpiresAt)
+			},
+		},
+		{
+			name: "Failure - 使用済みのトークンの再利用はファミリーごと失効させる",
+			setupMocks: func() {
+				token := newToken()
+				usedAt := now.Add(-time.Minute)
+				token.UsedAt = &usedAt
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(token, nil).Once()
+				expectFamilyRevoked()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "INVALID_REFRESH_TOKEN")
+			},
+		},
+		{
+			name: "Failure - 同時に交換された (使用済みにできなかった) 場合も再利用として扱う",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(newToken(), nil).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, familyID).Return(activeSession, nil).Once()
+				s.mockTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, mock.Anything, tokenID).Return(model.ErrNotFound).Once()
+				expectFamilyRevoked()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "INVALID_REFRESH_TOKEN")
+			},
+		},
+		{
+			name: "Failure - 失効済みのトークン (ファミリーは既に失効しているので何もしない)",
+			setupMocks: func() {
+				token := newToken()
+				token.RevokedAt = &now
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "INVALID_REFRESH_TOKEN")
+			},
+		},
+		{
+			name: "Failure - 有効期限切れのトークン",
+			setupMocks: func() {
+				token := newToken()
+				token.ExpiresAt = now.Add(-time.Minute)
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "INVALID_REFRESH_TOKEN")
+				s.ErrorIs(err, model.ErrForbidden)
+			},
+		},
+		{
+			name: "Failure - ログアウト済みのセッションでは再発行しない",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(newToken(), nil).Once()
+				s.mockSessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, familyID).Return(&model.Session{SessionID: familyID, TenantID: tenantID, RevokedAt: &now}, nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "INVALID_REFRESH_TOKEN")
+			},
+		},
+		{
+			name: "Failure - 存在しないトークン",
+			setupMocks: func() {
+				s.mockTokenRepo.On("FindRefreshTokenByHashForUpdate", mock.Anything, mock.Anything, tokenHash).Return(nil, model.ErrNotFound).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "INVALID_REFRESH_TOKEN")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			res, err := s.authService.RefreshToken(context.Background(), presented)
+
+			tc.checkResult(res, err)
+			s.assertExpectations()
+		})
+	}
+}
@@ -1,25 +1,23 @@
+//go:generate mockery --name AuthService --output ./mocks --outpkg mocks --case=underscore
 package service
 
 import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"go_4_vocab_keep/internal/config"
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/oidc"
 	"go_4_vocab_keep/internal/repository"
-	"io"
-	"net/http"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 	"gorm.io/gorm"
 )
 
@@ -27,44 +25,65 @@ import (
 type AuthService interface {
 	RegisterTenant(ctx context.Context, req *model.RegisterRequest) (*model.Tenant, error)
 	VerifyAccount(ctx context.Context, tokenString string) error
+	ResendVerificationEmail(ctx context.Context, email string) error
 	Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error)
 	GetTenant(ctx context.Context, tenantID uuid.UUID) (*model.Tenant, error)
 	RequestPasswordReset(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, token, newPassword string) error
 	HandleGoogleLogin(ctx context.Context, code string) (*model.LoginResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*model.LoginResponse, error)
+	Logout(ctx context.Context, tenantID, sessionID uuid.UUID) error
+	ListSessions(ctx context.Context, tenantID, currentSessionID uuid.UUID) ([]model.SessionResponse, error)
+	RevokeSession(ctx context.Context, tenantID, sessionID uuid.UUID) error
+	EnrollTOTP(ctx context.Context, tenantID uuid.UUID) (*model.TOTPEnrollResponse, error)
+	ConfirmTOTP(ctx context.Context, tenantID uuid.UUID, code string) (*model.RecoveryCodesResponse, error)
+	DisableTOTP(ctx context.Context, tenantID uuid.UUID, password string) error
+	LoginWithMFA(ctx context.Context, req *model.MFALoginRequest) (*model.LoginResponse, error)
+	ChangePassword(ctx context.Context, tenantID, sessionID uuid.UUID, req *model.ChangePasswordRequest) error
+	RequestEmailChange(ctx context.Context, tenantID, sessionID uuid.UUID, req *model.ChangeEmailRequest) error
+	ConfirmEmailChange(ctx context.Context, tokenString string) error
+	ListIdentities(ctx context.Context, tenantID uuid.UUID) ([]model.IdentityResponse, error)
+	LinkGoogleIdentity(ctx context.Context, tenantID, sessionID uuid.UUID, code, password string) (*model.IdentityResponse, error)
+	UnlinkIdentity(ctx context.Context, tenantID, sessionID uuid.UUID, identityID uint, password string) error
+	SetLocalPassword(ctx context.Context, tenantID, sessionID uuid.UUID, password string) error
+	ListOIDCProviders(ctx context.Context) []model.OIDCProviderResponse
+	StartOIDCLogin(ctx context.Context, providerKey string) (*model.OIDCAuthorizeResponse, error)
+	HandleOIDCLogin(ctx context.Context, providerKey, code, state, binding string) (*model.LoginResponse, error)
+	StartOIDCLink(ctx context.Context, tenantID uuid.UUID, providerKey string) (*model.OIDCAuthorizeResponse, error)
+	LinkOIDCIdentity(ctx context.Context, tenantID, sessionID uuid.UUID, providerKey, code, state, binding, password string) (*model.IdentityResponse, error)
 }
 
 // authService 構造体に依存関係を追加
 type authService struct {
-	db                *gorm.DB
-	tenantRepo        repository.TenantRepository
-	identityRepo      repository.IdentityRepository
-	tokenRepo         repository.TokenRepository
-	mailer            Mailer
-	cfg               *config.Config
-	googleOAuthConfig *oauth2.Config
+	db             *gorm.DB
+	tenantRepo     repository.TenantRepository
+	identityRepo   repository.IdentityRepository
+	tokenRepo      repository.TokenRepository
+	sessionRepo    repository.SessionRepository
+	mfaRepo        repository.MFARepository
+	loginGuard     *LoginGuard
+	sessionCache   *SessionCache
+	googleProvider *oidc.Provider
+	oidcProviders  map[string]*oidc.Provider
+	mailer         Mailer
+	cfg            *config.Config
 }
 
-func NewAuthService(db *gorm.DB, tenantRepo repository.TenantRepository, identityRepo repository.IdentityRepository, tokenRepo repository.TokenRepository, mailer Mailer, cfg *config.Config) AuthService {
-	gOAuthConfig := &oauth2.Config{
-		ClientID:     cfg.GoogleOAuth.ClientID,
-		ClientSecret: cfg.GoogleOAuth.ClientSecret,
-		RedirectURL:  cfg.GoogleOAuth.RedirectURL,
-		Scopes: []string{
-			"https://www.googleapis.com/auth/userinfo.email",
-			"https://www.googleapis.com/auth/userinfo.profile",
-		},
-		Endpoint: google.Endpoint,
-	}
-
+// NewAuthService は AuthService を返します。googleProvider が nil (Google ログインを使わない設定) の場合、Google でのログイン・連携はエラーになります。
+func NewAuthService(db *gorm.DB, tenantRepo repository.TenantRepository, identityRepo repository.IdentityRepository, tokenRepo repository.TokenRepository, sessionRepo repository.SessionRepository, mfaRepo repository.MFARepository, loginGuard *LoginGuard, sessionCache *SessionCache, googleProvider *oidc.Provider, oidcProviders map[string]*oidc.Provider, mailer Mailer, cfg *config.Config) AuthService {
 	return &authService{
-		db:                db,
-		tenantRepo:        tenantRepo,
-		identityRepo:      identityRepo,
-		tokenRepo:         tokenRepo,
-		mailer:            mailer,
-		cfg:               cfg,
-		googleOAuthConfig: gOAuthConfig,
+		db:             db,
+		tenantRepo:     tenantRepo,
+		identityRepo:   identityRepo,
+		tokenRepo:      tokenRepo,
+		sessionRepo:    sessionRepo,
+		mfaRepo:        mfaRepo,
+		loginGuard:     loginGuard,
+		sessionCache:   sessionCache,
+		oidcProviders:  oidcProviders,
+		mailer:         mailer,
+		googleProvider: googleProvider,
+		cfg:            cfg,
 	}
 }
 
@@ -73,13 +92,13 @@ func (s *authService) RegisterTenant(ctx context.Context, req *model.RegisterReq
 	var newTenant *model.Tenant
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Emailでの重複チェック
-		_, err := s.tenantRepo.FindByEmail(ctx, tx, req.Email)
-		if err == nil {
+		// Emailでの重複チェック (有効化されていないアカウントは登録し直せる)
+		existingTenant, err := s.tenantRepo.FindByEmail(ctx, tx, req.Email)
+		if err == nil && existingTenant.IsActive {
 			logger.Warn("Email already exists", "email", req.Email)
 			return model.NewAppError("DUPLICATE_EMAIL", "このメールアドレスは既に使用されています。", "email", model.ErrConflict)
 		}
-		if !errors.Is(err, model.ErrNotFound) {
+		if err != nil && !errors.Is(err, model.ErrNotFound) {
 			logger.Error("Failed to check email existence", "error", err)
 			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", err)
 		}
@@ -90,6 +109,11 @@ func (s *authService) RegisterTenant(ctx context.Context, req *model.RegisterReq
 		}
 		hashedPasswordStr := string(hashedPassword)
 
+		if existingTenant != nil {
+			newTenant = existingTenant
+			return s.reregisterInactiveTenant(ctx, tx, existingTenant, req.Name, hashedPasswordStr)
+		}
+
 		tenant := &model.Tenant{
 			TenantID: uuid.New(),
 			Name:     req.Name,
@@ -111,16 +135,7 @@ func (s *authService) RegisterTenant(ctx context.Context, req *model.RegisterReq
 			return model.NewAppError("INTERNAL_SERVER_ERROR", "認証情報の作成に失敗しました。", "", err)
 		}
 
-		tokenString, err := s.generateAndSaveVerificationToken(ctx, tx, newTenant.TenantID)
-		if err != nil {
-			return err
-		}
-
-		if err := s.sendVerificationEmail(ctx, newTenant.Email, tokenString); err != nil {
-			return model.NewAppError("EMAIL_SEND_FAILED", "確認メールの送信に失敗しました。", "", err)
-		}
-
-		return nil
+		return s.sendNewVerificationEmail(ctx, tx, newTenant, nil, nil)
 	})
 
 	if err != nil {
@@ -151,7 +166,7 @@ func (s *authService) VerifyAccount(ctx context.Context, tokenString string) err
 		if time.Now().After(token.ExpiresAt) {
 			logger.Warn("Verification token expired", "token", tokenString, "expires_at", token.ExpiresAt)
 			_ = s.tokenRepo.DeleteVerificationToken(ctx, tx, tokenString) // 期限切れトークンは削除
-			return model.NewAppError("INVALID_TOKEN", "このリンクの有効期限が切れています。", "token", model.ErrInvalidInput)
+			return model.NewAppError("INVALID_TOKEN", "このリンクの有効期限が切れています。確認メールを再送してください。", "token", model.ErrInvalidInput)
 		}
 
 		// ユーザーを有効化
@@ -165,10 +180,15 @@ func (s *authService) VerifyAccount(ctx context.Context, tokenString string) err
 			return model.NewAppError("NOT_FOUND", "アカウントが見つかりません。", "", model.ErrNotFound)
 		}
 
-		// 使用済みトークンを削除
-		if err := s.tokenRepo.DeleteVerificationToken(ctx, tx, tokenString); err != nil {
+		// 登録し直した名前とパスワードは、そのときに送ったリンクで有効化した場合に限り反映する
+		if err := s.applyPendingRegistration(ctx, tx, token); err != nil {
+			return err
+		}
+
+		// 使用済みトークンを削除 (他の登録内容が後から反映されないよう、テナントのトークンをすべて削除する)
+		if err := s.tokenRepo.DeleteVerificationTokensByTenantID(ctx, tx, token.TenantID); err != nil {
 			logger.Error("Failed to delete used verification token", "error", err, "token", tokenString)
-			// トークン削除エラーは致命的ではないので、処理は続行する
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "アカウントの有効化に失敗しました。", "", err)
 		}
 
 		logger.Info("Account verified successfully", "tenant_id", token.TenantID)
@@ -179,20 +199,29 @@ func (s *authService) VerifyAccount(ctx context.Context, tokenString string) err
 func (s *authService) Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error) {
 	logger := middleware.GetLogger(ctx).With("email", req.Email)
 
+	// 失敗が続いているアカウント・IPアドレスはパスワードを確認せずに拒否する
+	account := "email:" + strings.ToLower(req.Email)
+	if err := s.loginGuard.Check(ctx, account); err != nil {
+		return nil, err
+	}
+
 	identity, err := s.identityRepo.FindByProvider(ctx, s.db, model.AuthProviderLocal, req.Email)
 	if err != nil {
 		if errors.Is(err, model.ErrNotFound) {
-			return nil, model.NewAppError("AUTHENTICATION_FAILED", "メールアドレスまたはパスワードが正しくありません。", "", model.ErrInvalidInput)
+			return nil, s.loginFailed(ctx, account)
 		}
 		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
 	}
 
 	if identity.PasswordHash == nil {
-		return nil, model.NewAppError("AUTHENTICATION_FAILED", "メールアドレスまたはパスワードが正しくありません。", "", model.ErrInvalidInput)
+		return nil, s.loginFailed(ctx, account)
 	}
 	err = bcrypt.CompareHashAndPassword([]byte(*identity.PasswordHash), []byte(req.Password))
 	if err != nil {
-		return nil, model.NewAppError("AUTHENTICATION_FAILED", "メールアドレスまたはパスワードが正しくありません。", "", model.ErrInvalidInput)
+		return nil, s.loginFailed(ctx, account)
+	}
+	if err := s.loginGuard.Reset(ctx, account); err != nil {
+		return nil, err
 	}
 
 	tenant, err := s.tenantRepo.FindByID(ctx, s.db, identity.TenantID)
@@ -204,93 +233,50 @@ func (s *authService) Login(ctx context.Context, req *model.LoginRequest) (*mode
 		return nil, model.NewAppError("ACCOUNT_NOT_ACTIVE", "アカウントが有効化されていません。登録時に送信されたメールをご確認ください。", "", model.ErrForbidden)
 	}
 
-	logger.Info("Login successful", "tenant_id", tenant.TenantID)
-	return s.generateAppJWT(ctx, tenant)
+	logger.Info("Password authentication successful", "tenant_id", tenant.TenantID)
+	return s.completeLogin(ctx, tenant)
 }
 
-func (s *authService) HandleGoogleLogin(ctx context.Context, code string) (*model.LoginResponse, error) {
-	logger := middleware.GetLogger(ctx)
-
-	googleToken, err := s.googleOAuthConfig.Exchange(ctx, code)
-	if err != nil {
-		return nil, model.NewAppError("GOOGLE_AUTH_FAILED", "Google認証コードの交換に失敗しました。", "", err)
+// loginFailed はログインの失敗を記録し、AUTHENTICATION_FAILED のエラーを返します
+func (s *authService) loginFailed(ctx context.Context, account string) error {
+	middleware.GetLogger(ctx).Warn("Password authentication failed")
+	if err := s.loginGuard.RecordFailure(ctx, account); err != nil {
+		return err
 	}
+	return model.NewAppError("AUTHENTICATION_FAILED", "メールアドレスまたはパスワードが正しくありません。", "", model.ErrInvalidInput)
+}
 
-	response, err := http.Get("https://www.googleapis.com/oauth2/v2/userinfo?access_token=" + googleToken.AccessToken)
+// HandleGoogleLogin は Google の認可コードを ID トークンに交換して検証し、Google アカウントでログインします。
+// 初めてのアカウントは、確認済みのメールアドレスが一致するアカウントに連携するか、新しいアカウントを作成します。
+func (s *authService) HandleGoogleLogin(ctx context.Context, code string) (*model.LoginResponse, error) {
+	user, err := s.fetchGoogleUser(ctx, code)
 	if err != nil {
-		return nil, model.NewAppError("GOOGLE_API_FAILED", "Googleからのユーザー情報取得に失敗しました。", "", err)
+		return nil, err
 	}
-	defer response.Body.Close()
-
-	body, err := io.ReadAll(response.Body)
+	tenant, err := s.findOrCreateExternalTenant(ctx, user)
 	if err != nil {
-		return nil, model.NewAppError("GOOGLE_API_FAILED", "ユーザー情報の解析に失敗しました。", "", err)
-	}
-
-	var googleUser struct {
-		ID    string `json:"id"`
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	}
-	if err := json.Unmarshal(body, &googleUser); err != nil {
-		return nil, model.NewAppError("GOOGLE_API_FAILED", "ユーザー情報の解析に失敗しました。", "", err)
-	}
-	logger = logger.With("google_email", googleUser.Email, "google_id", googleUser.ID)
-
-	identity, err := s.identityRepo.FindByProvider(ctx, s.db, model.AuthProviderGoogle, googleUser.ID)
-	if err == nil {
-		logger.Info("Google user already exists, logging in")
-		tenant, findErr := s.tenantRepo.FindByID(ctx, s.db, identity.TenantID)
-		if findErr != nil {
-			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザー情報の取得に失敗しました。", "", findErr)
-		}
-		return s.generateAppJWT(ctx, tenant)
+		return nil, err
 	}
+	return s.completeLogin(ctx, tenant)
+}
 
-	if !errors.Is(err, model.ErrNotFound) {
-		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "DBエラーが発生しました。", "", err)
+// fetchGoogleUser は Google の認可コードをトークンに交換し、ID トークンの署名・発行者・対象者・有効期限を検証して利用者を返します
+func (s *authService) fetchGoogleUser(ctx context.Context, code string) (*externalUser, error) {
+	if s.googleProvider == nil {
+		return nil, model.NewAppError("GOOGLE_LOGIN_NOT_CONFIGURED", "Googleログインは利用できません。", "", model.ErrNotFound)
 	}
-
-	logger.Info("New Google login detected, processing registration or linking")
-	var targetTenant *model.Tenant
-	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		existingTenant, findErr := s.tenantRepo.FindByEmail(ctx, tx, googleUser.Email)
-		if findErr != nil && !errors.Is(findErr, model.ErrNotFound) {
-			return findErr
-		}
-
-		if errors.Is(findErr, model.ErrNotFound) {
-			logger.Info("No existing email, creating new tenant and identity")
-			newTenant := &model.Tenant{
-				TenantID: uuid.New(),
-				Name:     googleUser.Name,
-				Email:    googleUser.Email,
-				IsActive: true,
-			}
-			if createErr := s.tenantRepo.Create(ctx, tx, newTenant); createErr != nil {
-				return createErr
-			}
-			targetTenant = newTenant
-		} else {
-			logger.Info("Existing email found, linking Google identity to tenant", "tenant_id", existingTenant.TenantID)
-			targetTenant = existingTenant
-		}
-
-		newIdentity := &model.Identity{
-			TenantID:     targetTenant.TenantID,
-			AuthProvider: model.AuthProviderGoogle,
-			ProviderID:   googleUser.ID,
-		}
-		if createErr := s.identityRepo.Create(ctx, tx, newIdentity); createErr != nil {
-			return createErr
-		}
-		return nil
-	})
+	claims, err := s.googleProvider.Exchange(ctx, code, "", "")
 	if err != nil {
-		return nil, model.NewAppError("DB_OPERATION_FAILED", "ユーザー処理中にエラーが発生しました。", "", err)
+		middleware.GetLogger(ctx).Warn("Google code exchange failed", "error", err)
+		return nil, model.NewAppError("GOOGLE_AUTH_FAILED", "Googleでの認証に失敗しました。", "", model.ErrForbidden)
 	}
-
-	return s.generateAppJWT(ctx, targetTenant)
+	return &externalUser{
+		Provider:      model.AuthProviderGoogle,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
 }
 
 func (s *authService) RequestPasswordReset(ctx context.Context, email string) error {
@@ -371,13 +357,15 @@ func (s *authService) GetTenant(ctx context.Context, tenantID uuid.UUID) (*model
 	return tenant, nil
 }
 
-func (s *authService) generateAndSaveVerificationToken(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) (string, error) {
+// generateAndSaveVerificationToken は verificationToken にトークンと有効期限を設定して保存し、トークンを返します
+func (s *authService) generateAndSaveVerificationToken(ctx context.Context, tx *gorm.DB, verificationToken *model.UserVerificationToken) (string, error) {
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
 		return "", model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの生成に失敗しました。", "", err)
 	}
 	tokenString := hex.EncodeToString(tokenBytes)
-	verificationToken := &model.UserVerificationToken{Token: tokenString, TenantID: tenantID, ExpiresAt: time.Now().Add(24 * time.Hour)}
+	verificationToken.Token = tokenString
+	verificationToken.ExpiresAt = time.Now().Add(24 * time.Hour)
 	if err := s.tokenRepo.CreateVerificationToken(ctx, tx, verificationToken); err != nil {
 		return "", model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの保存に失敗しました。", "", err)
 	}
@@ -404,10 +392,12 @@ func (s *authService) generateAndSavePasswordResetToken(ctx context.Context, tx
 	return tokenString, nil
 }
 
-func (s *authService) generateAppJWT(ctx context.Context, tenant *model.Tenant) (*model.LoginResponse, error) {
+// generateAppJWT はアクセストークンを発行します。jti にはセッションIDを入れ、ログアウト後は使えないようにします。
+func (s *authService) generateAppJWT(ctx context.Context, tenant *model.Tenant, sessionID uuid.UUID) (*model.LoginResponse, error) {
 	claims := &jwt.RegisteredClaims{
 		Issuer:    s.cfg.App.Name,
 		Subject:   tenant.TenantID.String(),
+		ID:        sessionID.String(),
 		ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.cfg.JWT.AccessTokenTTL)),
 		IssuedAt:  jwt.NewNumericDate(time.Now()),
 	}
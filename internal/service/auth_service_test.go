@@ -11,8 +11,13 @@ import (
 	"go_4_vocab_keep/internal/service"
 	servicemocks "go_4_vocab_keep/internal/service/mocks" // Mailerのモック
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // --- テストスイートの定義 ---
@@ -20,24 +25,38 @@ import (
 type AuthServiceTestSuite struct {
 	suite.Suite // testifyのSuiteを埋め込む
 
-	mockTenantRepo *mocks.TenantRepository
-	mockTokenRepo  *mocks.TokenRepository
-	mockMailer     *servicemocks.Mailer
-	cfg            *config.Config
-	authService    service.AuthService
+	db                   *gorm.DB
+	mockTenantRepo       *mocks.TenantRepository
+	mockIdentityRepo     *mocks.IdentityRepository
+	mockTokenRepo        *mocks.TokenRepository
+	mockSessionRepo      *mocks.SessionRepository
+	mockMFARepo          *mocks.MFARepository
+	mockLoginAttemptRepo *mocks.LoginAttemptRepository
+	mockMailer           *servicemocks.Mailer
+	cfg                  *config.Config
+	authService          service.AuthService
 }
 
 // --- セットアップメソッド ---
 // 各テスト(`TestXxx`)が実行される直前に呼ばれる
 func (s *AuthServiceTestSuite) SetupTest() {
+	// トランザクションを張るためのDB (リポジトリはモックなのでテーブルは不要)
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	s.Require().NoError(err)
+	s.db = db
+
 	// 各テストの前に、モックを新しく生成してクリーンな状態にする
 	s.mockTenantRepo = new(mocks.TenantRepository)
+	s.mockIdentityRepo = new(mocks.IdentityRepository)
 	s.mockTokenRepo = new(mocks.TokenRepository)
+	s.mockSessionRepo = new(mocks.SessionRepository)
+	s.mockMFARepo = new(mocks.MFARepository)
+	s.mockLoginAttemptRepo = new(mocks.LoginAttemptRepository)
 	s.mockMailer = new(servicemocks.Mailer)
 
 	// テスト用のダミー設定
 	s.cfg = &config.Config{
-		App: config.AppConfig{FrontendURL: "http://localhost:3000"},
+		App: config.AppConfig{Name: "kioku-test", FrontendURL: "http://localhost:3000"},
 		JWT: config.JWTConfig{
 			SecretKey:      "test-secret",
 			AccessTokenTTL: 15 * time.Minute,
@@ -45,7 +64,28 @@ func (s *AuthServiceTestSuite) SetupTest() {
 	}
 
 	// テスト対象のサービスにモックを注入してインスタンスを生成
-	s.authService = service.NewAuthService(nil, s.mockTenantRepo, s.mockTokenRepo, s.mockMailer, s.cfg)
+	loginGuard := service.NewLoginGuard(s.db, s.mockLoginAttemptRepo, s.cfg)
+	sessionCache := service.NewSessionCache(s.db, s.mockSessionRepo, s.cfg)
+	s.authService = service.NewAuthService(s.db, s.mockTenantRepo, s.mockIdentityRepo, s.mockTokenRepo, s.mockSessionRepo, s.mockMFARepo,
+		loginGuard, sessionCache, nil, nil, s.mockMailer, s.cfg)
+}
+
+// assertExpectations はモックの呼び出しが期待通りだったか全体を検証する
+func (s *AuthServiceTestSuite) assertExpectations() {
+	s.mockTenantRepo.AssertExpectations(s.T())
+	s.mockIdentityRepo.AssertExpectations(s.T())
+	s.mockTokenRepo.AssertExpectations(s.T())
+	s.mockSessionRepo.AssertExpectations(s.T())
+	s.mockMFARepo.AssertExpectations(s.T())
+	s.mockLoginAttemptRepo.AssertExpectations(s.T())
+	s.mockMailer.AssertExpectations(s.T())
+}
+
+// assertAppErrorCode は err が code の AppError であることを検証する
+func (s *AuthServiceTestSuite) assertAppErrorCode(err error, code string) {
+	var appErr *model.AppError
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(code, appErr.Detail.Code)
 }
 
 // --- テストランナー ---
@@ -56,6 +96,10 @@ func TestAuthService(t *testing.T) {
 
 // --- RegisterTenantメソッドのテスト ---
 func (s *AuthServiceTestSuite) TestRegisterTenant() {
+	inactiveTenant := func() *model.Tenant {
+		return &model.Tenant{TenantID: uuid.New(), Name: "old name", Email: "test@example.com", IsActive: false}
+	}
+
 	// テストケースをテーブルとして定義
 	testCases := []struct {
 		name        string // テストケース名
@@ -69,33 +113,71 @@ func (s *AuthServiceTestSuite) TestRegisterTenant() {
 			setupMocks: func() {
 				// 正常系のモック設定
 				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(nil, model.ErrNotFound).Once()
-				s.mockTenantRepo.On("FindByName", mock.Anything, mock.Anything, "test").Return(nil, model.ErrNotFound).Once()
 				s.mockTenantRepo.On("Create", mock.Anything, mock.Anything, mock.AnythingOfType("*model.Tenant")).Return(nil).Once()
-				s.mockTokenRepo.On("CreateVerificationToken", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
-				s.mockMailer.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				s.mockIdentityRepo.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(i *model.Identity) bool {
+					return i.AuthProvider == model.AuthProviderLocal && i.ProviderID == "test@example.com" && i.PasswordHash != nil
+				})).Return(nil).Once()
+				s.mockTokenRepo.On("DeleteVerificationTokensByTenantID", mock.Anything, mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil).Once()
+				s.mockTokenRepo.On("CreateVerificationToken", mock.Anything, mock.Anything, mock.MatchedBy(func(t *model.UserVerificationToken) bool {
+					// 新規登録では反映を保留する内容はない
+					return t.Token != "" && t.PendingName == nil && t.PendingPasswordHash == nil
+				})).Return(nil).Once()
+				s.mockMailer.On("Send", mock.Anything, "test@example.com", mock.Anything, mock.Anything).Return(nil).Once()
 			},
 			checkResult: func(tenant *model.Tenant, err error) {
 				s.NoError(err)
 				s.NotNil(tenant)
 				s.Equal("test@example.com", tenant.Email)
+				s.False(tenant.IsActive)
 			},
 		},
 		{
 			name: "Failure - Emailが重複している",
 			req:  &model.RegisterRequest{Name: "test", Email: "test@example.com", Password: "password"},
 			setupMocks: func() {
-				// Email重複時のモック設定
-				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(&model.Tenant{}, nil).Once()
+				// Email重複時のモック設定 (有効化済みのアカウント)
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(&model.Tenant{IsActive: true}, nil).Once()
 			},
 			checkResult: func(tenant *model.Tenant, err error) {
 				s.Nil(tenant)
 				s.Error(err)
-				var appErr *model.AppError
-				s.ErrorAs(err, &appErr)
-				s.Equal("DUPLICATE_EMAIL", appErr.Detail.Code)
+				s.assertAppErrorCode(err, "DUPLICATE_EMAIL")
+			},
+		},
+		{
+			name: "Success - 有効化されていないアカウントには登録し直せる (名前とパスワードは有効化まで保留)",
+			req:  &model.RegisterRequest{Name: "new name", Email: "test@example.com", Password: "password"},
+			setupMocks: func() {
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(inactiveTenant(), nil).Once()
+				s.mockTokenRepo.On("FindLatestVerificationTokenByTenantID", mock.Anything, mock.Anything, mock.AnythingOfType("uuid.UUID")).
+					Return(&model.UserVerificationToken{CreatedAt: time.Now().Add(-time.Hour)}, nil).Once()
+				s.mockTokenRepo.On("DeleteVerificationTokensByTenantID", mock.Anything, mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil).Once()
+				s.mockTokenRepo.On("CreateVerificationToken", mock.Anything, mock.Anything, mock.MatchedBy(func(t *model.UserVerificationToken) bool {
+					return t.PendingName != nil && *t.PendingName == "new name" && t.PendingPasswordHash != nil
+				})).Return(nil).Once()
+				s.mockMailer.On("Send", mock.Anything, "test@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+				// 既存の名前・認証情報はここでは更新しない (Update や identityRepo は呼ばれない)
+			},
+			checkResult: func(tenant *model.Tenant, err error) {
+				s.NoError(err)
+				s.Require().NotNil(tenant)
+				s.Equal("old name", tenant.Name)
+			},
+		},
+		{
+			name: "Failure - 確認メールを送ったばかりのアカウントには登録し直せない",
+			req:  &model.RegisterRequest{Name: "new name", Email: "test@example.com", Password: "password"},
+			setupMocks: func() {
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(inactiveTenant(), nil).Once()
+				s.mockTokenRepo.On("FindLatestVerificationTokenByTenantID", mock.Anything, mock.Anything, mock.AnythingOfType("uuid.UUID")).
+					Return(&model.UserVerificationToken{CreatedAt: time.Now()}, nil).Once()
+			},
+			checkResult: func(tenant *model.Tenant, err error) {
+				s.Error(err)
+				s.assertAppErrorCode(err, "VERIFICATION_EMAIL_RECENTLY_SENT")
+				s.ErrorIs(err, model.ErrTooManyRequests)
 			},
 		},
-		// ここに他のテストケース（名前の重複、DBエラーなど）を追加していく
 	}
 
 	// テーブルのループ実行
@@ -114,14 +196,139 @@ func (s *AuthServiceTestSuite) TestRegisterTenant() {
 			tc.checkResult(createdTenant, err)
 
 			// モックの呼び出しが期待通りだったか全体を検証
-			s.mockTenantRepo.AssertExpectations(s.T())
-			s.mockTokenRepo.AssertExpectations(s.T())
-			s.mockMailer.AssertExpectations(s.T())
+			s.assertExpectations()
 		})
 	}
 }
 
-// --- Loginメソッドのテストも同様に記述 ---
+// --- Loginメソッドのテスト ---
 func (s *AuthServiceTestSuite) TestLogin() {
-	// ... Login用のテストケーステーブルを定義 ...
+	tenantID := uuid.New()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	s.Require().NoError(err)
+	hashStr := string(hash)
+	account := "account:email:test@example.com"
+	localIdentity := &model.Identity{ID: 1, TenantID: tenantID, AuthProvider: model.AuthProviderLocal, ProviderID: "test@example.com", PasswordHash: &hashStr}
+
+	testCases := []struct {
+		name        string
+		req         *model.LoginRequest
+		setupMocks  func()
+		checkResult func(res *model.LoginResponse, err error)
+	}{
+		{
+			name: "Success - パスワードでログインしてセッションを作成する",
+			req:  &model.LoginRequest{Email: "Test@example.com", Password: "password"},
+			setupMocks: func() {
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				s.mockIdentityRepo.On("FindByProvider", mock.Anything, mock.Anything, model.AuthProviderLocal, "Test@example.com").Return(localIdentity, nil).Once()
+				s.mockLoginAttemptRepo.On("Delete", mock.Anything, mock.Anything, account).Return(nil).Once()
+				s.mockTenantRepo.On("FindByID", mock.Anything, mock.Anything, tenantID).Return(&model.Tenant{TenantID: tenantID, IsActive: true}, nil).Once()
+				s.mockMFARepo.On("FindTOTP", mock.Anything, mock.Anything, tenantID).Return(nil, model.ErrNotFound).Once()
+				s.mockSessionRepo.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(session *model.Session) bool {
+					return session.TenantID == tenantID
+				})).Return(nil).Once()
+				s.mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.AnythingOfType("*model.RefreshToken")).Return(nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Require().NoError(err)
+				s.NotEmpty(res.AccessToken)
+				s.NotEmpty(res.RefreshToken)
+				s.False(res.MFARequired)
+			},
+		},
+		{
+			name: "Success - 2段階認証が有効な場合は MFA トークンだけを返す",
+			req:  &model.LoginRequest{Email: "test@example.com", Password: "password"},
+			setupMocks: func() {
+				enabledAt := time.Now()
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				s.mockIdentityRepo.On("FindByProvider", mock.Anything, mock.Anything, model.AuthProviderLocal, "test@example.com").Return(localIdentity, nil).Once()
+				s.mockLoginAttemptRepo.On("Delete", mock.Anything, mock.Anything, account).Return(nil).Once()
+				s.mockTenantRepo.On("FindByID", mock.Anything, mock.Anything, tenantID).Return(&model.Tenant{TenantID: tenantID, IsActive: true}, nil).Once()
+				s.mockMFARepo.On("FindTOTP", mock.Anything, mock.Anything, tenantID).Return(&model.TenantTOTP{TenantID: tenantID, EnabledAt: &enabledAt}, nil).Once()
+				// セッションは作成しない
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Require().NoError(err)
+				s.True(res.MFARequired)
+				s.NotEmpty(res.MFAToken)
+				s.Empty(res.AccessToken)
+			},
+		},
+		{
+			name: "Failure - パスワードが違う場合は失敗回数を記録する",
+			req:  &model.LoginRequest{Email: "test@example.com", Password: "wrong"},
+			setupMocks: func() {
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				s.mockIdentityRepo.On("FindByProvider", mock.Anything, mock.Anything, model.AuthProviderLocal, "test@example.com").Return(localIdentity, nil).Once()
+				s.mockLoginAttemptRepo.On("FindOrCreateForUpdate", mock.Anything, mock.Anything, account).Return(&model.LoginAttempt{Key: account}, nil).Once()
+				s.mockLoginAttemptRepo.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(a *model.LoginAttempt) bool {
+					return a.Key == account && a.Failures == 1 && a.LockedUntil == nil
+				})).Return(nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "AUTHENTICATION_FAILED")
+			},
+		},
+		{
+			name: "Failure - アカウントが存在しない場合もパスワードが違う場合と同じエラー",
+			req:  &model.LoginRequest{Email: "test@example.com", Password: "password"},
+			setupMocks: func() {
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				s.mockIdentityRepo.On("FindByProvider", mock.Anything, mock.Anything, model.AuthProviderLocal, "test@example.com").Return(nil, model.ErrNotFound).Once()
+				s.mockLoginAttemptRepo.On("FindOrCreateForUpdate", mock.Anything, mock.Anything, account).Return(&model.LoginAttempt{Key: account}, nil).Once()
+				s.mockLoginAttemptRepo.On("Save", mock.Anything, mock.Anything, mock.AnythingOfType("*model.LoginAttempt")).Return(nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "AUTHENTICATION_FAILED")
+			},
+		},
+		{
+			name: "Failure - ロック中はパスワードを確認せずに拒否する",
+			req:  &model.LoginRequest{Email: "test@example.com", Password: "password"},
+			setupMocks: func() {
+				lockedUntil := time.Now().Add(time.Minute)
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).
+					Return([]*model.LoginAttempt{{Key: account, Failures: 5, LockedUntil: &lockedUntil}}, nil).Once()
+				// identityRepo は呼ばれない
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "ACCOUNT_LOCKED")
+				var appErr *model.AppError
+				s.Require().ErrorAs(err, &appErr)
+				s.Greater(appErr.RetryAfter, time.Duration(0))
+			},
+		},
+		{
+			name: "Failure - 有効化されていないアカウント",
+			req:  &model.LoginRequest{Email: "test@example.com", Password: "password"},
+			setupMocks: func() {
+				s.mockLoginAttemptRepo.On("FindByKeys", mock.Anything, mock.Anything, []string{account}).Return([]*model.LoginAttempt{}, nil).Once()
+				s.mockIdentityRepo.On("FindByProvider", mock.Anything, mock.Anything, model.AuthProviderLocal, "test@example.com").Return(localIdentity, nil).Once()
+				s.mockLoginAttemptRepo.On("Delete", mock.Anything, mock.Anything, account).Return(nil).Once()
+				s.mockTenantRepo.On("FindByID", mock.Anything, mock.Anything, tenantID).Return(&model.Tenant{TenantID: tenantID, IsActive: false}, nil).Once()
+			},
+			checkResult: func(res *model.LoginResponse, err error) {
+				s.Nil(res)
+				s.assertAppErrorCode(err, "ACCOUNT_NOT_ACTIVE")
+				s.ErrorIs(err, model.ErrForbidden)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			res, err := s.authService.Login(context.Background(), tc.req)
+
+			tc.checkResult(res, err)
+			s.assertExpectations()
+		})
+	}
 }
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// startSession はログインしたテナントの新しいセッションを作成し、アクセストークンとリフレッシュトークンを発行します
+func (s *authService) startSession(ctx context.Context, tenant *model.Tenant) (*model.LoginResponse, error) {
+	client := middleware.GetClientInfo(ctx)
+	var res *model.LoginResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		session := &model.Session{
+			SessionID:  uuid.New(),
+			TenantID:   tenant.TenantID,
+			UserAgent:  client.UserAgent,
+			IPAddress:  client.IPAddress,
+			LastSeenAt: time.Now(),
+		}
+		if err := s.sessionRepo.Create(ctx, tx, session); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "セッションの作成に失敗しました。", "", err)
+		}
+
+		var err error
+		res, err = s.issueTokens(ctx, tx, tenant, session.SessionID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Logout はリクエストのセッションを失効させます。既に失効している場合も成功とします。
+func (s *authService) Logout(ctx context.Context, tenantID, sessionID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID, "session_id", sessionID)
+	if err := s.revokeSession(ctx, tenantID, sessionID); err != nil && !errors.Is(err, model.ErrNotFound) {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "ログアウトに失敗しました。", "", err)
+	}
+	logger.Info("Logged out")
+	return nil
+}
+
+// ListSessions はログイン中のセッションを最後に使われた順に返します
+func (s *authService) ListSessions(ctx context.Context, tenantID, currentSessionID uuid.UUID) ([]model.SessionResponse, error) {
+	// リフレッシュトークンの有効期限を過ぎて使われていないセッションは、もう再発行できないので含めない
+	ttl := s.cfg.JWT.RefreshTokenTTL
+	if ttl <= 0 {
+		ttl = defaultRefreshTokenTTL
+	}
+	sessions, err := s.sessionRepo.FindActiveByTenantID(ctx, s.db, tenantID, time.Now().Add(-ttl))
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "セッションの取得に失敗しました。", "", err)
+	}
+
+	res := make([]model.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, model.SessionResponse{
+			SessionID:  session.SessionID,
+			Device:     describeUserAgent(session.UserAgent),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.SessionID == currentSessionID,
+		})
+	}
+	return res, nil
+}
+
+// RevokeSession は指定したセッションを失効させ、その端末をログアウトさせます
+func (s *authService) RevokeSession(ctx context.Context, tenantID, sessionID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID, "session_id", sessionID)
+	if err := s.revokeSession(ctx, tenantID, sessionID); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("SESSION_NOT_FOUND", "セッションが見つかりません。", "session_id", model.ErrNotFound)
+		}
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "セッションの失効に失敗しました。", "", err)
+	}
+	logger.Info("Session revoked")
+	return nil
+}
+
+// revokeSession はセッションと、そのセッションのリフレッシュトークンをすべて失効させます。
+// セッションが見つからないか既に失効している場合は model.ErrNotFound を返します。
+func (s *authService) revokeSession(ctx context.Context, tenantID, sessionID uuid.UUID) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.sessionRepo.Revoke(ctx, tx, tenantID, sessionID); err != nil {
+			return err
+		}
+		return s.tokenRepo.RevokeRefreshTokenFamily(ctx, tx, sessionID)
+	})
+	if err != nil {
+		return err
+	}
+	s.sessionCache.Invalidate(sessionID)
+	return nil
+}
+
+// revokeOtherSessions は keepSessionID 以外のセッションと、それらのリフレッシュトークンをすべて失効させ、失効させたセッションのIDを返します。
+// トランザクションのコミット後に、返したセッションの sessionCache を無効にしてください。
+func (s *authService) revokeOtherSessions(ctx context.Context, tx *gorm.DB, tenantID, keepSessionID uuid.UUID) ([]uuid.UUID, error) {
+	revoked, err := s.sessionRepo.RevokeOthers(ctx, tx, tenantID, keepSessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range revoked {
+		if err := s.tokenRepo.RevokeRefreshTokenFamily(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+	return revoked, nil
+}
+
+// describeUserAgent は User-Agent から "Chrome on Windows" のような端末の説明を作ります。判別できない場合は空文字を返します。
+func describeUserAgent(ua string) string {
+	if ua == "" {
+		return ""
+	}
+	browser := firstMatch(ua, [][2]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"CriOS/", "Chrome"},
+		{"Safari/", "Safari"},
+	})
+	platform := firstMatch(ua, [][2]string{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	})
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	default:
+		return platform
+	}
+}
+
+// firstMatch は patterns のうち ua に最初に含まれるものの名前を返します
+func firstMatch(ua string, patterns [][2]string) string {
+	for _, p := range patterns {
+		if strings.Contains(ua, p[0]) {
+			return p[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository/mocks"
+	"go_4_vocab_keep/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// --- セッション管理のテスト ---
+func (s *AuthServiceTestSuite) TestSessions() {
+	tenantID := uuid.New()
+	currentID := uuid.New()
+	otherID := uuid.New()
+
+	s.Run("Success - ListSessions は端末の説明と現在のセッションを返す", func() {
+		s.SetupTest()
+		s.mockSessionRepo.On("FindActiveByTenantID", mock.Anything, mock.Anything, tenantID, mock.AnythingOfType("time.Time")).Return([]*model.Session{
+			{SessionID: currentID, TenantID: tenantID, UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"},
+			{SessionID: otherID, TenantID: tenantID, UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"},
+		}, nil).Once()
+
+		sessions, err := s.authService.ListSessions(context.Background(), tenantID, currentID)
+
+		s.Require().NoError(err)
+		s.Require().Len(sessions, 2)
+		s.Equal("Chrome on Windows", sessions[0].Device)
+		s.True(sessions[0].Current)
+		s.Equal("Safari on iOS", sessions[1].Device)
+		s.False(sessions[1].Current)
+		s.assertExpectations()
+	})
+
+	s.Run("Success - RevokeSession はセッションとリフレッシュトークンを失効させる", func() {
+		s.SetupTest()
+		s.mockSessionRepo.On("Revoke", mock.Anything, mock.Anything, tenantID, otherID).Return(nil).Once()
+		s.mockTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, otherID).Return(nil).Once()
+
+		err := s.authService.RevokeSession(context.Background(), tenantID, otherID)
+
+		s.NoError(err)
+		s.assertExpectations()
+	})
+
+	s.Run("Failure - RevokeSession で他のテナントや失効済みのセッションは見つからない", func() {
+		s.SetupTest()
+		s.mockSessionRepo.On("Revoke", mock.Anything, mock.Anything, tenantID, otherID).Return(model.ErrNotFound).Once()
+
+		err := s.authService.RevokeSession(context.Background(), tenantID, otherID)
+
+		s.assertAppErrorCode(err, "SESSION_NOT_FOUND")
+		s.ErrorIs(err, model.ErrNotFound)
+		s.assertExpectations()
+	})
+
+	s.Run("Success - Logout は既に失効していても成功する", func() {
+		s.SetupTest()
+		s.mockSessionRepo.On("Revoke", mock.Anything, mock.Anything, tenantID, currentID).Return(model.ErrNotFound).Once()
+
+		err := s.authService.Logout(context.Background(), tenantID, currentID)
+
+		s.NoError(err)
+		s.assertExpectations()
+	})
+
+	s.Run("Failure - Logout でDBエラー", func() {
+		s.SetupTest()
+		s.mockSessionRepo.On("Revoke", mock.Anything, mock.Anything, tenantID, currentID).Return(nil).Once()
+		s.mockTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, mock.Anything, currentID).Return(errors.New("db error")).Once()
+
+		err := s.authService.Logout(context.Background(), tenantID, currentID)
+
+		s.assertAppErrorCode(err, "INTERNAL_SERVER_ERROR")
+		s.assertExpectations()
+	})
+}
+
+// --- SessionCacheのテスト ---
+func TestSessionCache(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	tenantID := uuid.New()
+	sessionID := uuid.New()
+
+	t.Run("正常系: 有効なセッションの確認結果をキャッシュする", func(t *testing.T) {
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(&model.Session{SessionID: sessionID, TenantID: tenantID}, nil).Once()
+		sessionRepo.On("Touch", mock.Anything, mock.Anything, sessionID, mock.Anything).Return(nil).Once()
+		cache := service.NewSessionCache(db, sessionRepo, &config.Config{})
+
+		for i := 0; i < 2; i++ {
+			active, err := cache.IsSessionActive(ctx, tenantID, sessionID)
+			require.NoError(t, err)
+			assert.True(t, active)
+		}
+		// 他のテナントのトークンに同じ jti が入っていても有効とみなさない
+		active, err := cache.IsSessionActive(ctx, uuid.New(), sessionID)
+		require.NoError(t, err)
+		assert.False(t, active)
+		sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 失効させた後は Invalidate でDBを確認し直す", func(t *testing.T) {
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(&model.Session{SessionID: sessionID, TenantID: tenantID}, nil).Once()
+		sessionRepo.On("Touch", mock.Anything, mock.Anything, sessionID, mock.Anything).Return(nil).Once()
+		cache := service.NewSessionCache(db, sessionRepo, &config.Config{})
+
+		active, err := cache.IsSessionActive(ctx, tenantID, sessionID)
+		require.NoError(t, err)
+		assert.True(t, active)
+
+		cache.Invalidate(sessionID)
+		sessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(nil, model.ErrNotFound).Once()
+		active, err = cache.IsSessionActive(ctx, tenantID, sessionID)
+		require.NoError(t, err)
+		assert.False(t, active)
+		sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: DBエラーはキャッシュせずに返す", func(t *testing.T) {
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("FindByID", mock.Anything, mock.Anything, tenantID, sessionID).Return(nil, errors.New("db error")).Twice()
+		cache := service.NewSessionCache(db, sessionRepo, &config.Config{})
+
+		for i := 0; i < 2; i++ {
+			_, err := cache.IsSessionActive(ctx, tenantID, sessionID)
+			assert.Error(t, err)
+		}
+		sessionRepo.AssertExpectations(t)
+	})
+}
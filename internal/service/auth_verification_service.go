@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultVerificationResendCooldown は auth.verification_resend_cooldown が未設定の場合に確認メールを送り直せる間隔
+const defaultVerificationResendCooldown = time.Minute
+
+// ResendVerificationEmail は有効化されていないアカウントに確認メールを送り直します。前回のリンクは使えなくなります。
+// メールアドレスが登録されているかどうかを知られないよう、アカウントがない・有効化済み・前回の送信から間もない場合も何もせずに成功を返します。
+func (s *authService) ResendVerificationEmail(ctx context.Context, email string) error {
+	logger := middleware.GetLogger(ctx).With("email", email)
+
+	tenant, err := s.tenantRepo.FindByEmail(ctx, s.db, email)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			logger.Warn("Verification email resend requested for non-existent tenant")
+			return nil
+		}
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "エラーが発生しました。", "", err)
+	}
+	if tenant.IsActive {
+		logger.Info("Verification email resend requested for active tenant", "tenant_id", tenant.TenantID)
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		wait, err := s.verificationResendWait(ctx, tx, tenant.TenantID)
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			logger.Warn("Verification email resend requested during cooldown", "tenant_id", tenant.TenantID, "retry_after", wait)
+			return nil
+		}
+		// 登録し直した内容は、送り直したリンクで有効化した場合にも反映する
+		var pendingName, pendingPasswordHash *string
+		latest, err := s.tokenRepo.FindLatestVerificationTokenByTenantID(ctx, tx, tenant.TenantID)
+		if err != nil && !errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "エラーが発生しました。", "", err)
+		}
+		if latest != nil {
+			pendingName, pendingPasswordHash = latest.PendingName, latest.PendingPasswordHash
+		}
+		if err := s.sendNewVerificationEmail(ctx, tx, tenant, pendingName, pendingPasswordHash); err != nil {
+			return err
+		}
+		logger.Info("Verification email resent", "tenant_id", tenant.TenantID)
+		return nil
+	})
+}
+
+// reregisterInactiveTenant は有効化されていないアカウントに登録し直した名前とパスワードで、確認メールを送り直します。
+// 先に他人が同じメールアドレスで登録した場合に乗っ取られないよう、既存の名前とパスワードはここでは書き換えず、
+// 新しい有効化トークンに保存して、そのリンクで有効化した時に限り反映します (VerifyAccount)。
+func (s *authService) reregisterInactiveTenant(ctx context.Context, tx *gorm.DB, tenant *model.Tenant, name, passwordHash string) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenant.TenantID)
+
+	wait, err := s.verificationResendWait(ctx, tx, tenant.TenantID)
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		logger.Warn("Re-registration requested during verification email cooldown", "retry_after", wait)
+		appErr := model.NewAppError("VERIFICATION_EMAIL_RECENTLY_SENT", "確認メールを送信したばかりです。しばらく待ってから再度お試しください。", "", model.ErrTooManyRequests)
+		appErr.RetryAfter = wait
+		return appErr
+	}
+
+	if err := s.sendNewVerificationEmail(ctx, tx, tenant, &name, &passwordHash); err != nil {
+		return err
+	}
+	logger.Info("Inactive tenant re-registered, pending until verification")
+	return nil
+}
+
+// applyPendingRegistration は有効化トークンに保存された、登録し直した名前とパスワードをアカウントに反映します
+func (s *authService) applyPendingRegistration(ctx context.Context, tx *gorm.DB, token *model.UserVerificationToken) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", token.TenantID)
+
+	if token.PendingName != nil {
+		if err := tx.Model(&model.Tenant{}).Where("tenant_id = ?", token.TenantID).Update("name", *token.PendingName).Error; err != nil {
+			logger.Error("Failed to apply re-registered name", "error", err)
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザーの更新に失敗しました。", "", err)
+		}
+	}
+	if token.PendingPasswordHash == nil {
+		return nil
+	}
+
+	identity, err := s.identityRepo.FindByTenantIDAndProvider(ctx, tx, token.TenantID, model.AuthProviderLocal)
+	switch {
+	case err == nil:
+		if err := tx.Model(identity).Update("password_hash", token.PendingPasswordHash).Error; err != nil {
+			logger.Error("Failed to apply re-registered password", "error", err)
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "認証情報の更新に失敗しました。", "", err)
+		}
+	case errors.Is(err, model.ErrNotFound):
+		tenant, err := s.tenantRepo.FindByID(ctx, tx, token.TenantID)
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "ユーザーの取得に失敗しました。", "", err)
+		}
+		identity = &model.Identity{
+			TenantID:     token.TenantID,
+			AuthProvider: model.AuthProviderLocal,
+			ProviderID:   tenant.Email,
+			PasswordHash: token.PendingPasswordHash,
+		}
+		if err := s.identityRepo.Create(ctx, tx, identity); err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "認証情報の作成に失敗しました。", "", err)
+		}
+	default:
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", err)
+	}
+
+	logger.Info("Re-registered credentials applied on verification")
+	return nil
+}
+
+// sendNewVerificationEmail は発行済みの有効化トークンを削除し、新しいトークンで確認メールを送ります。
+// 最後に発行したトークンだけを有効にするため、登録し直す前のリンクでは有効化できなくなります。
+// pendingName と pendingPasswordHash は登録し直した場合に指定し、有効化した時に反映します。
+func (s *authService) sendNewVerificationEmail(ctx context.Context, tx *gorm.DB, tenant *model.Tenant, pendingName, pendingPasswordHash *string) error {
+	if err := s.tokenRepo.DeleteVerificationTokensByTenantID(ctx, tx, tenant.TenantID); err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "トークンの削除に失敗しました。", "", err)
+	}
+	tokenString, err := s.generateAndSaveVerificationToken(ctx, tx, &model.UserVerificationToken{
+		TenantID:            tenant.TenantID,
+		PendingName:         pendingName,
+		PendingPasswordHash: pendingPasswordHash,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.sendVerificationEmail(ctx, tenant.Email, tokenString); err != nil {
+		return model.NewAppError("EMAIL_SEND_FAILED", "確認メールの送信に失敗しました。", "", err)
+	}
+	return nil
+}
+
+// verificationResendWait は確認メールを送り直せるようになるまでの残り時間を返します。すぐに送れる場合は0を返します。
+func (s *authService) verificationResendWait(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID) (time.Duration, error) {
+	cooldown := s.cfg.Auth.VerificationResendCooldown
+	if cooldown <= 0 {
+		cooldown = defaultVerificationResendCooldown
+	}
+
+	latest, err := s.tokenRepo.FindLatestVerificationTokenByTenantID(ctx, tx, tenantID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, model.NewAppError("INTERNAL_SERVER_ERROR", "エラーが発生しました。", "", err)
+	}
+	if wait := time.Until(latest.CreatedAt.Add(cooldown)); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,157 @@
+package service_test
+
+import (
+	"context"
+	"time"
+
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// --- ResendVerificationEmailメソッドのテスト ---
+func (s *AuthServiceTestSuite) TestResendVerificationEmail() {
+	tenant := &model.Tenant{TenantID: uuid.New(), Name: "name", Email: "test@example.com", IsActive: false}
+	pendingName := "new name"
+	pendingHash := "pending-hash"
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+	}{
+		{
+			name: "Success - 登録し直した内容を引き継いで送り直す",
+			setupMocks: func() {
+				latest := &model.UserVerificationToken{TenantID: tenant.TenantID, PendingName: &pendingName, PendingPasswordHash: &pendingHash, CreatedAt: time.Now().Add(-time.Hour)}
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(tenant, nil).Once()
+				s.mockTokenRepo.On("FindLatestVerificationTokenByTenantID", mock.Anything, mock.Anything, tenant.TenantID).Return(latest, nil).Twice()
+				s.mockTokenRepo.On("DeleteVerificationTokensByTenantID", mock.Anything, mock.Anything, tenant.TenantID).Return(nil).Once()
+				s.mockTokenRepo.On("CreateVerificationToken", mock.Anything, mock.Anything, mock.MatchedBy(func(t *model.UserVerificationToken) bool {
+					return t.PendingName != nil && *t.PendingName == pendingName && t.PendingPasswordHash != nil && *t.PendingPasswordHash == pendingHash
+				})).Return(nil).Once()
+				s.mockMailer.On("Send", mock.Anything, "test@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "Success - 前回の送信から間もない場合は何もしない",
+			setupMocks: func() {
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(tenant, nil).Once()
+				s.mockTokenRepo.On("FindLatestVerificationTokenByTenantID", mock.Anything, mock.Anything, tenant.TenantID).
+					Return(&model.UserVerificationToken{TenantID: tenant.TenantID, CreatedAt: time.Now()}, nil).Once()
+				// メールは送らない
+			},
+		},
+		{
+			name: "Success - 有効化済みのアカウントには送らない",
+			setupMocks: func() {
+				s.mockTenantRepo.On("FindByEmail", mock.Anything, mock.Anything, "test@example.com").Return(&model.Tenant{TenantID: tenant.TenantID, IsActive: true}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.setupMocks()
+
+			// アカウントの有無を知られないよう、どの場合も成功を返す
+			err := s.authService.ResendVerificationEmail(context.Background(), "test@example.com")
+
+			s.NoError(err)
+			s.assertExpectations()
+		})
+	}
+}
+
+// --- VerifyAccountメソッドのテスト ---
+func (s *AuthServiceTestSuite) TestVerifyAccount() {
+	// 有効化はテナントとログイン方法をDBで直接更新するため、テーブルを作成したDBを使う
+	db, err := gorm.Open(sqlite.Open("file:verify_account_test?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	s.Require().NoError(err)
+	s.Require().NoError(db.AutoMigrate(&model.Tenant{}, &model.Identity{}))
+
+	oldHash := "old-hash"
+	newHash := "new-hash"
+	newName := "new name"
+
+	testCases := []struct {
+		name      string
+		pending   bool
+		wantName  string
+		wantHash  string
+		tokenTTL  time.Duration
+		wantError string
+	}{
+		{
+			name:     "Success - 登録し直した名前とパスワードを有効化した時に反映する",
+			pending:  true,
+			wantName: newName,
+			wantHash: newHash,
+			tokenTTL: time.Hour,
+		},
+		{
+			name:     "Success - 登録し直していなければ既存の名前とパスワードのまま",
+			wantName: "old name",
+			wantHash: oldHash,
+			tokenTTL: time.Hour,
+		},
+		{
+			name:      "Failure - 有効期限切れのリンク",
+			wantName:  "old name",
+			wantHash:  oldHash,
+			tokenTTL:  -time.Minute,
+			wantError: "INVALID_TOKEN",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			authService := service.NewAuthService(db, s.mockTenantRepo, s.mockIdentityRepo, s.mockTokenRepo, s.mockSessionRepo, s.mockMFARepo,
+				service.NewLoginGuard(db, s.mockLoginAttemptRepo, s.cfg), service.NewSessionCache(db, s.mockSessionRepo, s.cfg), nil, nil, s.mockMailer, s.cfg)
+
+			tenantID := uuid.New()
+			hash := oldHash
+			s.Require().NoError(db.Create(&model.Tenant{TenantID: tenantID, Name: "old name", Email: tenantID.String() + "@example.com"}).Error)
+			identity := &model.Identity{TenantID: tenantID, AuthProvider: model.AuthProviderLocal, ProviderID: tenantID.String() + "@example.com", PasswordHash: &hash}
+			s.Require().NoError(db.Create(identity).Error)
+
+			token := &model.UserVerificationToken{Token: "token", TenantID: tenantID, ExpiresAt: time.Now().Add(tc.tokenTTL)}
+			if tc.pending {
+				token.PendingName, token.PendingPasswordHash = &newName, &newHash
+			}
+			s.mockTokenRepo.On("FindVerificationToken", mock.Anything, mock.Anything, "token").Return(token, nil).Once()
+			if tc.wantError == "" {
+				if tc.pending {
+					s.mockIdentityRepo.On("FindByTenantIDAndProvider", mock.Anything, mock.Anything, tenantID, model.AuthProviderLocal).Return(identity, nil).Once()
+				}
+				s.mockTokenRepo.On("DeleteVerificationTokensByTenantID", mock.Anything, mock.Anything, tenantID).Return(nil).Once()
+			} else {
+				s.mockTokenRepo.On("DeleteVerificationToken", mock.Anything, mock.Anything, "token").Return(nil).Once()
+			}
+
+			err := authService.VerifyAccount(context.Background(), "token")
+
+			var gotTenant model.Tenant
+			s.Require().NoError(db.First(&gotTenant, "tenant_id = ?", tenantID).Error)
+			var gotIdentity model.Identity
+			s.Require().NoError(db.First(&gotIdentity, identity.ID).Error)
+			if tc.wantError != "" {
+				s.assertAppErrorCode(err, tc.wantError)
+				s.False(gotTenant.IsActive)
+			} else {
+				s.Require().NoError(err)
+				s.True(gotTenant.IsActive)
+			}
+			s.Equal(tc.wantName, gotTenant.Name)
+			s.Require().NotNil(gotIdentity.PasswordHash)
+			s.Equal(tc.wantHash, *gotIdentity.PasswordHash)
+			s.assertExpectations()
+		})
+	}
+}
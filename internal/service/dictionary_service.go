@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/dictionary"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+)
+
+// defaultDictionaryMaxResults は dictionary.max_results が未設定の場合に返す見出しの最大数
+const defaultDictionaryMaxResults = 10
+
+// DictionaryService は単語の意味を辞書で調べます
+type DictionaryService interface {
+	Lookup(ctx context.Context, term string) (*model.DictionaryLookupResponse, error)
+}
+
+type dictionaryService struct {
+	provider   dictionary.DictionaryProvider
+	maxResults int
+}
+
+// NewDictionaryService は DictionaryService を返します。provider が nil (辞書を使わない設定) の場合、検索はエラーになります。
+func NewDictionaryService(provider dictionary.DictionaryProvider, cfg *config.Config) DictionaryService {
+	maxResults := cfg.Dictionary.MaxResults
+	if maxResults <= 0 {
+		maxResults = defaultDictionaryMaxResults
+	}
+	return &dictionaryService{provider: provider, maxResults: maxResults}
+}
+
+// Lookup は term を辞書で調べ、見つかった見出しを返します。見つからない場合は空の一覧を返します。
+func (s *dictionaryService) Lookup(ctx context.Context, term string) (*model.DictionaryLookupResponse, error) {
+	logger := middleware.GetLogger(ctx)
+	if s.provider == nil {
+		return nil, model.NewAppError("DICTIONARY_NOT_CONFIGURED", "辞書が設定されていないため、意味を調べられません。", "", model.ErrUnavailable)
+	}
+
+	term = strings.TrimSpace(term)
+	entries, err := s.provider.Lookup(ctx, term)
+	if err != nil {
+		logger.Error("Failed to look up dictionary", "error", err, "term", term)
+		return nil, model.NewAppError("DICTIONARY_LOOKUP_FAILED", "辞書の検索に失敗しました。", "", err)
+	}
+
+	res := &model.DictionaryLookupResponse{Term: term, Entries: make([]model.DictionaryEntry, 0, min(len(entries), s.maxResults))}
+	for _, e := range entries[:min(len(entries), s.maxResults)] {
+		res.Entries = append(res.Entries, model.DictionaryEntry{
+			Term:         e.Term,
+			Reading:      e.Reading,
+			Definitions:  e.Definitions,
+			PartOfSpeech: e.PartOfSpeech,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository"
+	"go_4_vocab_keep/internal/wordio"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExportService は単語と学習進捗のファイルへの書き出しを扱います
+type ExportService interface {
+	ExportWords(ctx context.Context, tenantID uuid.UUID, req *model.ExportWordsRequest, w io.Writer) error
+}
+
+type exportService struct {
+	db       *gorm.DB
+	wordRepo repository.WordRepository
+}
+
+func NewExportService(db *gorm.DB, wordRepo repository.WordRepository) ExportService {
+	return &exportService{
+		db:       db,
+		wordRepo: wordRepo,
+	}
+}
+
+// ExportWords は条件に合う単語を学習進捗とともに req.Format の形式で w に書き出します。
+// DBから1行ずつ読みながら書き出すため、件数が多くても全件をメモリに載せません。
+// w への最初の書き込みより前に起きたエラーは AppError として返します。
+func (s *exportService) ExportWords(ctx context.Context, tenantID uuid.UUID, req *model.ExportWordsRequest, w io.Writer) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	filter, err := buildWordFilter(req.Level, req.CreatedFrom, req.CreatedTo, req.Query)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := wordio.NewExporter(w, req.Format)
+	if err != nil {
+		if errors.Is(err, wordio.ErrUnsupportedFormat) {
+			return model.NewAppError("VALIDATION_ERROR", "未対応のエクスポート形式です。", "format", model.ErrInvalidInput)
+		}
+		logger.Error("Failed to initialize exporter", "error", err, "format", req.Format)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "エクスポートの準備に失敗しました。", "", err)
+	}
+
+	count := 0
+	err = s.wordRepo.StreamWithProgress(ctx, s.db, tenantID, filter, func(row *model.WordWithProgress) error {
+		count++
+		return exporter.Write(toExportRecord(row))
+	})
+	if err != nil {
+		exporter.Close()
+		logger.Error("Failed to export words", "error", err, "exported", count)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "単語のエクスポートに失敗しました。", "", err)
+	}
+	if err := exporter.Close(); err != nil {
+		logger.Error("Failed to finish export", "error", err, "exported", count)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "単語のエクスポートに失敗しました。", "", err)
+	}
+
+	logger.Info("Words exported", "format", req.Format, "count", count)
+	return nil
+}
+
+func toExportRecord(row *model.WordWithProgress) wordio.ExportRecord {
+	rec := wordio.ExportRecord{
+		WordID:         row.WordID.String(),
+		Term:           row.Term,
+		Definition:     row.Definition,
+		Tags:           row.Tags,
+		NextReviewDate: row.NextReviewDate,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+	if row.Level != nil {
+		rec.Level = int(*row.Level)
+	}
+	return rec
+}
+
+// buildWordFilter はクエリパラメータの値から単語の絞り込み条件を組み立てます。
+// 日付は RFC3339 か YYYY-MM-DD で指定でき、YYYY-MM-DD の上限はその日の終わりまでを含みます。
+func buildWordFilter(level int, createdFrom, createdTo, query string) (model.WordFilter, error) {
+	var filter model.WordFilter
+	if level > 0 {
+		l := model.ProgressLevel(level)
+		filter.Level = &l
+	}
+	if createdFrom != "" {
+		t, _, err := parseFilterDate(createdFrom)
+		if err != nil {
+			return filter, model.NewAppError("VALIDATION_ERROR", "created_fromはRFC3339またはYYYY-MM-DD形式で指定してください。", "created_from", model.ErrInvalidInput)
+		}
+		filter.CreatedFrom = &t
+	}
+	if createdTo != "" {
+		t, dateOnly, err := parseFilterDate(createdTo)
+		if err != nil {
+			return filter, model.NewAppError("VALIDATION_ERROR", "created_toはRFC3339またはYYYY-MM-DD形式で指定してください。", "created_to", model.ErrInvalidInput)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.CreatedTo = &t
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, model.NewAppError("VALIDATION_ERROR", "created_fromはcreated_toより前の日時を指定してください。", "created_from", model.ErrInvalidInput)
+	}
+	filter.Query = query
+	return filter, nil
+}
+
+func parseFilterDate(s string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	return t, true, nil
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository"
+	"go_4_vocab_keep/internal/wordio"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// importBatchSize は1トランザクションで取り込む行数
+const importBatchSize = 200
+
+// maxSuffixAttempts は重複時に "term (n)" を探す上限
+const maxSuffixAttempts = 100
+
+// maxImportTermLength は取り込む単語の最大文字数 (リクエストの term の上限に合わせる)
+const maxImportTermLength = 255
+
+// ImportService は外部ファイルからの単語の一括取り込みを扱います
+type ImportService interface {
+	ImportDelimited(ctx context.Context, tenantID uuid.UUID, r io.Reader, req *model.ImportWordsRequest) (*model.ImportWordsResponse, error)
+	ImportAnki(ctx context.Context, tenantID uuid.UUID, r io.ReaderAt, size int64, req *model.ImportAnkiRequest) (*model.ImportWordsResponse, error)
+}
+
+type importService struct {
+	db         *gorm.DB
+	wordRepo   repository.WordRepository
+	progRepo   repository.ProgressRepository
+	cfg        *config.Config
+	normalizer termNormalizer
+}
+
+func NewImportService(db *gorm.DB, wordRepo repository.WordRepository, progRepo repository.ProgressRepository, cfg *config.Config) ImportService {
+	return &importService{
+		db:         db,
+		wordRepo:   wordRepo,
+		progRepo:   progRepo,
+		cfg:        cfg,
+		normalizer: newTermNormalizer(cfg),
+	}
+}
+
+// ImportDelimited は CSV/TSV を読み込み、単語として取り込みます。
+// DryRun の場合はDBに書き込まず、各行がどう処理されるかだけを返します。
+func (s *importService) ImportDelimited(ctx context.Context, tenantID uuid.UUID, r io.Reader, req *model.ImportWordsRequest) (*model.ImportWordsResponse, error) {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	records, err := wordio.ReadDelimited(r, wordio.DelimitedOptions{
+		Format:           req.Format,
+		Encoding:         req.Encoding,
+		TermColumn:       req.TermColumn,
+		DefinitionColumn: req.DefinitionColumn,
+	})
+	if err != nil {
+		logger.Warn("Failed to parse import file", "error", err)
+		return nil, newImportFileError(err)
+	}
+
+	return s.importRecords(ctx, tenantID, records, req.OnDuplicate, req.DryRun)
+}
+
+// ImportAnki は Anki のパッケージ (.apkg/.colpkg) を読み込み、ノートを単語として取り込みます。
+// WithScheduling の場合は、カードの復習間隔を学習レベルに変換して学習進捗を引き継ぎます。
+func (s *importService) ImportAnki(ctx context.Context, tenantID uuid.UUID, r io.ReaderAt, size int64, req *model.ImportAnkiRequest) (*model.ImportWordsResponse, error) {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+
+	records, err := wordio.ReadAnki(r, size, wordio.AnkiOptions{
+		TermField:         req.TermField,
+		DefinitionField:   req.DefinitionField,
+		WithScheduling:    req.WithScheduling,
+		MaxCollectionSize: s.cfg.Import.MaxAnkiCollectionSize,
+	})
+	if err != nil {
+		logger.Warn("Failed to parse anki package", "error", err)
+		return nil, newImportFileError(err)
+	}
+
+	return s.importRecords(ctx, tenantID, records, req.OnDuplicate, req.DryRun)
+}
+
+// importRecords は読み込んだレコードを importBatchSize 件ずつのトランザクションで取り込みます。
+// あるバッチでDBエラーが起きた場合、そのバッチはロールバックされ、それ以前のバッチは確定したままになります。
+func (s *importService) importRecords(ctx context.Context, tenantID uuid.UUID, records []wordio.Record, strategy string, dryRun bool) (*model.ImportWordsResponse, error) {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
+	if strategy == "" {
+		strategy = model.DuplicateStrategySkip
+	}
+
+	resp := &model.ImportWordsResponse{
+		DryRun: dryRun,
+		Total:  len(records),
+		Rows:   make([]model.ImportRowResult, 0, len(records)),
+	}
+	// ファイル内で先に出現した単語 (正規化済み)。DryRunではDBに書き込まないため、ファイル内の重複はここで検出する
+	claimed := make(map[string]bool)
+
+	for start := 0; start < len(records); start += importBatchSize {
+		end := min(start+importBatchSize, len(records))
+		batch := records[start:end]
+		batchClaimed := make(map[string]bool)
+		var batchRows []model.ImportRowResult
+
+		run := func(tx *gorm.DB) error {
+			batchRows = batchRows[:0]
+			for _, rec := range batch {
+				row, err := s.importRecord(ctx, tx, tenantID, rec, strategy, dryRun, claimed, batchClaimed)
+				if err != nil {
+					return err
+				}
+				batchRows = append(batchRows, row)
+			}
+			return nil
+		}
+
+		var err error
+		if dryRun {
+			err = run(s.db.WithContext(ctx))
+		} else {
+			err = s.db.WithContext(ctx).Transaction(run)
+		}
+		if err != nil {
+			logger.Error("Import batch failed", "error", err, "first_row", batch[0].Row, "imported_rows", start)
+			return nil, model.NewAppError("IMPORT_FAILED", fmt.Sprintf("%d行目以降の取り込み中にエラーが発生しました。それ以前の行は取り込み済みです。", batch[0].Row), "", err)
+		}
+
+		for term := range batchClaimed {
+			claimed[term] = true
+		}
+		for _, row := range batchRows {
+			resp.Rows = append(resp.Rows, row)
+			switch row.Status {
+			case model.ImportStatusCreated:
+				resp.Created++
+			case model.ImportStatusOverwritten:
+				resp.Overwritten++
+			case model.ImportStatusRenamed:
+				resp.Renamed++
+			case model.ImportStatusSkipped:
+				resp.Skipped++
+			case model.ImportStatusFailed:
+				resp.Failed++
+			}
+		}
+	}
+
+	logger.Info("Import finished",
+		"dry_run", dryRun,
+		"total", resp.Total,
+		"created", resp.Created,
+		"overwritten", resp.Overwritten,
+		"renamed", resp.Renamed,
+		"skipped", resp.Skipped,
+		"failed", resp.Failed,
+	)
+	return resp, nil
+}
+
+// importRecord は1行分を取り込みます。行単位の問題は結果に failed として記録し、
+// DBエラーのみを error として返します (バッチ全体がロールバックされる)。
+func (s *importService) importRecord(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, rec wordio.Record, strategy string, dryRun bool, claimed, batchClaimed map[string]bool) (model.ImportRowResult, error) {
+	row := model.ImportRowResult{Row: rec.Row, Term: rec.Term}
+
+	if rec.Term == "" {
+		row.Status = model.ImportStatusFailed
+		row.Message = "単語が空です。"
+		return row, nil
+	}
+	if utf8.RuneCountInString(rec.Term) > maxImportTermLength {
+		row.Status = model.ImportStatusFailed
+		row.Message = fmt.Sprintf("単語が長すぎます (%d文字以内)。", maxImportTermLength)
+		return row, nil
+	}
+	if rec.Definition == "" {
+		row.Status = model.ImportStatusFailed
+		row.Message = "意味が空です。"
+		return row, nil
+	}
+
+	exists, err := s.termTaken(ctx, tx, tenantID, rec.Term, claimed, batchClaimed)
+	if err != nil {
+		return row, err
+	}
+
+	if !exists {
+		wordID, err := s.createImportedWord(ctx, tx, tenantID, rec.Term, rec, dryRun)
+		if err != nil {
+			return row, err
+		}
+		batchClaimed[s.normalizer.normalize(rec.Term)] = true
+		row.Status = model.ImportStatusCreated
+		row.WordID = wordID
+		return row, nil
+	}
+
+	switch strategy {
+	case model.DuplicateStrategyOverwrite:
+		existing, err := s.wordRepo.FindByNormalizedTerm(ctx, tx, tenantID, s.normalizer.normalize(rec.Term))
+		if err != nil && !errors.Is(err, model.ErrNotFound) {
+			return row, err
+		}
+		row.Status = model.ImportStatusOverwritten
+		if existing == nil {
+			// DryRunでファイル内の先行行とだけ重複している場合は、まだDBに存在しない
+			return row, nil
+		}
+		row.WordID = &existing.WordID
+		updates := make(map[string]interface{})
+		if existing.Definition != rec.Definition {
+			updates["Definition"] = rec.Definition
+		}
+		if len(rec.Tags) > 0 {
+			updates["Tags"] = mergeTags(existing.Tags, rec.Tags)
+		}
+		if !dryRun && len(updates) > 0 {
+			if err := s.wordRepo.Update(ctx, tx, tenantID, existing.WordID, updates); err != nil {
+				return row, err
+			}
+		}
+		return row, nil
+
+	case model.DuplicateStrategySuffix:
+		for n := 2; n <= maxSuffixAttempts; n++ {
+			candidate := fmt.Sprintf("%s (%d)", rec.Term, n)
+			if utf8.RuneCountInString(candidate) > maxImportTermLength {
+				// 連番が増えるほど長くなるため、以降の候補もすべて上限を超える
+				row.Status = model.ImportStatusFailed
+				row.Message = fmt.Sprintf("連番を付けると単語が長すぎます (%d文字以内)。", maxImportTermLength)
+				return row, nil
+			}
+			taken, err := s.termTaken(ctx, tx, tenantID, candidate, claimed, batchClaimed)
+			if err != nil {
+				return row, err
+			}
+			if taken {
+				continue
+			}
+			wordID, err := s.createImportedWord(ctx, tx, tenantID, candidate, rec, dryRun)
+			if err != nil {
+				return row, err
+			}
+			batchClaimed[s.normalizer.normalize(candidate)] = true
+			row.Status = model.ImportStatusRenamed
+			row.WordID = wordID
+			row.Message = fmt.Sprintf("「%s」として登録します。", candidate)
+			return row, nil
+		}
+		row.Status = model.ImportStatusFailed
+		row.Message = "連番を付けた単語名がすべて使用済みです。"
+		return row, nil
+
+	default:
+		row.Status = model.ImportStatusSkipped
+		row.Message = "その単語は既に登録されています。"
+		return row, nil
+	}
+}
+
+// termTaken は単語が既存の単語、またはこのインポートで先に取り込んだ単語と重複するかを返します (正規化した単語で比較する)
+func (s *importService) termTaken(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, term string, claimed, batchClaimed map[string]bool) (bool, error) {
+	key := s.normalizer.normalize(term)
+	if claimed[key] || batchClaimed[key] {
+		return true, nil
+	}
+	return s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, key, nil)
+}
+
+// createImportedWord は term という名前で rec の内容を登録します (連番付きの名前で登録する場合があるため term は別に受け取る)
+func (s *importService) createImportedWord(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, term string, rec wordio.Record, dryRun bool) (*uuid.UUID, error) {
+	if dryRun {
+		return nil, nil
+	}
+	word := &model.Word{
+		WordID:         uuid.New(),
+		TenantID:       tenantID,
+		Term:           term,
+		NormalizedTerm: s.normalizer.normalize(term),
+		Definition:     rec.Definition,
+		Tags:           rec.Tags,
+	}
+
+	if rec.Review == nil {
+		if err := createWordWithInitialProgress(ctx, tx, s.wordRepo, s.progRepo, word); err != nil {
+			return nil, err
+		}
+		return &word.WordID, nil
+	}
+
+	level, next := progressFromReviewState(rec.Review)
+	if err := createWordWithProgress(ctx, tx, s.wordRepo, s.progRepo, word, level, next); err != nil {
+		return nil, err
+	}
+	return &word.WordID, nil
+}
+
+// ankiLeechLapses はAnkiの既定のリーチ判定 (この回数以上忘れたカード) の閾値
+const ankiLeechLapses = 8
+
+// progressFromReviewState はインポート元の復習間隔を学習レベルに変換します。
+// レベルごとの復習間隔 (Level1: 3日, Level2: 7日, Level3: 14日) に合わせ、
+// 7日以上は Level3、3日以上は Level2 とします。リーチ化したカードは Level1 からやり直します。
+func progressFromReviewState(state *wordio.ReviewState) (model.ProgressLevel, time.Time) {
+	level := model.Level1
+	switch {
+	case state.Lapses >= ankiLeechLapses:
+		level = model.Level1
+	case state.IntervalDays >= 7:
+		level = model.Level3
+	case state.IntervalDays >= 3:
+		level = model.Level2
+	}
+	return level, state.Due
+}
+
+// mergeTags は既存のタグに新しいタグを重複なく追加します
+func mergeTags(existing, added []string) pq.StringArray {
+	merged := make(pq.StringArray, 0, len(existing)+len(added))
+	seen := make(map[string]bool)
+	for _, tag := range append(append([]string{}, existing...), added...) {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		merged = append(merged, tag)
+	}
+	return merged
+}
+
+// newImportFileError はファイル解析エラーをクライアント向けの AppError に変換します
+func newImportFileError(err error) *model.AppError {
+	switch {
+	case errors.Is(err, wordio.ErrFieldNotFound):
+		return model.NewAppError("INVALID_IMPORT_FILE", "指定されたフィールドがノートタイプに見つかりません。", "", fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	case errors.Is(err, wordio.ErrAnkiCollectionTooLarge):
+		return model.NewAppError("IMPORT_FILE_TOO_LARGE", "Ankiのパッケージが大きすぎるため読み込めません。", "file", fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	case errors.Is(err, wordio.ErrInvalidAnkiPackage):
+		return model.NewAppError("INVALID_IMPORT_FILE", "Ankiのパッケージ (.apkg/.colpkg) として読み込めませんでした。", "file", fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	case errors.Is(err, wordio.ErrColumnNotFound):
+		return model.NewAppError("INVALID_IMPORT_FILE", "指定された列がヘッダーに見つかりません。", "", fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	case errors.Is(err, wordio.ErrEmptyFile):
+		return model.NewAppError("INVALID_IMPORT_FILE", "ファイルが空です。", "file", model.ErrInvalidInput)
+	case errors.Is(err, wordio.ErrTooManyRecords):
+		return model.NewAppError("INVALID_IMPORT_FILE", fmt.Sprintf("一度に取り込めるのは%d行までです。", wordio.MaxRecords), "file", model.ErrInvalidInput)
+	case errors.Is(err, wordio.ErrUnsupportedFormat), errors.Is(err, wordio.ErrUnsupportedEncoding):
+		return model.NewAppError("INVALID_IMPORT_FILE", "未対応のファイル形式または文字コードです。", "", model.ErrInvalidInput)
+	default:
+		return model.NewAppError("INVALID_IMPORT_FILE", "ファイルを読み込めませんでした。形式と文字コードをご確認ください。", "file", fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository/mocks"
+	"go_4_vocab_keep/internal/wordio"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Test importRecords (単語の長さ) ---
+func Test_importService_importRecords_TermLength(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	maxTerm := strings.Repeat("あ", maxImportTermLength)
+	longTerm := strings.Repeat("あ", maxImportTermLength+1)
+	// " (2)" を付けると上限を超える長さの単語
+	nearMaxTerm := strings.Repeat("a", maxImportTermLength-3)
+
+	tests := []struct {
+		name        string
+		rec         wordio.Record
+		strategy    string
+		setupMock   func(wordRepo *mocks.WordRepository)
+		wantStatus  string
+		wantMessage string
+	}{
+		{
+			name: "正常系: 上限ちょうどの単語は取り込める",
+			rec:  wordio.Record{Row: 2, Term: maxTerm, Definition: "def"},
+			setupMock: func(wordRepo *mocks.WordRepository) {
+				wordRepo.On("CheckNormalizedTermExists", ctx, mock.Anything, tenantID, normalizedTerm(maxTerm), (*uuid.UUID)(nil)).Return(false, nil).Once()
+			},
+			wantStatus: model.ImportStatusCreated,
+		},
+		{
+			name:        "異常系: 上限を超える単語は failed になる",
+			rec:         wordio.Record{Row: 2, Term: longTerm, Definition: "def"},
+			setupMock:   func(wordRepo *mocks.WordRepository) { /* リポジトリは呼ばれない */ },
+			wantStatus:  model.ImportStatusFailed,
+			wantMessage: "単語が長すぎます",
+		},
+		{
+			name:     "異常系: 連番を付けると上限を超える場合は failed になる",
+			rec:      wordio.Record{Row: 2, Term: nearMaxTerm, Definition: "def"},
+			strategy: model.DuplicateStrategySuffix,
+			setupMock: func(wordRepo *mocks.WordRepository) {
+				wordRepo.On("CheckNormalizedTermExists", ctx, mock.Anything, tenantID, normalizedTerm(nearMaxTerm), (*uuid.UUID)(nil)).Return(true, nil).Once()
+			},
+			wantStatus:  model.ImportStatusFailed,
+			wantMessage: "連番を付けると単語が長すぎます",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordRepo := new(mocks.WordRepository)
+			progRepo := new(mocks.ProgressRepository)
+			tt.setupMock(wordRepo)
+			s := NewImportService(setupTestDBWord(), wordRepo, progRepo, &config.Config{})
+
+			// DryRun のため単語の作成は呼ばれない
+			resp, err := s.(*importService).importRecords(ctx, tenantID, []wordio.Record{tt.rec}, tt.strategy, true)
+
+			require.NoError(t, err)
+			require.Len(t, resp.Rows, 1)
+			assert.Equal(t, tt.wantStatus, resp.Rows[0].Status)
+			assert.Contains(t, resp.Rows[0].Message, tt.wantMessage)
+			if tt.wantStatus == model.ImportStatusFailed {
+				assert.Equal(t, 1, resp.Failed)
+			}
+			wordRepo.AssertExpectations(t)
+			progRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// auth.login_protection が未設定の場合の既定値
+const (
+	defaultAccountMaxFailures = 5
+	defaultIPMaxFailures      = 20
+	defaultBaseLockout        = 30 * time.Second
+	defaultMaxLockout         = time.Hour
+	defaultFailureResetAfter  = time.Hour
+)
+
+// LoginGuard はアカウント単位・IPアドレス単位でログインの失敗回数を数え、総当たり攻撃を防ぎます。
+// 連続して失敗した回数が上限に達すると一定時間ロックし、その後も失敗するたびにロックする時間を倍にします。
+// 回数はDBに保存するため、複数のサーバーで制限を共有します。
+type LoginGuard struct {
+	db   *gorm.DB
+	repo repository.LoginAttemptRepository
+
+	accountMaxFailures int
+	ipMaxFailures      int
+	baseLockout        time.Duration
+	maxLockout         time.Duration
+	resetAfter         time.Duration
+}
+
+func NewLoginGuard(db *gorm.DB, repo repository.LoginAttemptRepository, cfg *config.Config) *LoginGuard {
+	p := cfg.Auth.LoginProtection
+	g := &LoginGuard{
+		db:                 db,
+		repo:               repo,
+		accountMaxFailures: p.AccountMaxFailures,
+		ipMaxFailures:      p.IPMaxFailures,
+		baseLockout:        p.BaseLockout,
+		maxLockout:         p.MaxLockout,
+		resetAfter:         p.ResetAfter,
+	}
+	if g.accountMaxFailures <= 0 {
+		g.accountMaxFailures = defaultAccountMaxFailures
+	}
+	if g.ipMaxFailures <= 0 {
+		g.ipMaxFailures = defaultIPMaxFailures
+	}
+	if g.baseLockout <= 0 {
+		g.baseLockout = defaultBaseLockout
+	}
+	if g.maxLockout < g.baseLockout {
+		g.maxLockout = max(defaultMaxLockout, g.baseLockout)
+	}
+	if g.resetAfter <= 0 {
+		g.resetAfter = defaultFailureResetAfter
+	}
+	return g
+}
+
+// Check はアカウント (account) かリクエスト元の IP アドレスがロックされていれば ACCOUNT_LOCKED のエラーを返します。
+// account はメールアドレスなど、ログインしようとしているアカウントを表す文字列です。
+func (g *LoginGuard) Check(ctx context.Context, account string) error {
+	attempts, err := g.repo.FindByKeys(ctx, g.db, g.keys(ctx, account))
+	if err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, a := range attempts {
+		if a.LockedUntil != nil && a.LockedUntil.After(now) {
+			retryAfter = max(retryAfter, a.LockedUntil.Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		middleware.GetLogger(ctx).Warn("Login rejected due to lockout", "retry_after", retryAfter)
+		appErr := model.NewAppError("ACCOUNT_LOCKED", "ログインの失敗が続いたため、一時的にロックしています。しばらく待ってから再度お試しください。", "", model.ErrTooManyRequests)
+		appErr.RetryAfter = retryAfter
+		return appErr
+	}
+	return nil
+}
+
+// RecordFailure はアカウントとリクエスト元の IP アドレスの失敗回数を増やし、上限に達していればロックします
+func (g *LoginGuard) RecordFailure(ctx context.Context, account string) error {
+	now := time.Now()
+	for i, key := range g.keys(ctx, account) {
+		limit := g.accountMaxFailures
+		if i > 0 {
+			limit = g.ipMaxFailures
+		}
+		err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			attempt, err := g.repo.FindOrCreateForUpdate(ctx, tx, key)
+			if err != nil {
+				return err
+			}
+			// 最後の失敗から時間が経っていれば数え直す
+			if now.Sub(attempt.LastFailedAt) > g.resetAfter && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(now)) {
+				attempt.Failures = 0
+			}
+			attempt.Failures++
+			attempt.LastFailedAt = now
+			if lockout := g.lockout(attempt.Failures, limit); lockout > 0 {
+				lockedUntil := now.Add(lockout)
+				attempt.LockedUntil = &lockedUntil
+				middleware.GetLogger(ctx).Warn("Login locked", "key", key, "failures", attempt.Failures, "lockout", lockout)
+			}
+			return g.repo.Save(ctx, tx, attempt)
+		})
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+		}
+	}
+	return nil
+}
+
+// Reset はログインに成功したアカウントの失敗回数を消します。IP アドレスの回数は、攻撃者が自分のアカウントで
+// ログインして消せないよう、時間の経過でだけ数え直します。
+func (g *LoginGuard) Reset(ctx context.Context, account string) error {
+	if err := g.repo.Delete(ctx, g.db, accountAttemptKey(account)); err != nil {
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部エラー", "", err)
+	}
+	return nil
+}
+
+// PurgeStale は数え直しの対象になった古い失敗の記録を削除し、削除した件数を返します
+func (g *LoginGuard) PurgeStale(ctx context.Context, now time.Time) (int64, error) {
+	return g.repo.DeleteStale(ctx, g.db, now.Add(-g.resetAfter))
+}
+
+// lockout は連続して failures 回失敗した場合にロックする時間を返します。上限に達していなければ0です。
+func (g *LoginGuard) lockout(failures, limit int) time.Duration {
+	if failures < limit {
+		return 0
+	}
+	d := g.baseLockout
+	for i := limit; i < failures && d < g.maxLockout; i++ {
+		d *= 2
+	}
+	return min(d, g.maxLockout)
+}
+
+// keys は失敗回数を数えるキーを返します。先頭がアカウント、IP アドレスが分かれば2番目が IP アドレスのキーです。
+func (g *LoginGuard) keys(ctx context.Context, account string) []string {
+	keys := []string{accountAttemptKey(account)}
+	if ip := middleware.GetClientInfo(ctx).IPAddress; ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+func accountAttemptKey(account string) string {
+	return "account:" + account
+}
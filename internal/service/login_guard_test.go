@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLoginGuardWithMocks() (*LoginGuard, *mocks.LoginAttemptRepository) {
+	mockRepo := new(mocks.LoginAttemptRepository)
+	cfg := &config.Config{Auth: config.AuthConfig{LoginProtection: config.LoginProtectionConfig{
+		AccountMaxFailures: 3,
+		IPMaxFailures:      10,
+		BaseLockout:        time.Minute,
+		MaxLockout:         5 * time.Minute,
+		ResetAfter:         time.Hour,
+	}}}
+	return NewLoginGuard(setupTestDBWord(), mockRepo, cfg), mockRepo
+}
+
+// --- Test Check ---
+func Test_LoginGuard_Check(t *testing.T) {
+	guard, mockRepo := setupLoginGuardWithMocks()
+	ctx := context.Background()
+	future := time.Now().Add(2 * time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name           string
+		attempts       []*model.LoginAttempt
+		repoErr        error
+		wantErrCode    string
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:     "正常系: 失敗の記録がない",
+			attempts: []*model.LoginAttempt{},
+		},
+		{
+			name:     "正常系: ロックの期限が過ぎている",
+			attempts: []*model.LoginAttempt{{Key: "account:a", Failures: 3, LockedUntil: &past}},
+		},
+		{
+			name:           "異常系: ロック中",
+			attempts:       []*model.LoginAttempt{{Key: "account:a", Failures: 3, LockedUntil: &future}},
+			wantErrCode:    "ACCOUNT_LOCKED",
+			wantRetryAfter: 2 * time.Minute,
+		},
+		{
+			name:        "異常系: リポジトリでDBエラー",
+			repoErr:     errors.New("db error"),
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.Mock = mock.Mock{}
+			mockRepo.On("FindByKeys", ctx, mock.AnythingOfType("*gorm.DB"), []string{"account:a"}).Return(tt.attempts, tt.repoErr).Once()
+
+			err := guard.Check(ctx, "a")
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				if tt.wantRetryAfter > 0 {
+					var appErr *model.AppError
+					require.ErrorAs(t, err, &appErr)
+					assert.ErrorIs(t, err, model.ErrTooManyRequests)
+					assert.InDelta(t, tt.wantRetryAfter.Seconds(), appErr.RetryAfter.Seconds(), 1)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test RecordFailure ---
+func Test_LoginGuard_RecordFailure(t *testing.T) {
+	guard, mockRepo := setupLoginGuardWithMocks()
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		existing      *model.LoginAttempt
+		wantFailures  int
+		wantLockedFor time.Duration // 0 の場合はロックしない
+	}{
+		{
+			name:         "正常系: 上限未満ではロックしない",
+			existing:     &model.LoginAttempt{Key: "account:a", Failures: 1, LastFailedAt: now.Add(-time.Minute)},
+			wantFailures: 2,
+		},
+		{
+			name:          "正常系: 上限に達したらロックする",
+			existing:      &model.LoginAttempt{Key: "account:a", Failures: 2, LastFailedAt: now.Add(-time.Minute)},
+			wantFailures:  3,
+			wantLockedFor: time.Minute,
+		},
+		{
+			name:          "正常系: 上限を超えて失敗するたびにロックする時間を倍にする",
+			existing:      &model.LoginAttempt{Key: "account:a", Failures: 4, LastFailedAt: now.Add(-time.Minute)},
+			wantFailures:  5,
+			wantLockedFor: 4 * time.Minute,
+		},
+		{
+			name:          "正常系: ロックする時間は上限で止める",
+			existing:      &model.LoginAttempt{Key: "account:a", Failures: 10, LastFailedAt: now.Add(-time.Minute)},
+			wantFailures:  11,
+			wantLockedFor: 5 * time.Minute,
+		},
+		{
+			name:         "正常系: 最後の失敗から時間が経っていれば数え直す",
+			existing:     &model.LoginAttempt{Key: "account:a", Failures: 2, LastFailedAt: now.Add(-2 * time.Hour)},
+			wantFailures: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.Mock = mock.Mock{}
+			mockRepo.On("FindOrCreateForUpdate", ctx, mock.AnythingOfType("*gorm.DB"), "account:a").Return(tt.existing, nil).Once()
+			mockRepo.On("Save", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LoginAttempt")).Return(nil).Once()
+
+			err := guard.RecordFailure(ctx, "a")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFailures, tt.existing.Failures)
+			if tt.wantLockedFor > 0 {
+				require.NotNil(t, tt.existing.LockedUntil)
+				assert.WithinDuration(t, time.Now().Add(tt.wantLockedFor), *tt.existing.LockedUntil, 5*time.Second)
+			} else {
+				assert.Nil(t, tt.existing.LockedUntil)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// --- Test Reset ---
+func Test_LoginGuard_Reset(t *testing.T) {
+	guard, mockRepo := setupLoginGuardWithMocks()
+	ctx := context.Background()
+
+	// アカウントの回数だけを消す (IP アドレスの回数は消さない)
+	mockRepo.On("Delete", ctx, mock.AnythingOfType("*gorm.DB"), "account:a").Return(nil).Once()
+
+	require.NoError(t, guard.Reset(ctx, "a"))
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "go_4_vocab_keep/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	service "go_4_vocab_keep/internal/service"
+
+	uuid "github.com/google/uuid"
+)
+
+// AttachmentService is an autogenerated mock type for the AttachmentService type
+type AttachmentService struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, tenantID, wordID, attachmentID
+func (_m *AttachmentService) Delete(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, attachmentID uuid.UUID) error {
+	ret := _m.Called(ctx, tenantID, wordID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, tenantID, wordID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetURL provides a mock function with given fields: ctx, tenantID, wordID, attachmentID
+func (_m *AttachmentService) GetURL(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, attachmentID uuid.UUID) (*model.AttachmentURLResponse, error) {
+	ret := _m.Called(ctx, tenantID, wordID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURL")
+	}
+
+	var r0 *model.AttachmentURLResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*model.AttachmentURLResponse, error)); ok {
+		return rf(ctx, tenantID, wordID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) *model.AttachmentURLResponse); ok {
+		r0 = rf(ctx, tenantID, wordID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AttachmentURLResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, wordID, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, tenantID, wordID
+func (_m *AttachmentService) List(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID) ([]model.WordAttachment, error) {
+	ret := _m.Called(ctx, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.WordAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]model.WordAttachment, error)); ok {
+		return rf(ctx, tenantID, wordID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []model.WordAttachment); ok {
+		r0 = rf(ctx, tenantID, wordID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WordAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, wordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upload provides a mock function with given fields: ctx, tenantID, wordID, input
+func (_m *AttachmentService) Upload(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, input *service.UploadAttachmentInput) (*model.WordAttachment, error) {
+	ret := _m.Called(ctx, tenantID, wordID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 *model.WordAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *service.UploadAttachmentInput) (*model.WordAttachment, error)); ok {
+		return rf(ctx, tenantID, wordID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *service.UploadAttachmentInput) *model.WordAttachment); ok {
+		r0 = rf(ctx, tenantID, wordID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WordAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *service.UploadAttachmentInput) error); ok {
+		r1 = rf(ctx, tenantID, wordID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentService creates a new instance of AttachmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentService {
+	mock := &AttachmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
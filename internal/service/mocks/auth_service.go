@@ -0,0 +1,717 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "go_4_vocab_keep/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AuthService is an autogenerated mock type for the AuthService type
+type AuthService struct {
+	mock.Mock
+}
+
+// ChangePassword provides a mock function with given fields: ctx, tenantID, sessionID, req
+func (_m *AuthService) ChangePassword(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID, req *model.ChangePasswordRequest) error {
+	ret := _m.Called(ctx, tenantID, sessionID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *model.ChangePasswordRequest) error); ok {
+		r0 = rf(ctx, tenantID, sessionID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmEmailChange provides a mock function with given fields: ctx, tokenString
+func (_m *AuthService) ConfirmEmailChange(ctx context.Context, tokenString string) error {
+	ret := _m.Called(ctx, tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenString)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, tenantID, code
+func (_m *AuthService) ConfirmTOTP(ctx context.Context, tenantID uuid.UUID, code string) (*model.RecoveryCodesResponse, error) {
+	ret := _m.Called(ctx, tenantID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 *model.RecoveryCodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.RecoveryCodesResponse, error)); ok {
+		return rf(ctx, tenantID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.RecoveryCodesResponse); ok {
+		r0 = rf(ctx, tenantID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RecoveryCodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, tenantID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, tenantID, password
+func (_m *AuthService) DisableTOTP(ctx context.Context, tenantID uuid.UUID, password string) error {
+	ret := _m.Called(ctx, tenantID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, tenantID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, tenantID
+func (_m *AuthService) EnrollTOTP(ctx context.Context, tenantID uuid.UUID) (*model.TOTPEnrollResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 *model.TOTPEnrollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.TOTPEnrollResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.TOTPEnrollResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TOTPEnrollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTenant provides a mock function with given fields: ctx, tenantID
+func (_m *AuthService) GetTenant(ctx context.Context, tenantID uuid.UUID) (*model.Tenant, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenant")
+	}
+
+	var r0 *model.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Tenant, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Tenant); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleGoogleLogin provides a mock function with given fields: ctx, code
+func (_m *AuthService) HandleGoogleLogin(ctx context.Context, code string) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGoogleLogin")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.LoginResponse, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.LoginResponse); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleOIDCLogin provides a mock function with given fields: ctx, providerKey, code, state, binding
+func (_m *AuthService) HandleOIDCLogin(ctx context.Context, providerKey string, code string, state string, binding string) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, providerKey, code, state, binding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleOIDCLogin")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*model.LoginResponse, error)); ok {
+		return rf(ctx, providerKey, code, state, binding)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *model.LoginResponse); ok {
+		r0 = rf(ctx, providerKey, code, state, binding)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, providerKey, code, state, binding)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkGoogleIdentity provides a mock function with given fields: ctx, tenantID, sessionID, code, password
+func (_m *AuthService) LinkGoogleIdentity(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID, code string, password string) (*model.IdentityResponse, error) {
+	ret := _m.Called(ctx, tenantID, sessionID, code, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkGoogleIdentity")
+	}
+
+	var r0 *model.IdentityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, string) (*model.IdentityResponse, error)); ok {
+		return rf(ctx, tenantID, sessionID, code, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, string) *model.IdentityResponse); ok {
+		r0 = rf(ctx, tenantID, sessionID, code, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdentityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, string) error); ok {
+		r1 = rf(ctx, tenantID, sessionID, code, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkOIDCIdentity provides a mock function with given fields: ctx, tenantID, sessionID, providerKey, code, state, binding, password
+func (_m *AuthService) LinkOIDCIdentity(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID, providerKey string, code string, state string, binding string, password string) (*model.IdentityResponse, error) {
+	ret := _m.Called(ctx, tenantID, sessionID, providerKey, code, state, binding, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkOIDCIdentity")
+	}
+
+	var r0 *model.IdentityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, string, string, string, string) (*model.IdentityResponse, error)); ok {
+		return rf(ctx, tenantID, sessionID, providerKey, code, state, binding, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, string, string, string, string) *model.IdentityResponse); ok {
+		r0 = rf(ctx, tenantID, sessionID, providerKey, code, state, binding, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdentityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, tenantID, sessionID, providerKey, code, state, binding, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentities provides a mock function with given fields: ctx, tenantID
+func (_m *AuthService) ListIdentities(ctx context.Context, tenantID uuid.UUID) ([]model.IdentityResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIdentities")
+	}
+
+	var r0 []model.IdentityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.IdentityResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.IdentityResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.IdentityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOIDCProviders provides a mock function with given fields: ctx
+func (_m *AuthService) ListOIDCProviders(ctx context.Context) []model.OIDCProviderResponse {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOIDCProviders")
+	}
+
+	var r0 []model.OIDCProviderResponse
+	if rf, ok := ret.Get(0).(func(context.Context) []model.OIDCProviderResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OIDCProviderResponse)
+		}
+	}
+
+	return r0
+}
+
+// ListSessions provides a mock function with given fields: ctx, tenantID, currentSessionID
+func (_m *AuthService) ListSessions(ctx context.Context, tenantID uuid.UUID, currentSessionID uuid.UUID) ([]model.SessionResponse, error) {
+	ret := _m.Called(ctx, tenantID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []model.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]model.SessionResponse, error)); ok {
+		return rf(ctx, tenantID, currentSessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []model.SessionResponse); ok {
+		r0 = rf(ctx, tenantID, currentSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, currentSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, req
+func (_m *AuthService) Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.LoginRequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.LoginRequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.LoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithMFA provides a mock function with given fields: ctx, req
+func (_m *AuthService) LoginWithMFA(ctx context.Context, req *model.MFALoginRequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithMFA")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MFALoginRequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MFALoginRequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.MFALoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, tenantID, sessionID
+func (_m *AuthService) Logout(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, tenantID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, tenantID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.LoginResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.LoginResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegisterTenant provides a mock function with given fields: ctx, req
+func (_m *AuthService) RegisterTenant(ctx context.Context, req *model.RegisterRequest) (*model.Tenant, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterTenant")
+	}
+
+	var r0 *model.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RegisterRequest) (*model.Tenant, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RegisterRequest) *model.Tenant); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.RegisterRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestEmailChange provides a mock function with given fields: ctx, tenantID, sessionID, req
+func (_m *AuthService) RequestEmailChange(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID, req *model.ChangeEmailRequest) error {
+	ret := _m.Called(ctx, tenantID, sessionID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestEmailChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *model.ChangeEmailRequest) error); ok {
+		r0 = rf(ctx, tenantID, sessionID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestPasswordReset provides a mock function with given fields: ctx, email
+func (_m *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendVerificationEmail provides a mock function with given fields: ctx, email
+func (_m *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerificationEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, newPassword
+func (_m *AuthService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _m.Called(ctx, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, tenantID, sessionID
+func (_m *AuthService) RevokeSession(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, tenantID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, tenantID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLocalPassword provides a mock function with given fields: ctx, tenantID, sessionID, password
+func (_m *AuthService) SetLocalPassword(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID, password string) error {
+	ret := _m.Called(ctx, tenantID, sessionID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLocalPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, tenantID, sessionID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartOIDCLink provides a mock function with given fields: ctx, tenantID, providerKey
+func (_m *AuthService) StartOIDCLink(ctx context.Context, tenantID uuid.UUID, providerKey string) (*model.OIDCAuthorizeResponse, error) {
+	ret := _m.Called(ctx, tenantID, providerKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartOIDCLink")
+	}
+
+	var r0 *model.OIDCAuthorizeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.OIDCAuthorizeResponse, error)); ok {
+		return rf(ctx, tenantID, providerKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.OIDCAuthorizeResponse); ok {
+		r0 = rf(ctx, tenantID, providerKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OIDCAuthorizeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, tenantID, providerKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartOIDCLogin provides a mock function with given fields: ctx, providerKey
+func (_m *AuthService) StartOIDCLogin(ctx context.Context, providerKey string) (*model.OIDCAuthorizeResponse, error) {
+	ret := _m.Called(ctx, providerKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartOIDCLogin")
+	}
+
+	var r0 *model.OIDCAuthorizeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.OIDCAuthorizeResponse, error)); ok {
+		return rf(ctx, providerKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.OIDCAuthorizeResponse); ok {
+		r0 = rf(ctx, providerKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OIDCAuthorizeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, providerKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlinkIdentity provides a mock function with given fields: ctx, tenantID, sessionID, identityID, password
+func (_m *AuthService) UnlinkIdentity(ctx context.Context, tenantID uuid.UUID, sessionID uuid.UUID, identityID uint, password string) error {
+	ret := _m.Called(ctx, tenantID, sessionID, identityID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlinkIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uint, string) error); ok {
+		r0 = rf(ctx, tenantID, sessionID, identityID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyAccount provides a mock function with given fields: ctx, tokenString
+func (_m *AuthService) VerifyAccount(ctx context.Context, tokenString string) error {
+	ret := _m.Called(ctx, tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenString)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthService {
+	mock := &AuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
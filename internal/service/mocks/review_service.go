@@ -1,4 +1,4 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.4. DO NOT EDIT.
 
 package mocks
 
@@ -16,9 +16,9 @@ type ReviewService struct {
 	mock.Mock
 }
 
-// GetReviewWords provides a mock function with given fields: ctx, tenantID
-func (_m *ReviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID) ([]*model.ReviewWordResponse, error) {
-	ret := _m.Called(ctx, tenantID)
+// GetReviewWords provides a mock function with given fields: ctx, tenantID, opts
+func (_m *ReviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) ([]*model.ReviewWordResponse, error) {
+	ret := _m.Called(ctx, tenantID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetReviewWords")
@@ -26,19 +26,19 @@ func (_m *ReviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID)
 
 	var r0 []*model.ReviewWordResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.ReviewWordResponse, error)); ok {
-		return rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.ReviewWordsOptions) ([]*model.ReviewWordResponse, error)); ok {
+		return rf(ctx, tenantID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.ReviewWordResponse); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.ReviewWordsOptions) []*model.ReviewWordResponse); ok {
+		r0 = rf(ctx, tenantID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.ReviewWordResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, model.ReviewWordsOptions) error); ok {
+		r1 = rf(ctx, tenantID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,17 +46,45 @@ func (_m *ReviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID)
 	return r0, r1
 }
 
-// UpsertLearningProgressBasedOnReview provides a mock function with given fields: ctx, tenantID, wordID, isCorrect
-func (_m *ReviewService) UpsertLearningProgressBasedOnReview(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, isCorrect bool) error {
-	ret := _m.Called(ctx, tenantID, wordID, isCorrect)
+// GetReviewWordsCount provides a mock function with given fields: ctx, tenantID, opts
+func (_m *ReviewService) GetReviewWordsCount(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) (int64, error) {
+	ret := _m.Called(ctx, tenantID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewWordsCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.ReviewWordsOptions) (int64, error)); ok {
+		return rf(ctx, tenantID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.ReviewWordsOptions) int64); ok {
+		r0 = rf(ctx, tenantID, opts)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, model.ReviewWordsOptions) error); ok {
+		r1 = rf(ctx, tenantID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertLearningProgressBasedOnReview provides a mock function with given fields: ctx, tenantID, wordID, direction, isCorrect
+func (_m *ReviewService) UpsertLearningProgressBasedOnReview(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, direction string, isCorrect bool) error {
+	ret := _m.Called(ctx, tenantID, wordID, direction, isCorrect)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpsertLearningProgressBasedOnReview")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, bool) error); ok {
-		r0 = rf(ctx, tenantID, wordID, isCorrect)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, bool) error); ok {
+		r0 = rf(ctx, tenantID, wordID, direction, isCorrect)
 	} else {
 		r0 = ret.Error(0)
 	}
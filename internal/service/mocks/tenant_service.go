@@ -1,109 +0,0 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
-
-package mocks
-
-import (
-	context "context"
-	model "go_4_vocab_keep/internal/model"
-
-	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
-)
-
-// TenantService is an autogenerated mock type for the TenantService type
-type TenantService struct {
-	mock.Mock
-}
-
-// CreateTenant provides a mock function with given fields: ctx, name
-func (_m *TenantService) CreateTenant(ctx context.Context, name string) (*model.Tenant, error) {
-	ret := _m.Called(ctx, name)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CreateTenant")
-	}
-
-	var r0 *model.Tenant
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Tenant, error)); ok {
-		return rf(ctx, name)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Tenant); ok {
-		r0 = rf(ctx, name)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Tenant)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DeleteTenant provides a mock function with given fields: ctx, tenantID
-func (_m *TenantService) DeleteTenant(ctx context.Context, tenantID uuid.UUID) error {
-	ret := _m.Called(ctx, tenantID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DeleteTenant")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, tenantID)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// GetTenant provides a mock function with given fields: ctx, tenantID
-func (_m *TenantService) GetTenant(ctx context.Context, tenantID uuid.UUID) (*model.Tenant, error) {
-	ret := _m.Called(ctx, tenantID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetTenant")
-	}
-
-	var r0 *model.Tenant
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Tenant, error)); ok {
-		return rf(ctx, tenantID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Tenant); ok {
-		r0 = rf(ctx, tenantID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Tenant)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, tenantID)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// NewTenantService creates a new instance of TenantService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewTenantService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *TenantService {
-	mock := &TenantService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
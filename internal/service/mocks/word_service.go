@@ -1,4 +1,4 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.4. DO NOT EDIT.
 
 package mocks
 
@@ -16,6 +16,36 @@ type WordService struct {
 	mock.Mock
 }
 
+// BulkWords provides a mock function with given fields: ctx, tenantID, req
+func (_m *WordService) BulkWords(ctx context.Context, tenantID uuid.UUID, req *model.BulkWordsRequest) (*model.BulkWordsResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWords")
+	}
+
+	var r0 *model.BulkWordsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.BulkWordsRequest) (*model.BulkWordsResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.BulkWordsRequest) *model.BulkWordsResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BulkWordsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.BulkWordsRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteWord provides a mock function with given fields: ctx, tenantID, wordID
 func (_m *WordService) DeleteWord(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID) error {
 	ret := _m.Called(ctx, tenantID, wordID)
@@ -34,6 +64,96 @@ func (_m *WordService) DeleteWord(ctx context.Context, tenantID uuid.UUID, wordI
 	return r0
 }
 
+// ExtractWords provides a mock function with given fields: ctx, tenantID, req
+func (_m *WordService) ExtractWords(ctx context.Context, tenantID uuid.UUID, req *model.ExtractWordsRequest) (*model.ExtractWordsResponse, error) {
+	ret := _m.Called(ctx, tenantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtractWords")
+	}
+
+	var r0 *model.ExtractWordsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.ExtractWordsRequest) (*model.ExtractWordsResponse, error)); ok {
+		return rf(ctx, tenantID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.ExtractWordsRequest) *model.ExtractWordsResponse); ok {
+		r0 = rf(ctx, tenantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExtractWordsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.ExtractWordsRequest) error); ok {
+		r1 = rf(ctx, tenantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDuplicates provides a mock function with given fields: ctx, tenantID
+func (_m *WordService) FindDuplicates(ctx context.Context, tenantID uuid.UUID) (*model.DuplicateWordsResponse, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
+	}
+
+	var r0 *model.DuplicateWordsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.DuplicateWordsResponse, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.DuplicateWordsResponse); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DuplicateWordsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateReadings provides a mock function with given fields: ctx
+func (_m *WordService) GenerateReadings(ctx context.Context) (*model.GenerateReadingsResult, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateReadings")
+	}
+
+	var r0 *model.GenerateReadingsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.GenerateReadingsResult, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.GenerateReadingsResult); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GenerateReadingsResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWord provides a mock function with given fields: ctx, tenantID, wordID
 func (_m *WordService) GetWord(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID) (*model.Word, error) {
 	ret := _m.Called(ctx, tenantID, wordID)
@@ -94,6 +214,36 @@ func (_m *WordService) GetWords(ctx context.Context, tenantID uuid.UUID) ([]*mod
 	return r0, r1
 }
 
+// ListRevisions provides a mock function with given fields: ctx, tenantID, wordID
+func (_m *WordService) ListRevisions(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID) ([]*model.WordRevisionResponse, error) {
+	ret := _m.Called(ctx, tenantID, wordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 []*model.WordRevisionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]*model.WordRevisionResponse, error)); ok {
+		return rf(ctx, tenantID, wordID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []*model.WordRevisionResponse); ok {
+		r0 = rf(ctx, tenantID, wordID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.WordRevisionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, wordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PatchWord provides a mock function with given fields: ctx, tenantID, wordID, req
 func (_m *WordService) PatchWord(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, req *model.PatchWordRequest) (*model.Word, error) {
 	ret := _m.Called(ctx, tenantID, wordID, req)
@@ -184,6 +334,66 @@ func (_m *WordService) PutWord(ctx context.Context, tenantID uuid.UUID, wordID u
 	return r0, r1
 }
 
+// RenormalizeTerms provides a mock function with given fields: ctx
+func (_m *WordService) RenormalizeTerms(ctx context.Context) (*model.RenormalizeTermsResult, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenormalizeTerms")
+	}
+
+	var r0 *model.RenormalizeTermsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.RenormalizeTermsResult, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.RenormalizeTermsResult); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RenormalizeTermsResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevertRevision provides a mock function with given fields: ctx, tenantID, wordID, revisionNo
+func (_m *WordService) RevertRevision(ctx context.Context, tenantID uuid.UUID, wordID uuid.UUID, revisionNo int) (*model.Word, error) {
+	ret := _m.Called(ctx, tenantID, wordID, revisionNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertRevision")
+	}
+
+	var r0 *model.Word
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, int) (*model.Word, error)); ok {
+		return rf(ctx, tenantID, wordID, revisionNo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, int) *model.Word); ok {
+		r0 = rf(ctx, tenantID, wordID, revisionNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Word)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, tenantID, wordID, revisionNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewWordService creates a new instance of WordService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewWordService(t interface {
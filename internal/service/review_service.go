@@ -1,15 +1,18 @@
+//go:generate mockery --name ReviewService --output ./mocks --outpkg mocks --case=underscore
 package service
 
 import (
 	"context"
 	"errors"
 	"log/slog"
+	"math/rand/v2"
 	"time"
 
 	"go_4_vocab_keep/internal/config"
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
 	"go_4_vocab_keep/internal/repository"
+	"go_4_vocab_keep/internal/textutil"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,9 +20,9 @@ import (
 
 // ReviewService インターフェース (変更なし)
 type ReviewService interface {
-	GetReviewWords(ctx context.Context, tenantID uuid.UUID) ([]*model.ReviewWordResponse, error)
-	GetReviewWordsCount(ctx context.Context, tenantID uuid.UUID) (int64, error)
-	UpsertLearningProgressBasedOnReview(ctx context.Context, tenantID, wordID uuid.UUID, isCorrect bool) error
+	GetReviewWords(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) ([]*model.ReviewWordResponse, error)
+	GetReviewWordsCount(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) (int64, error)
+	UpsertLearningProgressBasedOnReview(ctx context.Context, tenantID, wordID uuid.UUID, direction string, isCorrect bool) error
 }
 
 // reviewService 構造体から logger を削除
@@ -38,10 +41,14 @@ func NewReviewService(db *gorm.DB, progRepo repository.ProgressRepository, cfg *
 	}
 }
 
-func (s *reviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID) ([]*model.ReviewWordResponse, error) {
+func (s *reviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) ([]*model.ReviewWordResponse, error) {
 	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
 
-	progresses, err := s.progRepo.FindReviewableByTenant(ctx, s.db, tenantID, time.Now(), s.cfg.App.ReviewLimit)
+	directions, err := s.reviewDirections(ctx, tenantID, opts)
+	if err != nil {
+		return nil, err
+	}
+	progresses, err := s.progRepo.FindReviewableByTenant(ctx, s.db, tenantID, directions, time.Now(), s.cfg.App.ReviewLimit)
 	if err != nil {
 		logger.Error("Failed to find reviewable words from repository", "error", err)
 		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "復習単語の取得に失敗しました。", "", err)
@@ -53,24 +60,52 @@ func (s *reviewService) GetReviewWords(ctx context.Context, tenantID uuid.UUID)
 			logger.Warn("Found progress with nil Word during review generation, skipping", "progress_id", p.ProgressID)
 			continue
 		}
-		responses = append(responses, &model.ReviewWordResponse{
+		res := &model.ReviewWordResponse{
 			WordID:     p.WordID,
+			Direction:  p.Direction,
 			Term:       p.Word.Term,
 			Definition: p.Word.Definition,
 			Level:      p.Level,
-		})
+		}
+		if opts.WithDetails {
+			res.Reading = p.Word.Reading
+			res.PartOfSpeech = p.Word.PartOfSpeech
+			res.Examples = p.Word.Examples
+			res.Notes = p.Word.Notes
+			res.Senses = p.Word.Senses
+		}
+		if p.Direction == model.DirectionCloze {
+			res.Cloze = makeClozeCard(p.Word)
+			if res.Cloze == nil {
+				// 例文が編集され、単語を含まなくなった場合など
+				logger.Debug("No example contains the term, skipping cloze card", "word_id", p.WordID)
+				continue
+			}
+		} else if opts.SingleSense && len(p.Word.Senses) > 0 {
+			// 復習のたびに異なる語義が出題されるよう、ランダムに1つ選ぶ
+			sense := p.Word.Senses[rand.IntN(len(p.Word.Senses))]
+			res.Sense = &sense
+			res.Definition = sense.Definition
+		}
+		responses = append(responses, res)
 	}
 
 	logger.Info("Successfully retrieved review words", "count", len(responses))
 	return responses, nil
 }
 
-func (s *reviewService) GetReviewWordsCount(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+// GetReviewWordsCount は復習日を迎えた単語の数を返します。
+// 穴埋め問題を含める場合、穴埋め問題を作れない例文しかない単語も数に含まれます。
+func (s *reviewService) GetReviewWordsCount(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) (int64, error) {
 	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID)
 
+	directions, err := s.reviewDirections(ctx, tenantID, opts)
+	if err != nil {
+		return 0, err
+	}
 	// リポジトリにカウント用のメソッドを追加するのが理想だが、
 	// ここでは既存のメソッドを流用して効率的に実装
-	progresses, err := s.progRepo.FindReviewableByTenant(ctx, s.db, tenantID, time.Now(), 9999) // limitを大きく設定
+	progresses, err := s.progRepo.FindReviewableByTenant(ctx, s.db, tenantID, directions, time.Now(), 9999) // limitを大きく設定
 	if err != nil {
 		logger.Error("Failed to find reviewable words for count", "error", err)
 		return 0, model.NewAppError("INTERNAL_SERVER_ERROR", "単語数の取得に失敗しました。", "", err)
@@ -81,11 +116,44 @@ func (s *reviewService) GetReviewWordsCount(ctx context.Context, tenantID uuid.U
 	return count, nil
 }
 
-func (s *reviewService) UpsertLearningProgressBasedOnReview(ctx context.Context, tenantID, wordID uuid.UUID, isCorrect bool) error {
-	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID, "word_id", wordID)
+// reviewDirections は出題する形式を返します。
+// 穴埋め問題を含める場合は、例文が追加された単語の穴埋めの学習進捗をここで作成します。
+func (s *reviewService) reviewDirections(ctx context.Context, tenantID uuid.UUID, opts model.ReviewWordsOptions) ([]string, error) {
+	if !opts.Cloze {
+		return []string{model.DirectionRecognition}, nil
+	}
+	created, err := s.progRepo.CreateMissingCloze(ctx, s.db, tenantID, initialNextReviewDate())
+	if err != nil {
+		middleware.GetLogger(ctx).Error("Failed to create cloze progress", "error", err, "tenant_id", tenantID)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "復習単語の取得に失敗しました。", "", err)
+	}
+	if created > 0 {
+		middleware.GetLogger(ctx).Info("Cloze progress created", "tenant_id", tenantID, "created", created)
+	}
+	return []string{model.DirectionRecognition, model.DirectionCloze}, nil
+}
+
+// makeClozeCard は単語の例文のうち、単語 (活用形を含む) を含むものからランダムに1つ選んで穴埋め問題を作ります。
+// 単語を含む例文がない場合は nil を返します。
+func makeClozeCard(word *model.Word) *model.ClozeCard {
+	var cards []*model.ClozeCard
+	for _, ex := range word.Examples {
+		if cloze, ok := textutil.MakeCloze(ex.Sentence, word.Term); ok {
+			cards = append(cards, &model.ClozeCard{Text: cloze.Text, Answer: cloze.Answer, Translation: ex.Translation})
+		}
+	}
+	if len(cards) == 0 {
+		return nil
+	}
+	return cards[rand.IntN(len(cards))]
+}
+
+// UpsertLearningProgressBasedOnReview は復習結果に応じて、単語の direction の出題形式の学習進捗を更新します
+func (s *reviewService) UpsertLearningProgressBasedOnReview(ctx context.Context, tenantID, wordID uuid.UUID, direction string, isCorrect bool) error {
+	logger := middleware.GetLogger(ctx).With("tenant_id", tenantID, "word_id", wordID, "direction", direction)
 
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		progress, err := s.progRepo.FindByWordID(ctx, tx, tenantID, wordID)
+		progress, err := s.progRepo.FindByWordID(ctx, tx, tenantID, wordID, direction)
 		if err != nil && !errors.Is(err, model.ErrNotFound) {
 			logger.Error("Error finding progress in transaction", "error", err)
 			return model.NewAppError("INTERNAL_SERVER_ERROR", "学習進捗の確認中にエラーが発生しました。", "", err)
@@ -105,6 +173,7 @@ func (s *reviewService) UpsertLearningProgressBasedOnReview(ctx context.Context,
 				ProgressID:     uuid.New(),
 				TenantID:       tenantID,
 				WordID:         wordID,
+				Direction:      direction,
 				Level:          newLevel,
 				NextReviewDate: nextReviewDate,
 				LastReviewedAt: &now,
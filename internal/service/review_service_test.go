@@ -3,8 +3,6 @@ package service
 import (
 	"context"
 	"errors"
-	"io"
-	"log/slog"
 	"testing"
 	"time"
 
@@ -26,22 +24,13 @@ import (
 // --- テストヘルパー関数 (DBセットアップ) ---
 // UpsertLearningProgressBasedOnReview がトランザクションを使うためDBが必要
 func setupTestDBReviewService() *gorm.DB {
-	// インメモリSQLiteを使用。実際のDBスキーマに合わせて調整が必要な場合あり。
-	// AutoMigrateはテストが依存する最小限のモデルに対して行う。
+	// インメモリSQLiteを使用。リポジトリはモックにするため、トランザクションを張れればよい
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent), // DBログ抑制
-		// SkipDefaultTransaction: true, // サービス内で明示的にトランザクションを張るので true でも良いが、デフォルト(false)でも動作するはず
 	})
 	if err != nil {
 		panic("failed to connect database for testing: " + err.Error())
 	}
-	// サービスのテストではマイグレーションは必須ではないことが多いが、
-	// トランザクション内でGORMが参照する可能性があるため、念のため実行
-	err = db.AutoMigrate(&model.LearningProgress{}, &model.Word{})
-	if err != nil {
-		panic("failed to migrate database for testing: " + err.Error())
-	}
-
 	return db
 }
 
@@ -49,11 +38,23 @@ func setupTestDBReviewService() *gorm.DB {
 func setupReviewServiceWithMocks(cfg config.Config) (ReviewService, *gorm.DB, *mocks.ProgressRepository) {
 	db := setupTestDBReviewService() // テスト用DBを取得
 	mockProgRepo := new(mocks.ProgressRepository)
-	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil)) // ログ出力を抑制
-	reviewService := NewReviewService(db, mockProgRepo, cfg, testLogger)
+	reviewService := NewReviewService(db, mockProgRepo, &cfg)
 	return reviewService, db, mockProgRepo
 }
 
+// assertAppErrorCode は err が code の AppError であることを検証します
+func assertAppErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *model.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, code, appErr.Detail.Code)
+}
+
+// sameDay は2つの時刻が同じ日付かどうかを返します
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
 // --- Test GetReviewWords ---
 func Test_reviewService_GetReviewWords(t *testing.T) {
 	testTenantID := uuid.New()
@@ -62,65 +63,151 @@ func Test_reviewService_GetReviewWords(t *testing.T) {
 	ctx := context.Background()
 
 	now := time.Now()
+	recognitionOnly := []string{model.DirectionRecognition}
+	withCloze := []string{model.DirectionRecognition, model.DirectionCloze}
+
 	// モックが返すテストデータ
 	wordID1 := uuid.New()
 	wordID2 := uuid.New()
 	wordID3 := uuid.New() // Wordがnilのテスト用
+	senses := []model.WordSense{
+		{SenseID: uuid.New(), WordID: wordID1, Position: 0, Definition: "sense def 1"},
+		{SenseID: uuid.New(), WordID: wordID1, Position: 1, Definition: "sense def 2"},
+	}
+	word1 := &model.Word{
+		WordID:       wordID1,
+		Term:         "apple",
+		Definition:   "def1",
+		Reading:      "アップル",
+		PartOfSpeech: "noun",
+		Examples:     model.WordExamples{{Sentence: "I ate two apples.", Translation: "りんごを2つ食べた。"}},
+		Notes:        "note1",
+		Senses:       senses,
+	}
+	word2 := &model.Word{WordID: wordID2, Term: "banana", Definition: "def2", Examples: model.WordExamples{{Sentence: "I like fruit."}}}
 	progressesFromRepo := []*model.LearningProgress{
-		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID1, Level: model.Level1, NextReviewDate: now.Add(-time.Hour), Word: &model.Word{WordID: wordID1, Term: "term1", Definition: "def1"}},
-		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID2, Level: model.Level2, NextReviewDate: now.Add(-2 * time.Hour), Word: &model.Word{WordID: wordID2, Term: "term2", Definition: "def2"}},
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID1, Direction: model.DirectionRecognition, Level: model.Level1, NextReviewDate: now.Add(-time.Hour), Word: word1},
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID2, Direction: model.DirectionRecognition, Level: model.Level2, NextReviewDate: now.Add(-2 * time.Hour), Word: word2},
 		// データ不整合テスト用: Wordがnil
-		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID3, Level: model.Level1, NextReviewDate: now.Add(-3 * time.Hour), Word: nil},
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID3, Direction: model.DirectionRecognition, Level: model.Level1, NextReviewDate: now.Add(-3 * time.Hour), Word: nil},
+	}
+	clozeProgresses := []*model.LearningProgress{
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID1, Direction: model.DirectionCloze, Level: model.Level1, NextReviewDate: now.Add(-time.Hour), Word: word1},
+		// 例文に単語が含まれないため出題されない
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: wordID2, Direction: model.DirectionCloze, Level: model.Level1, NextReviewDate: now.Add(-time.Hour), Word: word2},
 	}
 
 	tests := []struct {
 		name             string
+		opts             model.ReviewWordsOptions
 		setupMock        func()
-		wantErr          error
+		wantErrCode      string
 		expectedResCount int                                                 // 期待するレスポンスの数
 		checkResponse    func(t *testing.T, res []*model.ReviewWordResponse) // レスポンス内容チェック用関数
 	}{
 		{
 			name: "正常系: 復習単語を複数件取得 (Word nil除く)",
 			setupMock: func() {
-				// anyTime := mock.AnythingOfType("time.Time") でも可
-				// time.Time の比較は mock.MatchedBy で行う方が確実な場合もある
-				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, recognitionOnly, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
 					Return(progressesFromRepo, nil).Once()
 			},
-			wantErr:          nil,
 			expectedResCount: 2, // Wordがnilのものはスキップされるため
 			checkResponse: func(t *testing.T, res []*model.ReviewWordResponse) {
-				require.Len(t, res, 2)
-				assert.Equal(t, progressesFromRepo[0].WordID, res[0].WordID)
-				assert.Equal(t, progressesFromRepo[0].Word.Term, res[0].Term)
-				assert.Equal(t, progressesFromRepo[0].Level, res[0].Level)
-				assert.Equal(t, progressesFromRepo[1].WordID, res[1].WordID)
-				assert.Equal(t, progressesFromRepo[1].Word.Term, res[1].Term)
-				assert.Equal(t, progressesFromRepo[1].Level, res[1].Level)
+				assert.Equal(t, wordID1, res[0].WordID)
+				assert.Equal(t, model.DirectionRecognition, res[0].Direction)
+				assert.Equal(t, "apple", res[0].Term)
+				assert.Equal(t, "def1", res[0].Definition)
+				assert.Equal(t, model.Level1, res[0].Level)
+				// 詳細情報はオプションを指定した場合のみ含める
+				assert.Empty(t, res[0].Reading)
+				assert.Empty(t, res[0].Examples)
+				assert.Empty(t, res[0].Senses)
+				assert.Nil(t, res[0].Sense)
+				assert.Equal(t, wordID2, res[1].WordID)
+				assert.Equal(t, model.Level2, res[1].Level)
 			},
 		},
 		{
 			name: "正常系: 復習単語が0件",
 			setupMock: func() {
-				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, recognitionOnly, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
 					Return([]*model.LearningProgress{}, nil).Once() // 空のスライスを返す
 			},
-			wantErr:          nil,
 			expectedResCount: 0,
+		},
+		{
+			name: "正常系: WithDetails で詳細情報を含める",
+			opts: model.ReviewWordsOptions{WithDetails: true},
+			setupMock: func() {
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, recognitionOnly, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
+					Return(progressesFromRepo[:1], nil).Once()
+			},
+			expectedResCount: 1,
 			checkResponse: func(t *testing.T, res []*model.ReviewWordResponse) {
-				assert.Empty(t, res) // 空であることを確認
+				assert.Equal(t, "アップル", res[0].Reading)
+				assert.Equal(t, "noun", res[0].PartOfSpeech)
+				assert.Equal(t, []model.WordExample(word1.Examples), res[0].Examples)
+				assert.Equal(t, "note1", res[0].Notes)
+				assert.Equal(t, senses, res[0].Senses)
+				assert.Equal(t, "def1", res[0].Definition) // SingleSense でなければ単語の意味のまま
+				assert.Nil(t, res[0].Sense)
+			},
+		},
+		{
+			name: "正常系: SingleSense で語義を1つ出題する",
+			opts: model.ReviewWordsOptions{SingleSense: true},
+			setupMock: func() {
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, recognitionOnly, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
+					Return(progressesFromRepo[:2], nil).Once()
+			},
+			expectedResCount: 2,
+			checkResponse: func(t *testing.T, res []*model.ReviewWordResponse) {
+				require.NotNil(t, res[0].Sense)
+				assert.Contains(t, senses, *res[0].Sense)
+				assert.Equal(t, res[0].Sense.Definition, res[0].Definition)
+				// 語義がない単語は単語の意味のまま
+				assert.Nil(t, res[1].Sense)
+				assert.Equal(t, "def2", res[1].Definition)
+			},
+		},
+		{
+			name: "正常系: Cloze で穴埋め問題を含める",
+			opts: model.ReviewWordsOptions{Cloze: true},
+			setupMock: func() {
+				mockProgRepo.On("CreateMissingCloze", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, mock.AnythingOfType("time.Time")).
+					Return(int64(1), nil).Once()
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, withCloze, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
+					Return(append([]*model.LearningProgress{progressesFromRepo[1]}, clozeProgresses...), nil).Once()
+			},
+			expectedResCount: 2, // 例文に単語が含まれない穴埋め問題はスキップされる
+			checkResponse: func(t *testing.T, res []*model.ReviewWordResponse) {
+				assert.Equal(t, model.DirectionRecognition, res[0].Direction)
+				assert.Nil(t, res[0].Cloze)
+				assert.Equal(t, model.DirectionCloze, res[1].Direction)
+				assert.Equal(t, wordID1, res[1].WordID)
+				require.NotNil(t, res[1].Cloze)
+				assert.Equal(t, "I ate two ___.", res[1].Cloze.Text)
+				assert.Equal(t, "apples", res[1].Cloze.Answer)
+				assert.Equal(t, "りんごを2つ食べた。", res[1].Cloze.Translation)
+			},
+		},
+		{
+			name: "異常系: 穴埋めの学習進捗の作成でエラー",
+			opts: model.ReviewWordsOptions{Cloze: true},
+			setupMock: func() {
+				mockProgRepo.On("CreateMissingCloze", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, mock.AnythingOfType("time.Time")).
+					Return(int64(0), errors.New("db error")).Once()
+				// FindReviewableByTenant は呼ばれない
 			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 		{
 			name: "異常系: リポジトリでエラー発生",
 			setupMock: func() {
-				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, recognitionOnly, mock.AnythingOfType("time.Time"), testCfg.App.ReviewLimit).
 					Return(nil, errors.New("db connection error")).Once()
 			},
-			wantErr:          model.ErrInternalServer, // サービスが変換する
-			expectedResCount: 0,
-			checkResponse:    nil, // エラーなのでレスポンスチェック不要
+			wantErrCode: "INTERNAL_SERVER_ERROR", // サービスが変換する
 		},
 	}
 
@@ -129,16 +216,16 @@ func Test_reviewService_GetReviewWords(t *testing.T) {
 			mockProgRepo.Mock = mock.Mock{} // モックリセット
 			tt.setupMock()
 
-			responses, err := reviewService.GetReviewWords(ctx, testTenantID)
+			responses, err := reviewService.GetReviewWords(ctx, testTenantID, tt.opts)
 
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
+				assertAppErrorCode(t, err, tt.wantErrCode)
 				assert.Nil(t, responses)
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, responses)
-				assert.Len(t, responses, tt.expectedResCount)
+				require.Len(t, responses, tt.expectedResCount)
 				if tt.checkResponse != nil {
 					tt.checkResponse(t, responses)
 				}
@@ -149,6 +236,72 @@ func Test_reviewService_GetReviewWords(t *testing.T) {
 	}
 }
 
+// --- Test GetReviewWordsCount ---
+func Test_reviewService_GetReviewWordsCount(t *testing.T) {
+	testTenantID := uuid.New()
+	testCfg := config.Config{App: config.AppConfig{ReviewLimit: 5}}
+	reviewService, _, mockProgRepo := setupReviewServiceWithMocks(testCfg)
+	ctx := context.Background()
+
+	progresses := []*model.LearningProgress{
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: uuid.New(), Direction: model.DirectionRecognition},
+		{ProgressID: uuid.New(), TenantID: testTenantID, WordID: uuid.New(), Direction: model.DirectionCloze},
+	}
+
+	tests := []struct {
+		name        string
+		opts        model.ReviewWordsOptions
+		setupMock   func()
+		wantErrCode string
+		wantCount   int64
+	}{
+		{
+			name: "正常系: 意味を答える問題の数",
+			setupMock: func() {
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, []string{model.DirectionRecognition}, mock.AnythingOfType("time.Time"), 9999).
+					Return(progresses[:1], nil).Once()
+			},
+			wantCount: 1,
+		},
+		{
+			name: "正常系: Cloze で穴埋め問題も数える",
+			opts: model.ReviewWordsOptions{Cloze: true},
+			setupMock: func() {
+				mockProgRepo.On("CreateMissingCloze", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, mock.AnythingOfType("time.Time")).
+					Return(int64(0), nil).Once()
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, []string{model.DirectionRecognition, model.DirectionCloze}, mock.AnythingOfType("time.Time"), 9999).
+					Return(progresses, nil).Once()
+			},
+			wantCount: 2,
+		},
+		{
+			name: "異常系: リポジトリでエラー発生",
+			setupMock: func() {
+				mockProgRepo.On("FindReviewableByTenant", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, []string{model.DirectionRecognition}, mock.AnythingOfType("time.Time"), 9999).
+					Return(nil, errors.New("db connection error")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProgRepo.Mock = mock.Mock{}
+			tt.setupMock()
+
+			count, err := reviewService.GetReviewWordsCount(ctx, testTenantID, tt.opts)
+
+			if tt.wantErrCode != "" {
+				assertAppErrorCode(t, err, tt.wantErrCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantCount, count)
+			}
+			mockProgRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // --- Test UpsertLearningProgressBasedOnReview ---
 func Test_reviewService_UpsertLearningProgressBasedOnReview(t *testing.T) {
 	testTenantID := uuid.New()
@@ -163,219 +316,192 @@ func Test_reviewService_UpsertLearningProgressBasedOnReview(t *testing.T) {
 	testTimeNow := time.Now().Truncate(time.Second) // 秒以下を切り捨てて比較しやすくする
 
 	// 既存の進捗データの例 (FindByWordID が返す想定)
-	existingWord := &model.Word{WordID: testWordID, TenantID: testTenantID, Term: "existing", Definition: "def"}
 	existingProgress := &model.LearningProgress{
 		ProgressID:     testProgressID,
 		TenantID:       testTenantID,
 		WordID:         testWordID,
+		Direction:      model.DirectionRecognition,
 		Level:          model.Level1, // 初期レベル
 		NextReviewDate: testTimeNow.Add(-time.Hour),
-		Word:           existingWord, // Preloadされている想定
 	}
 
 	tests := []struct {
 		name             string
+		direction        string
 		isCorrect        bool
 		setupMock        func(now time.Time) // モック設定 (FindByWordID, Create/Update)
-		wantErr          error
+		wantErrCode      string
 		checkExpectation func(t *testing.T, now time.Time) // モック呼び出し検証
 	}{
 		// --- 新規作成 (Create) のテストケース ---
 		{
 			name:      "正常系: 新規作成 正解 Level1 -> Level2",
+			direction: model.DirectionRecognition,
 			isCorrect: true,
 			setupMock: func(now time.Time) {
 				// 1. FindByWordID が ErrNotFound を返す
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(nil, model.ErrNotFound).Once()
 				// 2. Create が呼ばれるはず
 				mockProgRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
-					expectedNextReview := now.AddDate(0, 0, 3)
 					return p.TenantID == testTenantID &&
 						p.WordID == testWordID &&
+						p.Direction == model.DirectionRecognition &&
 						p.Level == model.Level2 && // Level2 になるはず
-						p.NextReviewDate.Year() == expectedNextReview.Year() && // 日付比較 (Truncateで比較しても良い)
-						p.NextReviewDate.Month() == expectedNextReview.Month() &&
-						p.NextReviewDate.Day() == expectedNextReview.Day() &&
-						p.LastReviewedAt != nil && !p.LastReviewedAt.IsZero() && time.Since(*p.LastReviewedAt) < time.Second // LastReviewedAtが設定される
+						sameDay(p.NextReviewDate, now.AddDate(0, 0, 3)) &&
+						p.LastReviewedAt != nil && time.Since(*p.LastReviewedAt) < time.Second // LastReviewedAtが設定される
 				})).Return(nil).Once()
 			},
-			wantErr: nil,
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
-			},
 		},
 		{
 			name:      "正常系: 新規作成 不正解 Level1 -> Level1",
+			direction: model.DirectionRecognition,
 			isCorrect: false,
 			setupMock: func(now time.Time) {
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(nil, model.ErrNotFound).Once()
 				mockProgRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
-					expectedNextReview := now.AddDate(0, 0, 1)
 					return p.Level == model.Level1 && // Level1 のまま
-						p.NextReviewDate.Year() == expectedNextReview.Year() &&
-						p.NextReviewDate.Month() == expectedNextReview.Month() &&
-						p.NextReviewDate.Day() == expectedNextReview.Day() && // 翌日
-						p.LastReviewedAt != nil && !p.LastReviewedAt.IsZero()
+						!p.NextReviewDate.After(now) && // すぐに復習対象になる
+						p.LastReviewedAt != nil
 				})).Return(nil).Once()
 			},
-			wantErr: nil,
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
+		},
+		{
+			name:      "正常系: 穴埋めの学習進捗を新規作成",
+			direction: model.DirectionCloze,
+			isCorrect: true,
+			setupMock: func(now time.Time) {
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionCloze).
+					Return(nil, model.ErrNotFound).Once()
+				mockProgRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
+					return p.Direction == model.DirectionCloze && p.Level == model.Level2
+				})).Return(nil).Once()
 			},
 		},
 		{
 			name:      "異常系: 新規作成時 Createエラー",
+			direction: model.DirectionRecognition,
 			isCorrect: true,
 			setupMock: func(now time.Time) {
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(nil, model.ErrNotFound).Once()
 				// Create がエラーを返す
 				mockProgRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).
 					Return(errors.New("db create error")).Once()
 			},
-			wantErr: model.ErrInternalServer, // サービスが変換
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
-			},
+			wantErrCode: "INTERNAL_SERVER_ERROR", // サービスが変換
 		},
 
 		// --- 更新 (Update) のテストケース ---
 		{
 			name:      "正常系: 更新 正解 Level1 -> Level2",
+			direction: model.DirectionRecognition,
 			isCorrect: true,
 			setupMock: func(now time.Time) {
 				// 1. FindByWordID が既存データを返す
-				//    テスト実行時の時刻を使うため、コピーして渡す
 				progressToReturn := *existingProgress // ポインタの参照先をコピー
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(&progressToReturn, nil).Once()
 				// 2. Update が呼ばれるはず
 				mockProgRepo.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
-					expectedNextReview := now.AddDate(0, 0, 3)
 					return p.ProgressID == existingProgress.ProgressID && // IDが同じか
 						p.Level == model.Level2 && // Level2 に更新
-						p.NextReviewDate.Year() == expectedNextReview.Year() &&
-						p.NextReviewDate.Month() == expectedNextReview.Month() &&
-						p.NextReviewDate.Day() == expectedNextReview.Day() &&
-						p.LastReviewedAt != nil && !p.LastReviewedAt.IsZero() && time.Since(*p.LastReviewedAt) < time.Second
+						sameDay(p.NextReviewDate, now.AddDate(0, 0, 3)) &&
+						p.LastReviewedAt != nil && time.Since(*p.LastReviewedAt) < time.Second
 				})).Return(nil).Once()
 			},
-			wantErr: nil,
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
+		},
+		{
+			name:      "正常系: 更新 正解 Level3 は Level3 のまま",
+			direction: model.DirectionRecognition,
+			isCorrect: true,
+			setupMock: func(now time.Time) {
+				progressToReturn := *existingProgress
+				progressToReturn.Level = model.Level3
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
+					Return(&progressToReturn, nil).Once()
+				mockProgRepo.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
+					return p.Level == model.Level3 && sameDay(p.NextReviewDate, now.AddDate(0, 0, 14))
+				})).Return(nil).Once()
 			},
 		},
 		{
 			name:      "正常系: 更新 不正解 Level2 -> Level1",
+			direction: model.DirectionRecognition,
 			isCorrect: false,
 			setupMock: func(now time.Time) {
 				progressToReturn := *existingProgress
 				progressToReturn.Level = model.Level2 // テスト用にレベルを変更
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(&progressToReturn, nil).Once()
 				mockProgRepo.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
-					expectedNextReview := now.AddDate(0, 0, 1)
 					return p.ProgressID == existingProgress.ProgressID &&
 						p.Level == model.Level1 && // Level1 に戻る
-						p.NextReviewDate.Year() == expectedNextReview.Year() &&
-						p.NextReviewDate.Month() == expectedNextReview.Month() &&
-						p.NextReviewDate.Day() == expectedNextReview.Day() &&
-						p.LastReviewedAt != nil && !p.LastReviewedAt.IsZero()
+						!p.NextReviewDate.After(now) &&
+						p.LastReviewedAt != nil
 				})).Return(nil).Once()
 			},
-			wantErr: nil,
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
-			},
 		},
 		{
-			name:      "異常系: 更新時 FindByWordID エラー (NotFound以外)",
+			name:      "異常系: FindByWordID エラー (NotFound以外)",
+			direction: model.DirectionRecognition,
 			isCorrect: true,
 			setupMock: func(now time.Time) {
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(nil, errors.New("some db error")).Once()
 				// Create/Update は呼ばれない
 			},
-			wantErr: model.ErrInternalServer,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 			checkExpectation: func(t *testing.T, now time.Time) {
 				mockProgRepo.AssertExpectations(t) // FindByWordID が呼ばれたことを確認
-				mockProgRepo.AssertNotCalled(t, "Create")
-				mockProgRepo.AssertNotCalled(t, "Update")
-			},
-		},
-		{
-			name:      "異常系: 更新時 Wordがnil",
-			isCorrect: true,
-			setupMock: func(now time.Time) {
-				progressToReturn := *existingProgress
-				progressToReturn.Word = nil // Word を nil にする
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
-					Return(&progressToReturn, nil).Once()
-				// Update は呼ばれない
-			},
-			wantErr: model.ErrNotFound, // Word が無効な場合は NotFound
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
-				mockProgRepo.AssertNotCalled(t, "Update")
+				mockProgRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+				mockProgRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
 			},
 		},
 		{
-			name:      "異常系: 更新時 Wordが論理削除済み",
+			name:      "異常系: 更新時 Updateエラー",
+			direction: model.DirectionRecognition,
 			isCorrect: true,
 			setupMock: func(now time.Time) {
 				progressToReturn := *existingProgress
-				deletedWord := *existingWord
-				deletedWord.DeletedAt = gorm.DeletedAt{Time: now, Valid: true} // 論理削除
-				progressToReturn.Word = &deletedWord
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(&progressToReturn, nil).Once()
-				// Update は呼ばれない
-			},
-			wantErr: model.ErrNotFound,
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
-				mockProgRepo.AssertNotCalled(t, "Update")
+				// Update がエラーを返す
+				mockProgRepo.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).
+					Return(errors.New("db update error")).Once()
 			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 		{
-			name:      "異常系: 更新時 Updateエラー",
+			name:      "異常系: 更新時 更新対象が見つからない (競合)",
+			direction: model.DirectionRecognition,
 			isCorrect: true,
 			setupMock: func(now time.Time) {
 				progressToReturn := *existingProgress
-				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID).
+				mockProgRepo.On("FindByWordID", ctx, mock.AnythingOfType("*gorm.DB"), testTenantID, testWordID, model.DirectionRecognition).
 					Return(&progressToReturn, nil).Once()
-				// Update がエラーを返す
 				mockProgRepo.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).
-					Return(errors.New("db update error")).Once()
-			},
-			wantErr: model.ErrInternalServer,
-			checkExpectation: func(t *testing.T, now time.Time) {
-				mockProgRepo.AssertExpectations(t)
+					Return(model.ErrNotFound).Once()
 			},
+			wantErrCode: "NOT_FOUND",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// DBはトランザクション内で使われるが、リポジトリモックへの影響はない
-			// DBの状態はテストケース間でクリアされるように setupTestDBReviewService を毎回呼ぶ
-			// （ただし、このテストではDB自体への書き込みはテストしない）
-			_ = setupTestDBReviewService() // DBの準備（インスタンスはここでは使わない）
-
 			mockProgRepo.Mock = mock.Mock{}
 			// テスト実行時の時間を記録
 			testStart := time.Now().Truncate(time.Second)
 			tt.setupMock(testStart) // モック設定に関数内で使う時間を渡す
 
 			// サービスメソッド呼び出し
-			err := reviewService.UpsertLearningProgressBasedOnReview(ctx, testTenantID, testWordID, tt.isCorrect)
+			err := reviewService.UpsertLearningProgressBasedOnReview(ctx, testTenantID, testWordID, tt.direction, tt.isCorrect)
 
 			// エラー検証
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
+				assertAppErrorCode(t, err, tt.wantErrCode)
 			} else {
 				require.NoError(t, err)
 			}
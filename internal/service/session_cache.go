@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultSessionCacheTTL は auth.session_cache_ttl が未設定の場合にセッションの状態をキャッシュする時間
+const defaultSessionCacheTTL = 30 * time.Second
+
+// maxSessionCacheEntries はキャッシュするセッションの最大数。超えた場合は期限切れのものから捨てる
+const maxSessionCacheEntries = 10000
+
+// SessionCache はセッションが失効していないかをDBで確認し、結果を短時間キャッシュします (middleware.SessionChecker)。
+// このインスタンスで失効させたセッションはすぐに反映されますが、他のインスタンスで失効させたものは
+// キャッシュの有効期間が過ぎるまで有効とみなされます。
+type SessionCache struct {
+	db          *gorm.DB
+	sessionRepo repository.SessionRepository
+	ttl         time.Duration
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]sessionCacheEntry
+}
+
+type sessionCacheEntry struct {
+	tenantID  uuid.UUID
+	active    bool
+	expiresAt time.Time
+}
+
+func NewSessionCache(db *gorm.DB, sessionRepo repository.SessionRepository, cfg *config.Config) *SessionCache {
+	ttl := cfg.Auth.SessionCacheTTL
+	if ttl <= 0 {
+		ttl = defaultSessionCacheTTL
+	}
+	return &SessionCache{db: db, sessionRepo: sessionRepo, ttl: ttl, entries: make(map[uuid.UUID]sessionCacheEntry)}
+}
+
+// IsSessionActive はセッションが存在し、失効していないかを返します。
+// キャッシュにない場合はDBを確認し、あわせてセッションの最終利用日時を更新します。
+func (c *SessionCache) IsSessionActive(ctx context.Context, tenantID, sessionID uuid.UUID) (bool, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[sessionID]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.active && entry.tenantID == tenantID, nil
+	}
+
+	active := false
+	session, err := c.sessionRepo.FindByID(ctx, c.db, tenantID, sessionID)
+	switch {
+	case err == nil:
+		active = session.RevokedAt == nil
+	case !errors.Is(err, model.ErrNotFound):
+		return false, err
+	}
+	if active {
+		if err := c.sessionRepo.Touch(ctx, c.db, sessionID, middleware.GetClientInfo(ctx)); err != nil {
+			// 最終利用日時の更新に失敗しても認証は続ける
+			middleware.GetLogger(ctx).Warn("Failed to update session last seen", "error", err, "session_id", sessionID)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxSessionCacheEntries {
+		c.pruneLocked(now)
+	}
+	c.entries[sessionID] = sessionCacheEntry{tenantID: tenantID, active: active, expiresAt: now.Add(c.ttl)}
+	return active, nil
+}
+
+// Invalidate はセッションのキャッシュを捨て、次の確認でDBを見るようにします。失効させた直後に呼び出してください。
+func (c *SessionCache) Invalidate(sessionID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, sessionID)
+}
+
+func (c *SessionCache) pruneLocked(now time.Time) {
+	for id, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+	if len(c.entries) >= maxSessionCacheEntries {
+		c.entries = make(map[uuid.UUID]sessionCacheEntry)
+	}
+}
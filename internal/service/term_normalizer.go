@@ -0,0 +1,21 @@
+package service
+
+import (
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/textutil"
+)
+
+// termNormalizer は重複判定に使う正規化済みの単語 (words.normalized_term) を計算します。
+// 正規化した値が同じ単語は、同一テナント内で重複として扱われます。
+type termNormalizer struct {
+	opts textutil.NormalizeOptions
+}
+
+// newTermNormalizer は設定 (term_normalization) に従って単語を正規化する termNormalizer を返します
+func newTermNormalizer(cfg *config.Config) termNormalizer {
+	return termNormalizer{opts: textutil.NormalizeOptions{UnifyKana: cfg.TermNormalization.UnifyKana}}
+}
+
+func (n termNormalizer) normalize(term string) string {
+	return textutil.NormalizeTerm(term, n.opts)
+}
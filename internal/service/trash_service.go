@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go_4_vocab_keep/internal/blobstore"
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// purgeBatchSize は保持期間切れの単語を完全削除する際に1トランザクションで削除する件数
+const purgeBatchSize = 500
+
+// TrashService はゴミ箱 (論理削除済みの単語) の一覧・復元・完全削除を扱います
+type TrashService interface {
+	ListWords(ctx context.Context, tenantID uuid.UUID) ([]*model.TrashedWordResponse, error)
+	RestoreWord(ctx context.Context, tenantID, wordID uuid.UUID) (*model.Word, error)
+	PurgeWord(ctx context.Context, tenantID, wordID uuid.UUID) error
+	EmptyTrash(ctx context.Context, tenantID uuid.UUID) (int64, error)
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type trashService struct {
+	db         *gorm.DB
+	wordRepo   repository.WordRepository
+	progRepo   repository.ProgressRepository
+	blobs      blobstore.BlobStore
+	cfg        *config.Config
+	normalizer termNormalizer
+}
+
+// NewTrashService はゴミ箱のサービスを返します。
+// blobs は完全削除した単語の添付ファイルを削除するために使います。
+func NewTrashService(db *gorm.DB, wordRepo repository.WordRepository, progRepo repository.ProgressRepository, blobs blobstore.BlobStore, cfg *config.Config) TrashService {
+	return &trashService{
+		db:         db,
+		wordRepo:   wordRepo,
+		progRepo:   progRepo,
+		blobs:      blobs,
+		cfg:        cfg,
+		normalizer: newTermNormalizer(cfg),
+	}
+}
+
+// retention はゴミ箱の保持期間を返します
+func (s *trashService) retention() time.Duration {
+	return time.Duration(s.cfg.Trash.RetentionDays) * 24 * time.Hour
+}
+
+func (s *trashService) ListWords(ctx context.Context, tenantID uuid.UUID) ([]*model.TrashedWordResponse, error) {
+	logger := middleware.GetLogger(ctx)
+	words, err := s.wordRepo.FindDeletedByTenant(ctx, s.db, tenantID)
+	if err != nil {
+		logger.Error("Failed to get trashed words", "error", err)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "ゴミ箱の単語の取得に失敗しました。", "", err)
+	}
+
+	res := make([]*model.TrashedWordResponse, 0, len(words))
+	for _, w := range words {
+		res = append(res, &model.TrashedWordResponse{
+			WordID:     w.WordID,
+			Term:       w.Term,
+			Definition: w.Definition,
+			Tags:       w.Tags,
+			CreatedAt:  w.CreatedAt,
+			DeletedAt:  w.DeletedAt.Time,
+			PurgeAt:    w.DeletedAt.Time.Add(s.retention()),
+		})
+	}
+	return res, nil
+}
+
+// RestoreWord はゴミ箱の単語を復元します。削除時に残しておいた学習進捗もそのまま引き継がれます。
+func (s *trashService) RestoreWord(ctx context.Context, tenantID, wordID uuid.UUID) (*model.Word, error) {
+	logger := middleware.GetLogger(ctx)
+	var restored *model.Word
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		words, err := s.wordRepo.FindDeletedByIDs(ctx, tx, tenantID, []uuid.UUID{wordID})
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の取得に失敗しました。", "", err)
+		}
+		if len(words) == 0 {
+			return model.NewAppError("NOT_FOUND", "指定された単語はゴミ箱にありません。", "word_id", model.ErrNotFound)
+		}
+
+		exists, err := s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, s.normalizer.normalize(words[0].Term), nil)
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", err)
+		}
+		if exists {
+			return model.NewAppError("DUPLICATE_TERM", "同じ単語が既に登録されているため復元できません。", "term", model.ErrConflict)
+		}
+
+		if err := s.wordRepo.Restore(ctx, tx, tenantID, wordID); err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				return model.NewAppError("DUPLICATE_TERM", "同じ単語が既に登録されているため復元できません。", "term", model.ErrConflict)
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の復元に失敗しました。", "", err)
+		}
+
+		// 以前の削除処理で学習進捗が削除されている単語は、Level1 からやり直す
+		if _, err := s.progRepo.FindByWordID(ctx, tx, tenantID, wordID, model.DirectionRecognition); err != nil {
+			if !errors.Is(err, model.ErrNotFound) {
+				return model.NewAppError("INTERNAL_SERVER_ERROR", "学習進捗の取得に失敗しました。", "", err)
+			}
+			progress := &model.LearningProgress{
+				ProgressID:     uuid.New(),
+				TenantID:       tenantID,
+				WordID:         wordID,
+				Level:          model.Level1,
+				NextReviewDate: initialNextReviewDate(),
+			}
+			if err := s.progRepo.Create(ctx, tx, progress); err != nil {
+				return model.NewAppError("INTERNAL_SERVER_ERROR", "学習進捗の作成に失敗しました。", "", err)
+			}
+		}
+
+		restored, err = s.wordRepo.FindByID(ctx, tx, tenantID, wordID)
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "復元後の単語の取得に失敗しました。", "", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Word restored from trash", "word_id", wordID)
+	return restored, nil
+}
+
+// PurgeWord はゴミ箱の単語を完全に削除します
+func (s *trashService) PurgeWord(ctx context.Context, tenantID, wordID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+	if err := s.wordRepo.Purge(ctx, s.db, tenantID, wordID); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return model.NewAppError("NOT_FOUND", "指定された単語はゴミ箱にありません。", "word_id", model.ErrNotFound)
+		}
+		logger.Error("Failed to purge word", "error", err)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の完全削除に失敗しました。", "", err)
+	}
+	s.deleteAttachmentBlobs(ctx, []uuid.UUID{wordID})
+	logger.Info("Word purged from trash", "word_id", wordID)
+	return nil
+}
+
+// EmptyTrash はテナントのゴミ箱を空にし、削除した件数を返します
+func (s *trashService) EmptyTrash(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	logger := middleware.GetLogger(ctx)
+	purged, err := s.wordRepo.PurgeAllDeleted(ctx, s.db, tenantID)
+	if err != nil {
+		logger.Error("Failed to empty trash", "error", err)
+		return 0, model.NewAppError("INTERNAL_SERVER_ERROR", "ゴミ箱を空にできませんでした。", "", err)
+	}
+	s.deleteAttachmentBlobs(ctx, purged)
+	logger.Info("Trash emptied", "purged", len(purged))
+	return int64(len(purged)), nil
+}
+
+// PurgeExpired は全テナントを対象に、保持期間を過ぎたゴミ箱の単語を完全に削除し、削除した件数を返します。
+// 保持期間の設定が0以下の場合は何もしません。
+func (s *trashService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	logger := middleware.GetLogger(ctx)
+	if s.cfg.Trash.RetentionDays <= 0 {
+		return 0, nil
+	}
+	before := now.Add(-s.retention())
+
+	var total int64
+	for {
+		purged, err := s.wordRepo.PurgeDeletedBefore(ctx, s.db, before, purgeBatchSize)
+		if err != nil {
+			logger.Error("Failed to purge expired trash", "error", err, "purged", total)
+			return total, err
+		}
+		s.deleteAttachmentBlobs(ctx, purged)
+		total += int64(len(purged))
+		if len(purged) < purgeBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.Info("Expired trash purged", "purged", total, "before", before)
+	}
+	return total, nil
+}
+
+// deleteAttachmentBlobs は完全削除した単語の添付ファイルをストレージから削除します。
+// 単語の削除はコミット済みのため、失敗してもログに残すだけにします (ファイルが残っても参照されることはない)。
+func (s *trashService) deleteAttachmentBlobs(ctx context.Context, wordIDs []uuid.UUID) {
+	logger := middleware.GetLogger(ctx)
+	ctx = context.WithoutCancel(ctx)
+	for _, wordID := range wordIDs {
+		if err := s.blobs.DeletePrefix(ctx, model.AttachmentStoragePrefix(wordID)); err != nil {
+			logger.Warn("Failed to delete attachment blobs of purged word", "error", err, "word_id", wordID)
+		}
+	}
+}
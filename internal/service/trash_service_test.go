@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go_4_vocab_keep/internal/config"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/repository/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Test RestoreWord ---
+func Test_trashService_RestoreWord(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	wordID := uuid.New()
+	// 大文字小文字・前後の空白が違っても正規化すると同じ単語になる
+	deleted := &model.Word{WordID: wordID, TenantID: tenantID, Term: " Apple ", Definition: "りんご"}
+	anyTx := mock.AnythingOfType("*gorm.DB")
+
+	tests := []struct {
+		name        string
+		setupMock   func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository)
+		wantErrCode string
+	}{
+		{
+			name: "正常系: 学習進捗を引き継いで復元する",
+			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
+				wordRepo.On("FindDeletedByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordID}).Return([]*model.Word{deleted}, nil).Once()
+				wordRepo.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(deleted.Term), (*uuid.UUID)(nil)).Return(false, nil).Once()
+				wordRepo.On("Restore", ctx, anyTx, tenantID, wordID).Return(nil).Once()
+				progRepo.On("FindByWordID", ctx, anyTx, tenantID, wordID, model.DirectionRecognition).Return(&model.LearningProgress{WordID: wordID}, nil).Once()
+				wordRepo.On("FindByID", ctx, anyTx, tenantID, wordID).Return(deleted, nil).Once()
+			},
+		},
+		{
+			name: "異常系: ゴミ箱にない",
+			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
+				wordRepo.On("FindDeletedByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordID}).Return([]*model.Word{}, nil).Once()
+			},
+			wantErrCode: "NOT_FOUND",
+		},
+		{
+			name: "異常系: 正規化すると同じ単語が登録されている",
+			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
+				wordRepo.On("FindDeletedByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordID}).Return([]*model.Word{deleted}, nil).Once()
+				wordRepo.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm("apple"), (*uuid.UUID)(nil)).Return(true, nil).Once()
+				// Restore は呼ばれない
+			},
+			wantErrCode: "DUPLICATE_TERM",
+		},
+		{
+			name: "異常系: 復元時に一意制約違反 (同時登録)",
+			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
+				wordRepo.On("FindDeletedByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordID}).Return([]*model.Word{deleted}, nil).Once()
+				wordRepo.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(deleted.Term), (*uuid.UUID)(nil)).Return(false, nil).Once()
+				wordRepo.On("Restore", ctx, anyTx, tenantID, wordID).Return(model.ErrConflict).Once()
+			},
+			wantErrCode: "DUPLICATE_TERM",
+		},
+		{
+			name: "異常系: 重複チェックでDBエラー",
+			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
+				wordRepo.On("FindDeletedByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordID}).Return([]*model.Word{deleted}, nil).Once()
+				wordRepo.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(deleted.Term), (*uuid.UUID)(nil)).Return(false, errors.New("db error")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordRepo := new(mocks.WordRepository)
+			progRepo := new(mocks.ProgressRepository)
+			tt.setupMock(wordRepo, progRepo)
+			s := NewTrashService(setupTestDBWord(), wordRepo, progRepo, nil, &config.Config{})
+
+			word, err := s.RestoreWord(ctx, tenantID, wordID)
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				assert.Nil(t, word)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, wordID, word.WordID)
+			}
+			wordRepo.AssertExpectations(t)
+			progRepo.AssertExpectations(t)
+		})
+	}
+}
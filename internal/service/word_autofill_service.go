@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/textutil"
+)
+
+// autofillDefinitionSeparator は辞書の複数の語義を1つの意味にまとめる際の区切り
+const autofillDefinitionSeparator = " / "
+
+// maxAutofillDefinitionLength は辞書から補う意味の最大文字数 (語義の definition の上限に合わせる)
+const maxAutofillDefinitionLength = 1000
+
+// autofillWord は単語作成リクエストのうち省略された意味・読み・品詞を、辞書の最初の見出しで補います。
+// 意味を省略していて辞書に見つからない・辞書を使えない場合は、意味は必須のためエラーにします。
+// 意味を入力済みの場合、辞書を使えなくても入力された内容のまま登録できるようにします。
+// 辞書の値はリクエストの検証を通っていないため、登録できない品詞は捨て、長すぎる意味・読みは切り詰めるか捨てます。
+func (s *wordService) autofillWord(ctx context.Context, req *model.PostWordRequest) error {
+	logger := middleware.GetLogger(ctx)
+	if req.Definition != "" && req.Reading != "" && req.PartOfSpeech != "" {
+		return nil
+	}
+	if s.dictionary == nil {
+		if req.Definition != "" {
+			return nil
+		}
+		return model.NewAppError("DICTIONARY_NOT_CONFIGURED", "辞書が設定されていないため、意味を調べられません。意味を入力してください。", "definition", model.ErrUnavailable)
+	}
+
+	res, err := s.dictionary.Lookup(ctx, req.Term)
+	if err != nil {
+		if req.Definition != "" {
+			logger.Warn("Dictionary lookup failed, registering without autofill", "error", err, "term", req.Term)
+			return nil
+		}
+		return err
+	}
+	if len(res.Entries) == 0 {
+		if req.Definition == "" {
+			return model.NewAppError("DEFINITION_NOT_FOUND", "辞書に意味が見つかりませんでした。意味を入力してください。", "definition", model.ErrInvalidInput)
+		}
+		return nil
+	}
+
+	e := res.Entries[0]
+	if req.Definition == "" {
+		req.Definition = joinAutofillDefinitions(e.Definitions)
+		if req.Definition == "" {
+			return model.NewAppError("DEFINITION_NOT_FOUND", "辞書に意味が見つかりませんでした。意味を入力してください。", "definition", model.ErrInvalidInput)
+		}
+	}
+	// かなだけの単語に読みは不要
+	if req.Reading == "" && textutil.ContainsKanji(req.Term) && utf8.RuneCountInString(e.Reading) <= maxReadingLength {
+		req.Reading = e.Reading
+	}
+	if req.PartOfSpeech == "" {
+		if model.IsValidPartOfSpeech(e.PartOfSpeech) {
+			req.PartOfSpeech = e.PartOfSpeech
+		} else {
+			logger.Warn("Ignoring unsupported part of speech from dictionary", "term", req.Term, "part_of_speech", e.PartOfSpeech)
+		}
+	}
+	return nil
+}
+
+// joinAutofillDefinitions は辞書の語義を maxAutofillDefinitionLength 文字に収まるだけ区切りでつなげます。
+// 最初の語義だけで上限を超える場合は、その語義を上限の文字数で切り詰めます。
+func joinAutofillDefinitions(definitions []string) string {
+	var b strings.Builder
+	length := 0
+	for _, d := range definitions {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		n := utf8.RuneCountInString(d)
+		if length == 0 {
+			if n > maxAutofillDefinitionLength {
+				return string([]rune(d)[:maxAutofillDefinitionLength])
+			}
+			b.WriteString(d)
+			length = n
+			continue
+		}
+		n += utf8.RuneCountInString(autofillDefinitionSeparator)
+		if length+n > maxAutofillDefinitionLength {
+			break
+		}
+		b.WriteString(autofillDefinitionSeparator)
+		b.WriteString(d)
+		length += n
+	}
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDictionary は決まった結果を返す DictionaryService です
+type stubDictionary struct {
+	res *model.DictionaryLookupResponse
+	err error
+}
+
+func (d *stubDictionary) Lookup(ctx context.Context, term string) (*model.DictionaryLookupResponse, error) {
+	return d.res, d.err
+}
+
+// --- Test PostWord (autofill) ---
+func Test_wordService_PostWord_Autofill(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	term := "走る"
+
+	dictionaryResult := &model.DictionaryLookupResponse{
+		Term: term,
+		Entries: []model.DictionaryEntry{
+			{Term: term, Reading: "はしる", Definitions: []string{"to run", "to travel"}, PartOfSpeech: model.PartOfSpeechVerb},
+			{Term: term, Reading: "はしる", Definitions: []string{"ignored"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		dictionary  DictionaryService
+		req         *model.PostWordRequest
+		wantErr     error
+		wantErrCode string
+		wantCreated bool
+		checkWord   func(t *testing.T, word *model.Word)
+	}{
+		{
+			name:        "正常系: 省略した意味・読み・品詞を辞書の最初の見出しで補う",
+			dictionary:  &stubDictionary{res: dictionaryResult},
+			req:         &model.PostWordRequest{Term: term, Autofill: true},
+			wantCreated: true,
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Equal(t, "to run / to travel", word.Definition)
+				assert.Equal(t, "はしる", word.Reading)
+				assert.False(t, word.ReadingManual) // 辞書で補った読みは利用者の入力として扱わない
+				assert.Equal(t, model.PartOfSpeechVerb, word.PartOfSpeech)
+			},
+		},
+		{
+			name:        "正常系: 入力済みの項目は辞書で上書きしない",
+			dictionary:  &stubDictionary{res: dictionaryResult},
+			req:         &model.PostWordRequest{Term: term, Definition: "走ること", Reading: "そう", Autofill: true},
+			wantCreated: true,
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Equal(t, "走ること", word.Definition)
+				assert.Equal(t, "そう", word.Reading)
+				assert.True(t, word.ReadingManual)
+				assert.Equal(t, model.PartOfSpeechVerb, word.PartOfSpeech)
+			},
+		},
+		{
+			name: "正常系: 登録できない品詞は捨てる",
+			dictionary: &stubDictionary{res: &model.DictionaryLookupResponse{Entries: []model.DictionaryEntry{
+				{Term: term, Reading: "はしる", Definitions: []string{"to run"}, PartOfSpeech: "godan verb"},
+			}}},
+			req:         &model.PostWordRequest{Term: term, Autofill: true},
+			wantCreated: true,
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Equal(t, "to run", word.Definition)
+				assert.Empty(t, word.PartOfSpeech)
+			},
+		},
+		{
+			name:        "正常系: 意味を入力済みなら辞書の検索に失敗しても登録できる",
+			dictionary:  &stubDictionary{err: errors.New("dictionary unavailable")},
+			req:         &model.PostWordRequest{Term: term, Definition: "走ること", Autofill: true},
+			wantCreated: true,
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Equal(t, "走ること", word.Definition)
+				assert.Empty(t, word.PartOfSpeech)
+			},
+		},
+		{
+			name:        "正常系: 意味を入力済みなら辞書が設定されていなくても登録できる",
+			dictionary:  nil,
+			req:         &model.PostWordRequest{Term: term, Definition: "走ること", Autofill: true},
+			wantCreated: true,
+		},
+		{
+			name:        "異常系: 意味を省略して辞書が設定されていない",
+			dictionary:  nil,
+			req:         &model.PostWordRequest{Term: term, Autofill: true},
+			wantErr:     model.ErrUnavailable,
+			wantErrCode: "DICTIONARY_NOT_CONFIGURED",
+		},
+		{
+			name:        "異常系: 意味を省略して辞書に見つからない",
+			dictionary:  &stubDictionary{res: &model.DictionaryLookupResponse{Term: term}},
+			req:         &model.PostWordRequest{Term: term, Autofill: true},
+			wantErr:     model.ErrInvalidInput,
+			wantErrCode: "DEFINITION_NOT_FOUND",
+		},
+		{
+			name:       "異常系: 意味を省略して辞書の検索に失敗",
+			dictionary: &stubDictionary{err: model.NewAppError("DICTIONARY_UNAVAILABLE", "辞書を利用できません。", "", model.ErrUnavailable)},
+			req:        &model.PostWordRequest{Term: term, Autofill: true},
+			wantErr:    model.ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordService, _, m := setupWordServiceWithMocks(tt.dictionary)
+			if tt.wantCreated {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(term), (*uuid.UUID)(nil)).
+					Return(false, nil).Once()
+				m.word.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).Return(nil).Once()
+				m.progress.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).Return(nil).Once()
+			}
+
+			createdWord, err := wordService.PostWord(ctx, tenantID, tt.req)
+
+			if !tt.wantCreated {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErrCode != "" {
+					assertAppErrorCode(t, err, tt.wantErrCode)
+				}
+				assert.Nil(t, createdWord)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, createdWord)
+				if tt.checkWord != nil {
+					tt.checkWord(t, createdWord)
+				}
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+// --- Test joinAutofillDefinitions ---
+func Test_joinAutofillDefinitions(t *testing.T) {
+	long := strings.Repeat("あ", maxAutofillDefinitionLength-5)
+
+	tests := []struct {
+		name        string
+		definitions []string
+		want        string
+	}{
+		{
+			name:        "正常系: 区切りでつなげる (空の語義は除く)",
+			definitions: []string{" to run ", "", "to travel"},
+			want:        "to run / to travel",
+		},
+		{
+			name:        "正常系: 上限を超える語義以降は含めない",
+			definitions: []string{long, "abc", "d"},
+			want:        long,
+		},
+		{
+			name:        "正常系: 最初の語義だけで上限を超える場合は切り詰める",
+			definitions: []string{strings.Repeat("い", maxAutofillDefinitionLength+10)},
+			want:        strings.Repeat("い", maxAutofillDefinitionLength),
+		},
+		{
+			name:        "正常系: 語義がない",
+			definitions: nil,
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinAutofillDefinitions(tt.definitions)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, utf8.RuneCountInString(got), maxAutofillDefinitionLength)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BulkWords は複数の単語に同じ操作を1つのトランザクションで行います。
+// 単語ごとの問題 (見つからない・復元先の単語が重複している等) は結果に記録して処理を続け、
+// DBエラーが起きた場合は全体をロールバックします。
+func (s *wordService) BulkWords(ctx context.Context, tenantID uuid.UUID, req *model.BulkWordsRequest) (*model.BulkWordsResponse, error) {
+	logger := middleware.GetLogger(ctx).With("action", req.Action)
+	resp := &model.BulkWordsResponse{Action: req.Action}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids, err := s.resolveBulkTargets(ctx, tx, tenantID, req)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			resp.Results = []model.BulkItemResult{}
+			return nil
+		}
+
+		var words []*model.Word
+		if req.Action == model.BulkActionRestore {
+			words, err = s.wordRepo.FindDeletedByIDs(ctx, tx, tenantID, ids)
+		} else {
+			words, err = s.wordRepo.FindByIDs(ctx, tx, tenantID, ids)
+		}
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の取得に失敗しました。", "", err)
+		}
+		byID := make(map[uuid.UUID]*model.Word, len(words))
+		for _, w := range words {
+			byID[w.WordID] = w
+		}
+
+		// 復元する単語同士で単語名が重複しないよう、この操作で復元した単語名 (正規化済み) を覚えておく
+		restoredTerms := make(map[string]bool)
+		resp.Results = make([]model.BulkItemResult, 0, len(ids))
+		for _, id := range ids {
+			word, ok := byID[id]
+			if !ok {
+				resp.Results = append(resp.Results, model.BulkItemResult{WordID: id, Status: model.BulkStatusNotFound})
+				continue
+			}
+			// 1件ずつセーブポイントで囲み、一意制約違反などで失敗した単語の変更だけを取り消せるようにする
+			var result model.BulkItemResult
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				var err error
+				result, err = s.applyBulkAction(ctx, tx, tenantID, word, req, restoredTerms)
+				return err
+			})
+			if errors.Is(err, model.ErrConflict) {
+				// 重複チェックの後に同じ単語が登録された場合。この単語だけを失敗として続ける
+				delete(restoredTerms, s.normalizer.normalize(word.Term))
+				result = model.BulkItemResult{
+					WordID:  id,
+					Status:  model.BulkStatusFailed,
+					Message: fmt.Sprintf("「%s」は既に登録されているため復元できません。", word.Term),
+				}
+				err = nil
+			}
+			if err != nil {
+				logger.Error("Bulk action failed", "error", err, "word_id", id)
+				return model.NewAppError("INTERNAL_SERVER_ERROR", "一括操作に失敗しました。変更はすべて取り消されました。", "", err)
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Total = len(resp.Results)
+	for _, r := range resp.Results {
+		switch r.Status {
+		case model.BulkStatusSucceeded:
+			resp.Succeeded++
+		case model.BulkStatusUnchanged:
+			resp.Unchanged++
+		case model.BulkStatusNotFound:
+			resp.NotFound++
+		case model.BulkStatusFailed:
+			resp.Failed++
+		}
+	}
+
+	logger.Info("Bulk action finished",
+		"total", resp.Total,
+		"succeeded", resp.Succeeded,
+		"unchanged", resp.Unchanged,
+		"not_found", resp.NotFound,
+		"failed", resp.Failed,
+	)
+	return resp, nil
+}
+
+// resolveBulkTargets は一括操作の対象となる単語IDを返します (filter 指定の場合は条件に合う単語を検索する)
+func (s *wordService) resolveBulkTargets(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, req *model.BulkWordsRequest) ([]uuid.UUID, error) {
+	if req.Filter == nil {
+		return req.WordIDs, nil
+	}
+
+	filter, err := buildWordFilter(req.Filter.Level, req.Filter.CreatedFrom, req.Filter.CreatedTo, req.Filter.Query)
+	if err != nil {
+		return nil, err
+	}
+	filter.OnlyDeleted = req.Action == model.BulkActionRestore
+
+	// 上限を超えたかどうかを判定するため、1件多く取得する
+	ids, err := s.wordRepo.FindIDsByFilter(ctx, tx, tenantID, filter, model.MaxBulkItems+1)
+	if err != nil {
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "対象の単語の検索に失敗しました。", "", err)
+	}
+	if len(ids) > model.MaxBulkItems {
+		return nil, model.NewAppError("TOO_MANY_ITEMS", fmt.Sprintf("条件に合う単語が%d件を超えています。条件を絞り込んでください。", model.MaxBulkItems), "filter", model.ErrInvalidInput)
+	}
+	return ids, nil
+}
+
+// applyBulkAction は1つの単語に操作を行います。DBエラーのみを error として返します。
+func (s *wordService) applyBulkAction(ctx context.Context, tx *gorm.DB, tenantID uuid.UUID, word *model.Word, req *model.BulkWordsRequest, restoredTerms map[string]bool) (model.BulkItemResult, error) {
+	result := model.BulkItemResult{WordID: word.WordID, Status: model.BulkStatusSucceeded}
+
+	switch req.Action {
+	case model.BulkActionDelete:
+		// DeleteWord と同じく、学習進捗は残したままゴミ箱に移す
+		if err := s.wordRepo.Delete(ctx, tx, tenantID, word.WordID); err != nil {
+			return result, err
+		}
+
+	case model.BulkActionRestore:
+		normalized := s.normalizer.normalize(word.Term)
+		exists, err := s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, normalized, nil)
+		if err != nil {
+			return result, err
+		}
+		if exists || restoredTerms[normalized] {
+			result.Status = model.BulkStatusFailed
+			result.Message = fmt.Sprintf("「%s」は既に登録されているため復元できません。", word.Term)
+			return result, nil
+		}
+		if err := s.wordRepo.Restore(ctx, tx, tenantID, word.WordID); err != nil {
+			return result, err
+		}
+		restoredTerms[normalized] = true
+		if err := s.ensureProgress(ctx, tx, tenantID, word.WordID); err != nil {
+			return result, err
+		}
+
+	case model.BulkActionReplaceDefinition:
+		definition := strings.ReplaceAll(word.Definition, req.Find, req.Replace)
+		if definition == word.Definition {
+			result.Status = model.BulkStatusUnchanged
+			return result, nil
+		}
+		if strings.TrimSpace(definition) == "" {
+			result.Status = model.BulkStatusFailed
+			result.Message = "置換すると意味が空になるため変更できません。"
+			return result, nil
+		}
+		if err := s.wordRepo.Update(ctx, tx, tenantID, word.WordID, map[string]interface{}{"Definition": definition}); err != nil {
+			return result, err
+		}
+
+	case model.BulkActionResetProgress:
+		err := s.progRepo.ResetLevel(ctx, tx, tenantID, word.WordID, initialNextReviewDate())
+		if errors.Is(err, model.ErrNotFound) {
+			err = s.ensureProgress(ctx, tx, tenantID, word.WordID)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// ensureProgress は学習進捗がなければ Level1 の学習進捗を作成します
+func (s *wordService) ensureProgress(ctx context.Context, tx *gorm.DB, tenantID, wordID uuid.UUID) error {
+	_, err := s.progRepo.FindByWordID(ctx, tx, tenantID, wordID, model.DirectionRecognition)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, model.ErrNotFound) {
+		return err
+	}
+	return s.progRepo.Create(ctx, tx, &model.LearningProgress{
+		ProgressID:     uuid.New(),
+		TenantID:       tenantID,
+		WordID:         wordID,
+		Level:          model.Level1,
+		NextReviewDate: initialNextReviewDate(),
+	})
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Test BulkWords ---
+func Test_wordService_BulkWords(t *testing.T) {
+	ctx := context.Background()
+	wordService, _, m := setupWordServiceWithMocks(nil)
+
+	tenantID := uuid.New()
+	wordA := &model.Word{WordID: uuid.New(), TenantID: tenantID, Term: "apple", Definition: "りんご (果物)"}
+	wordAUpper := &model.Word{WordID: uuid.New(), TenantID: tenantID, Term: " Apple", Definition: "りんご"} // 正規化すると wordA と同じ単語
+	wordB := &model.Word{WordID: uuid.New(), TenantID: tenantID, Term: "banana", Definition: "バナナ"}
+	missingID := uuid.New()
+	ids := []uuid.UUID{wordA.WordID, wordB.WordID}
+	anyTx := mock.AnythingOfType("*gorm.DB")
+
+	tests := []struct {
+		name        string
+		req         *model.BulkWordsRequest
+		setupMock   func(m *wordServiceMocks)
+		wantStatus  []string // 単語ごとの結果のステータス (対象の順)
+		wantErrCode string
+	}{
+		{
+			name: "正常系: 削除 (見つからない単語は not_found)",
+			req:  &model.BulkWordsRequest{WordIDs: []uuid.UUID{wordA.WordID, missingID}, Action: model.BulkActionDelete},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordA.WordID, missingID}).Return([]*model.Word{wordA}, nil).Once()
+				m.word.On("Delete", ctx, anyTx, tenantID, wordA.WordID).Return(nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusSucceeded, model.BulkStatusNotFound},
+		},
+		{
+			name: "正常系: 復元 (有効な単語と重複する単語は failed)",
+			req:  &model.BulkWordsRequest{WordIDs: ids, Action: model.BulkActionRestore},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindDeletedByIDs", ctx, anyTx, tenantID, ids).Return([]*model.Word{wordA, wordB}, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(wordA.Term), (*uuid.UUID)(nil)).Return(false, nil).Once()
+				m.word.On("Restore", ctx, anyTx, tenantID, wordA.WordID).Return(nil).Once()
+				m.progress.On("FindByWordID", ctx, anyTx, tenantID, wordA.WordID, model.DirectionRecognition).Return(&model.LearningProgress{}, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(wordB.Term), (*uuid.UUID)(nil)).Return(true, nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusSucceeded, model.BulkStatusFailed},
+		},
+		{
+			name: "正常系: 復元で一意制約違反 (同時登録) になった単語だけ failed にして続ける",
+			req:  &model.BulkWordsRequest{WordIDs: ids, Action: model.BulkActionRestore},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindDeletedByIDs", ctx, anyTx, tenantID, ids).Return([]*model.Word{wordA, wordB}, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(wordA.Term), (*uuid.UUID)(nil)).Return(false, nil).Once()
+				m.word.On("Restore", ctx, anyTx, tenantID, wordA.WordID).Return(model.ErrConflict).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(wordB.Term), (*uuid.UUID)(nil)).Return(false, nil).Once()
+				m.word.On("Restore", ctx, anyTx, tenantID, wordB.WordID).Return(nil).Once()
+				// 学習進捗がない単語は Level1 から作り直す
+				m.progress.On("FindByWordID", ctx, anyTx, tenantID, wordB.WordID, model.DirectionRecognition).Return(nil, model.ErrNotFound).Once()
+				m.progress.On("Create", ctx, anyTx, mock.MatchedBy(func(p *model.LearningProgress) bool {
+					return p.WordID == wordB.WordID && p.Level == model.Level1
+				})).Return(nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusFailed, model.BulkStatusSucceeded},
+		},
+		{
+			name: "正常系: 正規化すると同じ単語を同時に復元した場合は後の単語が failed",
+			req:  &model.BulkWordsRequest{WordIDs: []uuid.UUID{wordA.WordID, wordAUpper.WordID}, Action: model.BulkActionRestore},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindDeletedByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordA.WordID, wordAUpper.WordID}).Return([]*model.Word{wordA, wordAUpper}, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm(wordA.Term), (*uuid.UUID)(nil)).Return(false, nil).Twice()
+				m.word.On("Restore", ctx, anyTx, tenantID, wordA.WordID).Return(nil).Once()
+				m.progress.On("FindByWordID", ctx, anyTx, tenantID, wordA.WordID, model.DirectionRecognition).Return(&model.LearningProgress{}, nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusSucceeded, model.BulkStatusFailed},
+		},
+		{
+			name: "正常系: 意味の置換 (含まない単語は unchanged)",
+			req:  &model.BulkWordsRequest{WordIDs: ids, Action: model.BulkActionReplaceDefinition, Find: " (果物)", Replace: ""},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByIDs", ctx, anyTx, tenantID, ids).Return([]*model.Word{wordA, wordB}, nil).Once()
+				m.word.On("Update", ctx, anyTx, tenantID, wordA.WordID, map[string]interface{}{"Definition": "りんご"}).Return(nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusSucceeded, model.BulkStatusUnchanged},
+		},
+		{
+			name: "正常系: 置換すると意味が空になる単語は failed",
+			req:  &model.BulkWordsRequest{WordIDs: []uuid.UUID{wordB.WordID}, Action: model.BulkActionReplaceDefinition, Find: "バナナ"},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordB.WordID}).Return([]*model.Word{wordB}, nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusFailed},
+		},
+		{
+			name: "正常系: 学習進捗のリセット (進捗がない単語は作成する)",
+			req:  &model.BulkWordsRequest{WordIDs: ids, Action: model.BulkActionResetProgress},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByIDs", ctx, anyTx, tenantID, ids).Return([]*model.Word{wordA, wordB}, nil).Once()
+				m.progress.On("ResetLevel", ctx, anyTx, tenantID, wordA.WordID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+				m.progress.On("ResetLevel", ctx, anyTx, tenantID, wordB.WordID, mock.AnythingOfType("time.Time")).Return(model.ErrNotFound).Once()
+				m.progress.On("FindByWordID", ctx, anyTx, tenantID, wordB.WordID, model.DirectionRecognition).Return(nil, model.ErrNotFound).Once()
+				m.progress.On("Create", ctx, anyTx, mock.AnythingOfType("*model.LearningProgress")).Return(nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusSucceeded, model.BulkStatusSucceeded},
+		},
+		{
+			name: "正常系: filter で対象を選ぶ",
+			req:  &model.BulkWordsRequest{Filter: &model.BulkWordFilter{Level: 2, Query: "a"}, Action: model.BulkActionDelete},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindIDsByFilter", ctx, anyTx, tenantID, mock.MatchedBy(func(f model.WordFilter) bool {
+					return f.Level != nil && *f.Level == model.Level2 && f.Query == "a" && !f.OnlyDeleted
+				}), model.MaxBulkItems+1).Return([]uuid.UUID{wordA.WordID}, nil).Once()
+				m.word.On("FindByIDs", ctx, anyTx, tenantID, []uuid.UUID{wordA.WordID}).Return([]*model.Word{wordA}, nil).Once()
+				m.word.On("Delete", ctx, anyTx, tenantID, wordA.WordID).Return(nil).Once()
+			},
+			wantStatus: []string{model.BulkStatusSucceeded},
+		},
+		{
+			name: "正常系: 復元の filter は削除済みの単語から選ぶ",
+			req:  &model.BulkWordsRequest{Filter: &model.BulkWordFilter{}, Action: model.BulkActionRestore},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindIDsByFilter", ctx, anyTx, tenantID, mock.MatchedBy(func(f model.WordFilter) bool {
+					return f.OnlyDeleted
+				}), model.MaxBulkItems+1).Return([]uuid.UUID{}, nil).Once()
+			},
+			wantStatus: []string{},
+		},
+		{
+			name: "異常系: filter に合う単語が上限を超える",
+			req:  &model.BulkWordsRequest{Filter: &model.BulkWordFilter{Query: "a"}, Action: model.BulkActionDelete},
+			setupMock: func(m *wordServiceMocks) {
+				tooMany := make([]uuid.UUID, model.MaxBulkItems+1)
+				for i := range tooMany {
+					tooMany[i] = uuid.New()
+				}
+				m.word.On("FindIDsByFilter", ctx, anyTx, tenantID, mock.AnythingOfType("model.WordFilter"), model.MaxBulkItems+1).Return(tooMany, nil).Once()
+			},
+			wantErrCode: "TOO_MANY_ITEMS",
+		},
+		{
+			name:        "異常系: filter の日付が不正",
+			req:         &model.BulkWordsRequest{Filter: &model.BulkWordFilter{CreatedFrom: "yesterday"}, Action: model.BulkActionDelete},
+			setupMock:   func(m *wordServiceMocks) { /* リポジトリは呼ばれない */ },
+			wantErrCode: "VALIDATION_ERROR",
+		},
+		{
+			name: "異常系: 削除でDBエラー (全体を取り消す)",
+			req:  &model.BulkWordsRequest{WordIDs: ids, Action: model.BulkActionDelete},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByIDs", ctx, anyTx, tenantID, ids).Return([]*model.Word{wordA, wordB}, nil).Once()
+				m.word.On("Delete", ctx, anyTx, tenantID, wordA.WordID).Return(nil).Once()
+				m.word.On("Delete", ctx, anyTx, tenantID, wordB.WordID).Return(errors.New("db delete error")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.reset()
+			tt.setupMock(m)
+
+			resp, err := wordService.BulkWords(ctx, tenantID, tt.req)
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, tt.req.Action, resp.Action)
+				assert.Equal(t, len(tt.wantStatus), resp.Total)
+				statuses := make([]string, 0, len(resp.Results))
+				for _, r := range resp.Results {
+					statuses = append(statuses, r.Status)
+				}
+				assert.Equal(t, tt.wantStatus, statuses)
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
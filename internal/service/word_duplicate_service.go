@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/google/uuid"
+)
+
+// renormalizeBatchSize は正規化済みの単語を再計算する際に1度に読み込む件数
+const renormalizeBatchSize = 500
+
+// FindDuplicates は有効な単語のうち、表記がほぼ同じもの (textutil.SimilarityKey が一致するもの) をグループにして返します。
+// 登録時の重複判定より緩い基準のため、別の単語として登録できたものも含まれます。統合するかどうかは利用者が判断します。
+func (s *wordService) FindDuplicates(ctx context.Context, tenantID uuid.UUID) (*model.DuplicateWordsResponse, error) {
+	logger := middleware.GetLogger(ctx)
+	words, err := s.wordRepo.FindByTenant(ctx, s.db, tenantID)
+	if err != nil {
+		logger.Error("Failed to get words for duplicate report", "error", err)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "単語リストの取得に失敗しました。", "", err)
+	}
+	return &model.DuplicateWordsResponse{Groups: groupNearDuplicates(words)}, nil
+}
+
+// groupNearDuplicates は SimilarityKey が同じ単語が2件以上あるものをグループにします。
+// グループ内は登録日時の古い順、グループはキーの順に並べます。
+func groupNearDuplicates(words []*model.Word) []model.DuplicateWordGroup {
+	byKey := make(map[string][]*model.Word)
+	for _, w := range words {
+		key := textutil.SimilarityKey(w.Term)
+		if key == "" {
+			continue // 記号だけの単語などはまとめない
+		}
+		byKey[key] = append(byKey[key], w)
+	}
+
+	groups := make([]model.DuplicateWordGroup, 0)
+	for key, ws := range byKey {
+		if len(ws) < 2 {
+			continue
+		}
+		sort.Slice(ws, func(i, j int) bool {
+			if ws[i].CreatedAt.Equal(ws[j].CreatedAt) {
+				return ws[i].WordID.String() < ws[j].WordID.String()
+			}
+			return ws[i].CreatedAt.Before(ws[j].CreatedAt)
+		})
+		groups = append(groups, model.DuplicateWordGroup{Key: key, Words: ws})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Key < groups[j].Key })
+	return groups
+}
+
+// RenormalizeTerms は全テナントの単語 (ゴミ箱を含む) について正規化済みの単語を再計算し、変わったものを更新します。
+// 正規化の設定を変更した後や、マイグレーションで近似値を設定した後に実行します。
+// 更新すると有効な単語と重複するものは更新せずに件数と内容をログに残します (どちらかを削除・変更してから再実行する)。
+func (s *wordService) RenormalizeTerms(ctx context.Context) (*model.RenormalizeTermsResult, error) {
+	logger := middleware.GetLogger(ctx)
+	result := &model.RenormalizeTermsResult{}
+
+	after := uuid.Nil
+	for {
+		words, err := s.wordRepo.FindAllAfter(ctx, s.db, after, renormalizeBatchSize)
+		if err != nil {
+			logger.Error("Failed to read words for renormalization", "error", err, "scanned", result.Scanned)
+			return result, err
+		}
+		for _, w := range words {
+			result.Scanned++
+			normalized := s.normalizer.normalize(w.Term)
+			if normalized == w.NormalizedTerm {
+				continue
+			}
+			// 1件ずつ確定させる (一意制約違反でトランザクション全体が中断されないように)
+			err := s.wordRepo.UpdateNormalizedTerm(ctx, s.db, w.WordID, normalized)
+			switch {
+			case err == nil:
+				result.Updated++
+			case errors.Is(err, model.ErrConflict):
+				result.Conflicts++
+				logger.Warn("Normalized term conflicts with another active word",
+					"tenant_id", w.TenantID,
+					"word_id", w.WordID,
+					"term", w.Term,
+					"normalized_term", normalized,
+				)
+			case errors.Is(err, model.ErrNotFound):
+				// 走査中に完全削除された
+			default:
+				logger.Error("Failed to update normalized term", "error", err, "word_id", w.WordID)
+				return result, err
+			}
+		}
+		if len(words) < renormalizeBatchSize {
+			break
+		}
+		after = words[len(words)-1].WordID
+	}
+
+	logger.Info("Renormalized terms", "scanned", result.Scanned, "updated", result.Updated, "conflicts", result.Conflicts)
+	return result, nil
+}
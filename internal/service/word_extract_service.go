@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"unicode/utf8"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/google/uuid"
+)
+
+// extractLookupBatchSize は登録済みの単語を確認する際に1度のクエリで渡す候補の数
+const extractLookupBatchSize = 1000
+
+// maxExtractExampleLength は候補の例文として返す文の最大文字数 (WordExample.Sentence の上限に合わせる)
+const maxExtractExampleLength = 1000
+
+// ExtractWords はテキストから単語の候補を抽出し、登録済みの単語 (正規化済みの単語が一致するもの) を除いて返します。
+// 候補は出現回数の多い順に並べ、最初に出現した文を例文として付けます。正規化すると同じになる候補は1つにまとめます。
+func (s *wordService) ExtractWords(ctx context.Context, tenantID uuid.UUID, req *model.ExtractWordsRequest) (*model.ExtractWordsResponse, error) {
+	logger := middleware.GetLogger(ctx)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = model.DefaultExtractLimit
+	}
+
+	var candidates []textutil.Candidate
+	var keys []string
+	indexByKey := make(map[string]int)
+	for _, c := range textutil.ExtractCandidates(req.Text) {
+		key := s.normalizer.normalize(c.Term)
+		if key == "" {
+			continue
+		}
+		if i, ok := indexByKey[key]; ok {
+			candidates[i].Count += c.Count
+			continue
+		}
+		indexByKey[key] = len(candidates)
+		candidates = append(candidates, c)
+		keys = append(keys, key)
+	}
+
+	existing := make(map[string]bool)
+	for start := 0; start < len(keys); start += extractLookupBatchSize {
+		end := min(start+extractLookupBatchSize, len(keys))
+		found, err := s.wordRepo.FindExistingNormalizedTerms(ctx, s.db, tenantID, keys[start:end])
+		if err != nil {
+			logger.Error("Failed to find existing words for extraction", "error", err)
+			return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "登録済みの単語の確認に失敗しました。", "", err)
+		}
+		for _, key := range found {
+			existing[key] = true
+		}
+	}
+
+	resp := &model.ExtractWordsResponse{Candidates: make([]model.WordCandidate, 0, min(limit, len(candidates)))}
+	var remaining []textutil.Candidate
+	for i, c := range candidates {
+		if existing[keys[i]] {
+			resp.SkippedExisting++
+			continue
+		}
+		remaining = append(remaining, c)
+	}
+	// まとめた候補は出現回数が変わっているため並べ直す (同数の場合は先に出現した順のまま)
+	sort.SliceStable(remaining, func(i, j int) bool { return remaining[i].Count > remaining[j].Count })
+	resp.TotalCandidates = len(remaining)
+
+	for _, c := range remaining[:min(limit, len(remaining))] {
+		examples := []model.WordExample{}
+		if utf8.RuneCountInString(c.Sentence) <= maxExtractExampleLength {
+			examples = append(examples, model.WordExample{Sentence: c.Sentence})
+		}
+		resp.Candidates = append(resp.Candidates, model.WordCandidate{
+			Term:         c.Term,
+			Reading:      c.Reading,
+			PartOfSpeech: c.PartOfSpeech,
+			Examples:     examples,
+			Count:        c.Count,
+		})
+	}
+
+	logger.Info("Extracted word candidates", "candidates", resp.TotalCandidates, "skipped_existing", resp.SkippedExisting)
+	return resp, nil
+}
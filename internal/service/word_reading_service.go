@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/google/uuid"
+)
+
+// maxReadingLength は読みの最大文字数 (リクエストの reading の上限に合わせる)
+const maxReadingLength = 255
+
+// autoReading は単語から自動生成する読みを返します。
+// 漢字を含まない単語、辞書にない漢字を含む単語、読みが長すぎる単語は空を返します (読みを付けない)。
+func autoReading(term string) string {
+	reading, ok := textutil.JapaneseReading(term)
+	if !ok || utf8.RuneCountInString(reading) > maxReadingLength {
+		return ""
+	}
+	return reading
+}
+
+// addReadingUpdates は読みの変更を updates に追加します。term は更新後の単語です。
+// reading に空でない値を指定した場合は利用者の入力として保存し、以降は自動生成で上書きしません。
+// 空文字を指定した場合は自動生成の読みに戻します。指定しない (nil) 場合は、自動生成の読みであれば単語に合わせて作り直します。
+func addReadingUpdates(updates map[string]interface{}, word *model.Word, term string, reading *string) {
+	var value string
+	var manual bool
+	switch {
+	case reading != nil && *reading != "":
+		value, manual = *reading, true
+	case reading == nil && word.ReadingManual:
+		return
+	default:
+		value = autoReading(term)
+	}
+
+	if value != word.Reading {
+		updates["Reading"] = value
+	}
+	if manual != word.ReadingManual {
+		updates["ReadingManual"] = manual
+	}
+}
+
+// GenerateReadings は全テナントの単語 (ゴミ箱を含む) のうち、読みを利用者が入力していないものについて読みを自動生成し直します。
+// 自動生成を導入する前に登録した単語や、辞書を更新した後の単語に読みを付けるために実行します。
+func (s *wordService) GenerateReadings(ctx context.Context) (*model.GenerateReadingsResult, error) {
+	logger := middleware.GetLogger(ctx)
+	result := &model.GenerateReadingsResult{}
+
+	after := uuid.Nil
+	for {
+		words, err := s.wordRepo.FindAllAfter(ctx, s.db, after, renormalizeBatchSize)
+		if err != nil {
+			logger.Error("Failed to read words for reading generation", "error", err, "scanned", result.Scanned)
+			return result, err
+		}
+		for _, w := range words {
+			result.Scanned++
+			if w.ReadingManual {
+				continue
+			}
+			reading := autoReading(w.Term)
+			if reading == "" && textutil.ContainsKanji(w.Term) {
+				result.Unresolved++
+			}
+			if reading == w.Reading {
+				continue
+			}
+			err := s.wordRepo.UpdateAutoReading(ctx, s.db, w.WordID, reading)
+			switch {
+			case err == nil:
+				result.Updated++
+			case errors.Is(err, model.ErrNotFound):
+				// 走査中に完全削除されたか、利用者が読みを入力した
+			default:
+				logger.Error("Failed to update reading", "error", err, "word_id", w.WordID)
+				return result, err
+			}
+		}
+		if len(words) < renormalizeBatchSize {
+			break
+		}
+		after = words[len(words)-1].WordID
+	}
+
+	logger.Info("Generated readings", "scanned", result.Scanned, "updated", result.Updated, "unresolved", result.Unresolved)
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// ListRevisions は単語の変更履歴を新しい順に返します
+func (s *wordService) ListRevisions(ctx context.Context, tenantID, wordID uuid.UUID) ([]*model.WordRevisionResponse, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if _, err := s.wordRepo.FindByID(ctx, s.db, tenantID, wordID); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, model.NewAppError("NOT_FOUND", "指定された単語は見つかりませんでした。", "word_id", model.ErrNotFound)
+		}
+		logger.Error("Failed to get word for revisions", "error", err)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "単語の取得に失敗しました。", "", err)
+	}
+
+	revisions, err := s.revisionRepo.FindByWordID(ctx, s.db, tenantID, wordID)
+	if err != nil {
+		logger.Error("Failed to get word revisions", "error", err)
+		return nil, model.NewAppError("INTERNAL_SERVER_ERROR", "変更履歴の取得に失敗しました。", "", err)
+	}
+
+	res := make([]*model.WordRevisionResponse, 0, len(revisions))
+	for _, rev := range revisions {
+		res = append(res, &model.WordRevisionResponse{
+			Revision:  rev.RevisionNo,
+			OldValues: rev.OldValues,
+			NewValues: rev.NewValues,
+			RequestID: rev.RequestID,
+			CreatedAt: rev.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// RevertRevision は指定した変更を取り消し、その変更で書き換えられた項目を変更前の値に戻します。
+// 取り消し自体も新しい変更履歴として記録されるため、取り消しをさらに取り消すこともできます。
+func (s *wordService) RevertRevision(ctx context.Context, tenantID, wordID uuid.UUID, revisionNo int) (*model.Word, error) {
+	logger := middleware.GetLogger(ctx)
+	var revertedWord *model.Word
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		word, err := s.wordRepo.FindByID(ctx, tx, tenantID, wordID)
+		if err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				return model.NewAppError("NOT_FOUND", "指定された単語は見つかりませんでした。", "word_id", model.ErrNotFound)
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の取得に失敗しました。", "", err)
+		}
+
+		rev, err := s.revisionRepo.FindByRevisionNo(ctx, tx, tenantID, wordID, revisionNo)
+		if err != nil {
+			if errors.Is(err, model.ErrNotFound) {
+				return model.NewAppError("NOT_FOUND", "指定された変更履歴は見つかりませんでした。", "rev", model.ErrNotFound)
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "変更履歴の取得に失敗しました。", "", err)
+		}
+
+		updates := make(map[string]interface{})
+		if rev.OldValues.Term != nil && *rev.OldValues.Term != word.Term {
+			normalized := s.normalizer.normalize(*rev.OldValues.Term)
+			exists, checkErr := s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, normalized, &wordID)
+			if checkErr != nil {
+				return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", checkErr)
+			}
+			if exists {
+				return model.NewAppError("DUPLICATE_TERM", "元に戻すと他の単語と重複します。", "term", model.ErrConflict)
+			}
+			updates["Term"] = *rev.OldValues.Term
+			updates["NormalizedTerm"] = normalized
+		}
+		if rev.OldValues.Definition != nil {
+			updates["Definition"] = *rev.OldValues.Definition
+		}
+		if rev.OldValues.Tags != nil {
+			updates["Tags"] = pq.StringArray(*rev.OldValues.Tags)
+		}
+		if rev.OldValues.Reading != nil {
+			updates["Reading"] = *rev.OldValues.Reading
+		}
+		if rev.OldValues.PartOfSpeech != nil {
+			updates["PartOfSpeech"] = *rev.OldValues.PartOfSpeech
+		}
+		if rev.OldValues.Examples != nil {
+			updates["Examples"] = model.WordExamples(*rev.OldValues.Examples)
+		}
+		if rev.OldValues.Notes != nil {
+			updates["Notes"] = *rev.OldValues.Notes
+		}
+
+		if err := s.wordRepo.Update(ctx, tx, tenantID, wordID, updates); err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				return model.NewAppError("DUPLICATE_TERM", "元に戻すと他の単語と重複します。", "term", model.ErrConflict)
+			}
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の更新に失敗しました。", "", err)
+		}
+
+		revertedWord, err = s.wordRepo.FindByID(ctx, tx, tenantID, wordID)
+		if err != nil {
+			return model.NewAppError("INTERNAL_SERVER_ERROR", "更新後の単語の取得に失敗しました。", "", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Word revision reverted", "word_id", wordID, "revision", revisionNo)
+	return revertedWord, nil
+}
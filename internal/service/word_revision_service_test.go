@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Test RevertRevision ---
+func Test_wordService_RevertRevision(t *testing.T) {
+	ctx := context.Background()
+	wordService, _, m := setupWordServiceWithMocks(nil)
+
+	tenantID := uuid.New()
+	wordID := uuid.New()
+	anyTx := mock.AnythingOfType("*gorm.DB")
+	ptr := func(s string) *string { return &s }
+
+	currentWord := func() *model.Word {
+		return &model.Word{WordID: wordID, TenantID: tenantID, Term: "new_term", Definition: "new_def", Tags: pq.StringArray{"b"}}
+	}
+	// 単語名・意味・タグを書き換えた変更
+	termRevision := &model.WordRevision{
+		WordID:     wordID,
+		RevisionNo: 2,
+		OldValues:  model.WordRevisionValues{Term: ptr("old_term"), Definition: ptr("old_def"), Tags: &[]string{"a"}},
+		NewValues:  model.WordRevisionValues{Term: ptr("new_term"), Definition: ptr("new_def"), Tags: &[]string{"b"}},
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(m *wordServiceMocks)
+		wantErrCode string
+	}{
+		{
+			name: "正常系: 変更された項目を変更前の値に戻す",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(currentWord(), nil).Once()
+				m.revision.On("FindByRevisionNo", ctx, anyTx, tenantID, wordID, 2).Return(termRevision, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm("old_term"), &wordID).Return(false, nil).Once()
+				m.word.On("Update", ctx, anyTx, tenantID, wordID, map[string]interface{}{
+					"Term":           "old_term",
+					"NormalizedTerm": normalizedTerm("old_term"),
+					"Definition":     "old_def",
+					"Tags":           pq.StringArray{"a"},
+				}).Return(nil).Once()
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(&model.Word{WordID: wordID, Term: "old_term"}, nil).Once()
+			},
+		},
+		{
+			name: "正常系: 単語名が現在と同じなら重複チェックしない",
+			setupMock: func(m *wordServiceMocks) {
+				word := currentWord()
+				word.Term = "old_term"
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(word, nil).Once()
+				m.revision.On("FindByRevisionNo", ctx, anyTx, tenantID, wordID, 2).Return(termRevision, nil).Once()
+				m.word.On("Update", ctx, anyTx, tenantID, wordID, map[string]interface{}{
+					"Definition": "old_def",
+					"Tags":       pq.StringArray{"a"},
+				}).Return(nil).Once()
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(word, nil).Once()
+			},
+		},
+		{
+			name: "異常系: 単語が見つからない",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(nil, model.ErrNotFound).Once()
+			},
+			wantErrCode: "NOT_FOUND",
+		},
+		{
+			name: "異常系: 変更履歴が見つからない",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(currentWord(), nil).Once()
+				m.revision.On("FindByRevisionNo", ctx, anyTx, tenantID, wordID, 2).Return(nil, model.ErrNotFound).Once()
+			},
+			wantErrCode: "NOT_FOUND",
+		},
+		{
+			name: "異常系: 元に戻すと他の単語と重複する",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(currentWord(), nil).Once()
+				m.revision.On("FindByRevisionNo", ctx, anyTx, tenantID, wordID, 2).Return(termRevision, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm("old_term"), &wordID).Return(true, nil).Once()
+			},
+			wantErrCode: "DUPLICATE_TERM",
+		},
+		{
+			name: "異常系: Updateで一意制約違反 (同時登録)",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(currentWord(), nil).Once()
+				m.revision.On("FindByRevisionNo", ctx, anyTx, tenantID, wordID, 2).Return(termRevision, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm("old_term"), &wordID).Return(false, nil).Once()
+				m.word.On("Update", ctx, anyTx, tenantID, wordID, mock.Anything).Return(model.ErrConflict).Once()
+			},
+			wantErrCode: "DUPLICATE_TERM",
+		},
+		{
+			name: "異常系: UpdateでDBエラー",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, anyTx, tenantID, wordID).Return(currentWord(), nil).Once()
+				m.revision.On("FindByRevisionNo", ctx, anyTx, tenantID, wordID, 2).Return(termRevision, nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, anyTx, tenantID, normalizedTerm("old_term"), &wordID).Return(false, nil).Once()
+				m.word.On("Update", ctx, anyTx, tenantID, wordID, mock.Anything).Return(errors.New("db update error")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.reset()
+			tt.setupMock(m)
+
+			word, err := wordService.RevertRevision(ctx, tenantID, wordID, 2)
+
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				assert.Nil(t, word)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, word)
+				assert.Equal(t, wordID, word.WordID)
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"go_4_vocab_keep/internal/middleware"
+	"go_4_vocab_keep/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// replaceSenses は単語の語義をリクエストの内容で置き換え、word.Senses を更新後の語義にします。
+// word.Senses には現在の語義が読み込まれている必要があります (sense_id の指定はその中の語義に限る)。
+func (s *wordService) replaceSenses(ctx context.Context, tx *gorm.DB, word *model.Word, reqs []model.WordSenseRequest) error {
+	logger := middleware.GetLogger(ctx)
+
+	owned := make(map[uuid.UUID]bool, len(word.Senses))
+	for _, sense := range word.Senses {
+		owned[sense.SenseID] = true
+	}
+
+	senses := make([]model.WordSense, 0, len(reqs))
+	used := make(map[uuid.UUID]bool, len(reqs))
+	for i, req := range reqs {
+		id := uuid.New()
+		if req.SenseID != nil {
+			if !owned[*req.SenseID] || used[*req.SenseID] {
+				return model.NewAppError("INVALID_SENSE_ID", "指定された語義はこの単語に存在しないか、重複しています。", "senses", model.ErrInvalidInput)
+			}
+			id = *req.SenseID
+		}
+		used[id] = true
+		senses = append(senses, model.WordSense{
+			SenseID:    id,
+			TenantID:   word.TenantID,
+			WordID:     word.WordID,
+			Position:   i,
+			Definition: req.Definition,
+			Example:    req.Example,
+			Tag:        req.Tag,
+		})
+	}
+
+	if sensesEqual(word.Senses, senses) {
+		return nil
+	}
+	if err := s.senseRepo.ReplaceForWord(ctx, tx, word.TenantID, word.WordID, senses); err != nil {
+		logger.Error("Failed to replace word senses", "error", err)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "語義の更新に失敗しました。", "", err)
+	}
+	word.Senses = senses
+	return nil
+}
+
+// sensesEqual は語義の内容と順序が同じかを返します (作成日時などは比較しない)
+func sensesEqual(a, b []model.WordSense) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].SenseID != b[i].SenseID ||
+			a[i].Position != b[i].Position ||
+			a[i].Definition != b[i].Definition ||
+			a[i].Example != b[i].Example ||
+			a[i].Tag != b[i].Tag {
+			return false
+		}
+	}
+	return true
+}
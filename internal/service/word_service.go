@@ -1,19 +1,23 @@
+//go:generate mockery --name WordService --output ./mocks --outpkg mocks --case=underscore
 package service
 
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
+	"go_4_vocab_keep/internal/config"
 	"go_4_vocab_keep/internal/middleware"
 	"go_4_vocab_keep/internal/model"
 	"go_4_vocab_keep/internal/repository"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
-// WordService インターフェース (変更なし)
+// WordService は単語の登録・取得・更新・削除と一括操作を扱います
 type WordService interface {
 	PostWord(ctx context.Context, tenantID uuid.UUID, req *model.PostWordRequest) (*model.Word, error)
 	GetWord(ctx context.Context, tenantID, wordID uuid.UUID) (*model.Word, error)
@@ -21,21 +25,36 @@ type WordService interface {
 	PutWord(ctx context.Context, tenantID, wordID uuid.UUID, req *model.PutWordRequest) (*model.Word, error)
 	PatchWord(ctx context.Context, tenantID, wordID uuid.UUID, req *model.PatchWordRequest) (*model.Word, error)
 	DeleteWord(ctx context.Context, tenantID, wordID uuid.UUID) error
+	BulkWords(ctx context.Context, tenantID uuid.UUID, req *model.BulkWordsRequest) (*model.BulkWordsResponse, error)
+	FindDuplicates(ctx context.Context, tenantID uuid.UUID) (*model.DuplicateWordsResponse, error)
+	ExtractWords(ctx context.Context, tenantID uuid.UUID, req *model.ExtractWordsRequest) (*model.ExtractWordsResponse, error)
+	RenormalizeTerms(ctx context.Context) (*model.RenormalizeTermsResult, error)
+	GenerateReadings(ctx context.Context) (*model.GenerateReadingsResult, error)
+	ListRevisions(ctx context.Context, tenantID, wordID uuid.UUID) ([]*model.WordRevisionResponse, error)
+	RevertRevision(ctx context.Context, tenantID, wordID uuid.UUID, revisionNo int) (*model.Word, error)
 }
 
 // wordService 構造体から logger フィールドを削除
 type wordService struct {
-	db       *gorm.DB
-	wordRepo repository.WordRepository
-	progRepo repository.ProgressRepository
+	db           *gorm.DB
+	wordRepo     repository.WordRepository
+	progRepo     repository.ProgressRepository
+	revisionRepo repository.WordRevisionRepository
+	senseRepo    repository.SenseRepository
+	dictionary   DictionaryService // 単語作成時の自動入力 (autofill) に使う。nil の場合は自動入力できない
+	normalizer   termNormalizer
 }
 
 // NewWordService コンストラクタから logger 引数を削除
-func NewWordService(db *gorm.DB, wordRepo repository.WordRepository, progRepo repository.ProgressRepository) WordService {
+func NewWordService(db *gorm.DB, wordRepo repository.WordRepository, progRepo repository.ProgressRepository, revisionRepo repository.WordRevisionRepository, senseRepo repository.SenseRepository, dictionary DictionaryService, cfg *config.Config) WordService {
 	return &wordService{
-		db:       db,
-		wordRepo: wordRepo,
-		progRepo: progRepo,
+		db:           db,
+		wordRepo:     wordRepo,
+		progRepo:     progRepo,
+		revisionRepo: revisionRepo,
+		senseRepo:    senseRepo,
+		dictionary:   dictionary,
+		normalizer:   newTermNormalizer(cfg),
 	}
 }
 
@@ -43,8 +62,17 @@ func (s *wordService) PostWord(ctx context.Context, tenantID uuid.UUID, req *mod
 	logger := middleware.GetLogger(ctx)
 	var createdWord *model.Word
 
+	// 辞書で補った読みは利用者の入力ではないため、自動入力の前に判定する
+	readingManual := req.Reading != ""
+	if req.Autofill {
+		// 外部の辞書を呼び出す場合があるため、トランザクションの外で行う
+		if err := s.autofillWord(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		exists, err := s.wordRepo.CheckTermExists(ctx, tx, tenantID, req.Term, nil)
+		exists, err := s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, s.normalizer.normalize(req.Term), nil)
 		if err != nil {
 			logger.Error("Failed to check term existence", "error", err)
 			return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", err)
@@ -55,26 +83,23 @@ func (s *wordService) PostWord(ctx context.Context, tenantID uuid.UUID, req *mod
 		}
 
 		word := &model.Word{
-			WordID:     uuid.New(),
-			TenantID:   tenantID,
-			Term:       req.Term,
-			Definition: req.Definition,
-		}
-		if err := s.wordRepo.Create(ctx, tx, word); err != nil {
-			logger.Error("Failed to create word", "error", err)
-			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の作成に失敗しました。", "", err)
-		}
-
-		progress := &model.LearningProgress{
-			ProgressID:     uuid.New(),
+			WordID:         uuid.New(),
 			TenantID:       tenantID,
-			WordID:         word.WordID,
-			Level:          model.Level1,
-			NextReviewDate: time.Now().Add(-24 * time.Hour),
+			Term:           req.Term,
+			NormalizedTerm: s.normalizer.normalize(req.Term),
+			Definition:     req.Definition,
+			Reading:        req.Reading,
+			ReadingManual:  readingManual,
+			PartOfSpeech:   req.PartOfSpeech,
+			Examples:       req.Examples,
+			Notes:          req.Notes,
+			Senses:         []model.WordSense{},
 		}
-		if err := s.progRepo.Create(ctx, tx, progress); err != nil {
-			logger.Error("Failed to create initial learning progress", "error", err)
-			return model.NewAppError("INTERNAL_SERVER_ERROR", "学習進捗の作成に失敗しました。", "", err)
+		if err := createWordWithInitialProgress(ctx, tx, s.wordRepo, s.progRepo, word); err != nil {
+			return err
+		}
+		if err := s.replaceSenses(ctx, tx, word, req.Senses); err != nil {
+			return err
 		}
 
 		createdWord = word
@@ -128,7 +153,8 @@ func (s *wordService) PutWord(ctx context.Context, tenantID, wordID uuid.UUID, r
 
 		updates := make(map[string]interface{})
 		if req.Term != word.Term {
-			exists, checkErr := s.wordRepo.CheckTermExists(ctx, tx, tenantID, req.Term, &wordID)
+			normalized := s.normalizer.normalize(req.Term)
+			exists, checkErr := s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, normalized, &wordID)
 			if checkErr != nil {
 				return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", checkErr)
 			}
@@ -136,13 +162,23 @@ func (s *wordService) PutWord(ctx context.Context, tenantID, wordID uuid.UUID, r
 				return model.NewAppError("DUPLICATE_TERM", "その単語は既に使用されています。", "term", model.ErrConflict)
 			}
 			updates["Term"] = req.Term
+			updates["NormalizedTerm"] = normalized
 		}
 		if req.Definition != word.Definition {
 			updates["Definition"] = req.Definition
 		}
+		addReadingUpdates(updates, word, req.Term, &req.Reading)
+		addDetailUpdates(updates, word, &req.PartOfSpeech, (*model.WordExamples)(&req.Examples), &req.Notes)
+
+		if err := s.replaceSenses(ctx, tx, word, req.Senses); err != nil {
+			return err
+		}
 
 		if len(updates) > 0 {
 			if updateErr := s.wordRepo.Update(ctx, tx, tenantID, wordID, updates); updateErr != nil {
+				if errors.Is(updateErr, model.ErrConflict) {
+					return model.NewAppError("DUPLICATE_TERM", "その単語は既に使用されています。", "term", model.ErrConflict)
+				}
 				return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の更新に失敗しました。", "", updateErr)
 			}
 		}
@@ -177,7 +213,8 @@ func (s *wordService) PatchWord(ctx context.Context, tenantID, wordID uuid.UUID,
 
 		updates := make(map[string]interface{})
 		if req.Term != nil && *req.Term != word.Term {
-			exists, checkErr := s.wordRepo.CheckTermExists(ctx, tx, tenantID, *req.Term, &wordID)
+			normalized := s.normalizer.normalize(*req.Term)
+			exists, checkErr := s.wordRepo.CheckNormalizedTermExists(ctx, tx, tenantID, normalized, &wordID)
 			if checkErr != nil {
 				return model.NewAppError("INTERNAL_SERVER_ERROR", "サーバー内部でエラーが発生しました。", "", checkErr)
 			}
@@ -185,13 +222,29 @@ func (s *wordService) PatchWord(ctx context.Context, tenantID, wordID uuid.UUID,
 				return model.NewAppError("DUPLICATE_TERM", "その単語は既に使用されています。", "term", model.ErrConflict)
 			}
 			updates["Term"] = *req.Term
+			updates["NormalizedTerm"] = normalized
 		}
 		if req.Definition != nil && *req.Definition != word.Definition {
 			updates["Definition"] = *req.Definition
 		}
+		term := word.Term
+		if req.Term != nil {
+			term = *req.Term
+		}
+		addReadingUpdates(updates, word, term, req.Reading)
+		addDetailUpdates(updates, word, req.PartOfSpeech, (*model.WordExamples)(req.Examples), req.Notes)
+
+		if req.Senses != nil {
+			if err := s.replaceSenses(ctx, tx, word, *req.Senses); err != nil {
+				return err
+			}
+		}
 
 		if len(updates) > 0 {
 			if err := s.wordRepo.Update(ctx, tx, tenantID, wordID, updates); err != nil {
+				if errors.Is(err, model.ErrConflict) {
+					return model.NewAppError("DUPLICATE_TERM", "その単語は既に使用されています。", "term", model.ErrConflict)
+				}
 				return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の更新に失敗しました。", "", err)
 			}
 		}
@@ -214,35 +267,87 @@ func (s *wordService) PatchWord(ctx context.Context, tenantID, wordID uuid.UUID,
 func (s *wordService) DeleteWord(ctx context.Context, tenantID, wordID uuid.UUID) error {
 	logger := middleware.GetLogger(ctx)
 
-	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 関連する学習進捗も削除する必要がある
-		// Wordを削除する前にProgressを削除する
-		err := s.progRepo.DeleteByWordID(ctx, tx, tenantID, wordID) // ← このメソッドをprogRepoに追加する必要がある
-		if err != nil && !errors.Is(err, model.ErrNotFound) {
-			logger.Error("Failed to delete learning progress", "error", err)
-			return model.NewAppError("INTERNAL_SERVER_ERROR", "学習進捗の削除に失敗しました。", "", err)
-		}
-
-		err = s.wordRepo.Delete(ctx, tx, tenantID, wordID)
-		if err != nil {
-			if errors.Is(err, model.ErrNotFound) {
-				return model.ErrNotFound // 冪等性のために、見つからなくてもエラーとしない
-			}
-			logger.Error("Failed to delete word", "error", err)
-			return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の削除に失敗しました。", "", err)
-		}
-		return nil
-	})
-
+	// 論理削除してゴミ箱に移す。復元時に復習スケジュールを引き継げるよう、学習進捗は削除しない
+	// (完全削除時に外部キーの ON DELETE CASCADE で削除される)
+	err := s.wordRepo.Delete(ctx, s.db, tenantID, wordID)
 	if err != nil {
 		if errors.Is(err, model.ErrNotFound) {
 			// 冪等性: 見つからなくても成功扱い
 			logger.Info("Word to delete not found, but operation is successful (idempotent)", "word_id", wordID)
 			return nil
 		}
-		return err
+		logger.Error("Failed to delete word", "error", err)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の削除に失敗しました。", "", err)
+	}
+
+	logger.Info("Word moved to trash", "word_id", wordID)
+	return nil
+}
+
+// addDetailUpdates は詳細情報 (品詞・例文・メモ) のうち、指定され (nil でなく)、現在の値から変わるものを updates に追加します。
+// 読みは自動生成との兼ね合いがあるため addReadingUpdates で扱います。
+func addDetailUpdates(updates map[string]interface{}, word *model.Word, partOfSpeech *string, examples *model.WordExamples, notes *string) {
+	if partOfSpeech != nil && *partOfSpeech != word.PartOfSpeech {
+		updates["PartOfSpeech"] = *partOfSpeech
+	}
+	if examples != nil && !slices.Equal(*examples, word.Examples) {
+		updates["Examples"] = normalizeExamples(*examples)
+	}
+	if notes != nil && *notes != word.Notes {
+		updates["Notes"] = *notes
+	}
+}
+
+// normalizeExamples は NULL ではなく空の配列として保存されるよう、nil を空のスライスにします
+func normalizeExamples(examples model.WordExamples) model.WordExamples {
+	if examples == nil {
+		return model.WordExamples{}
+	}
+	return examples
+}
+
+// createWordWithInitialProgress は単語と初期状態の学習進捗を同一トランザクション内で作成します。
+// 単語を新規作成する経路 (単体登録・インポート) はすべてこの関数か createWordWithProgress を通す。
+func createWordWithInitialProgress(ctx context.Context, tx *gorm.DB, wordRepo repository.WordRepository, progRepo repository.ProgressRepository, word *model.Word) error {
+	return createWordWithProgress(ctx, tx, wordRepo, progRepo, word, model.Level1, initialNextReviewDate())
+}
+
+// initialNextReviewDate は Level1 から学習を始める単語の次回復習日 (すぐに復習対象になるよう前日にする)
+func initialNextReviewDate() time.Time {
+	return time.Now().Add(-24 * time.Hour)
+}
+
+// createWordWithProgress は単語と、指定したレベル・次回復習日の学習進捗を同一トランザクション内で作成します
+func createWordWithProgress(ctx context.Context, tx *gorm.DB, wordRepo repository.WordRepository, progRepo repository.ProgressRepository, word *model.Word, level model.ProgressLevel, nextReviewDate time.Time) error {
+	logger := middleware.GetLogger(ctx)
+
+	if word.Tags == nil {
+		word.Tags = pq.StringArray{} // NULL を入れないようにする (tags は NOT NULL)
+	}
+	word.Examples = normalizeExamples(word.Examples)
+	if word.Reading == "" && !word.ReadingManual {
+		word.Reading = autoReading(word.Term)
+	}
+	if err := wordRepo.Create(ctx, tx, word); err != nil {
+		if errors.Is(err, model.ErrConflict) {
+			// 重複チェックの後に同じ単語が同時に登録された場合
+			logger.Info("Term already exists (detected by unique index)", "term", word.Term)
+			return model.NewAppError("DUPLICATE_TERM", "その単語は既に使用されています。", "term", model.ErrConflict)
+		}
+		logger.Error("Failed to create word", "error", err)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "単語の作成に失敗しました。", "", err)
 	}
 
-	logger.Info("Word and associated progress deleted successfully", "word_id", wordID)
+	progress := &model.LearningProgress{
+		ProgressID:     uuid.New(),
+		TenantID:       word.TenantID,
+		WordID:         word.WordID,
+		Level:          level,
+		NextReviewDate: nextReviewDate,
+	}
+	if err := progRepo.Create(ctx, tx, progress); err != nil {
+		logger.Error("Failed to create initial learning progress", "error", err)
+		return model.NewAppError("INTERNAL_SERVER_ERROR", "学習進捗の作成に失敗しました。", "", err)
+	}
 	return nil
 }
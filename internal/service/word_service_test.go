@@ -4,11 +4,10 @@ package service
 import (
 	"context"
 	"errors"
-	"io"       // io.Discard のためにインポート
-	"log/slog" // slog をインポート
 	"testing"
 	"time"
 
+	"go_4_vocab_keep/internal/config"
 	"go_4_vocab_keep/internal/model"
 	"go_4_vocab_keep/internal/repository/mocks" // モックリポジトリのパス
 
@@ -30,35 +29,86 @@ func setupTestDBWord() *gorm.DB {
 	if err != nil {
 		panic("failed to connect database for testing: " + err.Error())
 	}
-	// 必要に応じてマイグレーションを実行
-	// db.AutoMigrate(&model.Word{}, &model.LearningProgress{})
 	return db
 }
 
-func setupWordServiceWithMocks() (WordService, *gorm.DB, *mocks.WordRepository, *mocks.ProgressRepository) {
+// wordServiceMocks は wordService に注入するモックリポジトリです
+type wordServiceMocks struct {
+	word     *mocks.WordRepository
+	progress *mocks.ProgressRepository
+	revision *mocks.WordRevisionRepository
+	sense    *mocks.SenseRepository
+}
+
+// reset は各テストケースの前にモックの呼び出し設定をクリアします
+func (m *wordServiceMocks) reset() {
+	m.word.Mock = mock.Mock{}
+	m.progress.Mock = mock.Mock{}
+	m.revision.Mock = mock.Mock{}
+	m.sense.Mock = mock.Mock{}
+}
+
+func (m *wordServiceMocks) assertExpectations(t *testing.T) {
+	m.word.AssertExpectations(t)
+	m.progress.AssertExpectations(t)
+	m.revision.AssertExpectations(t)
+	m.sense.AssertExpectations(t)
+}
+
+func setupWordServiceWithMocks(dictionary DictionaryService) (WordService, *gorm.DB, *wordServiceMocks) {
 	db := setupTestDBWord()
-	mockWordRepo := new(mocks.WordRepository)
-	mockProgRepo := new(mocks.ProgressRepository)
-	testLogger := slog.New(slog.NewTextHandler(io.Discard, nil)) // 出力を捨てるロガー
-	wordService := NewWordService(db, mockWordRepo, mockProgRepo, testLogger)
-	return wordService, db, mockWordRepo, mockProgRepo
+	m := &wordServiceMocks{
+		word:     new(mocks.WordRepository),
+		progress: new(mocks.ProgressRepository),
+		revision: new(mocks.WordRevisionRepository),
+		sense:    new(mocks.SenseRepository),
+	}
+	wordService := NewWordService(db, m.word, m.progress, m.revision, m.sense, dictionary, &config.Config{})
+	return wordService, db, m
+}
+
+// normalizedTerm はテスト用の設定で正規化した単語を返します
+func normalizedTerm(term string) string {
+	return newTermNormalizer(&config.Config{}).normalize(term)
 }
 
 // --- Test PostWord ---
 func Test_wordService_PostWord(t *testing.T) {
 	ctx := context.Background()
-	wordService, _, mockWordRepo, mockProgRepo := setupWordServiceWithMocks()
+	wordService, _, m := setupWordServiceWithMocks(nil)
 
 	tenantID := uuid.New()
 	testTerm := "test_term"
 	testDefinition := "test_definition"
+	unknownSenseID := uuid.New()
+
+	// 単語の作成から学習進捗の作成までが成功する場合のモック設定
+	expectCreate := func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
+		wordRepo.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(testTerm), (*uuid.UUID)(nil)).
+			Return(false, nil).Once()
+		wordRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
+			Run(func(args mock.Arguments) {
+				word := args.Get(2).(*model.Word)
+				assert.Equal(t, tenantID, word.TenantID)
+				assert.Equal(t, testTerm, word.Term)
+				assert.Equal(t, normalizedTerm(testTerm), word.NormalizedTerm)
+				assert.NotEqual(t, uuid.Nil, word.WordID)
+				assert.NotNil(t, word.Tags)
+				assert.NotNil(t, word.Examples)
+			}).Return(nil).Once()
+		progRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.MatchedBy(func(p *model.LearningProgress) bool {
+			// 登録直後から復習対象になる
+			return p.TenantID == tenantID && p.Level == model.Level1 && p.NextReviewDate.Before(time.Now())
+		})).Return(nil).Once()
+	}
 
 	tests := []struct {
-		name      string
-		req       *model.PostWordRequest
-		setupMock func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository)
-		wantErr   error
-		wantWord  bool
+		name        string
+		req         *model.PostWordRequest
+		setupMock   func(m *wordServiceMocks)
+		wantErr     error
+		wantErrCode string
+		checkWord   func(t *testing.T, word *model.Word)
 	}{
 		{
 			name: "正常系: 単語の作成成功",
@@ -66,51 +116,51 @@ func Test_wordService_PostWord(t *testing.T) {
 				Term:       testTerm,
 				Definition: testDefinition,
 			},
-			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
-				// 1. CheckTermExists (重複なし)
-				wordRepo.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, testTerm, (*uuid.UUID)(nil)).
-					Return(false, nil).Once()
-				// 2. wordRepo.Create (成功)
-				wordRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
-					Run(func(args mock.Arguments) {
-						word := args.Get(2).(*model.Word)
-						assert.Equal(t, tenantID, word.TenantID)
-						assert.Equal(t, testTerm, word.Term)
-						assert.Equal(t, testDefinition, word.Definition)
-						assert.NotEqual(t, uuid.Nil, word.WordID)
-					}).Return(nil).Once()
-				// // 3. progRepo.Create (成功) - 元コードでコメントアウトされているためここもコメントアウト
-				// progRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).
-				// 	Run(func(args mock.Arguments) {
-				// 		prog := args.Get(2).(*model.LearningProgress)
-				// 		assert.Equal(t, tenantID, prog.TenantID)
-				// 		assert.NotEqual(t, uuid.Nil, prog.WordID)
-				// 		assert.Equal(t, model.Level1, prog.Level)
-				// 		assert.WithinDuration(t, time.Now().AddDate(0, 0, 1), prog.NextReviewDate, time.Second*5)
-				// 	}).Return(nil).Once()
-			},
-			wantErr:  nil,
-			wantWord: true,
-		},
-		{
-			name: "異常系: Termが空",
+			setupMock: func(m *wordServiceMocks) {
+				expectCreate(m.word, m.progress)
+				// 語義がない場合は ReplaceForWord は呼ばれない
+			},
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Empty(t, word.Senses)
+			},
+		},
+		{
+			name: "正常系: 語義を含めて作成",
 			req: &model.PostWordRequest{
-				Term:       "",
+				Term:       testTerm,
 				Definition: testDefinition,
+				Senses: []model.WordSenseRequest{
+					{Definition: "sense1", Example: "example1"},
+					{Definition: "sense2", Tag: "tag2"},
+				},
+			},
+			setupMock: func(m *wordServiceMocks) {
+				expectCreate(m.word, m.progress)
+				m.sense.On("ReplaceForWord", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, mock.AnythingOfType("uuid.UUID"), mock.MatchedBy(func(senses []model.WordSense) bool {
+					return len(senses) == 2 &&
+						senses[0].Position == 0 && senses[0].Definition == "sense1" && senses[0].Example == "example1" &&
+						senses[1].Position == 1 && senses[1].Definition == "sense2" && senses[1].Tag == "tag2" &&
+						senses[0].SenseID != uuid.Nil && senses[0].SenseID != senses[1].SenseID
+				})).Return(nil).Once()
+			},
+			checkWord: func(t *testing.T, word *model.Word) {
+				require.Len(t, word.Senses, 2)
+				assert.Equal(t, word.WordID, word.Senses[0].WordID)
+				assert.Equal(t, "sense2", word.Senses[1].Definition)
 			},
-			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {},
-			wantErr:   model.ErrInvalidInput,
-			wantWord:  false,
 		},
 		{
-			name: "異常系: Definitionが空",
+			name: "異常系: 存在しない語義IDを指定",
 			req: &model.PostWordRequest{
 				Term:       testTerm,
-				Definition: "",
+				Definition: testDefinition,
+				Senses:     []model.WordSenseRequest{{SenseID: &unknownSenseID, Definition: "sense1"}},
+			},
+			setupMock: func(m *wordServiceMocks) {
+				expectCreate(m.word, m.progress)
 			},
-			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {},
-			wantErr:   model.ErrInvalidInput,
-			wantWord:  false,
+			wantErr:     model.ErrInvalidInput,
+			wantErrCode: "INVALID_SENSE_ID",
 		},
 		{
 			name: "異常系: Termが重複",
@@ -118,25 +168,39 @@ func Test_wordService_PostWord(t *testing.T) {
 				Term:       testTerm,
 				Definition: testDefinition,
 			},
-			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
-				wordRepo.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, testTerm, (*uuid.UUID)(nil)).
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(testTerm), (*uuid.UUID)(nil)).
 					Return(true, nil).Once()
 			},
-			wantErr:  model.ErrConflict,
-			wantWord: false,
+			wantErr:     model.ErrConflict,
+			wantErrCode: "DUPLICATE_TERM",
 		},
 		{
-			name: "異常系: CheckTermExistsでDBエラー",
+			name: "異常系: CheckNormalizedTermExistsでDBエラー",
 			req: &model.PostWordRequest{
 				Term:       testTerm,
 				Definition: testDefinition,
 			},
-			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
-				wordRepo.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, testTerm, (*uuid.UUID)(nil)).
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(testTerm), (*uuid.UUID)(nil)).
 					Return(false, errors.New("db error on check")).Once()
 			},
-			wantErr:  model.ErrInternalServer,
-			wantWord: false,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name: "異常系: wordRepo.Createで一意制約違反 (同時登録)",
+			req: &model.PostWordRequest{
+				Term:       testTerm,
+				Definition: testDefinition,
+			},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(testTerm), (*uuid.UUID)(nil)).
+					Return(false, nil).Once()
+				m.word.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
+					Return(model.ErrConflict).Once()
+			},
+			wantErr:     model.ErrConflict,
+			wantErrCode: "DUPLICATE_TERM",
 		},
 		{
 			name: "異常系: wordRepo.CreateでDBエラー",
@@ -144,48 +208,64 @@ func Test_wordService_PostWord(t *testing.T) {
 				Term:       testTerm,
 				Definition: testDefinition,
 			},
-			setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
-				wordRepo.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, testTerm, (*uuid.UUID)(nil)).
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(testTerm), (*uuid.UUID)(nil)).
 					Return(false, nil).Once()
-				wordRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
+				m.word.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
 					Return(errors.New("db error on create word")).Once()
 			},
-			wantErr:  model.ErrInternalServer,
-			wantWord: false,
-		},
-		// { // LearningProgress作成部分が有効な場合のテストケース例
-		// 	name: "異常系: progRepo.CreateでDBエラー",
-		// 	req: &model.PostWordRequest{
-		// 		Term:       testTerm,
-		// 		Definition: testDefinition,
-		// 	},
-		// 	setupMock: func(wordRepo *mocks.WordRepository, progRepo *mocks.ProgressRepository) {
-		// 		wordRepo.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, testTerm, (*uuid.UUID)(nil)).
-		// 			Return(false, nil).Once()
-		// 		wordRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
-		// 			Return(nil).Once()
-		// 		progRepo.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).
-		// 			Return(errors.New("db error on create progress")).Once()
-		// 	},
-		// 	wantErr:  model.ErrInternalServer,
-		// 	wantWord: false,
-		// },
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name: "異常系: progRepo.CreateでDBエラー",
+			req: &model.PostWordRequest{
+				Term:       testTerm,
+				Definition: testDefinition,
+			},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(testTerm), (*uuid.UUID)(nil)).
+					Return(false, nil).Once()
+				m.word.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).
+					Return(nil).Once()
+				m.progress.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).
+					Return(errors.New("db error on create progress")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name: "異常系: 語義の保存でDBエラー",
+			req: &model.PostWordRequest{
+				Term:       testTerm,
+				Definition: testDefinition,
+				Senses:     []model.WordSenseRequest{{Definition: "sense1"}},
+			},
+			setupMock: func(m *wordServiceMocks) {
+				expectCreate(m.word, m.progress)
+				m.sense.On("ReplaceForWord", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, mock.AnythingOfType("uuid.UUID"), mock.Anything).
+					Return(errors.New("db error on replace senses")).Once()
+			},
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// モックのリセットと再設定
-			mockWordRepo.Mock = mock.Mock{}
-			mockProgRepo.Mock = mock.Mock{}
+			m.reset()
 			if tt.setupMock != nil {
-				tt.setupMock(mockWordRepo, mockProgRepo)
+				tt.setupMock(m)
 			}
 
 			createdWord, err := wordService.PostWord(ctx, tenantID, tt.req)
 
-			if tt.wantErr != nil {
+			if tt.wantErr != nil || tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				if tt.wantErrCode != "" {
+					assertAppErrorCode(t, err, tt.wantErrCode)
+				}
 				assert.Nil(t, createdWord)
 			} else {
 				require.NoError(t, err)
@@ -194,12 +274,12 @@ func Test_wordService_PostWord(t *testing.T) {
 				assert.Equal(t, tt.req.Definition, createdWord.Definition)
 				assert.Equal(t, tenantID, createdWord.TenantID)
 				assert.NotEqual(t, uuid.Nil, createdWord.WordID)
-				// 成功時の wantWord フラグは不要だったので削除 (assert で確認するため)
+				if tt.checkWord != nil {
+					tt.checkWord(t, createdWord)
+				}
 			}
 
-			mockWordRepo.AssertExpectations(t)
-			// LearningProgress 作成が有効な場合はこちらも AssertExpectations
-			// mockProgRepo.AssertExpectations(t)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -207,7 +287,7 @@ func Test_wordService_PostWord(t *testing.T) {
 // --- Test GetWord ---
 func Test_wordService_GetWord(t *testing.T) {
 	ctx := context.Background()
-	wordService, db, mockWordRepo, mockProgRepo := setupWordServiceWithMocks() // db はリポジトリに渡すため必要
+	wordService, db, m := setupWordServiceWithMocks(nil) // db はリポジトリに渡すため必要
 
 	tenantID := uuid.New()
 	wordID := uuid.New()
@@ -221,11 +301,12 @@ func Test_wordService_GetWord(t *testing.T) {
 	}
 
 	tests := []struct {
-		name      string
-		inputWID  uuid.UUID
-		setupMock func(m *mocks.WordRepository)
-		wantErr   error
-		wantWord  *model.Word
+		name        string
+		inputWID    uuid.UUID
+		setupMock   func(m *mocks.WordRepository)
+		wantErr     error
+		wantErrCode string
+		wantWord    *model.Word
 	}{
 		{
 			name:     "正常系: 単語取得成功",
@@ -234,7 +315,6 @@ func Test_wordService_GetWord(t *testing.T) {
 				m.On("FindByID", ctx, db, tenantID, wordID).
 					Return(expectedWord, nil).Once()
 			},
-			wantErr:  nil,
 			wantWord: expectedWord,
 		},
 		{
@@ -244,8 +324,8 @@ func Test_wordService_GetWord(t *testing.T) {
 				m.On("FindByID", ctx, db, tenantID, wordID).
 					Return(nil, model.ErrNotFound).Once()
 			},
-			wantErr:  model.ErrNotFound,
-			wantWord: nil,
+			wantErr:     model.ErrNotFound,
+			wantErrCode: "NOT_FOUND",
 		},
 		{
 			name:     "異常系: リポジトリでDBエラー",
@@ -254,23 +334,25 @@ func Test_wordService_GetWord(t *testing.T) {
 				m.On("FindByID", ctx, db, tenantID, wordID).
 					Return(nil, errors.New("internal server error")).Once() // リポジトリが返すエラー
 			},
-			wantErr:  model.ErrInternalServer, // サービスが ErrInternalServer を返すことを期待
-			wantWord: nil,
+			wantErrCode: "INTERNAL_SERVER_ERROR", // サービスが AppError に変換する
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockWordRepo.Mock = mock.Mock{}
+			m.reset()
 			if tt.setupMock != nil {
-				tt.setupMock(mockWordRepo)
+				tt.setupMock(m.word)
 			}
 
 			word, err := wordService.GetWord(ctx, tenantID, tt.inputWID)
 
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assertAppErrorCode(t, err, tt.wantErrCode)
 				assert.Nil(t, word)
 			} else {
 				require.NoError(t, err)
@@ -278,8 +360,7 @@ func Test_wordService_GetWord(t *testing.T) {
 				assert.Equal(t, tt.wantWord, word)
 			}
 
-			mockWordRepo.AssertExpectations(t)
-			mockProgRepo.AssertNotCalled(t, mock.Anything)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -287,7 +368,7 @@ func Test_wordService_GetWord(t *testing.T) {
 // --- Test GetWords ---
 func Test_wordService_GetWords(t *testing.T) {
 	ctx := context.Background()
-	wordService, db, mockWordRepo, mockProgRepo := setupWordServiceWithMocks()
+	wordService, db, m := setupWordServiceWithMocks(nil)
 
 	tenantID := uuid.New()
 	expectedWords := []*model.Word{
@@ -296,11 +377,11 @@ func Test_wordService_GetWords(t *testing.T) {
 	}
 
 	tests := []struct {
-		name      string
-		setupMock func(m *mocks.WordRepository)
-		wantErr   error
-		wantWords []*model.Word
-		wantLen   int
+		name        string
+		setupMock   func(m *mocks.WordRepository)
+		wantErrCode string
+		wantWords   []*model.Word
+		wantLen     int
 	}{
 		{
 			name: "正常系: 複数件取得成功",
@@ -308,7 +389,6 @@ func Test_wordService_GetWords(t *testing.T) {
 				m.On("FindByTenant", ctx, db, tenantID).
 					Return(expectedWords, nil).Once()
 			},
-			wantErr:   nil,
 			wantWords: expectedWords,
 			wantLen:   2,
 		},
@@ -318,7 +398,6 @@ func Test_wordService_GetWords(t *testing.T) {
 				m.On("FindByTenant", ctx, db, tenantID).
 					Return([]*model.Word{}, nil).Once()
 			},
-			wantErr:   nil,
 			wantWords: []*model.Word{},
 			wantLen:   0,
 		},
@@ -328,24 +407,22 @@ func Test_wordService_GetWords(t *testing.T) {
 				m.On("FindByTenant", ctx, db, tenantID).
 					Return(nil, errors.New("db error on find by tenant")).Once()
 			},
-			wantErr:   model.ErrInternalServer, // サービスが変換する
-			wantWords: nil,
-			wantLen:   0,
+			wantErrCode: "INTERNAL_SERVER_ERROR", // サービスが変換する
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockWordRepo.Mock = mock.Mock{}
+			m.reset()
 			if tt.setupMock != nil {
-				tt.setupMock(mockWordRepo)
+				tt.setupMock(m.word)
 			}
 
 			words, err := wordService.GetWords(ctx, tenantID)
 
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr) // GetWords は ErrInternalServer をそのまま返す想定
+				assertAppErrorCode(t, err, tt.wantErrCode)
 				assert.Nil(t, words)
 			} else {
 				require.NoError(t, err)
@@ -354,8 +431,7 @@ func Test_wordService_GetWords(t *testing.T) {
 				assert.Equal(t, tt.wantWords, words)
 			}
 
-			mockWordRepo.AssertExpectations(t)
-			mockProgRepo.AssertNotCalled(t, mock.Anything)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -363,28 +439,35 @@ func Test_wordService_GetWords(t *testing.T) {
 // --- Test PutWord ---
 func Test_wordService_PutWord(t *testing.T) {
 	ctx := context.Background()
-	wordService, _, mockWordRepo, mockProgRepo := setupWordServiceWithMocks() // db は Transaction 用
+	wordService, _, m := setupWordServiceWithMocks(nil) // db は Transaction 用
 
 	tenantID := uuid.New()
 	wordID := uuid.New()
+	senseID := uuid.New()
 	originalTerm := "original_term_put" // 他のテストと区別
 	originalDef := "original_def_put"
 	newTerm := "new_term_put"
 	newDef := "new_def_put"
 
-	originalWord := &model.Word{
-		WordID:     wordID,
-		TenantID:   tenantID,
-		Term:       originalTerm,
-		Definition: originalDef,
+	// replaceSenses が word.Senses を書き換えるため、呼び出しごとに新しい値を返す
+	originalWord := func() *model.Word {
+		return &model.Word{
+			WordID:     wordID,
+			TenantID:   tenantID,
+			Term:       originalTerm,
+			Definition: originalDef,
+			Senses:     []model.WordSense{{SenseID: senseID, TenantID: tenantID, WordID: wordID, Position: 0, Definition: "sense"}},
+		}
 	}
+	keepSenses := []model.WordSenseRequest{{SenseID: &senseID, Definition: "sense"}}
 
 	tests := []struct {
 		name            string
 		inputWID        uuid.UUID
 		req             *model.PutWordRequest // 値型フィールドを持つDTO
-		setupMock       func(m *mocks.WordRepository)
+		setupMock       func(m *wordServiceMocks)
 		wantErr         error
+		wantErrCode     string
 		wantUpdatedTerm string
 		wantUpdatedDef  string
 	}{
@@ -394,16 +477,16 @@ func Test_wordService_PutWord(t *testing.T) {
 			req: &model.PutWordRequest{
 				Term:       newTerm, // 値を直接渡す
 				Definition: newDef,
+				Senses:     keepSenses,
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm, "Definition": newDef}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).Return(false, nil).Once()
+				expectedUpdates := map[string]interface{}{"Term": newTerm, "NormalizedTerm": normalizedTerm(newTerm), "Definition": newDef}
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
 				updatedWord := &model.Word{WordID: wordID, TenantID: tenantID, Term: newTerm, Definition: newDef}
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(updatedWord, nil).Once()
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(updatedWord, nil).Once()
 			},
-			wantErr:         nil,
 			wantUpdatedTerm: newTerm,
 			wantUpdatedDef:  newDef,
 		},
@@ -413,93 +496,115 @@ func Test_wordService_PutWord(t *testing.T) {
 			req: &model.PutWordRequest{
 				Term:       originalTerm, // 元と同じ値
 				Definition: originalDef,
+				Senses:     keepSenses,
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				// CheckTermExists や Update は呼ばれない
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				// CheckNormalizedTermExists や Update、ReplaceForWord は呼ばれない
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
 			},
-			wantErr:         nil,
 			wantUpdatedTerm: originalTerm,
 			wantUpdatedDef:  originalDef,
 		},
 		{
-			name:     "異常系: 更新対象が見つからない (PUT)",
+			name:     "正常系: 語義を省略すると語義をすべて削除する (PUT)",
 			inputWID: wordID,
 			req: &model.PutWordRequest{
-				Term:       newTerm,
-				Definition: newDef,
+				Term:       originalTerm,
+				Definition: originalDef,
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, model.ErrNotFound).Once()
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.sense.On("ReplaceForWord", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, []model.WordSense{}).Return(nil).Once()
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
 			},
-			wantErr: model.ErrNotFound,
+			wantUpdatedTerm: originalTerm,
+			wantUpdatedDef:  originalDef,
+		},
+		{
+			name:     "異常系: 更新対象が見つからない (PUT)",
+			inputWID: wordID,
+			req:      &model.PutWordRequest{Term: newTerm, Definition: newDef},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, model.ErrNotFound).Once()
+			},
+			wantErr:     model.ErrNotFound,
+			wantErrCode: "NOT_FOUND",
 		},
 		{
 			name:     "異常系: 新しいTermが重複 (PUT)",
 			inputWID: wordID,
+			req:      &model.PutWordRequest{Term: newTerm, Definition: newDef, Senses: keepSenses},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).Return(true, nil).Once()
+			},
+			wantErr:     model.ErrConflict,
+			wantErrCode: "DUPLICATE_TERM",
+		},
+		{
+			name:     "異常系: 他の単語の語義IDを指定 (PUT)",
+			inputWID: wordID,
 			req: &model.PutWordRequest{
-				Term:       newTerm,
-				Definition: newDef,
+				Term:       originalTerm,
+				Definition: originalDef,
+				Senses:     []model.WordSenseRequest{{SenseID: func() *uuid.UUID { id := uuid.New(); return &id }(), Definition: "sense"}},
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(true, nil).Once()
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
 			},
-			wantErr: model.ErrConflict,
+			wantErr:     model.ErrInvalidInput,
+			wantErrCode: "INVALID_SENSE_ID",
 		},
 		{
 			name:     "異常系: UpdateでDBエラー (PUT)",
 			inputWID: wordID,
-			req: &model.PutWordRequest{
-				Term:       newTerm,
-				Definition: newDef,
+			req:      &model.PutWordRequest{Term: originalTerm, Definition: newDef, Senses: keepSenses},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, map[string]interface{}{"Definition": newDef}).
+					Return(errors.New("db update error")).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm, "Definition": newDef}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(errors.New("db error on update")).Once()
-			},
-			wantErr: model.ErrInternalServer,
-		},
-		// バリデーションエラーのテストはサービス層ではなく、ハンドラー層やバリデーションミドルウェアで行うのが一般的
-		// {
-		// 	name:     "異常系: Term が空 (バリデーション想定)",
-		// 	inputWID: wordID,
-		// 	req: &model.PutWordRequest{
-		// 		Term:       "",
-		// 		Definition: newDef,
-		// 	},
-		// 	setupMock: nil, // バリデーションで弾かれる想定
-		// 	wantErr: model.ErrInvalidInput,
-		// },
+			wantErrCode: "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name:     "異常系: Updateで一意制約違反 (PUT)",
+			inputWID: wordID,
+			req:      &model.PutWordRequest{Term: newTerm, Definition: originalDef, Senses: keepSenses},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).Return(false, nil).Once()
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, mock.Anything).Return(model.ErrConflict).Once()
+			},
+			wantErr:     model.ErrConflict,
+			wantErrCode: "DUPLICATE_TERM",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockWordRepo.Mock = mock.Mock{}
+			m.reset()
 			if tt.setupMock != nil {
-				tt.setupMock(mockWordRepo)
+				tt.setupMock(m)
 			}
 
 			updatedWord, err := wordService.PutWord(ctx, tenantID, tt.inputWID, tt.req)
 
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assertAppErrorCode(t, err, tt.wantErrCode)
 				assert.Nil(t, updatedWord)
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, updatedWord)
 				assert.Equal(t, tt.wantUpdatedTerm, updatedWord.Term)
 				assert.Equal(t, tt.wantUpdatedDef, updatedWord.Definition)
-				assert.Equal(t, tenantID, updatedWord.TenantID)
-				assert.Equal(t, tt.inputWID, updatedWord.WordID)
 			}
 
-			mockWordRepo.AssertExpectations(t)
-			mockProgRepo.AssertNotCalled(t, mock.Anything)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -507,243 +612,196 @@ func Test_wordService_PutWord(t *testing.T) {
 // --- Test PatchWord ---
 func Test_wordService_PatchWord(t *testing.T) {
 	ctx := context.Background()
-	wordService, _, mockWordRepo, mockProgRepo := setupWordServiceWithMocks() // db は Transaction 用に必要
+	wordService, _, m := setupWordServiceWithMocks(nil)
 
 	tenantID := uuid.New()
 	wordID := uuid.New()
-	originalTerm := "original_term_patch" // 他のテストと区別
+	senseID := uuid.New()
+	originalTerm := "original_term_patch"
 	originalDef := "original_def_patch"
 	newTerm := "new_term_patch"
 	newDef := "new_def_patch"
-	emptyStr := ""
 
-	originalWord := &model.Word{
-		WordID:     wordID,
-		TenantID:   tenantID,
-		Term:       originalTerm,
-		Definition: originalDef,
-		CreatedAt:  time.Now().Add(-time.Hour),
-		UpdatedAt:  time.Now().Add(-time.Hour),
+	originalWord := func() *model.Word {
+		return &model.Word{
+			WordID:     wordID,
+			TenantID:   tenantID,
+			Term:       originalTerm,
+			Definition: originalDef,
+			Senses:     []model.WordSense{{SenseID: senseID, TenantID: tenantID, WordID: wordID, Position: 0, Definition: "sense"}},
+		}
 	}
+	ptr := func(s string) *string { return &s }
 
 	tests := []struct {
-		name            string
-		req             *model.PatchWordRequest // ポインタ型フィールドを持つDTO
-		setupMock       func(wordRepo *mocks.WordRepository)
-		wantErr         error
-		wantUpdatedTerm string
-		wantUpdatedDef  string
+		name        string
+		req         *model.PatchWordRequest
+		setupMock   func(m *wordServiceMocks)
+		wantErr     error
+		wantErrCode string
+		checkWord   func(t *testing.T, word *model.Word)
 	}{
 		{
 			name: "正常系: TermとDefinitionを更新 (PATCH)",
-			req: &model.PatchWordRequest{
-				Term:       &newTerm, // ポインタを渡す
-				Definition: &newDef,
+			req:  &model.PatchWordRequest{Term: ptr(newTerm), Definition: ptr(newDef)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).Return(false, nil).Once()
+				expectedUpdates := map[string]interface{}{"Term": newTerm, "NormalizedTerm": normalizedTerm(newTerm), "Definition": newDef}
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).
+					Return(&model.Word{WordID: wordID, TenantID: tenantID, Term: newTerm, Definition: newDef}, nil).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm, "Definition": newDef}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
-				updatedWord := &model.Word{WordID: wordID, TenantID: tenantID, Term: newTerm, Definition: newDef}
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(updatedWord, nil).Once()
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Equal(t, newTerm, word.Term)
+				assert.Equal(t, newDef, word.Definition)
 			},
-			wantErr:         nil,
-			wantUpdatedTerm: newTerm,
-			wantUpdatedDef:  newDef,
-		},
-		{
-			name: "正常系: Termのみ更新 (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
-				// Definitionはnil
-			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
-				updatedWord := &model.Word{WordID: wordID, TenantID: tenantID, Term: newTerm, Definition: originalDef}
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(updatedWord, nil).Once()
-			},
-			wantErr:         nil,
-			wantUpdatedTerm: newTerm,
-			wantUpdatedDef:  originalDef,
 		},
 		{
 			name: "正常系: Definitionのみ更新 (PATCH)",
-			req: &model.PatchWordRequest{
-				Definition: &newDef,
-				// Termはnil
+			req:  &model.PatchWordRequest{Definition: ptr(newDef)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, map[string]interface{}{"Definition": newDef}).Return(nil).Once()
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).
+					Return(&model.Word{WordID: wordID, TenantID: tenantID, Term: originalTerm, Definition: newDef}, nil).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				expectedUpdates := map[string]interface{}{"Definition": newDef}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
-				updatedWord := &model.Word{WordID: wordID, TenantID: tenantID, Term: originalTerm, Definition: newDef}
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(updatedWord, nil).Once()
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Equal(t, newDef, word.Definition)
 			},
-			wantErr:         nil,
-			wantUpdatedTerm: originalTerm,
-			wantUpdatedDef:  newDef,
 		},
 		{
-			name: "正常系: 更新内容がない (リクエストで指定なし) (PATCH)",
-			req: &model.PatchWordRequest{
-				Term:       nil,
-				Definition: nil,
+			name: "正常系: 更新内容がない (語義も指定なしなら変更しない) (PATCH)",
+			req:  &model.PatchWordRequest{},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Twice()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once() // 2回目のFindByID
+			checkWord: func(t *testing.T, word *model.Word) {
+				assert.Len(t, word.Senses, 1)
 			},
-			wantErr:         nil,
-			wantUpdatedTerm: originalTerm,
-			wantUpdatedDef:  originalDef,
 		},
 		{
-			name: "正常系: 更新内容がない (指定された値が既存と同じ) (PATCH)",
-			req: &model.PatchWordRequest{
-				Term:       &originalTerm, // 既存と同じ値
-				Definition: &originalDef,
+			name: "正常系: 語義を置き換える (PATCH)",
+			req: &model.PatchWordRequest{Senses: &[]model.WordSenseRequest{
+				{Definition: "new sense"},
+				{SenseID: &senseID, Definition: "sense updated"},
+			}},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.sense.On("ReplaceForWord", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, mock.MatchedBy(func(senses []model.WordSense) bool {
+					return len(senses) == 2 &&
+						senses[0].SenseID != senseID && senses[0].Position == 0 && senses[0].Definition == "new sense" &&
+						senses[1].SenseID == senseID && senses[1].Position == 1 && senses[1].Definition == "sense updated"
+				})).Return(nil).Once()
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once() // 2回目のFindByID
-			},
-			wantErr:         nil,
-			wantUpdatedTerm: originalTerm,
-			wantUpdatedDef:  originalDef,
 		},
 		{
-			name: "正常系: Termを空文字に更新 (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &emptyStr, // 空文字へのポインタ
+			name: "異常系: 同じ語義IDを重複して指定 (PATCH)",
+			req: &model.PatchWordRequest{Senses: &[]model.WordSenseRequest{
+				{SenseID: &senseID, Definition: "a"},
+				{SenseID: &senseID, Definition: "b"},
+			}},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, emptyStr, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": emptyStr}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
-				updatedWord := &model.Word{WordID: wordID, TenantID: tenantID, Term: emptyStr, Definition: originalDef}
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(updatedWord, nil).Once()
-			},
-			wantErr:         nil,
-			wantUpdatedTerm: emptyStr,
-			wantUpdatedDef:  originalDef,
+			wantErr:     model.ErrInvalidInput,
+			wantErrCode: "INVALID_SENSE_ID",
 		},
 		{
 			name: "異常系: 更新対象が見つからない (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
-			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, model.ErrNotFound).Once()
+			req:  &model.PatchWordRequest{Definition: ptr(newDef)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, model.ErrNotFound).Once()
 			},
-			wantErr: model.ErrNotFound,
+			wantErr:     model.ErrNotFound,
+			wantErrCode: "NOT_FOUND",
 		},
 		{
 			name: "異常系: 最初のFindByIDでDBエラー (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
+			req:  &model.PatchWordRequest{Definition: ptr(newDef)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, errors.New("db error")).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, model.ErrInternalServer).Once()
-			},
-			wantErr: model.ErrInternalServer,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 		{
 			name: "異常系: 新しいTermが重複 (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
-			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(true, nil).Once()
+			req:  &model.PatchWordRequest{Term: ptr(newTerm)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).Return(true, nil).Once()
 			},
-			wantErr: model.ErrConflict,
+			wantErr:     model.ErrConflict,
+			wantErrCode: "DUPLICATE_TERM",
 		},
 		{
-			name: "異常系: CheckTermExistsでDBエラー (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
+			name: "異常系: CheckNormalizedTermExistsでDBエラー (PATCH)",
+			req:  &model.PatchWordRequest{Term: ptr(newTerm)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).
+					Return(false, errors.New("db error")).Once()
 			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).
-					Return(false, errors.New("db error on check")).Once()
-			},
-			wantErr: model.ErrInternalServer,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 		{
-			name: "異常系: UpdateでDBエラー (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
-			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).
-					Return(errors.New("db error on update")).Once()
+			name: "異常系: Updateで一意制約違反 (PATCH)",
+			req:  &model.PatchWordRequest{Term: ptr(newTerm)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(newTerm), &wordID).Return(false, nil).Once()
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, mock.Anything).Return(model.ErrConflict).Once()
 			},
-			wantErr: model.ErrInternalServer,
+			wantErr:     model.ErrConflict,
+			wantErrCode: "DUPLICATE_TERM",
 		},
 		{
-			name: "異常系: UpdateでNotFoundエラー (競合) (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
-			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).
-					Return(model.ErrNotFound).Once() // Update が NotFound を返す
+			name: "異常系: UpdateでDBエラー (PATCH)",
+			req:  &model.PatchWordRequest{Definition: ptr(newDef)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, mock.Anything).Return(errors.New("db update error")).Once()
 			},
-			wantErr: model.ErrNotFound,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 		{
 			name: "異常系: 更新後のFindByIDでDBエラー (PATCH)",
-			req: &model.PatchWordRequest{
-				Term: &newTerm,
-			},
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord, nil).Once()
-				m.On("CheckTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, newTerm, &wordID).Return(false, nil).Once()
-				expectedUpdates := map[string]interface{}{"Term": newTerm}
-				m.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, expectedUpdates).Return(nil).Once()
-				m.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).
-					Return(nil, model.ErrInternalServer).Once() // 2回目のFindByIDでエラー
+			req:  &model.PatchWordRequest{Definition: ptr(newDef)},
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(originalWord(), nil).Once()
+				m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, mock.Anything).Return(nil).Once()
+				m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(nil, errors.New("db error")).Once()
 			},
-			wantErr: model.ErrInternalServer,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockWordRepo.Mock = mock.Mock{}
+			m.reset()
 			if tt.setupMock != nil {
-				tt.setupMock(mockWordRepo)
+				tt.setupMock(m)
 			}
 
-			updatedWord, err := wordService.PatchWord(ctx, tenantID, wordID, tt.req)
+			patchedWord, err := wordService.PatchWord(ctx, tenantID, wordID, tt.req)
 
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
-				assert.Nil(t, updatedWord)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assertAppErrorCode(t, err, tt.wantErrCode)
+				assert.Nil(t, patchedWord)
 			} else {
 				require.NoError(t, err)
-				require.NotNil(t, updatedWord)
-				assert.Equal(t, tt.wantUpdatedTerm, updatedWord.Term)
-				assert.Equal(t, tt.wantUpdatedDef, updatedWord.Definition)
-				assert.Equal(t, tenantID, updatedWord.TenantID)
-				assert.Equal(t, wordID, updatedWord.WordID)
+				require.NotNil(t, patchedWord)
+				if tt.checkWord != nil {
+					tt.checkWord(t, patchedWord)
+				}
 			}
 
-			mockWordRepo.AssertExpectations(t)
-			mockProgRepo.AssertNotCalled(t, mock.Anything)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -751,87 +809,206 @@ func Test_wordService_PatchWord(t *testing.T) {
 // --- Test DeleteWord ---
 func Test_wordService_DeleteWord(t *testing.T) {
 	ctx := context.Background()
-	wordService, _, mockWordRepo, mockProgRepo := setupWordServiceWithMocks()
+	wordService, db, m := setupWordServiceWithMocks(nil)
 
 	tenantID := uuid.New()
 	wordID := uuid.New()
-	invalidUUID := uuid.Nil
 
 	tests := []struct {
-		name      string
-		inputTID  uuid.UUID
-		inputWID  uuid.UUID
-		setupMock func(m *mocks.WordRepository)
-		wantErr   error
+		name        string
+		setupMock   func(m *wordServiceMocks)
+		wantErrCode string
 	}{
 		{
-			name:     "正常系: 削除成功",
-			inputTID: tenantID,
-			inputWID: wordID,
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("Delete", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).
-					Return(nil).Once()
+			name: "正常系: 削除成功",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("Delete", ctx, db, tenantID, wordID).Return(nil).Once()
+				// 学習進捗はゴミ箱から復元できるよう削除しない
 			},
-			wantErr: nil,
 		},
 		{
-			name:     "正常系: 削除対象が見つからない (冪等性)",
-			inputTID: tenantID,
-			inputWID: wordID,
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("Delete", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).
-					Return(model.ErrNotFound).Once()
+			name: "正常系: 削除対象が見つからない (冪等性)",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("Delete", ctx, db, tenantID, wordID).Return(model.ErrNotFound).Once()
 			},
-			wantErr: nil, // サービスは ErrNotFound を受け取っても nil を返す仕様
 		},
 		{
-			name:     "異常系: リポジトリでDBエラー",
-			inputTID: tenantID,
-			inputWID: wordID,
-			setupMock: func(m *mocks.WordRepository) {
-				m.On("Delete", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).
-					Return(errors.New("some internal db error")).Once()
+			name: "異常系: リポジトリでDBエラー",
+			setupMock: func(m *wordServiceMocks) {
+				m.word.On("Delete", ctx, db, tenantID, wordID).Return(errors.New("db delete error")).Once()
 			},
-			wantErr: model.ErrInternalServer,
-		},
-		{
-			name:      "異常系: tenantID が無効 (Nil UUID)",
-			inputTID:  invalidUUID,
-			inputWID:  wordID,
-			setupMock: nil, // リポジトリは呼ばれない
-			wantErr:   model.ErrInvalidInput,
-		},
-		{
-			name:      "異常系: wordID が無効 (Nil UUID)",
-			inputTID:  tenantID,
-			inputWID:  invalidUUID,
-			setupMock: nil, // リポジトリは呼ばれない
-			wantErr:   model.ErrInvalidInput,
+			wantErrCode: "INTERNAL_SERVER_ERROR",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockWordRepo.Mock = mock.Mock{}
-			if tt.setupMock != nil {
-				tt.setupMock(mockWordRepo)
-			}
+			m.reset()
+			tt.setupMock(m)
 
-			err := wordService.DeleteWord(ctx, tt.inputTID, tt.inputWID)
+			err := wordService.DeleteWord(ctx, tenantID, wordID)
 
-			if tt.wantErr != nil {
+			if tt.wantErrCode != "" {
 				require.Error(t, err)
-				assert.ErrorIs(t, err, tt.wantErr)
+				assertAppErrorCode(t, err, tt.wantErrCode)
 			} else {
 				require.NoError(t, err)
 			}
 
-			if tt.setupMock != nil {
-				mockWordRepo.AssertExpectations(t)
-			} else {
-				mockWordRepo.AssertNotCalled(t, mock.Anything) // バリデーションで弾かれるケース
+			m.assertExpectations(t)
+		})
+	}
+}
+
+// --- Test 詳細情報 (読み・品詞・例文・メモ) ---
+func Test_wordService_PostWord_Details(t *testing.T) {
+	ctx := context.Background()
+	wordService, _, m := setupWordServiceWithMocks(nil)
+	tenantID := uuid.New()
+
+	tests := []struct {
+		name        string
+		req         *model.PostWordRequest
+		wantReading string
+		wantManual  bool
+	}{
+		{
+			name:        "正常系: 読みを省略すると単語から自動生成する",
+			req:         &model.PostWordRequest{Term: "昨日", Definition: "yesterday", PartOfSpeech: model.PartOfSpeechNoun, Notes: "メモ"},
+			wantReading: "きのう",
+		},
+		{
+			name:        "正常系: 入力した読みはそのまま保存する",
+			req:         &model.PostWordRequest{Term: "昨日", Definition: "yesterday", Reading: "さくじつ"},
+			wantReading: "さくじつ",
+			wantManual:  true,
+		},
+		{
+			name: "正常系: 漢字を含まない単語には読みを付けない",
+			req: &model.PostWordRequest{
+				Term:       "apple",
+				Definition: "りんご",
+				Examples:   []model.WordExample{{Sentence: "I ate an apple.", Translation: "りんごを食べた。"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.reset()
+			m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(tt.req.Term), (*uuid.UUID)(nil)).Return(false, nil).Once()
+			m.word.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.Word")).Return(nil).Once()
+			m.progress.On("Create", ctx, mock.AnythingOfType("*gorm.DB"), mock.AnythingOfType("*model.LearningProgress")).Return(nil).Once()
+
+			word, err := wordService.PostWord(ctx, tenantID, tt.req)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReading, word.Reading)
+			assert.Equal(t, tt.wantManual, word.ReadingManual)
+			assert.Equal(t, tt.req.PartOfSpeech, word.PartOfSpeech)
+			assert.Equal(t, tt.req.Notes, word.Notes)
+			// 例文を省略しても NULL ではなく空の配列で保存する
+			require.NotNil(t, word.Examples)
+			assert.Equal(t, len(tt.req.Examples), len(word.Examples))
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func Test_wordService_UpdateWord_Details(t *testing.T) {
+	ctx := context.Background()
+	wordService, _, m := setupWordServiceWithMocks(nil)
+	tenantID := uuid.New()
+	wordID := uuid.New()
+	ptr := func(s string) *string { return &s }
+	examples := model.WordExamples{{Sentence: "昨日は雨だった。"}}
+
+	// 自動生成の読みを持つ単語
+	autoWord := func() *model.Word {
+		return &model.Word{WordID: wordID, TenantID: tenantID, Term: "昨日", Definition: "yesterday", Reading: "きのう",
+			PartOfSpeech: model.PartOfSpeechNoun, Examples: examples, Notes: "メモ"}
+	}
+	// 利用者が入力した読みを持つ単語
+	manualWord := func() *model.Word {
+		w := autoWord()
+		w.Reading, w.ReadingManual = "さくじつ", true
+		return w
+	}
+
+	tests := []struct {
+		name        string
+		word        func() *model.Word
+		update      func() (*model.Word, error)
+		wantUpdates map[string]interface{}
+	}{
+		{
+			name: "正常系: 空の読みで自動生成の読みに戻す (PATCH)",
+			word: manualWord,
+			update: func() (*model.Word, error) {
+				return wordService.PatchWord(ctx, tenantID, wordID, &model.PatchWordRequest{Reading: ptr("")})
+			},
+			wantUpdates: map[string]interface{}{"Reading": "きのう", "ReadingManual": false},
+		},
+		{
+			name: "正常系: 自動生成の読みは単語の変更に合わせて作り直す (PATCH)",
+			word: autoWord,
+			update: func() (*model.Word, error) {
+				return wordService.PatchWord(ctx, tenantID, wordID, &model.PatchWordRequest{Term: ptr("今日")})
+			},
+			wantUpdates: map[string]interface{}{"Term": "今日", "NormalizedTerm": normalizedTerm("今日"), "Reading": "きょう"},
+		},
+		{
+			name: "正常系: 入力した読みは単語を変更しても残す (PATCH)",
+			word: manualWord,
+			update: func() (*model.Word, error) {
+				return wordService.PatchWord(ctx, tenantID, wordID, &model.PatchWordRequest{Term: ptr("今日")})
+			},
+			wantUpdates: map[string]interface{}{"Term": "今日", "NormalizedTerm": normalizedTerm("今日")},
+		},
+		{
+			name: "正常系: 品詞を消し、例文とメモを変更する (PATCH)",
+			word: autoWord,
+			update: func() (*model.Word, error) {
+				newExamples := []model.WordExample{{Sentence: "昨日は晴れだった。", Translation: "It was sunny yesterday."}}
+				return wordService.PatchWord(ctx, tenantID, wordID, &model.PatchWordRequest{PartOfSpeech: ptr(""), Examples: &newExamples, Notes: ptr("新しいメモ")})
+			},
+			wantUpdates: map[string]interface{}{
+				"PartOfSpeech": "",
+				"Examples":     model.WordExamples{{Sentence: "昨日は晴れだった。", Translation: "It was sunny yesterday."}},
+				"Notes":        "新しいメモ",
+			},
+		},
+		{
+			name: "正常系: 省略した詳細情報は空になる (PUT)",
+			word: manualWord,
+			update: func() (*model.Word, error) {
+				return wordService.PutWord(ctx, tenantID, wordID, &model.PutWordRequest{Term: "昨日", Definition: "yesterday"})
+			},
+			wantUpdates: map[string]interface{}{
+				"Reading":       "きのう",
+				"ReadingManual": false,
+				"PartOfSpeech":  "",
+				"Examples":      model.WordExamples{},
+				"Notes":         "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.reset()
+			word := tt.word()
+			m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(word, nil).Once()
+			if term, ok := tt.wantUpdates["Term"]; ok {
+				m.word.On("CheckNormalizedTermExists", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, normalizedTerm(term.(string)), &wordID).Return(false, nil).Once()
 			}
-			mockProgRepo.AssertNotCalled(t, mock.Anything)
+			m.word.On("Update", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID, tt.wantUpdates).Return(nil).Once()
+			m.word.On("FindByID", ctx, mock.AnythingOfType("*gorm.DB"), tenantID, wordID).Return(word, nil).Once()
+
+			_, err := tt.update()
+
+			require.NoError(t, err)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,156 @@
+package textutil
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ClozeBlank は穴埋め問題の空欄を表す文字列
+const ClozeBlank = "___"
+
+// Cloze は例文中の単語を空欄にした穴埋め問題です
+type Cloze struct {
+	Text   string // 単語を ClozeBlank に置き換えた例文
+	Answer string // 空欄に入る、例文中での表記 (活用している場合は活用形)
+}
+
+// MakeCloze は例文 sentence に含まれる term を空欄にした穴埋め問題を返します。
+// 活用形も検出します (英語は規則ベースの原形推定、日本語は形態素解析による原形の比較)。
+// 例文に単語が見つからない場合は false を返します。複数回出現する場合は最初の1つだけを空欄にします。
+func MakeCloze(sentence, term string) (Cloze, bool) {
+	term = strings.TrimSpace(term)
+	if term == "" || strings.TrimSpace(sentence) == "" {
+		return Cloze{}, false
+	}
+
+	var start, end int
+	var ok bool
+	if ContainsJapanese(term) {
+		start, end, ok = findJapaneseTerm(sentence, term)
+	} else {
+		start, end, ok = findEnglishTerm(sentence, term)
+	}
+	if !ok {
+		start, end, ok = findLiteral(sentence, term)
+	}
+	if !ok {
+		return Cloze{}, false
+	}
+	return Cloze{
+		Text:   sentence[:start] + ClozeBlank + sentence[end:],
+		Answer: sentence[start:end],
+	}, true
+}
+
+// findJapaneseTerm は例文と単語を形態素解析し、原形の並びが一致する箇所を返します。
+// 「食べる」に対する「食べました」のように、続く助動詞も活用の一部として空欄に含めます。
+func findJapaneseTerm(sentence, term string) (int, int, bool) {
+	termMorphemes, err := analyzeJapanese(term)
+	if err != nil || len(termMorphemes) == 0 {
+		return 0, 0, false
+	}
+	morphemes, err := analyzeJapanese(sentence)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	for i := 0; i+len(termMorphemes) <= len(morphemes); i++ {
+		matched := true
+		for k, tm := range termMorphemes {
+			if morphemes[i+k].Base != tm.Base {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		j := i + len(termMorphemes)
+		for j < len(morphemes) && morphemes[j].POS == "助動詞" {
+			j++
+		}
+		return morphemes[i].Start, morphemes[j-1].End, true
+	}
+	return 0, 0, false
+}
+
+// wordSpan は文中の英単語の位置です
+type wordSpan struct {
+	Word       string
+	Start, End int
+}
+
+// englishWords は文を英単語 (英数字と、語中のアポストロフィ・ハイフン) に分割します
+func englishWords(s string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+	for i, r := range s {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) ||
+			(start >= 0 && (r == '\'' || r == '’' || r == '-'))
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			spans = append(spans, wordSpan{Word: s[start:i], Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{Word: s[start:], Start: start, End: len(s)})
+	}
+	return spans
+}
+
+// findEnglishTerm は例文の単語の並びのうち、term の各単語と一致するか語形変化したものを探します。
+// 「give up」に対する「gave up」のような句動詞も検出できます。
+// banker (bank + er) のような語形変化と紛らわしい単語を避けるため、そのままの形で一致する箇所を優先します。
+func findEnglishTerm(sentence, term string) (int, int, bool) {
+	termWords := englishWords(term)
+	if len(termWords) == 0 || !isPlainWords(term, termWords) {
+		return 0, 0, false
+	}
+	words := englishWords(sentence)
+
+	for _, inflected := range []bool{false, true} {
+		for i := 0; i+len(termWords) <= len(words); i++ {
+			matched := true
+			for k, tw := range termWords {
+				if !englishWordMatches(words[i+k].Word, tw.Word, inflected) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return words[i].Start, words[i+len(termWords)-1].End, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// isPlainWords は term が空白区切りの単語だけでできているか (記号を含まないか) を返します
+func isPlainWords(term string, words []wordSpan) bool {
+	plain := make([]string, len(words))
+	for i, w := range words {
+		plain[i] = w.Word
+	}
+	return strings.Join(plain, " ") == strings.Join(strings.Fields(term), " ")
+}
+
+func englishWordMatches(word, termWord string, inflected bool) bool {
+	if strings.EqualFold(word, termWord) {
+		return true
+	}
+	return inflected && slices.Contains(EnglishLemmas(word), strings.ToLower(termWord))
+}
+
+// findLiteral は大文字小文字を区別せずに term をそのまま探します (記号を含む単語など、単語分割で見つからない場合の予備)
+func findLiteral(sentence, term string) (int, int, bool) {
+	loc := regexp.MustCompile("(?i)" + regexp.QuoteMeta(term)).FindStringIndex(sentence)
+	if loc == nil {
+		return 0, 0, false
+	}
+	return loc[0], loc[1], true
+}
@@ -0,0 +1,71 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeCloze(t *testing.T) {
+	tests := []struct {
+		name     string
+		sentence string
+		term     string
+		want     textutil.Cloze
+		wantOK   bool
+	}{
+		{name: "正常系: 英語の原形", sentence: "I went to the bank to withdraw money.", term: "bank", want: textutil.Cloze{Text: "I went to the ___ to withdraw money.", Answer: "bank"}, wantOK: true},
+		{name: "正常系: 大文字小文字を区別しない", sentence: "Bank holidays are fun.", term: "bank", want: textutil.Cloze{Text: "___ holidays are fun.", Answer: "Bank"}, wantOK: true},
+		{name: "正常系: 単語の一部には一致しない", sentence: "The banker went to the bank.", term: "bank", want: textutil.Cloze{Text: "The banker went to the ___.", Answer: "bank"}, wantOK: true},
+		{name: "正常系: 複数形", sentence: "Both banks were closed.", term: "bank", want: textutil.Cloze{Text: "Both ___ were closed.", Answer: "banks"}, wantOK: true},
+		{name: "正常系: yをiesにした複数形", sentence: "She studies hard.", term: "study", want: textutil.Cloze{Text: "She ___ hard.", Answer: "studies"}, wantOK: true},
+		{name: "正常系: eを落とした進行形", sentence: "They are making dinner.", term: "make", want: textutil.Cloze{Text: "They are ___ dinner.", Answer: "making"}, wantOK: true},
+		{name: "正常系: 子音を重ねた過去形", sentence: "The bus stopped suddenly.", term: "stop", want: textutil.Cloze{Text: "The bus ___ suddenly.", Answer: "stopped"}, wantOK: true},
+		{name: "正常系: 不規則動詞の過去形", sentence: "He went home early.", term: "go", want: textutil.Cloze{Text: "He ___ home early.", Answer: "went"}, wantOK: true},
+		{name: "正常系: 比較級", sentence: "This box is bigger than that one.", term: "big", want: textutil.Cloze{Text: "This box is ___ than that one.", Answer: "bigger"}, wantOK: true},
+		{name: "正常系: 所有格", sentence: "The bank's policy changed.", term: "bank", want: textutil.Cloze{Text: "The ___ policy changed.", Answer: "bank's"}, wantOK: true},
+		{name: "正常系: 句動詞の活用", sentence: "She finally gave up smoking.", term: "give up", want: textutil.Cloze{Text: "She finally ___ smoking.", Answer: "gave up"}, wantOK: true},
+		{name: "正常系: 記号を含む単語はそのまま探す", sentence: "I learned C++ last year.", term: "C++", want: textutil.Cloze{Text: "I learned ___ last year.", Answer: "C++"}, wantOK: true},
+		{name: "正常系: 日本語の名詞", sentence: "銀行でお金をおろした。", term: "銀行", want: textutil.Cloze{Text: "___でお金をおろした。", Answer: "銀行"}, wantOK: true},
+		{name: "正常系: 日本語の動詞の活用形 (助動詞を含める)", sentence: "昨日すしを食べました。", term: "食べる", want: textutil.Cloze{Text: "昨日すしを___。", Answer: "食べました"}, wantOK: true},
+		{name: "正常系: 五段動詞の音便", sentence: "手紙を書いた。", term: "書く", want: textutil.Cloze{Text: "手紙を___。", Answer: "書いた"}, wantOK: true},
+		{name: "正常系: 形容詞の過去形", sentence: "値段が高かった。", term: "高い", want: textutil.Cloze{Text: "値段が___。", Answer: "高かった"}, wantOK: true},
+		{name: "正常系: サ変動詞", sentence: "毎日英語を勉強しています。", term: "勉強する", want: textutil.Cloze{Text: "毎日英語を___ています。", Answer: "勉強し"}, wantOK: true},
+		{name: "異常系: 例文に単語がない", sentence: "I like apples.", term: "bank", wantOK: false},
+		{name: "異常系: 日本語の例文に単語がない", sentence: "りんごが好きです。", term: "銀行", wantOK: false},
+		{name: "異常系: 空の例文", sentence: "", term: "bank", wantOK: false},
+		{name: "異常系: 空の単語", sentence: "I went to the bank.", term: " ", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := textutil.MakeCloze(tt.sentence, tt.term)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEnglishLemmas(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want string // 候補に含まれるべき原形
+	}{
+		{name: "正常系: 原形はそのまま", word: "Bank", want: "bank"},
+		{name: "正常系: 三人称単数", word: "watches", want: "watch"},
+		{name: "正常系: ieをyingにした進行形", word: "lying", want: "lie"},
+		{name: "正常系: yをiにした最上級", word: "happiest", want: "happy"},
+		{name: "正常系: eで終わる動詞の過去形", word: "liked", want: "like"},
+		{name: "正常系: 不規則な複数形", word: "children", want: "child"},
+		{name: "正常系: 不規則な比較級", word: "better", want: "good"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, textutil.EnglishLemmas(tt.word), tt.want)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package textutil
+
+import "strings"
+
+// englishIrregulars は規則では導けない語形変化と、その原形の対応表 (よく使われる不規則動詞・名詞・形容詞)
+var englishIrregulars = map[string][]string{
+	"am": {"be"}, "is": {"be"}, "are": {"be"}, "was": {"be"}, "were": {"be"}, "been": {"be"}, "being": {"be"},
+	"has": {"have"}, "had": {"have"}, "does": {"do"}, "did": {"do"}, "done": {"do"},
+	"went": {"go"}, "gone": {"go"}, "goes": {"go"},
+	"ate": {"eat"}, "eaten": {"eat"}, "began": {"begin"}, "begun": {"begin"},
+	"bought": {"buy"}, "brought": {"bring"}, "built": {"build"}, "caught": {"catch"},
+	"chose": {"choose"}, "chosen": {"choose"}, "came": {"come"}, "drew": {"draw"}, "drawn": {"draw"},
+	"drank": {"drink"}, "drunk": {"drink"}, "drove": {"drive"}, "driven": {"drive"},
+	"fell": {"fall"}, "fallen": {"fall"}, "felt": {"feel"}, "fought": {"fight"}, "found": {"find"},
+	"flew": {"fly"}, "flown": {"fly"}, "forgot": {"forget"}, "forgotten": {"forget"},
+	"gave": {"give"}, "given": {"give"}, "got": {"get"}, "gotten": {"get"}, "grew": {"grow"}, "grown": {"grow"},
+	"heard": {"hear"}, "held": {"hold"}, "kept": {"keep"}, "knew": {"know"}, "known": {"know"},
+	"laid": {"lay"}, "led": {"lead"}, "left": {"leave"}, "lent": {"lend"}, "lay": {"lie"}, "lain": {"lie"},
+	"lost": {"lose"}, "made": {"make"}, "meant": {"mean"}, "met": {"meet"}, "paid": {"pay"},
+	"ran": {"run"}, "rang": {"ring"}, "rung": {"ring"}, "rose": {"rise"}, "risen": {"rise"},
+	"said": {"say"}, "saw": {"see"}, "seen": {"see"}, "sold": {"sell"}, "sent": {"send"},
+	"shook": {"shake"}, "shaken": {"shake"}, "shot": {"shoot"}, "sang": {"sing"}, "sung": {"sing"},
+	"sat": {"sit"}, "slept": {"sleep"}, "spoke": {"speak"}, "spoken": {"speak"}, "spent": {"spend"},
+	"stood": {"stand"}, "stole": {"steal"}, "stolen": {"steal"}, "swam": {"swim"}, "swum": {"swim"},
+	"took": {"take"}, "taken": {"take"}, "taught": {"teach"}, "told": {"tell"}, "thought": {"think"},
+	"threw": {"throw"}, "thrown": {"throw"}, "understood": {"understand"}, "woke": {"wake"}, "woken": {"wake"},
+	"wore": {"wear"}, "worn": {"wear"}, "won": {"win"}, "wrote": {"write"}, "written": {"write"},
+	"broke": {"break"}, "broken": {"break"}, "froze": {"freeze"}, "frozen": {"freeze"}, "hid": {"hide"}, "hidden": {"hide"},
+	"rode": {"ride"}, "ridden": {"ride"}, "bit": {"bite"}, "bitten": {"bite"}, "fed": {"feed"}, "fled": {"flee"},
+	"dealt": {"deal"}, "dug": {"dig"}, "hung": {"hang"}, "struck": {"strike"}, "sought": {"seek"},
+	"children": {"child"}, "men": {"man"}, "women": {"woman"}, "people": {"person"}, "mice": {"mouse"},
+	"feet": {"foot"}, "teeth": {"tooth"}, "geese": {"goose"}, "lives": {"life"}, "knives": {"knife"},
+	"wives": {"wife"}, "leaves": {"leaf", "leave"}, "halves": {"half"}, "wolves": {"wolf"}, "shelves": {"shelf"},
+	"better": {"good", "well"}, "best": {"good", "well"}, "worse": {"bad"}, "worst": {"bad"},
+	"less": {"little"}, "least": {"little"}, "more": {"many", "much"}, "most": {"many", "much"},
+}
+
+// EnglishLemmas は英単語から語形変化 (複数形・三人称単数・過去形・過去分詞・進行形・比較級・最上級・所有格) を取り除いた、
+// 原形の候補を返します。辞書を使わない規則ベースのため候補は複数になり得ます (先頭は小文字にした word 自身)。
+func EnglishLemmas(word string) []string {
+	w := strings.ToLower(word)
+	lemmas := []string{w}
+	seen := map[string]bool{w: true}
+	add := func(s string) {
+		if len(s) >= 2 && !seen[s] {
+			seen[s] = true
+			lemmas = append(lemmas, s)
+		}
+	}
+
+	// 所有格 (bank's, banks')
+	for _, suffix := range []string{"'s", "’s", "'", "’"} {
+		if strings.HasSuffix(w, suffix) {
+			w = strings.TrimSuffix(w, suffix)
+			add(w)
+			break
+		}
+	}
+
+	for _, base := range englishIrregulars[w] {
+		add(base)
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ies"), strings.HasSuffix(w, "ied"):
+		add(w[:len(w)-3] + "y") // studies, studied → study
+	case strings.HasSuffix(w, "ier"):
+		add(w[:len(w)-3] + "y") // happier → happy
+	case strings.HasSuffix(w, "iest"):
+		add(w[:len(w)-4] + "y") // happiest → happy
+	}
+	if strings.HasSuffix(w, "ying") {
+		add(w[:len(w)-4] + "ie") // lying → lie
+	}
+	if strings.HasSuffix(w, "es") {
+		add(w[:len(w)-2]) // watches → watch
+	}
+	if strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+		add(w[:len(w)-1]) // cats → cat
+	}
+	for _, suffix := range []string{"ing", "ed", "er", "est"} {
+		if !strings.HasSuffix(w, suffix) {
+			continue
+		}
+		stem := w[:len(w)-len(suffix)]
+		add(stem)       // walked → walk
+		add(stem + "e") // making, liked, later → make, like, late
+		if n := len(stem); n >= 2 && stem[n-1] == stem[n-2] && !isEnglishVowel(stem[n-1]) {
+			add(stem[:n-1]) // stopped, running, bigger → stop, run, big
+		}
+	}
+	return lemmas
+}
+
+func isEnglishVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// LemmatizeEnglish は英単語の原形を1つに決めて返します (小文字)。
+// EnglishLemmas と違い候補を列挙せず、不規則変化の表と接尾辞の規則 (Porter の Step 1 相当) で推定します。
+// 辞書を使わないため writing → writ のように誤る場合があります。
+func LemmatizeEnglish(word string) string {
+	w := strings.ToLower(word)
+	for _, suffix := range []string{"'s", "’s", "'", "’"} {
+		if strings.HasSuffix(w, suffix) {
+			w = strings.TrimSuffix(w, suffix)
+			break
+		}
+	}
+	if bases, ok := englishIrregulars[w]; ok {
+		return bases[0]
+	}
+	if len(w) <= 3 {
+		return w
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ies"), strings.HasSuffix(w, "ied"):
+		if len(w) > 4 {
+			return w[:len(w)-3] + "y" // studies, studied → study
+		}
+		return w[:len(w)-1] // ties, died → tie, die
+	case strings.HasSuffix(w, "sses"):
+		return w[:len(w)-2] // classes → class
+	case strings.HasSuffix(w, "xes"), strings.HasSuffix(w, "zes"), strings.HasSuffix(w, "ches"), strings.HasSuffix(w, "shes"):
+		return w[:len(w)-2] // boxes, watches → box, watch
+	case strings.HasSuffix(w, "ss"), strings.HasSuffix(w, "us"), strings.HasSuffix(w, "is"):
+		return w // class, status, analysis
+	case strings.HasSuffix(w, "s"):
+		return w[:len(w)-1] // cats → cat
+	case strings.HasSuffix(w, "eed"):
+		return w // need, speed
+	case strings.HasSuffix(w, "ing"):
+		if stem := w[:len(w)-3]; hasEnglishVowel(stem) {
+			return restoreEnglishStem(stem)
+		}
+	case strings.HasSuffix(w, "ed"):
+		if stem := w[:len(w)-2]; hasEnglishVowel(stem) {
+			return restoreEnglishStem(stem)
+		}
+	}
+	return w
+}
+
+// restoreEnglishStem は -ing / -ed を取り除いた語幹を原形に戻します
+// (running → run のような子音の重複を戻し、making → make のような脱落した e を補います)
+func restoreEnglishStem(stem string) string {
+	if englishPlainStems[stem] {
+		return stem
+	}
+	n := len(stem)
+	switch {
+	case n >= 2 && stem[n-1] == stem[n-2] && !isEnglishVowel(stem[n-1]) && strings.IndexByte("lsz", stem[n-1]) < 0:
+		return stem[:n-1] // stopped → stop
+	case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "iz"), strings.HasSuffix(stem, "v"),
+		strings.HasSuffix(stem, "nc"), strings.HasSuffix(stem, "rc"), strings.HasSuffix(stem, "dg"):
+		return stem + "e" // created, solved, danced, judged → create, solve, dance, judge
+	case n >= 3 && stem[n-1] == 'l' && stem[n-2] != 'l' && !isEnglishVowel(stem[n-2]):
+		return stem + "e" // enabled, handled → enable, handle
+	case n >= 3 && stem[n-1] == 's' && isEnglishVowel(stem[n-2]) && stem[n-2] != 'u':
+		return stem + "e" // chased, closed → chase, close
+	case (n == 2 || n == 3 && !isEnglishVowel(stem[0])) && isEnglishVowel(stem[n-2]) && !isEnglishVowel(stem[n-1]) && strings.IndexByte("wxy", stem[n-1]) < 0:
+		return stem + "e" // making, used → make, use
+	}
+	return stem
+}
+
+// englishPlainStems は restoreEnglishStem の規則に当てはまるが、そのままで原形になる語幹 (eating → eat)
+var englishPlainStems = map[string]bool{
+	"eat": true, "beat": true, "heat": true, "seat": true, "treat": true, "cheat": true, "repeat": true, "defeat": true,
+}
+
+func hasEnglishVowel(s string) bool {
+	return strings.ContainsAny(s, "aeiouy")
+}
@@ -0,0 +1,228 @@
+package textutil
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Candidate はテキストから抽出した単語の候補です
+type Candidate struct {
+	Term         string // 原形
+	Reading      string // 読み (ひらがな。漢字を含む日本語の単語のみ)
+	PartOfSpeech string // 品詞 (model.PartOfSpeechXxx の値。英語は推定しないため空)
+	Count        int    // 出現回数
+	Sentence     string // 最初に出現した文
+}
+
+// 候補の品詞 (model.PartOfSpeechXxx と同じ値。textutil は model に依存しないため文字列で持つ)
+const (
+	candidateNoun      = "noun"
+	candidateVerb      = "verb"
+	candidateAdjective = "adjective"
+	candidateAdverb    = "adverb"
+)
+
+// minEnglishCandidateLength は英単語の候補とする最小の文字数 (これより短い語は機能語がほとんどのため除く)
+const minEnglishCandidateLength = 3
+
+// englishStopwords は候補から除く英語の機能語・ごく基本的な語
+var englishStopwords = toSet(
+	"the", "and", "but", "for", "nor", "yet", "not", "any", "all", "some", "each", "every", "both", "either", "neither",
+	"this", "that", "these", "those", "there", "here", "then", "than", "when", "where", "what", "which", "who", "whom",
+	"whose", "why", "how", "while", "because", "although", "though", "unless", "until", "since", "whether",
+	"you", "your", "yours", "she", "her", "hers", "him", "his", "its", "our", "ours", "they", "them", "their", "theirs",
+	"myself", "yourself", "himself", "herself", "itself", "ourselves", "themselves",
+	"be", "have", "do", "can", "could", "will", "would", "shall", "should", "may", "might", "must",
+	"about", "above", "after", "again", "against", "along", "among", "around", "before", "behind", "below", "beside",
+	"between", "beyond", "down", "during", "from", "into", "near", "off", "onto", "out", "over", "through", "toward",
+	"towards", "under", "upon", "with", "within", "without", "also", "just", "only", "very", "too", "so", "such",
+	"more", "most", "much", "many", "other", "another", "same", "own", "few", "one", "yes", "now", "ever", "never",
+)
+
+// japaneseStopwords は候補から除く日本語の語 (形式的に使われることの多い動詞・名詞)
+var japaneseStopwords = toSet(
+	"する", "ある", "いる", "なる", "できる", "いう", "言う", "ない", "よい", "いい", "くる", "来る", "おる", "やる",
+	"こと", "もの", "ため", "とき", "ところ", "ほう", "これ", "それ", "あれ", "どれ",
+	"今", "前", "後", "中", "上", "下", "時", "間",
+)
+
+// closingBrackets は文末記号の後に続けて同じ文に含める閉じ括弧・引用符
+const closingBrackets = "」』）)]】\"'’”"
+
+// ExtractCandidates は text を文に分割して単語の候補を抽出し、出現回数の多い順に返します (同数の場合は先に出現した順)。
+// 日本語は形態素解析で内容語 (名詞・動詞・形容詞・副詞) を取り出して原形にし、英語は LemmatizeEnglish で原形にします。
+// 数詞・代名詞・機能語は除きます。大文字小文字の違いは同じ単語として数えます。
+func ExtractCandidates(text string) []Candidate {
+	var candidates []*Candidate
+	byKey := make(map[string]*Candidate)
+	add := func(term, pos, sentence string, reading func() string) {
+		key := strings.ToLower(term)
+		if c, ok := byKey[key]; ok {
+			c.Count++
+			return
+		}
+		c := &Candidate{Term: term, PartOfSpeech: pos, Count: 1, Sentence: sentence}
+		if reading != nil {
+			c.Reading = reading()
+		}
+		byKey[key] = c
+		candidates = append(candidates, c)
+	}
+
+	for _, sentence := range splitSentences(text) {
+		if !ContainsJapanese(sentence) {
+			for _, w := range englishWords(sentence) {
+				if term, ok := englishCandidate(w.Word); ok {
+					add(term, "", sentence, nil)
+				}
+			}
+			continue
+		}
+		morphemes, err := analyzeJapanese(sentence)
+		if err != nil {
+			continue
+		}
+		for _, m := range morphemes {
+			if !ContainsJapanese(m.Surface) {
+				// 日本語の文に混ざった英単語
+				for _, w := range englishWords(m.Surface) {
+					if term, ok := englishCandidate(w.Word); ok {
+						add(term, "", sentence, nil)
+					}
+				}
+				continue
+			}
+			if pos, ok := japaneseCandidatePOS(m); ok && !japaneseStopwords[m.Base] && isJapaneseContentWord(m.Base) {
+				add(m.Base, pos, sentence, func() string { return japaneseReading(m) })
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Count > candidates[j].Count })
+	result := make([]Candidate, len(candidates))
+	for i, c := range candidates {
+		result[i] = *c
+	}
+	return result
+}
+
+// splitSentences は text を文末記号 (。！？.!?) と改行で文に分割します。
+// ピリオドは直後が空白か文末の場合だけ区切りとみなします (3.14 や e.g. の途中で区切らないため)。
+func splitSentences(text string) []string {
+	var sentences []string
+	var b strings.Builder
+	flush := func() {
+		if s := strings.TrimSpace(b.String()); s != "" {
+			sentences = append(sentences, s)
+		}
+		b.Reset()
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' || r == '\r' {
+			flush()
+			continue
+		}
+		b.WriteRune(r)
+		end := false
+		switch r {
+		case '。', '！', '？', '!', '?':
+			end = true
+		case '.':
+			end = i+1 == len(runes) || unicode.IsSpace(runes[i+1]) || strings.ContainsRune(closingBrackets, runes[i+1])
+		}
+		if !end {
+			continue
+		}
+		for i+1 < len(runes) && strings.ContainsRune(closingBrackets, runes[i+1]) {
+			i++
+			b.WriteRune(runes[i])
+		}
+		flush()
+	}
+	flush()
+	return sentences
+}
+
+// englishCandidate は英単語を候補の原形にします。数字を含む語・短い語・機能語は候補にしません。
+// 略語 (NASA 等、すべて大文字の語) は原形にせずそのまま返します。
+func englishCandidate(word string) (string, bool) {
+	letters := 0
+	for _, r := range word {
+		switch {
+		case unicode.IsLetter(r):
+			letters++
+		case r == '\'' || r == '’' || r == '-':
+		default:
+			return "", false
+		}
+	}
+	if letters < minEnglishCandidateLength {
+		return "", false
+	}
+	if strings.ToUpper(word) == word {
+		return word, true
+	}
+	lemma := LemmatizeEnglish(word)
+	if englishStopwords[strings.ToLower(word)] || englishStopwords[lemma] || len(lemma) < minEnglishCandidateLength {
+		return "", false
+	}
+	return lemma, true
+}
+
+// japaneseCandidatePOS は形態素が候補とする内容語であればその品詞を返します (IPA辞書の品詞体系)
+func japaneseCandidatePOS(m morpheme) (string, bool) {
+	switch m.POS {
+	case "名詞":
+		switch m.SubPOS {
+		case "一般", "固有名詞", "サ変接続", "副詞可能":
+			return candidateNoun, true
+		case "形容動詞語幹":
+			return candidateAdjective, true
+		}
+	case "動詞":
+		if m.SubPOS == "自立" {
+			return candidateVerb, true
+		}
+	case "形容詞":
+		if m.SubPOS == "自立" {
+			return candidateAdjective, true
+		}
+	case "副詞":
+		return candidateAdverb, true
+	}
+	return "", false
+}
+
+// isJapaneseContentWord は1文字のかなや記号だけの語など、単語として登録する意味の薄いものを除きます
+func isJapaneseContentWord(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 1 && !unicode.Is(unicode.Han, runes[0]) {
+		return false
+	}
+	return ContainsJapanese(s)
+}
+
+// japaneseReading は形態素の原形の読みをひらがなで返します。漢字を含まない語は読みが不要なため空を返します。
+func japaneseReading(m morpheme) string {
+	if !ContainsKanji(m.Base) {
+		return ""
+	}
+	if m.Base != m.Surface {
+		// 活用している語は、文中の読み (食べ → タベ) ではなく原形の読み (食べる → タベル) を求める
+		reading, _ := JapaneseReading(m.Base)
+		return reading
+	}
+	return KatakanaToHiragana(m.Reading)
+}
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,94 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLemmatizeEnglish(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want string
+	}{
+		{name: "正常系: 原形は小文字にするだけ", word: "Bank", want: "bank"},
+		{name: "正常系: 複数形", word: "banks", want: "bank"},
+		{name: "正常系: esを付けた三人称単数", word: "watches", want: "watch"},
+		{name: "正常系: yをiesにした複数形", word: "studies", want: "study"},
+		{name: "正常系: 短いiesの語", word: "ties", want: "tie"},
+		{name: "正常系: ssで終わる語はそのまま", word: "class", want: "class"},
+		{name: "正常系: ssesの複数形", word: "classes", want: "class"},
+		{name: "正常系: 規則動詞の過去形", word: "walked", want: "walk"},
+		{name: "正常系: eを落とした進行形", word: "making", want: "make"},
+		{name: "正常系: 子音を重ねた進行形", word: "running", want: "run"},
+		{name: "正常系: ateで終わる動詞", word: "created", want: "create"},
+		{name: "正常系: 母音とsで終わる語幹", word: "chased", want: "chase"},
+		{name: "正常系: 子音とlで終わる語幹", word: "handled", want: "handle"},
+		{name: "正常系: 重ねたsは戻さない", word: "passed", want: "pass"},
+		{name: "正常系: 規則の例外の語幹", word: "eating", want: "eat"},
+		{name: "正常系: eedで終わる語はそのまま", word: "need", want: "need"},
+		{name: "正常系: 母音のない語幹は変えない", word: "thing", want: "thing"},
+		{name: "正常系: 不規則動詞", word: "went", want: "go"},
+		{name: "正常系: 所有格", word: "teacher's", want: "teacher"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, textutil.LemmatizeEnglish(tt.word))
+		})
+	}
+}
+
+func TestExtractCandidates(t *testing.T) {
+	t.Run("正常系: 英語は原形にまとめて出現回数の多い順に返す", func(t *testing.T) {
+		got := textutil.ExtractCandidates("The cats chased a mouse. A cat sleeps all day!\nCats love fish.")
+
+		assert.Equal(t, []textutil.Candidate{
+			{Term: "cat", Count: 3, Sentence: "The cats chased a mouse."},
+			{Term: "chase", Count: 1, Sentence: "The cats chased a mouse."},
+			{Term: "mouse", Count: 1, Sentence: "The cats chased a mouse."},
+			{Term: "sleep", Count: 1, Sentence: "A cat sleeps all day!"},
+			{Term: "day", Count: 1, Sentence: "A cat sleeps all day!"},
+			{Term: "love", Count: 1, Sentence: "Cats love fish."},
+			{Term: "fish", Count: 1, Sentence: "Cats love fish."},
+		}, got)
+	})
+
+	t.Run("正常系: 日本語は内容語を原形と読み付きで返す", func(t *testing.T) {
+		got := textutil.ExtractCandidates("昨日りんごを食べました。今日もりんごを食べる。")
+
+		assert.Equal(t, []textutil.Candidate{
+			{Term: "りんご", PartOfSpeech: "noun", Count: 2, Sentence: "昨日りんごを食べました。"},
+			{Term: "食べる", Reading: "たべる", PartOfSpeech: "verb", Count: 2, Sentence: "昨日りんごを食べました。"},
+			{Term: "昨日", Reading: "きのう", PartOfSpeech: "noun", Count: 1, Sentence: "昨日りんごを食べました。"},
+			{Term: "今日", Reading: "きょう", PartOfSpeech: "noun", Count: 1, Sentence: "今日もりんごを食べる。"},
+		}, got)
+	})
+
+	t.Run("正常系: 数字・機能語・略語", func(t *testing.T) {
+		got := textutil.ExtractCandidates("In 2024 NASA launched it.")
+
+		assert.Equal(t, []textutil.Candidate{
+			{Term: "NASA", Count: 1, Sentence: "In 2024 NASA launched it."},
+			{Term: "launch", Count: 1, Sentence: "In 2024 NASA launched it."},
+		}, got)
+	})
+
+	t.Run("正常系: 小数点や閉じ括弧で文を区切らない", func(t *testing.T) {
+		got := textutil.ExtractCandidates("「猫が好き。」と言った。Pi is 3.14 exactly.")
+
+		var sentences []string
+		for _, c := range got {
+			sentences = append(sentences, c.Sentence)
+		}
+		assert.Contains(t, sentences, "「猫が好き。」")
+		assert.Contains(t, sentences, "Pi is 3.14 exactly.")
+	})
+
+	t.Run("異常系: 空のテキスト", func(t *testing.T) {
+		assert.Empty(t, textutil.ExtractCandidates("  \n "))
+	})
+}
@@ -0,0 +1,103 @@
+package textutil
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// jaTokenizer は日本語の形態素解析器 (IPA辞書) を返します。
+// 辞書の読み込みに時間とメモリを使うため、最初に使われたときに一度だけ初期化します。
+var jaTokenizer = sync.OnceValues(func() (*tokenizer.Tokenizer, error) {
+	return tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+})
+
+// morpheme は形態素解析の結果の1語です
+type morpheme struct {
+	Surface string // 文中の表記
+	Base    string // 原形 (辞書にない語は表記と同じ)
+	POS     string // 品詞 (名詞・動詞・助動詞など)
+	SubPOS  string // 品詞細分類 (一般・自立・数など)
+	Reading string // 読み (カタカナ。辞書にない語は空)
+	Start   int    // 文中の開始位置 (バイト)
+	End     int    // 文中の終了位置 (バイト)
+}
+
+// analyzeJapanese は s を形態素に分割します
+func analyzeJapanese(s string) ([]morpheme, error) {
+	t, err := jaTokenizer()
+	if err != nil {
+		return nil, err
+	}
+	tokens := t.Tokenize(s)
+	morphemes := make([]morpheme, 0, len(tokens))
+	for _, tok := range tokens {
+		m := morpheme{
+			Surface: tok.Surface,
+			Base:    tok.Surface,
+			Start:   tok.Position,
+			End:     tok.Position + len(tok.Surface),
+		}
+		if base, ok := tok.BaseForm(); ok && base != "*" {
+			m.Base = base
+		}
+		if pos := tok.POS(); len(pos) > 0 {
+			m.POS = pos[0]
+			if len(pos) > 1 {
+				m.SubPOS = pos[1]
+			}
+		}
+		if reading, ok := tok.Reading(); ok && reading != "*" {
+			m.Reading = reading
+		}
+		morphemes = append(morphemes, m)
+	}
+	return morphemes, nil
+}
+
+// ContainsJapanese は s にひらがな・カタカナ・漢字が含まれるかを返します
+func ContainsJapanese(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
+
+// JapaneseReading は漢字を含む語句の読みをひらがなで返します (IPA辞書による形態素解析)。
+// かな・英数字・記号はそのまま読みに含めます。漢字を含まない場合や、辞書にない漢字を含み読みを決められない場合は false を返します。
+func JapaneseReading(s string) (string, bool) {
+	if !ContainsKanji(s) {
+		return "", false
+	}
+	morphemes, err := analyzeJapanese(s)
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	for _, m := range morphemes {
+		switch {
+		case m.Reading != "":
+			b.WriteString(m.Reading)
+		case ContainsKanji(m.Surface):
+			return "", false
+		default:
+			b.WriteString(m.Surface)
+		}
+	}
+	return KatakanaToHiragana(b.String()), true
+}
+
+// ContainsKanji は s に漢字が含まれるかを返します
+func ContainsKanji(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJapaneseReading(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		want   string
+		wantOK bool
+	}{
+		{name: "正常系: 漢字の名詞", s: "銀行", want: "ぎんこう", wantOK: true},
+		{name: "正常系: 送り仮名を含む動詞", s: "食べる", want: "たべる", wantOK: true},
+		{name: "正常系: 複数の語からなる語句", s: "東京タワーに行く", want: "とうきょうたわーにいく", wantOK: true},
+		{name: "正常系: 英字はそのまま含める", s: "T字路", want: "Tじろ", wantOK: true},
+		{name: "異常系: 漢字を含まない", s: "りんご", wantOK: false},
+		{name: "異常系: 英語", s: "bank", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := textutil.JapaneseReading(tt.s)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package textutil は単語の比較・検索のための文字列処理を提供します。
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions は NormalizeTerm のオプションです
+type NormalizeOptions struct {
+	UnifyKana bool // true の場合、カタカナをひらがなに揃える (「リンゴ」と「りんご」を同一視する)
+}
+
+var caseFolder = cases.Fold()
+
+// NormalizeTerm は単語の重複判定に使う正規化済みの文字列を返します。
+//   - Unicode NFKC 正規化 (全角英数字・半角カナ・互換文字を統一)
+//   - ケースフォールディング (大文字小文字を同一視)
+//   - 前後の空白の除去と、連続する空白の1文字への集約
+//   - (オプション) カタカナのひらがなへの統一
+func NormalizeTerm(s string, opts NormalizeOptions) string {
+	s = norm.NFKC.String(s)
+	s = caseFolder.String(s)
+	s = strings.Join(strings.Fields(s), " ")
+	if opts.UnifyKana {
+		s = KatakanaToHiragana(s)
+	}
+	return s
+}
+
+// KatakanaToHiragana はカタカナをひらがなに変換します。対応するひらがながない文字 (ヷ等) はそのまま残します。
+func KatakanaToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'ァ' && r <= 'ヶ':
+			return r - ('ァ' - 'ぁ')
+		case r == 'ヽ' || r == 'ヾ':
+			return r - ('ヽ' - 'ゝ')
+		default:
+			return r
+		}
+	}, s)
+}
+
+// stripMarks は結合文字 (アクセント記号や濁点) を除去します
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// SimilarityKey は「ほぼ同じ」単語をまとめるためのキーを返します。重複の可能性がある単語の報告に使います。
+// NormalizeTerm (カナ統一あり) に加え、アクセント記号・濁点、空白、記号、長音符を無視します。
+// 例: "Café" と "cafe"、"e-mail" と "email"、"コーヒー" と "コーヒ" はそれぞれ同じキーになる。
+func SimilarityKey(s string) string {
+	s = NormalizeTerm(s, NormalizeOptions{UnifyKana: true})
+	if stripped, _, err := transform.String(stripMarks, s); err == nil {
+		s = stripped
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || r == 'ー' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,56 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"go_4_vocab_keep/internal/textutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTerm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  textutil.NormalizeOptions
+		want  string
+	}{
+		{name: "正常系: 大文字小文字を同一視", input: "Apple", want: "apple"},
+		{name: "正常系: 全角英数字を半角に", input: "ＡＰＰＬＥ１", want: "apple1"},
+		{name: "正常系: 半角カナを全角に", input: "ﾘﾝｺﾞ", want: "リンゴ"},
+		{name: "正常系: 前後の空白を除去し、連続する空白を1つに", input: "  ice　\t cream ", want: "ice cream"},
+		{name: "正常系: ドイツ語のエスツェットはケースフォールディングで ss になる", input: "Straße", want: "strasse"},
+		{name: "正常系: カナ統一なしではカタカナのまま", input: "リンゴ", want: "リンゴ"},
+		{name: "正常系: カナ統一ありではひらがなに揃える", input: "リンゴ", opts: textutil.NormalizeOptions{UnifyKana: true}, want: "りんご"},
+		{name: "正常系: 半角カナもカナ統一の対象", input: "ﾘﾝｺﾞ", opts: textutil.NormalizeOptions{UnifyKana: true}, want: "りんご"},
+		{name: "正常系: 長音符や漢字はそのまま", input: "コーヒー牛乳", opts: textutil.NormalizeOptions{UnifyKana: true}, want: "こーひー牛乳"},
+		{name: "正常系: 空文字", input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, textutil.NormalizeTerm(tt.input, tt.opts))
+		})
+	}
+}
+
+func TestSimilarityKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{name: "正常系: アクセント記号を無視", a: "Café", b: "cafe", same: true},
+		{name: "正常系: ハイフンと空白を無視", a: "e-mail", b: "E mail", same: true},
+		{name: "正常系: カタカナとひらがなを同一視", a: "リンゴ", b: "りんご", same: true},
+		{name: "正常系: 長音符を無視", a: "コーヒー", b: "コーヒ", same: true},
+		{name: "正常系: 句読点を無視", a: "Hello!", b: "hello", same: true},
+		{name: "正常系: 異なる単語は別のキー", a: "apple", b: "apply", same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, textutil.SimilarityKey(tt.a) == textutil.SimilarityKey(tt.b))
+		})
+	}
+}
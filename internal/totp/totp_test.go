@@ -0,0 +1,80 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret は RFC 6238 付録B の SHA1 のテストベクタの秘密鍵 ("12345678901234567890")
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238(t *testing.T) {
+	// RFC 6238 の8桁のコードの下6桁
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		got, err := totp.Code(rfcSecret, totp.Step(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	prev, err := totp.Code(rfcSecret, totp.Step(now)-1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "正常系: 現在のコード", input: "050471", skew: 1, wantStep: totp.Step(now), wantOK: true},
+		{name: "正常系: 空白を含むコード", input: "050 471", skew: 1, wantStep: totp.Step(now), wantOK: true},
+		{name: "正常系: 1つ前のステップのコード", input: prev, skew: 1, wantStep: totp.Step(now) - 1, wantOK: true},
+		{name: "異常系: 許容範囲外のステップのコード", input: prev, skew: 0},
+		{name: "異常系: 一致しないコード", input: "123456", skew: 1},
+		{name: "異常系: 桁数が違う", input: "50471", skew: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := totp.Validate(rfcSecret, tt.input, now, tt.skew)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantStep, step)
+		})
+	}
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+	_, err = totp.Code(secret, 1)
+	require.NoError(t, err)
+
+	u, err := url.Parse(totp.URI("Kioku", "user@example.com", secret))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Kioku:user@example.com", u.Path)
+	assert.Equal(t, secret, u.Query().Get("secret"))
+	assert.Equal(t, "Kioku", u.Query().Get("issuer"))
+}
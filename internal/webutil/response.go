@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"go_4_vocab_keep/internal/model" // プロジェクトのモジュールパスに合わせてください
 
@@ -27,6 +29,10 @@ func HandleError(w http.ResponseWriter, logger *slog.Logger, err error) {
 
 	if errors.As(err, &appErr) {
 		errResp = model.APIErrorResponse{Error: appErr.Detail}
+		if appErr.RetryAfter > 0 {
+			// 秒単位に切り上げる
+			w.Header().Set("Retry-After", strconv.FormatInt(int64((appErr.RetryAfter+time.Second-1)/time.Second), 10))
+		}
 	} else {
 		// ★受け取ったロガーでエラーを出力
 		logger.Error("Unhandled error occurred", slog.Any("error", err))
@@ -59,6 +65,10 @@ func MapErrorToStatusCode(err error) int {
 		return http.StatusConflict // 409 Conflict
 	case errors.Is(err, model.ErrForbidden) || errors.Is(err, model.ErrTenantNotFound):
 		return http.StatusForbidden
+	case errors.Is(err, model.ErrTooManyRequests):
+		return http.StatusTooManyRequests
+	case errors.Is(err, model.ErrUnavailable):
+		return http.StatusServiceUnavailable
 	default:
 		// ハンドリングされていないエラーは内部サーバーエラーとして扱う
 		return http.StatusInternalServerError
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strings"
 
+	"go_4_vocab_keep/internal/model"
+
 	"github.com/go-playground/locales/ja" // 日本語ロケール
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
@@ -18,11 +20,35 @@ var Validator *validator.Validate
 var Trans ut.Translator
 
 var fieldNameTranslations = map[string]string{
-	"name":       "名前",
-	"term":       "単語",
-	"definition": "意味",
-	"email":      "メールアドレス",
-	"is_correct": "回答の正誤",
+	"name":             "名前",
+	"term":             "単語",
+	"definition":       "意味",
+	"email":            "メールアドレス",
+	"is_correct":       "回答の正誤",
+	"file":             "ファイル",
+	"format":           "形式",
+	"level":            "レベル",
+	"word_ids":         "単語ID",
+	"action":           "操作",
+	"find":             "置換する文字列",
+	"reading":          "読み",
+	"part_of_speech":   "品詞",
+	"examples":         "例文",
+	"sentence":         "例文",
+	"translation":      "訳",
+	"notes":            "メモ",
+	"senses":           "語義",
+	"example":          "例文",
+	"tag":              "タグ",
+	"direction":        "出題形式",
+	"text":             "テキスト",
+	"limit":            "件数",
+	"code":             "認証コード",
+	"password":         "パスワード",
+	"mfa_token":        "MFAトークン",
+	"current_password": "現在のパスワード",
+	"new_password":     "新しいパスワード",
+	"new_email":        "新しいメールアドレス",
 	// ... 他のフィールドもここに追加 ...
 }
 
@@ -39,6 +65,13 @@ func init() {
 		return name
 	})
 
+	// アプリケーション固有のバリデーション
+	if err := Validator.RegisterValidation("part_of_speech", func(fl validator.FieldLevel) bool {
+		return model.IsValidPartOfSpeech(fl.Field().String())
+	}); err != nil {
+		log.Fatal(err)
+	}
+
 	// --- ここからが日本語化の処理 ---
 
 	// 日本語のロケールとトランスレータを設定
@@ -81,8 +114,10 @@ func init() {
 
 	// 例: "required" タグのメッセージをよりシンプルにする
 	registerTranslation("required", "{0}は必須項目です。")
+	registerTranslation("required_unless", "{0}は必須項目です。")
 	// 例: "email" タグのメッセージ
 	registerTranslation("email", "{0}は有効なメールアドレス形式ではありません。")
+	registerTranslation("part_of_speech", "{0}は有効な品詞ではありません。")
 	// --- min タグの修正 ---
 	Validator.RegisterTranslation("min", Trans, func(ut ut.Translator) error {
 		// メッセージテンプレートの登録
@@ -0,0 +1,353 @@
+package wordio
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"modernc.org/sqlite" // cgo不要のSQLiteドライバ (本番イメージはCGO無効でビルドされるため)
+)
+
+// Ankiのパッケージ内のコレクションファイル名 (新しい形式ほど優先する)
+const (
+	ankiCollection21b = "collection.anki21b" // Anki 2.1.50以降: zstd圧縮・スキーマ18
+	ankiCollection21  = "collection.anki21"  // Anki 2.1: スキーマ11
+	ankiCollection2   = "collection.anki2"   // 旧形式 (新しいAnkiでは互換用のダミーが入っていることがある)
+)
+
+// Ankiのカードの種類 (cards.type)
+const (
+	ankiCardTypeNew        = 0
+	ankiCardTypeLearning   = 1
+	ankiCardTypeReview     = 2
+	ankiCardTypeRelearning = 3
+)
+
+// ankiFieldSeparator は notes.flds のフィールド区切り文字
+const ankiFieldSeparator = "\x1f"
+
+// DefaultMaxAnkiCollectionSize は AnkiOptions.MaxCollectionSize を指定しなかった場合の、展開後のコレクションの最大サイズ (256MB)
+const DefaultMaxAnkiCollectionSize = 256 << 20
+
+// ankiZstdMaxWindow は zstd の展開に使うウィンドウの上限 (Anki が使う圧縮レベルでは数MBに収まる)
+const ankiZstdMaxWindow = 32 << 20
+
+var (
+	ErrInvalidAnkiPackage     = errors.New("invalid anki package")
+	ErrFieldNotFound          = errors.New("field not found in note type")
+	ErrAnkiCollectionTooLarge = errors.New("anki collection is too large")
+)
+
+// ReviewState はインポート元での復習スケジュールです。新規カードの場合は nil になります。
+type ReviewState struct {
+	IntervalDays int       // 直近の復習間隔 (日)
+	Lapses       int       // 忘れた回数
+	Due          time.Time // 次回の復習予定日
+}
+
+// AnkiOptions は .apkg/.colpkg 読み込み時のオプションです
+type AnkiOptions struct {
+	TermField       string // 単語として使うフィールド名 (空ならノートタイプの1番目のフィールド)
+	DefinitionField string // 意味として使うフィールド名 (空ならノートタイプの2番目のフィールド)
+	WithScheduling  bool   // true の場合、カードの復習状態を Record.Review に設定する
+	// MaxCollectionSize は展開後のコレクションの最大サイズ (バイト)。0 なら DefaultMaxAnkiCollectionSize。
+	// 小さなパッケージが巨大なファイルに展開されてディスクを使い切らないようにするための制限
+	MaxCollectionSize int64
+}
+
+func init() {
+	// Anki 2.1.50以降のスキーマは独自の照合順序 "unicase" を使うため、同名のものを登録しておく
+	_ = sqlite.RegisterCollationUtf8("unicase", func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+}
+
+// ReadAnki は Anki の .apkg/.colpkg (SQLiteのコレクションとメディアを含むzip) からノートを読み込みます。
+// 1ノート1レコードとし、スケジュールはノートの最初のカードのものを使います。メディアは読み込みません。
+func ReadAnki(r io.ReaderAt, size int64, opts AnkiOptions) ([]Record, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+	}
+
+	limit := opts.MaxCollectionSize
+	if limit <= 0 {
+		limit = DefaultMaxAnkiCollectionSize
+	}
+	path, cleanup, err := extractCollection(zr, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("wordio.ReadAnki: failed to open collection: %w", err)
+	}
+	defer db.Close()
+
+	return readAnkiCollection(db, opts)
+}
+
+// extractCollection はzip内のコレクションを一時ファイルに展開し、そのパスを返します。
+// 展開後のサイズが limit を超える場合は ErrAnkiCollectionTooLarge を返します。
+func extractCollection(zr *zip.Reader, limit int64) (string, func(), error) {
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var src *zip.File
+	compressed := false
+	switch {
+	case files[ankiCollection21b] != nil:
+		src, compressed = files[ankiCollection21b], true
+	case files[ankiCollection21] != nil:
+		src = files[ankiCollection21]
+	case files[ankiCollection2] != nil:
+		src = files[ankiCollection2]
+	default:
+		return "", nil, fmt.Errorf("%w: collection file not found", ErrInvalidAnkiPackage)
+	}
+
+	// zip のヘッダーの展開後サイズは偽装できるので、ここでの確認に加えて展開しながらも制限する
+	if src.UncompressedSize64 > uint64(limit) {
+		return "", nil, ErrAnkiCollectionTooLarge
+	}
+	rc, err := src.Open()
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+	}
+	defer rc.Close()
+
+	var in io.Reader = rc
+	if compressed {
+		dec, err := zstd.NewReader(rc, zstd.WithDecoderMaxMemory(uint64(limit)), zstd.WithDecoderMaxWindow(ankiZstdMaxWindow))
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+		}
+		defer dec.Close()
+		in = dec
+	}
+
+	tmp, err := os.CreateTemp("", "anki-collection-*.sqlite")
+	if err != nil {
+		return "", nil, fmt.Errorf("wordio.extractCollection: %w", err)
+	}
+	cleanup := func() { os.Remove(tmp.Name()) }
+	n, err := io.Copy(tmp, io.LimitReader(in, limit+1))
+	if err == nil && n > limit {
+		err = ErrAnkiCollectionTooLarge
+	}
+	if err != nil {
+		tmp.Close()
+		cleanup()
+		if errors.Is(err, ErrAnkiCollectionTooLarge) || errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+			return "", nil, ErrAnkiCollectionTooLarge
+		}
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("wordio.extractCollection: %w", err)
+	}
+	return tmp.Name(), cleanup, nil
+}
+
+func readAnkiCollection(db *sql.DB, opts AnkiOptions) ([]Record, error) {
+	var crt int64
+	if err := db.QueryRow("SELECT crt FROM col").Scan(&crt); err != nil {
+		return nil, fmt.Errorf("%w: failed to read col: %v", ErrInvalidAnkiPackage, err)
+	}
+	collectionCreated := time.Unix(crt, 0)
+
+	fieldNames, err := readFieldNames(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedules map[int64]*ReviewState
+	if opts.WithScheduling {
+		schedules, err = readSchedules(db, collectionCreated)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := db.Query("SELECT id, mid, tags, flds FROM notes ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read notes: %v", ErrInvalidAnkiPackage, err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var (
+			noteID, modelID int64
+			tags, flds      string
+		)
+		if err := rows.Scan(&noteID, &modelID, &tags, &flds); err != nil {
+			return nil, fmt.Errorf("%w: failed to scan note: %v", ErrInvalidAnkiPackage, err)
+		}
+		if len(records) >= MaxRecords {
+			return nil, fmt.Errorf("%w: limit is %d notes", ErrTooManyRecords, MaxRecords)
+		}
+
+		names := fieldNames[modelID]
+		termIdx, err := fieldIndex(names, opts.TermField, 0)
+		if err != nil {
+			return nil, err
+		}
+		defIdx, err := fieldIndex(names, opts.DefinitionField, 1)
+		if err != nil {
+			return nil, err
+		}
+		values := strings.Split(flds, ankiFieldSeparator)
+
+		records = append(records, Record{
+			Row:        len(records) + 1, // Ankiには行番号がないため、ノートの通し番号を使う
+			Term:       CleanAnkiField(fieldAt(values, termIdx)),
+			Definition: CleanAnkiField(fieldAt(values, defIdx)),
+			Tags:       strings.Fields(tags),
+			Review:     schedules[noteID],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+	}
+	return records, nil
+}
+
+// readFieldNames はノートタイプごとのフィールド名を ord 順で返します。
+// スキーマ18では fields テーブル、スキーマ11では col.models のJSONに格納されています。
+func readFieldNames(db *sql.DB) (map[int64][]string, error) {
+	result := make(map[int64][]string)
+
+	var hasFieldsTable int
+	if err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'fields'").Scan(&hasFieldsTable); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+	}
+
+	if hasFieldsTable > 0 {
+		rows, err := db.Query("SELECT ntid, name FROM fields ORDER BY ntid, ord")
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read fields: %v", ErrInvalidAnkiPackage, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var ntid int64
+			var name string
+			if err := rows.Scan(&ntid, &name); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+			}
+			result[ntid] = append(result[ntid], name)
+		}
+		return result, rows.Err()
+	}
+
+	var modelsJSON string
+	if err := db.QueryRow("SELECT models FROM col").Scan(&modelsJSON); err != nil {
+		return nil, fmt.Errorf("%w: failed to read models: %v", ErrInvalidAnkiPackage, err)
+	}
+	var models map[string]struct {
+		ID   int64 `json:"id"`
+		Flds []struct {
+			Name string `json:"name"`
+			Ord  int    `json:"ord"`
+		} `json:"flds"`
+	}
+	if err := json.Unmarshal([]byte(modelsJSON), &models); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse models: %v", ErrInvalidAnkiPackage, err)
+	}
+	for _, m := range models {
+		names := make([]string, len(m.Flds))
+		for _, f := range m.Flds {
+			if f.Ord >= 0 && f.Ord < len(names) {
+				names[f.Ord] = f.Name
+			}
+		}
+		result[m.ID] = names
+	}
+	return result, nil
+}
+
+// readSchedules はノートごとに最初のカード (ord が最小のもの) の復習状態を返します
+func readSchedules(db *sql.DB, collectionCreated time.Time) (map[int64]*ReviewState, error) {
+	rows, err := db.Query("SELECT nid, type, due, ivl, lapses FROM cards ORDER BY nid, ord")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read cards: %v", ErrInvalidAnkiPackage, err)
+	}
+	defer rows.Close()
+
+	result := make(map[int64]*ReviewState)
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var nid, cardType, due, ivl, lapses int64
+		if err := rows.Scan(&nid, &cardType, &due, &ivl, &lapses); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+		}
+		if seen[nid] {
+			continue
+		}
+		seen[nid] = true
+
+		switch cardType {
+		case ankiCardTypeReview:
+			// 復習カードの due はコレクション作成日からの日数
+			result[nid] = &ReviewState{
+				IntervalDays: int(ivl),
+				Lapses:       int(lapses),
+				Due:          collectionCreated.AddDate(0, 0, int(due)),
+			}
+		case ankiCardTypeLearning, ankiCardTypeRelearning:
+			// 学習中のカードはすぐに復習対象にする
+			result[nid] = &ReviewState{
+				IntervalDays: 0,
+				Lapses:       int(lapses),
+				Due:          time.Now(),
+			}
+		case ankiCardTypeNew:
+			// 新規カードは nil のまま (通常の新規登録と同じ扱い)
+		}
+	}
+	return result, rows.Err()
+}
+
+func fieldIndex(names []string, name string, defaultIdx int) (int, error) {
+	if name == "" {
+		return defaultIdx, nil
+	}
+	for i, n := range names {
+		if strings.EqualFold(strings.TrimSpace(n), strings.TrimSpace(name)) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+}
+
+var (
+	ankiSoundTag   = regexp.MustCompile(`\[sound:[^\]]*\]`)
+	ankiLineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>|</li>`)
+	ankiHTMLTag    = regexp.MustCompile(`<[^>]*>`)
+	ankiBlankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// CleanAnkiField はAnkiのフィールド値 (HTML) をプレーンテキストに変換します
+func CleanAnkiField(s string) string {
+	s = ankiSoundTag.ReplaceAllString(s, "")
+	s = ankiLineBreaks.ReplaceAllString(s, "\n")
+	s = ankiHTMLTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, " ", " ") // &nbsp;
+	s = ankiBlankLines.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
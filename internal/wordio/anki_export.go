@@ -0,0 +1,261 @@
+package wordio
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ankiSchema11 は Anki 2.1 (スキーマ11) のコレクションのテーブル定義。
+// Anki本体がインポート時に参照するテーブルはすべて作成しておく。
+var ankiSchema11 = []string{
+	`CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null, ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null, models text not null, decks text not null, dconf text not null, tags text not null)`,
+	`CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null, usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null, flags integer not null, data text not null)`,
+	`CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null, mod integer not null, usn integer not null, type integer not null, queue integer not null, due integer not null, ivl integer not null, factor integer not null, reps integer not null, lapses integer not null, left integer not null, odue integer not null, odid integer not null, flags integer not null, data text not null)`,
+	`CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ease integer not null, ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null, type integer not null)`,
+	`CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)`,
+	`CREATE INDEX ix_notes_usn on notes (usn)`,
+	`CREATE INDEX ix_cards_usn on cards (usn)`,
+	`CREATE INDEX ix_cards_nid on cards (nid)`,
+	`CREATE INDEX ix_cards_sched on cards (did, queue, due)`,
+}
+
+// エクスポートするノートタイプとデッキのID (Ankiはミリ秒のタイムスタンプを使う慣習だが、固定値で問題ない)
+const (
+	ankiExportModelID = 1700000000000
+	ankiExportDeckID  = 1700000000001
+	ankiExportName    = "Vocab Keep"
+	ankiDefaultFactor = 2500 // 新規カードの初期の易しさ (250%)
+)
+
+// ankiLevelIntervals は学習レベルをAnkiの復習間隔 (日) に変換する表。
+// インポート時の変換 (3日以上→Level2, 7日以上→Level3) と往復で一致するようにしている。
+var ankiLevelIntervals = map[int]int{2: 3, 3: 7}
+
+// ankiExporter は単語を Anki のパッケージ (.apkg) として書き出します。
+// zip は末尾に目次を持つ形式のため、コレクションを一時ファイルに作り、Close 時にまとめて書き出します。
+type ankiExporter struct {
+	w      io.Writer
+	dir    string
+	db     *sql.DB
+	tx     *sql.Tx
+	crt    time.Time
+	count  int64
+	closed bool
+}
+
+func newAnkiExporter(w io.Writer) (*ankiExporter, error) {
+	dir, err := os.MkdirTemp("", "anki-export-*")
+	if err != nil {
+		return nil, fmt.Errorf("wordio.newAnkiExporter: %w", err)
+	}
+	e := &ankiExporter{
+		w:   w,
+		dir: dir,
+		crt: time.Now().UTC().Truncate(24 * time.Hour),
+	}
+	if err := e.init(); err != nil {
+		e.cleanup()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *ankiExporter) init() error {
+	db, err := sql.Open("sqlite", e.collectionPath())
+	if err != nil {
+		return fmt.Errorf("wordio.newAnkiExporter: %w", err)
+	}
+	e.db = db
+
+	for _, stmt := range ankiSchema11 {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("wordio.newAnkiExporter: %w", err)
+		}
+	}
+
+	models, decks, dconf := ankiCollectionJSON(e.crt)
+	now := time.Now()
+	if _, err := db.Exec(`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, '{}', ?, ?, ?, '{}')`,
+		e.crt.Unix(), now.UnixMilli(), now.UnixMilli(), models, decks, dconf); err != nil {
+		return fmt.Errorf("wordio.newAnkiExporter: %w", err)
+	}
+
+	e.tx, err = db.Begin()
+	if err != nil {
+		return fmt.Errorf("wordio.newAnkiExporter: %w", err)
+	}
+	return nil
+}
+
+func (e *ankiExporter) collectionPath() string {
+	return e.dir + string(os.PathSeparator) + ankiCollection21
+}
+
+func (e *ankiExporter) Write(rec ExportRecord) error {
+	if e.closed {
+		return ErrExporterClosed
+	}
+	e.count++
+	id := ankiExportModelID + e.count // ノートID・カードIDは一意であればよい
+	mod := rec.UpdatedAt.Unix()
+	front := ankiFieldValue(rec.Term)
+	flds := front + ankiFieldSeparator + ankiFieldValue(rec.Definition)
+	tags := ""
+	if len(rec.Tags) > 0 {
+		tags = " " + strings.Join(rec.Tags, " ") + " " // Ankiのタグは前後に空白を付けて保存する
+	}
+
+	if _, err := e.tx.Exec(`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+		id, ankiGUID(rec.WordID), ankiExportModelID, mod, tags, flds, rec.Term, ankiChecksum(rec.Term)); err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Write: %w", err)
+	}
+
+	// 学習中の単語は復習カード、それ以外は新規カードとして書き出す
+	cardType, queue, due, ivl := ankiCardTypeNew, 0, e.count, 0
+	if interval, ok := ankiLevelIntervals[rec.Level]; ok && rec.NextReviewDate != nil {
+		cardType, queue, ivl = ankiCardTypeReview, ankiCardTypeReview, interval
+		due = int64(rec.NextReviewDate.UTC().Truncate(24*time.Hour).Sub(e.crt).Hours() / 24)
+	}
+	if _, err := e.tx.Exec(`INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, ?, ?, ?, ?, ?, 0, 0, 0, 0, 0, 0, '')`,
+		id, id, ankiExportDeckID, mod, cardType, queue, due, ivl, ankiDefaultFactor); err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Write: %w", err)
+	}
+	return nil
+}
+
+func (e *ankiExporter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	defer e.cleanup()
+
+	if err := e.tx.Commit(); err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+	if err := e.db.Close(); err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+
+	collection, err := os.Open(e.collectionPath())
+	if err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+	defer collection.Close()
+
+	zw := zip.NewWriter(e.w)
+	cw, err := zw.Create(ankiCollection21)
+	if err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+	if _, err := io.Copy(cw, collection); err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+	mw, err := zw.Create("media")
+	if err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+	if _, err := mw.Write([]byte("{}")); err != nil {
+		return fmt.Errorf("wordio.ankiExporter.Close: %w", err)
+	}
+	return zw.Close()
+}
+
+func (e *ankiExporter) cleanup() {
+	if e.db != nil {
+		e.db.Close()
+	}
+	os.RemoveAll(e.dir)
+}
+
+// ankiFieldValue はプレーンテキストをAnkiのフィールド値 (HTML) に変換します (CleanAnkiField の逆変換)
+func ankiFieldValue(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
+
+// ankiGUID はノートのGUIDを返します。同じ単語を再エクスポートした際にAnki側で同一ノートとして更新されるよう、単語IDから決める
+func ankiGUID(wordID string) string {
+	if id, err := uuid.Parse(wordID); err == nil {
+		return strings.ReplaceAll(id.String(), "-", "")[:10]
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// ankiChecksum はAnkiの重複検出用チェックサム (先頭フィールドのSHA1の先頭32bit)
+func ankiChecksum(s string) int64 {
+	sum := sha1.Sum([]byte(s))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+// ankiCollectionJSON は col テーブルに格納するノートタイプ・デッキ・デッキ設定のJSONを返します
+func ankiCollectionJSON(crt time.Time) (models, decks, dconf string) {
+	mod := crt.Unix()
+	field := func(name string, ord int) map[string]any {
+		return map[string]any{"name": name, "ord": ord, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []any{}}
+	}
+	m := map[string]any{
+		strconv.Itoa(ankiExportModelID): map[string]any{
+			"id":    ankiExportModelID,
+			"name":  ankiExportName,
+			"type":  0,
+			"mod":   mod,
+			"usn":   -1,
+			"sortf": 0,
+			"did":   ankiExportDeckID,
+			"flds":  []any{field("Front", 0), field("Back", 1)},
+			"tmpls": []any{map[string]any{
+				"name":  "Card 1",
+				"ord":   0,
+				"qfmt":  "{{Front}}",
+				"afmt":  "{{FrontSide}}\n\n<hr id=answer>\n\n{{Back}}",
+				"bqfmt": "",
+				"bafmt": "",
+				"did":   nil,
+			}},
+			"css":       ".card { font-family: arial; font-size: 20px; text-align: center; }",
+			"latexPre":  "",
+			"latexPost": "",
+			"req":       []any{[]any{0, "any", []any{0}}},
+			"tags":      []any{},
+			"vers":      []any{},
+		},
+	}
+	deck := func(id int64, name string) map[string]any {
+		return map[string]any{
+			"id": id, "name": name, "mod": mod, "usn": -1, "conf": 1, "desc": "", "dyn": 0, "collapsed": false,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+			"extendNew": 10, "extendRev": 50,
+		}
+	}
+	d := map[string]any{
+		"1":                            deck(1, "Default"),
+		strconv.Itoa(ankiExportDeckID): deck(ankiExportDeckID, ankiExportName),
+	}
+	c := map[string]any{
+		"1": map[string]any{
+			"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+			"new":   map[string]any{"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": ankiDefaultFactor, "order": 1, "perDay": 20},
+			"rev":   map[string]any{"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500},
+			"lapse": map[string]any{"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": ankiLeechFails, "leechAction": 1},
+		},
+	}
+	mb, _ := json.Marshal(m)
+	db, _ := json.Marshal(d)
+	cb, _ := json.Marshal(c)
+	return string(mb), string(db), string(cb)
+}
+
+// ankiLeechFails はAnkiの既定のリーチ判定の回数
+const ankiLeechFails = 8
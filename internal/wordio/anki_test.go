@@ -0,0 +1,200 @@
+package wordio_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/wordio"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ankiCrt はテスト用コレクションの作成日時
+var ankiCrt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// buildAnkiCollection は最小限のテーブルだけを持つAnkiのコレクション (SQLite) を作成し、そのバイト列を返します。
+// schema18 が true の場合は、フィールド名を col.models ではなく fields テーブルに格納します。
+func buildAnkiCollection(t *testing.T, schema18 bool) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "collection.sqlite")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	models := `{"1001":{"id":1001,"flds":[{"name":"Front","ord":0},{"name":"Back","ord":1},{"name":"Reading","ord":2}]}}`
+	stmts := []string{
+		`CREATE TABLE col (id INTEGER PRIMARY KEY, crt INTEGER NOT NULL, models TEXT NOT NULL)`,
+		`CREATE TABLE notes (id INTEGER PRIMARY KEY, mid INTEGER NOT NULL, tags TEXT NOT NULL, flds TEXT NOT NULL)`,
+		`CREATE TABLE cards (id INTEGER PRIMARY KEY, nid INTEGER NOT NULL, ord INTEGER NOT NULL, type INTEGER NOT NULL, due INTEGER NOT NULL, ivl INTEGER NOT NULL, lapses INTEGER NOT NULL)`,
+	}
+	if schema18 {
+		models = ""
+		stmts = append(stmts,
+			`CREATE TABLE fields (ntid INTEGER NOT NULL, ord INTEGER NOT NULL, name TEXT NOT NULL COLLATE unicase, PRIMARY KEY (ntid, ord))`,
+			`INSERT INTO fields VALUES (1001, 1, 'Back'), (1001, 0, 'Front'), (1001, 2, 'Reading')`,
+		)
+	}
+	stmts = append(stmts,
+		`INSERT INTO col VALUES (1, `+strconv.FormatInt(ankiCrt.Unix(), 10)+`, '`+models+`')`,
+		"INSERT INTO notes VALUES (1, 1001, ' vocab n5 ', 'apple\x1f<div>りんご</div><br>[sound:apple.mp3]\x1fアップル')",
+		"INSERT INTO notes VALUES (2, 1001, '', 'dog\x1f犬&amp;狗\x1fドッグ')",
+		"INSERT INTO notes VALUES (3, 1001, 'leech', 'cat\x1f猫\x1fキャット')",
+		// note 1: 復習カード (ord 1 のカードは無視される)
+		`INSERT INTO cards VALUES (10, 1, 0, 2, 30, 10, 1), (11, 1, 1, 0, 0, 0, 0)`,
+		// note 2: 新規カード
+		`INSERT INTO cards VALUES (20, 2, 0, 0, 5, 0, 0)`,
+		// note 3: 再学習中のカード
+		`INSERT INTO cards VALUES (30, 3, 0, 3, 1700000000, 1, 9)`,
+	)
+	for _, stmt := range stmts {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+	require.NoError(t, db.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return b
+}
+
+func buildZip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func zstdCompress(t *testing.T, b []byte) []byte {
+	t.Helper()
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer enc.Close()
+	return enc.EncodeAll(b, nil)
+}
+
+func TestReadAnki(t *testing.T) {
+	legacy := buildZip(t, map[string][]byte{
+		"collection.anki2": buildAnkiCollection(t, false),
+		"media":            []byte("{}"),
+	})
+	latest := buildZip(t, map[string][]byte{
+		// 新しい形式のパッケージには互換用のダミーの anki2 も含まれるが、anki21b を優先する
+		"collection.anki2":   []byte("not a database"),
+		"collection.anki21b": zstdCompress(t, buildAnkiCollection(t, true)),
+	})
+
+	tests := []struct {
+		name    string
+		input   []byte
+		opts    wordio.AnkiOptions
+		want    []wordio.Record
+		wantErr error
+	}{
+		{
+			name:  "正常系: スキーマ11 (デフォルトのフィールド・HTMLとタグの除去)",
+			input: legacy,
+			opts:  wordio.AnkiOptions{},
+			want: []wordio.Record{
+				{Row: 1, Term: "apple", Definition: "りんご", Tags: []string{"vocab", "n5"}},
+				{Row: 2, Term: "dog", Definition: "犬&狗", Tags: []string{}},
+				{Row: 3, Term: "cat", Definition: "猫", Tags: []string{"leech"}},
+			},
+		},
+		{
+			name:  "正常系: スキーマ18 (zstd圧縮) とフィールド名の指定",
+			input: latest,
+			opts:  wordio.AnkiOptions{TermField: "reading", DefinitionField: "Front"},
+			want: []wordio.Record{
+				{Row: 1, Term: "アップル", Definition: "apple", Tags: []string{"vocab", "n5"}},
+				{Row: 2, Term: "ドッグ", Definition: "dog", Tags: []string{}},
+				{Row: 3, Term: "キャット", Definition: "cat", Tags: []string{"leech"}},
+			},
+		},
+		{
+			name:    "異常系: 存在しないフィールド",
+			input:   legacy,
+			opts:    wordio.AnkiOptions{TermField: "Example"},
+			wantErr: wordio.ErrFieldNotFound,
+		},
+		{
+			name:    "異常系: 展開後のコレクションが上限を超える",
+			input:   legacy,
+			opts:    wordio.AnkiOptions{MaxCollectionSize: 1024},
+			wantErr: wordio.ErrAnkiCollectionTooLarge,
+		},
+		{
+			name: "異常系: zstdの展開後のサイズが上限を超える (圧縮率の高いデータ)",
+			input: buildZip(t, map[string][]byte{
+				"collection.anki21b": zstdCompress(t, make([]byte, 4<<20)),
+			}),
+			opts:    wordio.AnkiOptions{MaxCollectionSize: 1 << 20},
+			wantErr: wordio.ErrAnkiCollectionTooLarge,
+		},
+		{
+			name:    "異常系: zipではない",
+			input:   []byte("term,definition\n"),
+			wantErr: wordio.ErrInvalidAnkiPackage,
+		},
+		{
+			name:    "異常系: コレクションを含まないzip",
+			input:   buildZip(t, map[string][]byte{"media": []byte("{}")}),
+			wantErr: wordio.ErrInvalidAnkiPackage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordio.ReadAnki(bytes.NewReader(tt.input), int64(len(tt.input)), tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].Row, got[i].Row)
+				assert.Equal(t, tt.want[i].Term, got[i].Term)
+				assert.Equal(t, tt.want[i].Definition, got[i].Definition)
+				assert.ElementsMatch(t, tt.want[i].Tags, got[i].Tags)
+				assert.Nil(t, got[i].Review, "WithScheduling でない場合はスケジュールを読み込まない")
+			}
+		})
+	}
+}
+
+func TestReadAnki_WithScheduling(t *testing.T) {
+	input := buildZip(t, map[string][]byte{"collection.anki21": buildAnkiCollection(t, false)})
+
+	got, err := wordio.ReadAnki(bytes.NewReader(input), int64(len(input)), wordio.AnkiOptions{WithScheduling: true})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	// 復習カード: due はコレクション作成日からの日数
+	require.NotNil(t, got[0].Review)
+	assert.Equal(t, 10, got[0].Review.IntervalDays)
+	assert.Equal(t, 1, got[0].Review.Lapses)
+	assert.True(t, ankiCrt.AddDate(0, 0, 30).Equal(got[0].Review.Due))
+
+	// 新規カード: スケジュールなし
+	assert.Nil(t, got[1].Review)
+
+	// 再学習中のカード: すぐに復習対象
+	require.NotNil(t, got[2].Review)
+	assert.Equal(t, 9, got[2].Review.Lapses)
+	assert.WithinDuration(t, time.Now(), got[2].Review.Due, time.Minute)
+}
@@ -0,0 +1,175 @@
+// Package wordio は単語データのインポート/エクスポート用ファイル形式 (CSV/TSV, Anki) を扱います。
+// DBやHTTPには依存せず、ファイルと Record の相互変換だけを担当します。
+package wordio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// サポートする区切り文字形式
+const (
+	FormatCSV = "csv"
+	FormatTSV = "tsv"
+)
+
+// サポートする文字コード
+const (
+	EncodingUTF8     = "utf-8"
+	EncodingShiftJIS = "shift_jis"
+)
+
+// デフォルトのヘッダー名
+const (
+	DefaultTermColumn       = "term"
+	DefaultDefinitionColumn = "definition"
+)
+
+// MaxRecords は1ファイルから読み込む最大行数 (ヘッダーを除く)
+const MaxRecords = 10000
+
+var (
+	ErrUnsupportedFormat   = errors.New("unsupported file format")
+	ErrUnsupportedEncoding = errors.New("unsupported encoding")
+	ErrColumnNotFound      = errors.New("column not found in header")
+	ErrEmptyFile           = errors.New("file has no header row")
+	ErrTooManyRecords      = errors.New("too many records")
+)
+
+// Record はファイルから読み込んだ単語1件分のデータです
+type Record struct {
+	Row        int // 元ファイル上の行番号 (ヘッダーを1行目とする)
+	Term       string
+	Definition string
+	Tags       []string
+	Review     *ReviewState // インポート元の復習状態 (ない場合は nil)
+}
+
+// DelimitedOptions は CSV/TSV 読み込み時のオプションです
+type DelimitedOptions struct {
+	Format           string // FormatCSV or FormatTSV (空ならCSV)
+	Encoding         string // EncodingUTF8 or EncodingShiftJIS (空ならUTF-8)
+	TermColumn       string // 単語列のヘッダー名 (空なら "term")
+	DefinitionColumn string // 意味列のヘッダー名 (空なら "definition")
+}
+
+// ReadDelimited は CSV/TSV を読み込み、ヘッダーの対応付けに従って Record のスライスを返します。
+// ヘッダー名の比較は前後の空白と大文字小文字を無視します。
+func ReadDelimited(r io.Reader, opts DelimitedOptions) ([]Record, error) {
+	decoded, err := decodeReader(r, opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(decoded)
+	reader.FieldsPerRecord = -1 // 行ごとの列数の違いは許容し、必要な列だけを見る
+	switch strings.ToLower(opts.Format) {
+	case "", FormatCSV:
+		reader.Comma = ','
+	case FormatTSV:
+		reader.Comma = '\t'
+		reader.LazyQuotes = true // TSVでは引用符を特別扱いしないエクスポートが多いため
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, opts.Format)
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmptyFile
+		}
+		return nil, fmt.Errorf("wordio.ReadDelimited: failed to read header: %w", err)
+	}
+
+	termIdx, err := findColumn(header, opts.TermColumn, DefaultTermColumn)
+	if err != nil {
+		return nil, err
+	}
+	defIdx, err := findColumn(header, opts.DefinitionColumn, DefaultDefinitionColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("wordio.ReadDelimited: failed to parse: %w", err)
+		}
+		row, _ := reader.FieldPos(0) // csv.Reader は空行を読み飛ばすため、行番号は数えずに取得する
+		if isBlankRow(fields) {
+			continue
+		}
+		if len(records) >= MaxRecords {
+			return nil, fmt.Errorf("%w: limit is %d rows", ErrTooManyRecords, MaxRecords)
+		}
+		records = append(records, Record{
+			Row:        row,
+			Term:       strings.TrimSpace(fieldAt(fields, termIdx)),
+			Definition: strings.TrimSpace(fieldAt(fields, defIdx)),
+		})
+	}
+
+	return records, nil
+}
+
+// decodeReader は指定された文字コードから UTF-8 に変換する Reader を返します
+func decodeReader(r io.Reader, encoding string) (io.Reader, error) {
+	switch strings.ToLower(encoding) {
+	case "", EncodingUTF8, "utf8":
+		return stripBOM(r), nil
+	case EncodingShiftJIS, "sjis", "cp932":
+		// Excelが出力する「Shift_JIS」は実際にはCP932 (Windows-31J) だが、
+		// x/text の ShiftJIS デコーダーはCP932の拡張文字も扱える
+		return transform.NewReader(r, japanese.ShiftJIS.NewDecoder()), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+// stripBOM は先頭の UTF-8 BOM (Excelの「CSV UTF-8」形式で付与される) を取り除きます
+func stripBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && b[0] == 0xEF && b[1] == 0xBB && b[2] == 0xBF {
+		_, _ = br.Discard(3)
+	}
+	return br
+}
+
+func findColumn(header []string, name, defaultName string) (int, error) {
+	if name == "" {
+		name = defaultName
+	}
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %s", ErrColumnNotFound, name)
+}
+
+func fieldAt(fields []string, idx int) string {
+	if idx < 0 || idx >= len(fields) {
+		return ""
+	}
+	return fields[idx]
+}
+
+func isBlankRow(fields []string) bool {
+	for _, f := range fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
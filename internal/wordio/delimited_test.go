@@ -0,0 +1,110 @@
+package wordio_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go_4_vocab_keep/internal/wordio"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func toShiftJIS(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(s))
+	require.NoError(t, err)
+	return b
+}
+
+func TestReadDelimited(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		opts    wordio.DelimitedOptions
+		want    []wordio.Record
+		wantErr error
+	}{
+		{
+			name:  "正常系: CSV (デフォルトのヘッダー名)",
+			input: []byte("term,definition\napple,りんご\n\"bank, river\",\"土手\"\n"),
+			opts:  wordio.DelimitedOptions{},
+			want: []wordio.Record{
+				{Row: 2, Term: "apple", Definition: "りんご"},
+				{Row: 3, Term: "bank, river", Definition: "土手"},
+			},
+		},
+		{
+			name:  "正常系: TSV とヘッダーのマッピング (大文字小文字・空白を無視)",
+			input: []byte("No\t Word \tMeaning\n1\tdog\t犬\n"),
+			opts:  wordio.DelimitedOptions{Format: wordio.FormatTSV, TermColumn: "word", DefinitionColumn: "meaning"},
+			want:  []wordio.Record{{Row: 2, Term: "dog", Definition: "犬"}},
+		},
+		{
+			name:  "正常系: UTF-8 BOM 付きで空行はスキップ",
+			input: append([]byte{0xEF, 0xBB, 0xBF}, []byte("term,definition\n\ncat,猫\n,\n")...),
+			opts:  wordio.DelimitedOptions{},
+			want:  []wordio.Record{{Row: 3, Term: "cat", Definition: "猫"}},
+		},
+		{
+			name:  "正常系: Shift_JIS",
+			input: toShiftJIS(t, "単語,意味\n林檎,りんご\n"),
+			opts:  wordio.DelimitedOptions{Encoding: wordio.EncodingShiftJIS, TermColumn: "単語", DefinitionColumn: "意味"},
+			want:  []wordio.Record{{Row: 2, Term: "林檎", Definition: "りんご"}},
+		},
+		{
+			name:  "正常系: 列が足りない行は空文字として扱う",
+			input: []byte("term,definition\nlonely\n"),
+			opts:  wordio.DelimitedOptions{},
+			want:  []wordio.Record{{Row: 2, Term: "lonely", Definition: ""}},
+		},
+		{
+			name:    "異常系: ヘッダーに列がない",
+			input:   []byte("word,meaning\napple,りんご\n"),
+			opts:    wordio.DelimitedOptions{},
+			wantErr: wordio.ErrColumnNotFound,
+		},
+		{
+			name:    "異常系: 空ファイル",
+			input:   []byte(""),
+			opts:    wordio.DelimitedOptions{},
+			wantErr: wordio.ErrEmptyFile,
+		},
+		{
+			name:    "異常系: 未対応の形式",
+			input:   []byte("term,definition\n"),
+			opts:    wordio.DelimitedOptions{Format: "xlsx"},
+			wantErr: wordio.ErrUnsupportedFormat,
+		},
+		{
+			name:    "異常系: 未対応の文字コード",
+			input:   []byte("term,definition\n"),
+			opts:    wordio.DelimitedOptions{Encoding: "euc-jp"},
+			wantErr: wordio.ErrUnsupportedEncoding,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := wordio.ReadDelimited(bytes.NewReader(tc.input), tc.opts)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestReadDelimited_TooManyRecords(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("term,definition\n")
+	for i := 0; i <= wordio.MaxRecords; i++ {
+		sb.WriteString("a,b\n")
+	}
+	_, err := wordio.ReadDelimited(strings.NewReader(sb.String()), wordio.DelimitedOptions{})
+	assert.ErrorIs(t, err, wordio.ErrTooManyRecords)
+}
@@ -0,0 +1,150 @@
+package wordio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// エクスポート形式
+const (
+	FormatJSON = "json"
+	FormatApkg = "apkg"
+)
+
+// ErrExporterClosed は Close 後に Write した場合のエラー
+var ErrExporterClosed = errors.New("exporter already closed")
+
+// ExportRecord はエクスポートする単語1件分のデータです
+type ExportRecord struct {
+	WordID         string     `json:"word_id"`
+	Term           string     `json:"term"`
+	Definition     string     `json:"definition"`
+	Tags           []string   `json:"tags"`
+	Level          int        `json:"level,omitempty"`            // 学習進捗がない場合は 0
+	NextReviewDate *time.Time `json:"next_review_date,omitempty"` // 学習進捗がない場合は nil
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Exporter は単語を1件ずつ書き出します。
+// Close を呼ぶまで出力が完結しないため (JSONの閉じ括弧やzipの目次など)、必ず Close を呼んでください。
+type Exporter interface {
+	Write(rec ExportRecord) error
+	Close() error
+}
+
+// NewExporter は format に対応する Exporter を返します
+func NewExporter(w io.Writer, format string) (Exporter, error) {
+	switch format {
+	case FormatCSV:
+		return newDelimitedExporter(w, ','), nil
+	case FormatTSV:
+		return newDelimitedExporter(w, '\t'), nil
+	case FormatJSON:
+		return newJSONExporter(w), nil
+	case FormatApkg:
+		return newAnkiExporter(w)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// exportHeader は CSV/TSV のヘッダー行。先頭2列はインポート時のデフォルトの列名と揃えてある
+var exportHeader = []string{DefaultTermColumn, DefaultDefinitionColumn, "tags", "level", "next_review_date", "created_at"}
+
+type delimitedExporter struct {
+	csv         *csv.Writer
+	wroteHeader bool
+}
+
+func newDelimitedExporter(w io.Writer, comma rune) *delimitedExporter {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &delimitedExporter{csv: cw}
+}
+
+func (e *delimitedExporter) Write(rec ExportRecord) error {
+	if !e.wroteHeader {
+		if err := e.csv.Write(exportHeader); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+
+	level, next := "", ""
+	if rec.Level > 0 {
+		level = strconv.Itoa(rec.Level)
+	}
+	if rec.NextReviewDate != nil {
+		next = rec.NextReviewDate.UTC().Format(time.RFC3339)
+	}
+	// csv.Writer は内部でバッファリングし、溜まった分だけ書き出すため、全件をメモリに保持しない
+	return e.csv.Write([]string{
+		rec.Term,
+		rec.Definition,
+		strings.Join(rec.Tags, " "),
+		level,
+		next,
+		rec.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *delimitedExporter) Close() error {
+	if !e.wroteHeader {
+		if err := e.csv.Write(exportHeader); err != nil {
+			return err
+		}
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+// jsonExporter は単語の配列を1要素ずつJSONとして書き出します
+type jsonExporter struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newJSONExporter(w io.Writer) *jsonExporter {
+	return &jsonExporter{w: bufio.NewWriter(w)}
+}
+
+func (e *jsonExporter) Write(rec ExportRecord) error {
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "[\n"
+	}
+	if rec.Tags == nil {
+		rec.Tags = []string{}
+	}
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString(sep); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(b); err != nil {
+		return err
+	}
+	e.count++
+	return nil
+}
+
+func (e *jsonExporter) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	if _, err := e.w.WriteString(end); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
@@ -0,0 +1,123 @@
+package wordio_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go_4_vocab_keep/internal/wordio"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportRecords() []wordio.ExportRecord {
+	created := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	next := time.Now().UTC().AddDate(0, 0, 5).Truncate(24 * time.Hour)
+	return []wordio.ExportRecord{
+		{
+			WordID:         "3f1c1e0e-8b6a-4b8e-9a53-0c6a1b2d3e4f",
+			Term:           "apple",
+			Definition:     "りんご, 林檎\n(果物)",
+			Tags:           []string{"food", "n5"},
+			Level:          3,
+			NextReviewDate: &next,
+			CreatedAt:      created,
+			UpdatedAt:      created,
+		},
+		{
+			WordID:     "7a2b3c4d-1e2f-4a5b-8c6d-7e8f9a0b1c2d",
+			Term:       "<tag> & \"quote\"",
+			Definition: "記号",
+			CreatedAt:  created,
+			UpdatedAt:  created,
+		},
+	}
+}
+
+func export(t *testing.T, format string, recs []wordio.ExportRecord) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	e, err := wordio.NewExporter(&buf, format)
+	require.NoError(t, err)
+	for _, rec := range recs {
+		require.NoError(t, e.Write(rec))
+	}
+	require.NoError(t, e.Close())
+	return buf.Bytes()
+}
+
+func TestExporter_Delimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+	}{
+		{name: "正常系: CSV", format: wordio.FormatCSV},
+		{name: "正常系: TSV", format: wordio.FormatTSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := export(t, tt.format, exportRecords())
+
+			// エクスポートしたファイルはそのままインポートできる
+			got, err := wordio.ReadDelimited(bytes.NewReader(out), wordio.DelimitedOptions{Format: tt.format})
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			assert.Equal(t, "apple", got[0].Term)
+			assert.Equal(t, "りんご, 林檎\n(果物)", got[0].Definition)
+			assert.Equal(t, "<tag> & \"quote\"", got[1].Term)
+		})
+	}
+
+	t.Run("正常系: 0件でもヘッダー行を出力する", func(t *testing.T) {
+		out := export(t, wordio.FormatCSV, nil)
+		assert.Equal(t, "term,definition,tags,level,next_review_date,created_at\n", string(out))
+	})
+}
+
+func TestExporter_JSON(t *testing.T) {
+	t.Run("正常系: 配列として出力する", func(t *testing.T) {
+		out := export(t, wordio.FormatJSON, exportRecords())
+
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(out, &got))
+		require.Len(t, got, 2)
+		assert.Equal(t, "apple", got[0]["term"])
+		assert.EqualValues(t, 3, got[0]["level"])
+		assert.Equal(t, []any{}, got[1]["tags"])
+		assert.NotContains(t, got[1], "level")
+	})
+
+	t.Run("正常系: 0件の場合は空配列", func(t *testing.T) {
+		out := export(t, wordio.FormatJSON, nil)
+		assert.JSONEq(t, "[]", string(out))
+	})
+}
+
+func TestExporter_Apkg(t *testing.T) {
+	recs := exportRecords()
+	out := export(t, wordio.FormatApkg, recs)
+
+	// 書き出したパッケージを読み込み直して内容が一致することを確認する
+	got, err := wordio.ReadAnki(bytes.NewReader(out), int64(len(out)), wordio.AnkiOptions{WithScheduling: true})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, recs[0].Term, got[0].Term)
+	assert.Equal(t, recs[0].Definition, got[0].Definition)
+	assert.Equal(t, recs[0].Tags, got[0].Tags)
+	require.NotNil(t, got[0].Review, "学習中の単語は復習カードになる")
+	assert.Equal(t, 7, got[0].Review.IntervalDays)
+	assert.True(t, recs[0].NextReviewDate.Equal(got[0].Review.Due.UTC()))
+
+	assert.Equal(t, recs[1].Term, got[1].Term)
+	assert.Empty(t, got[1].Tags)
+	assert.Nil(t, got[1].Review, "学習進捗がない単語は新規カードになる")
+}
+
+func TestNewExporter_UnsupportedFormat(t *testing.T) {
+	_, err := wordio.NewExporter(&bytes.Buffer{}, "xlsx")
+	assert.ErrorIs(t, err, wordio.ErrUnsupportedFormat)
+}